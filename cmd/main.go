@@ -1,38 +1,664 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"flag"
+	"fmt"
+	"io"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
+	"go_project/internal/attributes"
 	"go_project/internal/database"
+	"go_project/internal/gql"
+	"go_project/internal/grpcserver"
 	"go_project/internal/handler"
+	"go_project/internal/idempotency"
+	"go_project/internal/jobs"
+	"go_project/internal/model"
+	"go_project/internal/ratelimit"
 	"go_project/internal/recorder"
 	"go_project/internal/repository"
+	"go_project/internal/scheduler"
+	"go_project/internal/storage"
+	"go_project/internal/transfer"
 	"go_project/internal/usecase"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+// 사용법:
+//
+//	main                                        - API 서버 실행 (HTTP :8080, gRPC :9090, 내부 진단 DEBUG_ADDR)
+//	main export [-format csv] [-o 파일]          - 리소스 내보내기 (기본: 표준 출력)
+//	main import [-format csv] [-dry-run] [-upsert] 파일 - 리소스 가져오기
+//
+// 환경 변수:
+//
+//	DATABASE_REPLICAS         - 읽기 복제본 DSN 목록 (;로 구분, 없으면 primary만 사용)
+//	DATABASE_HEALTH_CHECK_INTERVAL - 복제본 상태 확인과 회로 차단기 복구 확인 주기 (예: 5s, 기본: 10s)
+//	DATABASE_CONNECT_TIMEOUT  - 시작 시 primary 연결을 재시도하는 최대 시간 (기본: 1m)
+//	DATABASE_MAX_OPEN_CONNS, DATABASE_MAX_IDLE_CONNS - 풀마다 최대 연결 수와 유휴 연결 수 (기본: 25, 10)
+//	DATABASE_CONN_MAX_LIFETIME, DATABASE_CONN_MAX_IDLE_TIME - 연결 최대 수명과 유휴 시간 (기본: 30m, 5m)
+//	DATABASE_QUERY_TIMEOUT    - 리소스 쿼리 하나의 제한 시간 (0이면 제한 없음, 기본: 5s)
+//	DATABASE_RETRIES          - 일시적인 DB 오류일 때 쿼리를 실행할 최대 횟수 (1이면 재시도 안 함, 기본: 3)
+//	DATABASE_BREAKER_THRESHOLD - 회로 차단기를 여는 연속 연결 실패 횟수 (기본: 5)
+//	DATABASE_BREAKER_TIMEOUT  - 회로 차단기를 연 뒤 복구를 확인하기까지 기다리는 시간 (기본: 10s)
+//	RESOURCE_ATTRIBUTE_SCHEMA - 리소스 속성을 검증할 JSON Schema 파일 경로 (없으면 검증하지 않음)
+//	BLOB_STORE                - 첨부 파일 저장소 (file, memory, s3, 기본: file)
+//	BLOB_DIR                  - file 저장소의 디렉터리 (기본: ./data/attachments)
+//	S3_ENDPOINT, S3_REGION, S3_BUCKET, S3_ACCESS_KEY, S3_SECRET_KEY - s3 저장소 연결 설정
+//	ATTACHMENT_MAX_SIZE       - 첨부 파일 하나의 최대 크기 (바이트, 기본: 10MiB)
+//	DEBUG_ADDR                - /debug/vars(연결 풀, 캐시 통계)를 여는 내부 전용 주소 (off면 열지 않음, 기본: 127.0.0.1:6060)
+//	ATTACHMENT_ALLOWED_TYPES  - 허용할 MIME 타입 (쉼표로 구분, 예: image/*,application/pdf, 기본: 전체)
+//	CACHE_SIZE                - ID 조회 캐시에 보관할 최대 리소스 수 (0이면 캐시 사용 안 함, 기본: 10000)
+//	CACHE_TTL                 - ID 조회 캐시 보관 시간 (예: 30s, 기본: 1m)
+//	IDEMPOTENCY_STORE         - Idempotency-Key 처리 기록 저장소 (db, memory, 기본: db)
+//	IDEMPOTENCY_TTL           - 같은 Idempotency-Key의 재시도에 첫 응답을 돌려주는 기간 (예: 1h, 기본: 24h)
+//	RATE_LIMITS               - 그룹별 클라이언트 IP당 속도 제한 (그룹=초당요청수:버킷크기, 쉼표로 구분, off면 사용 안 함,
+//	                            기본: resources=20:40,bulk=1:5,docs=5:10)
+//	TRUSTED_PROXIES           - X-Forwarded-For를 믿을 프록시 주소나 CIDR (쉼표로 구분, 없으면 연결한 주소를 클라이언트 IP로 사용)
+//	DAILY_WRITE_QUOTA         - 클라이언트 IP별 하루(UTC) 쓰기 요청 한도 (0이면 제한 없음, 기본: 0)
+//	JOB_STORE                 - 백그라운드 작업 큐 저장소 (db, memory, 기본: db)
+//	JOB_CONCURRENCY           - 이 서버가 처리할 큐별 작업자 수 (큐=작업자수, 쉼표로 구분, 기본: default=2)
+//	SCHEDULE_CONFIG           - 예약 작업 설정 파일 경로 (YAML, 형식은 scheduler.Config, 없으면 기본 주기 사용)
+//	SCHEDULE_STORE            - 예약 작업 실행 기록과 리더 선출 (db: DB 기록과 advisory lock, memory: 서버 하나, 기본: db)
+//	EXPORT_DIR                - nightly-export 작업이 내보낸 파일을 저장할 디렉터리 (기본: ./data/exports)
+//	EXPIRED_ARCHIVE_DIR       - expired-sweep 작업이 만료된 리소스를 지우기 전에 NDJSON으로 보관할 디렉터리 (없으면 보관하지 않고 삭제)
 func main() {
-	// DB 초기화
-	db, err := database.InitDB()
+	// DB 초기화 (리소스 조회는 복제본으로, 그 외는 primary로)
+	dbConfig, err := databaseConfig()
+	if err != nil {
+		log.Fatalf("데이터베이스 설정 오류: %v", err)
+	}
+	// primary가 아직 뜨지 않았으면 DATABASE_CONNECT_TIMEOUT 동안 재시도
+	cluster, err := database.Open(dbConfig)
 	if err != nil {
 		log.Fatalf("데이터베이스 초기화 실패: %v", err)
 	}
+	defer cluster.Close()
+	db := cluster.Primary()
+	// 연결 풀별 상태와 사용량은 /debug/vars의 database_pools로 확인
+	expvar.Publish("database_pools", expvar.Func(func() any { return cluster.Stats() }))
 
-	// Recorder, Repository, Usecase, Handler 초기화
-	rec := recorder.NewRecorder(db)
+	// Recorder, Repository, Usecase 초기화
+	recorderOpts, err := recorderOptions()
+	if err != nil {
+		log.Fatalf("데이터베이스 설정 오류: %v", err)
+	}
+	rec := recorder.NewReplicatedRecorder[model.Base](cluster, recorderOpts...)
 	repo := repository.NewRepository(rec)
-	uc := usecase.NewUsecase(repo)
-	h := handler.NewHandler(uc)
+	// 생성/수정할 때마다 리소스 전체를 리비전으로 남김 (조회, 비교, 롤백은 /api/v1/resources/:id/revisions)
+	// 리비전은 리소스 쓰기와 같은 트랜잭션에서 저장하므로 테이블이 없으면 모든 쓰기가 실패함
+	if err := recorder.MigrateRevisions(db); err != nil {
+		log.Fatalf("데이터베이스 초기화 실패: %v", err)
+	}
+	transactor := recorder.NewTransactor(cluster, recorderOpts...)
+	revisionRepo := repository.NewRevisionRepository(recorder.NewRevisionRecorder(cluster, recorderOpts...))
+	repo = repository.NewVersionedRepository(repo, revisionRepo, transactor)
+	// 캐시는 가장 바깥에 두어 트랜잭션이 커밋된 뒤에 무효화
+	cacheOpts, cacheEnabled, err := cacheOptions()
+	if err != nil {
+		log.Fatalf("캐시 설정 오류: %v", err)
+	}
+	if cacheEnabled {
+		cached := repository.NewCachingRepository(repo, cacheOpts...)
+		// 캐시 적중률 등은 /debug/vars의 resource_cache로 확인
+		expvar.Publish("resource_cache", expvar.Func(func() any { return cached.CacheStats() }))
+		repo = cached
+	}
+	store, err := newBlobStore()
+	if err != nil {
+		log.Fatalf("첨부 파일 저장소 초기화 실패: %v", err)
+	}
+	attachmentOpts, err := attachmentOptions()
+	if err != nil {
+		log.Fatalf("첨부 파일 설정 오류: %v", err)
+	}
+	// 첨부 파일은 항상 사용하고 리소스를 삭제할 때도 조회하므로 테이블을 만듦
+	if err := recorder.MigrateAttachments(db); err != nil {
+		log.Fatalf("데이터베이스 초기화 실패: %v", err)
+	}
+	auc := usecase.NewAttachmentUsecase(repository.NewAttachmentRepository(recorder.NewAttachmentRecorder(db)), repo, store, attachmentOpts...)
+
+	// 리소스를 삭제하면 첨부 파일(메타데이터와 내용)도 함께 지우고, 가져오기는 한 트랜잭션으로 저장
+	opts := []usecase.Option{usecase.WithAttachments(auc), usecase.WithTransactor(transactor)}
+	if path := os.Getenv("RESOURCE_ATTRIBUTE_SCHEMA"); path != "" {
+		schema, err := loadAttributeSchema(path)
+		if err != nil {
+			log.Fatalf("속성 스키마 로드 실패: %v", err)
+		}
+		opts = append(opts, usecase.WithAttributeSchema(schema))
+	}
+	uc := usecase.NewUsecase(repo, opts...)
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			if err := runExport(uc, os.Args[2:]); err != nil {
+				log.Fatalf("내보내기 실패: %v", err)
+			}
+			return
+		case "import":
+			if err := runImport(uc, os.Args[2:]); err != nil {
+				log.Fatalf("가져오기 실패: %v", err)
+			}
+			return
+		default:
+			log.Fatalf("알 수 없는 명령: %s", os.Args[1])
+		}
+	}
+
+	// gRPC 서버는 별도 포트에서 같은 Usecase로 실행
+	lis, err := net.Listen("tcp", ":9090")
+	if err != nil {
+		log.Fatalf("gRPC 포트 열기 실패: %v", err)
+	}
+	go func() {
+		if err := grpcserver.NewGRPCServer(uc).Serve(lis); err != nil {
+			log.Fatalf("gRPC 서버 실행 실패: %v", err)
+		}
+	}()
+
+	ruc := usecase.NewRevisionUsecase(revisionRepo, uc)
+
+	idempotencyStore, idempotencyTTL, err := idempotencyOptions(db)
+	if err != nil {
+		log.Fatalf("멱등성 키 설정 오류: %v", err)
+	}
+
+	// SIGINT, SIGTERM을 받으면 새 요청과 작업을 받지 않고 실행 중인 것이 끝날 때까지 기다린 뒤 종료
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 백그라운드 작업 큐 (관리 API는 /api/v1/jobs)
+	jobStore, runnerOpts, err := jobOptions(db)
+	if err != nil {
+		log.Fatalf("작업 큐 설정 오류: %v", err)
+	}
+	exportDir := os.Getenv("EXPORT_DIR")
+	if exportDir == "" {
+		exportDir = "./data/exports"
+	}
+	runner := jobs.NewRunner(jobStore, runnerOpts...)
+	scheduler.RegisterJobs(runner, uc, exportDir, os.Getenv("EXPIRED_ARCHIVE_DIR"))
+	runnerDone := make(chan struct{})
+	go func() {
+		defer close(runnerDone)
+		runner.Run(ctx)
+	}()
+
+	// 예약 작업 (여러 서버 중 리더 하나만 실행, 관리 API는 /api/v1/tasks)
+	// 오래 걸리는 내보내기와 만료 정리는 작업 큐에 넣어 Runner가 재시도와 함께 실행
+	sched, err := newScheduler(db)
+	if err != nil {
+		log.Fatalf("예약 작업 설정 오류: %v", err)
+	}
+	sched.Register("idempotency-purge", scheduler.PurgeTask("만료된 멱등성 키", 0, idempotencyStore.Purge))
+	sched.Register("jobs-purge", scheduler.PurgeTask("끝난 작업", 7*24*time.Hour, jobStore.Purge))
+	sched.Register("task-runs-purge", scheduler.PurgeTask("예약 작업 실행 기록", 30*24*time.Hour, sched.History().Purge))
+	sched.Register("nightly-export", scheduler.EnqueueTask(jobStore, scheduler.ExportJob, scheduler.ExportPayload{Format: transfer.FormatNDJSON}))
+	sched.Register("expired-sweep", scheduler.EnqueueTask(jobStore, scheduler.ExpireJob, struct{}{}))
+	scheduleConfig, err := loadScheduleConfig()
+	if err != nil {
+		log.Fatalf("예약 작업 설정 오류: %v", err)
+	}
+	if err := sched.Configure(scheduleConfig); err != nil {
+		log.Fatalf("예약 작업 설정 오류: %v", err)
+	}
+	go sched.Run(ctx)
+
+	handlerOpts := []handler.Option{
+		handler.WithAttachments(auc),
+		handler.WithRevisions(ruc),
+		handler.WithIdempotency(idempotencyStore, idempotencyTTL),
+		handler.WithJobs(jobStore),
+		handler.WithScheduler(sched),
+	}
+	rateLimit, rateLimitEnabled, err := rateLimitConfig()
+	if err != nil {
+		log.Fatalf("속도 제한 설정 오류: %v", err)
+	}
+	if rateLimitEnabled {
+		handlerOpts = append(handlerOpts, handler.WithRateLimit(rateLimit))
+	}
+	h := handler.NewHandler(uc, handlerOpts...)
 
 	// Router 설정
 	r := gin.Default()
+	// 클라이언트 IP(속도 제한과 멱등성 키의 주체)는 지정한 프록시가 보낸 X-Forwarded-For만 믿음
+	if err := r.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Fatalf("TRUSTED_PROXIES 설정 오류: %v", err)
+	}
 
 	// 라우트 설정
 	h.RegisterRoutes(r)
+	gql.NewHandler(uc).RegisterRoutes(r)
+
+	// 진단 정보는 API와 다른 내부 주소에서만 제공
+	if addr := debugAddr(); addr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/vars", expvar.Handler())
+		go func() {
+			if err := http.ListenAndServe(addr, mux); err != nil {
+				log.Fatalf("진단 서버 실행 실패: %v", err)
+			}
+		}()
+	}
 
 	// 서버 시작
-	if err := r.Run(":8080"); err != nil {
-		log.Fatalf("서버 시작 실패: %v", err)
+	srv := &http.Server{Addr: ":8080", Handler: r}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("서버 시작 실패: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	log.Printf("서버 종료 중 (실행 중인 요청과 작업이 끝날 때까지 기다림)")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP 서버 종료 실패: %v", err)
+	}
+	<-runnerDone
+}
+
+// trustedProxies는 TRUSTED_PROXIES의 프록시 목록을 반환 (없으면 nil, 어떤 프록시도 믿지 않음)
+func trustedProxies() []string {
+	var proxies []string
+	for _, p := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
+
+// debugAddr는 DEBUG_ADDR로 진단 서버 주소를 반환 (off면 빈 문자열)
+func debugAddr() string {
+	switch v := os.Getenv("DEBUG_ADDR"); v {
+	case "":
+		return "127.0.0.1:6060"
+	case "off":
+		return ""
+	default:
+		return v
+	}
+}
+
+func loadAttributeSchema(path string) (*attributes.Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return attributes.ParseSchema(data)
+}
+
+// databaseConfig는 DATABASE_* 환경 변수로 primary와 읽기 복제본 연결을 설정
+// DSN에는 쉼표가 들어갈 수 있으므로 복제본은 ;로 구분
+func databaseConfig() (database.Config, error) {
+	cfg := database.Config{PrimaryDSN: database.DefaultDSN(), Pool: database.DefaultPoolConfig}
+	for _, dsn := range strings.Split(os.Getenv("DATABASE_REPLICAS"), ";") {
+		if dsn = strings.TrimSpace(dsn); dsn != "" {
+			cfg.ReplicaDSNs = append(cfg.ReplicaDSNs, dsn)
+		}
+	}
+	for name, dst := range map[string]*time.Duration{
+		"DATABASE_HEALTH_CHECK_INTERVAL": &cfg.HealthCheckInterval,
+		"DATABASE_CONNECT_TIMEOUT":       &cfg.ConnectTimeout,
+		"DATABASE_CONN_MAX_LIFETIME":     &cfg.Pool.ConnMaxLifetime,
+		"DATABASE_CONN_MAX_IDLE_TIME":    &cfg.Pool.ConnMaxIdleTime,
+		"DATABASE_BREAKER_TIMEOUT":       &cfg.Breaker.OpenTimeout,
+	} {
+		if err := durationEnv(name, dst); err != nil {
+			return cfg, err
+		}
+	}
+	for name, dst := range map[string]*int{
+		"DATABASE_MAX_OPEN_CONNS":    &cfg.Pool.MaxOpenConns,
+		"DATABASE_MAX_IDLE_CONNS":    &cfg.Pool.MaxIdleConns,
+		"DATABASE_BREAKER_THRESHOLD": &cfg.Breaker.Threshold,
+	} {
+		if err := intEnv(name, dst); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
+
+// recorderOptions는 DATABASE_QUERY_TIMEOUT, DATABASE_RETRIES로 리소스 쿼리의 제한 시간과 재시도를 설정
+func recorderOptions() ([]recorder.Option, error) {
+	timeout, attempts := 5*time.Second, 3
+	if v := os.Getenv("DATABASE_QUERY_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("잘못된 DATABASE_QUERY_TIMEOUT: %s", v)
+		}
+		timeout = d
+	}
+	if err := intEnv("DATABASE_RETRIES", &attempts); err != nil {
+		return nil, err
+	}
+	opts := []recorder.Option{recorder.WithRetry(attempts, 50*time.Millisecond)}
+	if timeout > 0 {
+		opts = append(opts, recorder.WithQueryTimeout(timeout))
+	}
+	return opts, nil
+}
+
+// durationEnv는 name 환경 변수가 있으면 양수 기간으로 파싱해 dst에 저장
+func durationEnv(name string, dst *time.Duration) error {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("잘못된 %s: %s", name, v)
+	}
+	*dst = d
+	return nil
+}
+
+// intEnv는 name 환경 변수가 있으면 양의 정수로 파싱해 dst에 저장
+func intEnv(name string, dst *int) error {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("잘못된 %s: %s", name, v)
+	}
+	*dst = n
+	return nil
+}
+
+// newBlobStore는 BLOB_STORE 환경 변수에 맞는 첨부 파일 저장소를 생성
+func newBlobStore() (storage.BlobStore, error) {
+	switch kind := os.Getenv("BLOB_STORE"); kind {
+	case "", "file":
+		dir := os.Getenv("BLOB_DIR")
+		if dir == "" {
+			dir = "./data/attachments"
+		}
+		return storage.NewFileStore(dir)
+	case "memory":
+		return storage.NewMemoryStore(), nil
+	case "s3":
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		})
+	default:
+		return nil, fmt.Errorf("알 수 없는 BLOB_STORE: %s", kind)
+	}
+}
+
+// attachmentOptions는 ATTACHMENT_* 환경 변수로 첨부 파일 제한을 설정
+func attachmentOptions() ([]usecase.AttachmentOption, error) {
+	var opts []usecase.AttachmentOption
+	if v := os.Getenv("ATTACHMENT_MAX_SIZE"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("잘못된 ATTACHMENT_MAX_SIZE: %s", v)
+		}
+		opts = append(opts, usecase.WithMaxAttachmentSize(n))
+	}
+	if v := os.Getenv("ATTACHMENT_ALLOWED_TYPES"); v != "" {
+		var types []string
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				types = append(types, t)
+			}
+		}
+		opts = append(opts, usecase.WithAllowedTypes(types...))
+	}
+	return opts, nil
+}
+
+// cacheOptions는 CACHE_* 환경 변수로 ID 조회 캐시를 설정 (CACHE_SIZE가 0이면 사용하지 않음)
+func cacheOptions() ([]repository.CacheOption, bool, error) {
+	var opts []repository.CacheOption
+	if v := os.Getenv("CACHE_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, false, fmt.Errorf("잘못된 CACHE_SIZE: %s", v)
+		}
+		if n == 0 {
+			return nil, false, nil
+		}
+		opts = append(opts, repository.WithCacheSize(n))
+	}
+	if v := os.Getenv("CACHE_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return nil, false, fmt.Errorf("잘못된 CACHE_TTL: %s", v)
+		}
+		opts = append(opts, repository.WithCacheTTL(ttl))
+	}
+	return opts, true, nil
+}
+
+// idempotencyOptions는 IDEMPOTENCY_* 환경 변수로 Idempotency-Key 저장소와 보관 기간을 설정
+func idempotencyOptions(db *gorm.DB) (idempotency.Store, time.Duration, error) {
+	var store idempotency.Store
+	switch kind := os.Getenv("IDEMPOTENCY_STORE"); kind {
+	case "", "db":
+		// 멱등성 키는 기본 저장소라 따로 준비하지 않아도 동작하도록 테이블을 만듦
+		if err := idempotency.Migrate(db); err != nil {
+			return nil, 0, err
+		}
+		store = idempotency.NewGormStore(db)
+	case "memory":
+		store = idempotency.NewMemoryStore()
+	default:
+		return nil, 0, fmt.Errorf("알 수 없는 IDEMPOTENCY_STORE: %s", kind)
+	}
+
+	ttl := handler.DefaultIdempotencyTTL
+	if v := os.Getenv("IDEMPOTENCY_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, 0, fmt.Errorf("잘못된 IDEMPOTENCY_TTL: %s", v)
+		}
+		ttl = d
+	}
+	return store, ttl, nil
+}
+
+// SCHEDULE_CONFIG가 없을 때의 예약 작업 주기
+var defaultScheduleConfig = scheduler.Config{
+	Tasks: map[string]string{
+		"idempotency-purge": "@hourly",
+		"jobs-purge":        "15 4 * * *",
+		"task-runs-purge":   "30 4 * * *",
+		"nightly-export":    "0 3 * * *",
+		"expired-sweep":     "*/5 * * * *", // 만료된 리소스는 만료 시각부터 조회되지 않으므로 삭제는 늦어도 됨
+	},
+}
+
+// newScheduler는 SCHEDULE_STORE 환경 변수에 맞는 실행 기록 저장소와 리더 선출로 Scheduler를 생성
+func newScheduler(db *gorm.DB) (*scheduler.Scheduler, error) {
+	switch kind := os.Getenv("SCHEDULE_STORE"); kind {
+	case "", "db":
+		// 실행 기록은 기본 저장소라 따로 준비하지 않아도 동작하도록 테이블을 만듦
+		if err := scheduler.Migrate(db); err != nil {
+			return nil, err
+		}
+		return scheduler.New(scheduler.NewGormHistory(db), scheduler.NewAdvisoryLock(db, scheduler.DefaultLockKey)), nil
+	case "memory":
+		return scheduler.New(scheduler.NewMemoryHistory(), scheduler.NewLocalLeader()), nil
+	default:
+		return nil, fmt.Errorf("알 수 없는 SCHEDULE_STORE: %s", kind)
+	}
+}
+
+// loadScheduleConfig는 SCHEDULE_CONFIG 파일을 읽음 (없으면 기본 주기)
+func loadScheduleConfig() (scheduler.Config, error) {
+	path := os.Getenv("SCHEDULE_CONFIG")
+	if path == "" {
+		return defaultScheduleConfig, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return scheduler.Config{}, err
+	}
+	return scheduler.ParseConfig(data)
+}
+
+// jobOptions는 JOB_* 환경 변수로 작업 큐 저장소와 큐별 작업자 수를 설정
+func jobOptions(db *gorm.DB) (jobs.Store, []jobs.Option, error) {
+	var store jobs.Store
+	switch kind := os.Getenv("JOB_STORE"); kind {
+	case "", "db":
+		// 작업 큐는 기본 저장소라 따로 준비하지 않아도 동작하도록 테이블을 만듦
+		if err := jobs.Migrate(db); err != nil {
+			return nil, nil, err
+		}
+		store = jobs.NewGormStore(db)
+	case "memory":
+		store = jobs.NewMemoryStore()
+	default:
+		return nil, nil, fmt.Errorf("알 수 없는 JOB_STORE: %s", kind)
+	}
+
+	spec := os.Getenv("JOB_CONCURRENCY")
+	if spec == "" {
+		spec = "default=2"
+	}
+	var opts []jobs.Option
+	for _, part := range strings.Split(spec, ",") {
+		queue, count, ok := strings.Cut(strings.TrimSpace(part), "=")
+		n, err := strconv.Atoi(count)
+		if !ok || queue == "" || err != nil || n <= 0 {
+			return nil, nil, fmt.Errorf("잘못된 JOB_CONCURRENCY: %s", spec)
+		}
+		opts = append(opts, jobs.WithConcurrency(queue, n))
+	}
+	return store, opts, nil
+}
+
+// 클라이언트 IP별 기본 속도 제한 (RATE_LIMITS가 없을 때)
+const defaultRateLimits = "resources=20:40,bulk=1:5,docs=5:10"
+
+// rateLimitConfig는 RATE_LIMITS, DAILY_WRITE_QUOTA 환경 변수로 속도 제한을 설정 (RATE_LIMITS가 off이고 한도도 없으면 사용하지 않음)
+func rateLimitConfig() (handler.RateLimitConfig, bool, error) {
+	cfg := handler.RateLimitConfig{Store: ratelimit.NewMemoryStore()}
+
+	spec := os.Getenv("RATE_LIMITS")
+	if spec == "" {
+		spec = defaultRateLimits
+	}
+	if spec != "off" {
+		limits, err := ratelimit.ParseLimits(spec)
+		if err != nil {
+			return cfg, false, err
+		}
+		cfg.Limits = limits
+	}
+
+	if v := os.Getenv("DAILY_WRITE_QUOTA"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return cfg, false, fmt.Errorf("잘못된 DAILY_WRITE_QUOTA: %s", v)
+		}
+		cfg.DailyWriteQuota = n
+	}
+	return cfg, len(cfg.Limits) > 0 || cfg.DailyWriteQuota > 0, nil
+}
+
+func runExport(uc usecase.Usecase[model.Base], args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	formatFlag := fs.String("format", "json", "출력 형식 (csv, ndjson, json)")
+	output := fs.String("o", "", "출력 파일 경로 (기본: 표준 출력)")
+	fs.Parse(args)
+
+	format, err := transfer.ParseFormat(*formatFlag)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	w := transfer.NewWriter(out, format)
+	if err := uc.Export(context.Background(), w.Write); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+func runImport(uc usecase.Usecase[model.Base], args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	formatFlag := fs.String("format", "", "입력 형식 (csv, ndjson, json, 기본: 확장자로 판단)")
+	dryRun := fs.Bool("dry-run", false, "검증만 하고 저장하지 않음")
+	upsert := fs.Bool("upsert", false, "같은 이름의 리소스가 있으면 수정")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("가져올 파일 경로를 하나 지정해야 합니다")
+	}
+	path := fs.Arg(0)
+
+	var format transfer.Format
+	var err error
+	if *formatFlag != "" {
+		format, err = transfer.ParseFormat(*formatFlag)
+	} else {
+		format, err = transfer.FormatFromFilename(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rows, err := transfer.ReadAll(f, format)
+	if err != nil {
+		return err
+	}
+
+	report, err := uc.Import(context.Background(), rows, model.ImportOptions{DryRun: *dryRun, Upsert: *upsert})
+	if err != nil {
+		return err
+	}
+
+	// 결과 보고서는 JSON으로 출력
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d개 행 처리 실패", report.Failed)
 	}
+	return nil
 }
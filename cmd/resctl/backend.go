@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go_project/internal/database"
+	"go_project/internal/model"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/transfer"
+	"go_project/internal/usecase"
+	"go_project/pkg/client"
+)
+
+// backend는 명령이 리소스를 다루는 방법
+// 기본은 API 클라이언트(*client.Client)이고, --direct면 로컬 DB의 Usecase(directBackend)를 사용
+type backend interface {
+	List(ctx context.Context, opts client.ListOptions) (*client.Page, error)
+	Get(ctx context.Context, id uint) (*client.Resource, error)
+	Create(ctx context.Context, r *client.Resource) (*client.Resource, error)
+	Update(ctx context.Context, id uint, r *client.Resource) (*client.Resource, error)
+	Delete(ctx context.Context, id uint) error
+	Import(ctx context.Context, filename string, r io.Reader, opts client.ImportOptions) (*client.ImportReport, error)
+	Export(ctx context.Context, format string, w io.Writer) error
+}
+
+var (
+	_ backend = (*client.Client)(nil)
+	_ backend = (*directBackend)(nil)
+)
+
+// listPageSize는 전체 목록을 나눠 가져올 때의 페이지 크기
+const listPageSize = 100
+
+// listAll은 페이지를 넘기며 모든 리소스를 가져옴
+func listAll(ctx context.Context, b backend) ([]*client.Resource, error) {
+	var all []*client.Resource
+	for page := 1; ; page++ {
+		p, err := b.List(ctx, client.ListOptions{Page: page, Size: listPageSize})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, p.Items...)
+		if !p.HasNext() || len(p.Items) == 0 {
+			return all, nil
+		}
+	}
+}
+
+// defaultBackend는 옵션에 맞는 백엔드와 정리 함수를 생성
+func defaultBackend(opts *commonOptions) (backend, func(), error) {
+	if opts.direct {
+		return newDirectBackend()
+	}
+
+	profile, err := resolveProfile(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientOpts := []client.Option{
+		client.WithTimeout(opts.timeout),
+		client.WithRetries(2, 200*time.Millisecond),
+	}
+	if profile.Token != "" {
+		clientOpts = append(clientOpts, client.WithToken(profile.Token))
+	}
+	return client.New(profile.Server, clientOpts...), func() {}, nil
+}
+
+// newDirectBackend는 서버와 같은 방식으로 DB에 연결해서 Usecase를 직접 사용
+func newDirectBackend() (backend, func(), error) {
+	db, err := database.InitDB()
+	if err != nil {
+		return nil, nil, err
+	}
+	uc := usecase.NewUsecase(repository.NewRepository(recorder.NewRecorder[model.Base](db)))
+	closeFn := func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+	return &directBackend{uc: uc}, closeFn, nil
+}
+
+// directBackend는 API 서버 없이 Usecase를 호출
+// 에러는 API 클라이언트와 같은 종류(client.ErrNotFound 등)로 바꿔서 반환
+type directBackend struct {
+	uc usecase.Usecase[model.Base]
+}
+
+func toResource(m *model.Base) *client.Resource {
+	return &client.Resource{
+		ID: m.ID, Name: m.Name, ParentID: m.ParentID, Labels: m.Labels, Attributes: m.Attributes,
+		CreatedAt: m.CreatedAt, UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func directErr(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrNotFound):
+		return fmt.Errorf("%w: %v", client.ErrNotFound, err)
+	case errors.Is(err, usecase.ErrInvalid):
+		return fmt.Errorf("%w: %v", client.ErrBadRequest, err)
+	case errors.Is(err, usecase.ErrConflict):
+		return fmt.Errorf("%w: %v", client.ErrConflict, err)
+	}
+	return err
+}
+
+func (d *directBackend) List(ctx context.Context, opts client.ListOptions) (*client.Page, error) {
+	opts.Page = max(opts.Page, 1)
+	bases, total, err := d.uc.List(ctx, model.ListOptions{Page: opts.Page, Size: opts.Size})
+	if err != nil {
+		return nil, directErr(err)
+	}
+	items := make([]*client.Resource, len(bases))
+	for i, b := range bases {
+		items[i] = toResource(b)
+	}
+	return &client.Page{Items: items, Total: total, Page: opts.Page, Size: opts.Size}, nil
+}
+
+func (d *directBackend) Get(ctx context.Context, id uint) (*client.Resource, error) {
+	m, err := d.uc.Get(ctx, id)
+	if err != nil {
+		return nil, directErr(err)
+	}
+	return toResource(m), nil
+}
+
+func (d *directBackend) Create(ctx context.Context, r *client.Resource) (*client.Resource, error) {
+	m := &model.Base{Name: r.Name}
+	if err := d.uc.Insert(ctx, m); err != nil {
+		return nil, directErr(err)
+	}
+	return toResource(m), nil
+}
+
+func (d *directBackend) Update(ctx context.Context, id uint, r *client.Resource) (*client.Resource, error) {
+	// API의 PUT과 같이 없는 ID는 404로 처리
+	if _, err := d.uc.Get(ctx, id); err != nil {
+		return nil, directErr(err)
+	}
+	m := &model.Base{Name: r.Name}
+	if err := d.uc.Modify(ctx, id, m); err != nil {
+		return nil, directErr(err)
+	}
+	return toResource(m), nil
+}
+
+func (d *directBackend) Delete(ctx context.Context, id uint) error {
+	return directErr(d.uc.Remove(ctx, id))
+}
+
+func (d *directBackend) Import(ctx context.Context, filename string, r io.Reader, opts client.ImportOptions) (*client.ImportReport, error) {
+	var format transfer.Format
+	var err error
+	if opts.Format != "" {
+		format, err = transfer.ParseFormat(opts.Format)
+	} else {
+		format, err = transfer.FormatFromFilename(filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := transfer.ReadAll(r, format)
+	if err != nil {
+		return nil, err
+	}
+	report, err := d.uc.Import(ctx, rows, model.ImportOptions{DryRun: opts.DryRun, Upsert: opts.Upsert})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &client.ImportReport{
+		DryRun:  report.DryRun,
+		Total:   report.Total,
+		Created: report.Created,
+		Updated: report.Updated,
+		Failed:  report.Failed,
+	}
+	for _, e := range report.Errors {
+		result.Errors = append(result.Errors, client.ImportError{Row: e.Row, Message: e.Message})
+	}
+	if result.Failed > 0 {
+		return result, fmt.Errorf("%w: %d개 행 처리 실패", client.ErrUnprocessable, result.Failed)
+	}
+	return result, nil
+}
+
+func (d *directBackend) Export(ctx context.Context, format string, w io.Writer) error {
+	f, err := transfer.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	tw := transfer.NewWriter(w, f)
+	if err := d.uc.Export(ctx, tw.Write); err != nil {
+		return err
+	}
+	return tw.Close()
+}
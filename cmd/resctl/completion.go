@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// 셸 자동완성 스크립트
+// 명령과 옵션은 고정 목록이고, --profile 값은 "resctl profile list -q"로 그때그때 가져옴
+const bashCompletion = `# resctl bash 자동완성
+# 사용: source <(resctl completion bash)
+_resctl() {
+    local cur prev cmds opts
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    cmds="list get create update delete import export watch profile completion help"
+    opts="--output -o --profile --server --token --timeout --direct"
+
+    case "$prev" in
+        -o|--output) COMPREPLY=($(compgen -W "table json yaml" -- "$cur")); return ;;
+        --profile) COMPREPLY=($(compgen -W "$(resctl profile list -q 2>/dev/null)" -- "$cur")); return ;;
+        --format) COMPREPLY=($(compgen -W "csv ndjson json" -- "$cur")); return ;;
+    esac
+
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=($(compgen -W "$cmds" -- "$cur"))
+        return
+    fi
+
+    case "${COMP_WORDS[1]}" in
+        list) opts="$opts --page --size" ;;
+        create|update) opts="$opts --name" ;;
+        import) opts="$opts --format --dry-run --upsert"; [[ "$cur" != -* ]] && { COMPREPLY=($(compgen -f -- "$cur")); return; } ;;
+        export) opts="$opts --format -f" ;;
+        watch) opts="$opts --interval" ;;
+        profile)
+            if [ "$COMP_CWORD" -eq 2 ]; then
+                COMPREPLY=($(compgen -W "list use set delete" -- "$cur"))
+            else
+                COMPREPLY=($(compgen -W "$(resctl profile list -q 2>/dev/null) --server --token" -- "$cur"))
+            fi
+            return ;;
+        completion) COMPREPLY=($(compgen -W "bash zsh fish" -- "$cur")); return ;;
+    esac
+    COMPREPLY=($(compgen -W "$opts" -- "$cur"))
+}
+complete -F _resctl resctl
+`
+
+const zshCompletion = `#compdef resctl
+# resctl zsh 자동완성
+# 사용: source <(resctl completion zsh)  또는 fpath의 _resctl 파일로 저장
+autoload -U bashcompinit && bashcompinit
+` + bashCompletion
+
+const fishCompletion = `# resctl fish 자동완성
+# 사용: resctl completion fish | source
+set -l cmds list get create update delete import export watch profile completion help
+complete -c resctl -f
+complete -c resctl -n "not __fish_seen_subcommand_from $cmds" -a "$cmds"
+complete -c resctl -s o -l output -x -a "table json yaml" -d "출력 형식"
+complete -c resctl -l profile -x -a "(resctl profile list -q 2>/dev/null)" -d "프로필"
+complete -c resctl -l server -x -d "API 서버 주소"
+complete -c resctl -l token -x -d "API 인증 토큰"
+complete -c resctl -l timeout -x -d "요청 제한 시간"
+complete -c resctl -l direct -d "로컬 DB에 직접 접근"
+complete -c resctl -n "__fish_seen_subcommand_from list" -l page -l size -x
+complete -c resctl -n "__fish_seen_subcommand_from create update" -l name -x
+complete -c resctl -n "__fish_seen_subcommand_from import export" -l format -x -a "csv ndjson json"
+complete -c resctl -n "__fish_seen_subcommand_from import" -F -l dry-run -l upsert
+complete -c resctl -n "__fish_seen_subcommand_from export" -s f -r -F
+complete -c resctl -n "__fish_seen_subcommand_from watch" -l interval -x
+complete -c resctl -n "__fish_seen_subcommand_from profile" -x -a "list use set delete (resctl profile list -q 2>/dev/null)"
+complete -c resctl -n "__fish_seen_subcommand_from completion" -x -a "bash zsh fish"
+`
+
+func (a *app) completion(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: bash, zsh, fish 중 하나를 지정해야 합니다", errUsage)
+	}
+	scripts := map[string]string{
+		"bash": bashCompletion,
+		"zsh":  zshCompletion,
+		"fish": fishCompletion,
+	}
+	script, ok := scripts[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: 지원하지 않는 셸 %q", errUsage, args[0])
+	}
+	_, err := fmt.Fprint(a.stdout, script)
+	return err
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 설정 파일 경로와 프로필을 덮어쓰는 환경 변수
+const (
+	envConfig  = "RESCTL_CONFIG"
+	envProfile = "RESCTL_PROFILE"
+	envServer  = "RESCTL_SERVER"
+	envToken   = "RESCTL_TOKEN"
+)
+
+const (
+	defaultProfile = "default"
+	defaultServer  = "http://localhost:8080"
+)
+
+// profile은 접속할 서버와 인증 정보
+type profile struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token,omitempty"`
+}
+
+// config는 설정 파일 내용
+//
+//	current: default
+//	profiles:
+//	  default:
+//	    server: http://localhost:8080
+//	  prod:
+//	    server: https://api.example.com
+//	    token: ...
+type config struct {
+	Current  string              `yaml:"current,omitempty"`
+	Profiles map[string]*profile `yaml:"profiles"`
+}
+
+// configPath는 설정 파일 경로 (RESCTL_CONFIG 또는 사용자 설정 디렉터리의 resctl/config.yaml)
+func configPath() (string, error) {
+	if p := os.Getenv(envConfig); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("설정 디렉터리를 찾을 수 없습니다: %w", err)
+	}
+	return filepath.Join(dir, "resctl", "config.yaml"), nil
+}
+
+// loadConfig는 설정 파일을 읽음 (파일이 없으면 빈 설정)
+func loadConfig() (*config, error) {
+	cfg := &config{Profiles: map[string]*profile{}}
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("설정 파일 파싱 실패 (%s): %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]*profile{}
+	}
+	return cfg, nil
+}
+
+// save는 설정 파일을 저장 (토큰이 들어 있으므로 본인만 읽을 수 있게 저장)
+func (c *config) save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// resolveProfile은 명령줄 옵션 > 환경 변수 > 설정 파일 > 기본값 순서로 접속 정보를 결정
+func resolveProfile(opts *commonOptions) (*profile, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	name := firstNonEmpty(opts.profile, os.Getenv(envProfile), cfg.Current, defaultProfile)
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		// 기본 프로필은 설정 파일이 없어도 사용할 수 있음
+		if name != defaultProfile {
+			return nil, fmt.Errorf("%w: 프로필 %q가 없습니다", errUsage, name)
+		}
+		p = &profile{}
+	}
+
+	return &profile{
+		Server: firstNonEmpty(opts.server, os.Getenv(envServer), p.Server, defaultServer),
+		Token:  firstNonEmpty(opts.token, os.Getenv(envToken), p.Token),
+	}, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// profileCmd는 프로필을 관리
+//
+//	resctl profile list
+//	resctl profile use 이름
+//	resctl profile set 이름 [--server URL] [--token 토큰]
+//	resctl profile delete 이름
+func (a *app) profileCmd(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: list, use, set, delete 중 하나를 지정해야 합니다", errUsage)
+	}
+
+	fs := a.newFlagSet("profile "+args[0], nil)
+	server := fs.String("server", "", "API 서버 주소 (set)")
+	token := fs.String("token", "", "API 인증 토큰 (set)")
+	quiet := fs.Bool("q", false, "이름만 출력 (list)")
+	names, err := parseFlags(fs, args[1:])
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	current := firstNonEmpty(cfg.Current, defaultProfile)
+
+	switch args[0] {
+	case "list":
+		sorted := make([]string, 0, len(cfg.Profiles))
+		for name := range cfg.Profiles {
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+		for _, name := range sorted {
+			switch {
+			case *quiet:
+				fmt.Fprintln(a.stdout, name)
+			case name == current:
+				fmt.Fprintf(a.stdout, "* %s\t%s\n", name, cfg.Profiles[name].Server)
+			default:
+				fmt.Fprintf(a.stdout, "  %s\t%s\n", name, cfg.Profiles[name].Server)
+			}
+		}
+		return nil
+
+	case "use", "set", "delete":
+		if len(names) != 1 {
+			return fmt.Errorf("%w: 프로필 이름을 하나 지정해야 합니다", errUsage)
+		}
+
+	default:
+		return fmt.Errorf("%w: 알 수 없는 profile 명령 %q", errUsage, args[0])
+	}
+
+	name := names[0]
+	switch args[0] {
+	case "use":
+		if _, ok := cfg.Profiles[name]; !ok {
+			return fmt.Errorf("%w: 프로필 %q가 없습니다", errUsage, name)
+		}
+		cfg.Current = name
+
+	case "set":
+		p, ok := cfg.Profiles[name]
+		if !ok {
+			p = &profile{Server: defaultServer}
+			cfg.Profiles[name] = p
+		}
+		if *server != "" {
+			p.Server = *server
+		}
+		if *token != "" {
+			p.Token = *token
+		}
+		if cfg.Current == "" {
+			cfg.Current = name
+		}
+
+	case "delete":
+		if _, ok := cfg.Profiles[name]; !ok {
+			return fmt.Errorf("%w: 프로필 %q가 없습니다", errUsage, name)
+		}
+		delete(cfg.Profiles, name)
+		if cfg.Current == name {
+			cfg.Current = ""
+		}
+	}
+	return cfg.save()
+}
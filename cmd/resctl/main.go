@@ -0,0 +1,394 @@
+// resctl은 리소스를 관리하는 명령줄 도구
+//
+// 사용법:
+//
+//	resctl list [--page N --size N]          - 리소스 목록
+//	resctl get ID                            - 리소스 조회
+//	resctl create --name 이름                 - 리소스 생성
+//	resctl update ID --name 이름              - 리소스 수정
+//	resctl delete ID...                      - 리소스 삭제
+//	resctl import [--format csv] [--dry-run] [--upsert] 파일
+//	resctl export [--format json] [-f 파일]
+//	resctl watch [--interval 2s]             - 변경 사항을 계속 출력
+//	resctl profile list|use|set|delete       - 접속 프로필 관리
+//	resctl completion bash|zsh|fish          - 셸 자동완성 스크립트 출력
+//
+// 공통 옵션:
+//
+//	-o, --output table|json|yaml   출력 형식 (기본: table)
+//	--profile 이름                  사용할 프로필 (기본: 설정 파일의 current)
+//	--server URL, --token 토큰       프로필 대신 직접 지정
+//	--direct                       API 대신 로컬 DB에 Usecase로 직접 접근
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"go_project/pkg/client"
+)
+
+// 종료 코드
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitNotFound = 3
+)
+
+var errUsage = errors.New("잘못된 사용법")
+
+// commonOptions는 모든 하위 명령이 받는 옵션
+type commonOptions struct {
+	output  string
+	profile string
+	server  string
+	token   string
+	timeout time.Duration
+	direct  bool
+}
+
+func (o *commonOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.output, "output", "table", "출력 형식 (table, json, yaml)")
+	fs.StringVar(&o.output, "o", "table", "--output의 줄임")
+	fs.StringVar(&o.profile, "profile", "", "사용할 프로필")
+	fs.StringVar(&o.server, "server", "", "API 서버 주소 (예: http://localhost:8080)")
+	fs.StringVar(&o.token, "token", "", "API 인증 토큰")
+	fs.DurationVar(&o.timeout, "timeout", 30*time.Second, "요청 제한 시간")
+	fs.BoolVar(&o.direct, "direct", false, "API 대신 로컬 DB에 직접 접근")
+}
+
+// app은 명령 실행에 필요한 입출력과 백엔드 생성 방법
+// 테스트에서 출력과 백엔드를 바꿔 끼울 수 있도록 분리
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	// newBackend는 옵션에 맞는 백엔드를 생성 (기본: API 또는 --direct면 로컬 DB)
+	newBackend func(opts *commonOptions) (backend, func(), error)
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, newBackend: defaultBackend}
+	os.Exit(a.run(ctx, os.Args[1:]))
+}
+
+// run은 명령을 실행하고 종료 코드를 반환
+func (a *app) run(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		a.usage()
+		return exitUsage
+	}
+
+	commands := map[string]func(context.Context, []string) error{
+		"list":       a.list,
+		"get":        a.get,
+		"create":     a.create,
+		"update":     a.update,
+		"delete":     a.delete,
+		"import":     a.importCmd,
+		"export":     a.export,
+		"watch":      a.watch,
+		"profile":    a.profileCmd,
+		"completion": a.completion,
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		a.usage()
+		return exitOK
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(a.stderr, "알 수 없는 명령: %s\n", name)
+		a.usage()
+		return exitUsage
+	}
+
+	err := cmd(ctx, args[1:])
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(a.stderr, "%s: %v\n", name, err)
+		return exitUsage
+	case errors.Is(err, client.ErrNotFound):
+		fmt.Fprintf(a.stderr, "오류: %v\n", err)
+		return exitNotFound
+	default:
+		fmt.Fprintf(a.stderr, "오류: %v\n", err)
+		return exitError
+	}
+}
+
+func (a *app) usage() {
+	fmt.Fprint(a.stderr, `사용법: resctl <명령> [옵션]
+
+명령:
+  list        리소스 목록
+  get         리소스 조회
+  create      리소스 생성
+  update      리소스 수정
+  delete      리소스 삭제
+  import      파일에서 리소스 가져오기
+  export      리소스 내보내기
+  watch       변경 사항을 계속 출력
+  profile     접속 프로필 관리
+  completion  셸 자동완성 스크립트 출력
+
+각 명령의 옵션은 "resctl <명령> -h"로 확인
+`)
+}
+
+// newFlagSet은 공통 옵션을 등록한 FlagSet을 생성
+func (a *app) newFlagSet(name string, opts *commonOptions) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	if opts != nil {
+		opts.register(fs)
+	}
+	return fs
+}
+
+// parseFlags는 위치 인자와 옵션이 섞여 있어도 모두 파싱하고 위치 인자만 반환
+// (flag 패키지는 첫 위치 인자에서 파싱을 멈추므로 "get 3 -o yaml" 같은 입력을 위해 필요)
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func parseID(s string) (uint, error) {
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: 잘못된 ID %q", errUsage, s)
+	}
+	return uint(id), nil
+}
+
+// withBackend는 옵션에 맞는 백엔드를 만들어 fn을 실행하고 정리
+func (a *app) withBackend(opts *commonOptions, fn func(backend, *printer) error) error {
+	p, err := newPrinter(opts.output, a.stdout)
+	if err != nil {
+		return err
+	}
+	b, closeFn, err := a.newBackend(opts)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	return fn(b, p)
+}
+
+func (a *app) list(ctx context.Context, args []string) error {
+	var opts commonOptions
+	fs := a.newFlagSet("list", &opts)
+	page := fs.Int("page", 0, "페이지 번호 (지정하지 않으면 전체)")
+	size := fs.Int("size", 20, "페이지 크기")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	return a.withBackend(&opts, func(b backend, p *printer) error {
+		var resources []*client.Resource
+		var err error
+		if *page > 0 {
+			var pg *client.Page
+			if pg, err = b.List(ctx, client.ListOptions{Page: *page, Size: *size}); err == nil {
+				resources = pg.Items
+			}
+		} else {
+			resources, err = listAll(ctx, b)
+		}
+		if err != nil {
+			return err
+		}
+		return p.resources(resources)
+	})
+}
+
+func (a *app) get(ctx context.Context, args []string) error {
+	var opts commonOptions
+	fs := a.newFlagSet("get", &opts)
+	ids, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return fmt.Errorf("%w: 조회할 ID를 하나 지정해야 합니다", errUsage)
+	}
+	id, err := parseID(ids[0])
+	if err != nil {
+		return err
+	}
+
+	return a.withBackend(&opts, func(b backend, p *printer) error {
+		r, err := b.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		return p.resource(r)
+	})
+}
+
+func (a *app) create(ctx context.Context, args []string) error {
+	var opts commonOptions
+	fs := a.newFlagSet("create", &opts)
+	name := fs.String("name", "", "리소스 이름")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return fmt.Errorf("%w: --name을 지정해야 합니다", errUsage)
+	}
+
+	return a.withBackend(&opts, func(b backend, p *printer) error {
+		r, err := b.Create(ctx, &client.Resource{Name: *name})
+		if err != nil {
+			return err
+		}
+		return p.resource(r)
+	})
+}
+
+func (a *app) update(ctx context.Context, args []string) error {
+	var opts commonOptions
+	fs := a.newFlagSet("update", &opts)
+	name := fs.String("name", "", "새 리소스 이름")
+	ids, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return fmt.Errorf("%w: 수정할 ID를 하나 지정해야 합니다", errUsage)
+	}
+	id, err := parseID(ids[0])
+	if err != nil {
+		return err
+	}
+	if *name == "" {
+		return fmt.Errorf("%w: --name을 지정해야 합니다", errUsage)
+	}
+
+	return a.withBackend(&opts, func(b backend, p *printer) error {
+		r, err := b.Update(ctx, id, &client.Resource{ID: id, Name: *name})
+		if err != nil {
+			return err
+		}
+		return p.resource(r)
+	})
+}
+
+func (a *app) delete(ctx context.Context, args []string) error {
+	var opts commonOptions
+	fs := a.newFlagSet("delete", &opts)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("%w: 삭제할 ID를 지정해야 합니다", errUsage)
+	}
+	ids := make([]uint, len(args))
+	for i, arg := range args {
+		if ids[i], err = parseID(arg); err != nil {
+			return err
+		}
+	}
+
+	return a.withBackend(&opts, func(b backend, p *printer) error {
+		for _, id := range ids {
+			if err := b.Delete(ctx, id); err != nil {
+				return fmt.Errorf("%d 삭제 실패: %w", id, err)
+			}
+			fmt.Fprintf(a.stderr, "%d 삭제됨\n", id)
+		}
+		return nil
+	})
+}
+
+func (a *app) importCmd(ctx context.Context, args []string) error {
+	var opts commonOptions
+	fs := a.newFlagSet("import", &opts)
+	format := fs.String("format", "", "입력 형식 (csv, ndjson, json, 기본: 확장자로 판단)")
+	dryRun := fs.Bool("dry-run", false, "검증만 하고 저장하지 않음")
+	upsert := fs.Bool("upsert", false, "같은 이름의 리소스가 있으면 수정")
+	paths, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(paths) != 1 {
+		return fmt.Errorf("%w: 가져올 파일 경로를 하나 지정해야 합니다 (표준 입력은 -)", errUsage)
+	}
+
+	path := paths[0]
+	var in io.Reader = a.stdin
+	if path == "-" {
+		if *format == "" {
+			return fmt.Errorf("%w: 표준 입력에서 읽을 때는 --format을 지정해야 합니다", errUsage)
+		}
+		path = "stdin." + *format
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	return a.withBackend(&opts, func(b backend, p *printer) error {
+		report, err := b.Import(ctx, path, in, client.ImportOptions{Format: *format, DryRun: *dryRun, Upsert: *upsert})
+		if report != nil {
+			if perr := p.importReport(report); perr != nil {
+				return perr
+			}
+		}
+		return err
+	})
+}
+
+func (a *app) export(ctx context.Context, args []string) error {
+	var opts commonOptions
+	fs := a.newFlagSet("export", &opts)
+	format := fs.String("format", "json", "출력 형식 (csv, ndjson, json)")
+	file := fs.String("f", "", "출력 파일 경로 (기본: 표준 출력)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	return a.withBackend(&opts, func(b backend, p *printer) error {
+		out := a.stdout
+		if *file != "" {
+			f, err := os.Create(*file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+		return b.Export(ctx, *format, out)
+	})
+}
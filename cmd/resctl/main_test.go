@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go_project/internal/handler"
+	"go_project/internal/model"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/usecase"
+	"go_project/pkg/client"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
+)
+
+// ResctlTestSuite는 메모리 Recorder 위의 실제 API 서버에 명령을 실행해서 검증
+type ResctlTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+	uc     usecase.Usecase[model.Base]
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	app    *app
+}
+
+func (s *ResctlTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.uc = usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))
+	router := gin.New()
+	handler.NewHandler(s.uc).RegisterAPIRoutes(router)
+	s.server = httptest.NewServer(router)
+
+	s.T().Setenv(envConfig, filepath.Join(s.T().TempDir(), "config.yaml"))
+	s.T().Setenv(envServer, s.server.URL)
+
+	s.stdout, s.stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	s.app = &app{stdin: strings.NewReader(""), stdout: s.stdout, stderr: s.stderr, newBackend: defaultBackend}
+}
+
+func (s *ResctlTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// exec는 명령을 실행하고 종료 코드를 반환 (출력 버퍼는 매번 비움)
+func (s *ResctlTestSuite) exec(args ...string) int {
+	s.stdout.Reset()
+	s.stderr.Reset()
+	return s.app.run(context.Background(), args)
+}
+
+func (s *ResctlTestSuite) TestCRUD() {
+	s.Equal(exitOK, s.exec("create", "--name", "테스트_데이터", "-o", "json"))
+	var created client.Resource
+	s.NoError(json.Unmarshal(s.stdout.Bytes(), &created))
+	s.Equal("테스트_데이터", created.Name)
+
+	// 위치 인자 뒤의 옵션도 파싱
+	s.Equal(exitOK, s.exec("update", "1", "--name", "수정된_데이터", "-o", "yaml"))
+	var updated client.Resource
+	s.NoError(yaml.Unmarshal(s.stdout.Bytes(), &updated))
+	s.Equal("수정된_데이터", updated.Name)
+
+	s.Equal(exitOK, s.exec("get", "1"))
+	s.Contains(s.stdout.String(), "NAME")
+	s.Contains(s.stdout.String(), "수정된_데이터")
+
+	s.Equal(exitOK, s.exec("delete", "1"))
+	s.Equal(exitNotFound, s.exec("get", "1"))
+	s.Contains(s.stderr.String(), "찾을 수 없습니다")
+}
+
+func (s *ResctlTestSuite) TestList() {
+	for _, name := range []string{"a", "b", "c"} {
+		s.Equal(exitOK, s.exec("create", "--name", name))
+	}
+
+	s.Equal(exitOK, s.exec("list", "-o", "json"))
+	var all []client.Resource
+	s.NoError(json.Unmarshal(s.stdout.Bytes(), &all))
+	s.Len(all, 3)
+
+	s.Equal(exitOK, s.exec("list", "--page", "2", "--size", "2", "-o", "json"))
+	var page []client.Resource
+	s.NoError(json.Unmarshal(s.stdout.Bytes(), &page))
+	s.Len(page, 1)
+	s.Equal("c", page[0].Name)
+}
+
+func (s *ResctlTestSuite) TestUsage() {
+	s.Equal(exitUsage, s.exec())
+	s.Equal(exitUsage, s.exec("unknown"))
+	s.Equal(exitUsage, s.exec("get", "abc"))
+	s.Equal(exitUsage, s.exec("list", "-o", "xml"))
+	s.Equal(exitUsage, s.exec("create"))
+}
+
+func (s *ResctlTestSuite) TestImportExport() {
+	path := filepath.Join(s.T().TempDir(), "resources.csv")
+	s.NoError(os.WriteFile(path, []byte("name\na\nb\n"), 0o600))
+
+	s.Equal(exitOK, s.exec("import", path))
+	s.Contains(s.stdout.String(), "생성 2")
+
+	// 표준 입력에서 읽기
+	s.app.stdin = strings.NewReader(`{"name":"c"}` + "\n")
+	s.Equal(exitOK, s.exec("import", "--format", "ndjson", "-", "-o", "json"))
+	var report client.ImportReport
+	s.NoError(json.Unmarshal(s.stdout.Bytes(), &report))
+	s.Equal(1, report.Created)
+
+	s.Equal(exitOK, s.exec("export", "--format", "csv"))
+	s.Equal(4, strings.Count(s.stdout.String(), "\n"))
+}
+
+func (s *ResctlTestSuite) TestProfile() {
+	s.T().Setenv(envServer, "")
+	s.Equal(exitOK, s.exec("profile", "set", "test", "--server", s.server.URL, "--token", "secret"))
+	s.Equal(exitOK, s.exec("profile", "set", "other", "--server", "http://127.0.0.1:1"))
+
+	s.Equal(exitOK, s.exec("profile", "list"))
+	s.Contains(s.stdout.String(), "* test")
+
+	p, err := resolveProfile(&commonOptions{})
+	s.NoError(err)
+	s.Equal(s.server.URL, p.Server)
+	s.Equal("secret", p.Token)
+
+	// 명령줄 옵션이 설정 파일보다 우선
+	p, err = resolveProfile(&commonOptions{profile: "other", token: "override"})
+	s.NoError(err)
+	s.Equal("http://127.0.0.1:1", p.Server)
+	s.Equal("override", p.Token)
+
+	s.Equal(exitUsage, s.exec("profile", "use", "none"))
+	s.Equal(exitOK, s.exec("profile", "delete", "other"))
+	_, err = resolveProfile(&commonOptions{profile: "other"})
+	s.ErrorIs(err, errUsage)
+}
+
+func (s *ResctlTestSuite) TestWatch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.NoError(s.uc.Insert(ctx, &model.Base{Name: "a"}))
+
+	s.stdout.Reset()
+	done := make(chan int)
+	go func() {
+		done <- s.app.run(ctx, []string{"watch", "--interval", "10ms", "-o", "json"})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	s.NoError(s.uc.Remove(ctx, 1))
+	s.NoError(s.uc.Insert(ctx, &model.Base{Name: "b"}))
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	s.Equal(exitOK, <-done)
+
+	var types []string
+	dec := json.NewDecoder(s.stdout)
+	for dec.More() {
+		var e watchEvent
+		s.NoError(dec.Decode(&e))
+		types = append(types, e.Type+":"+e.Resource.Name)
+	}
+	s.Equal([]string{"ADDED:a", "DELETED:a", "ADDED:b"}, types)
+}
+
+func TestResctlTestSuite(t *testing.T) {
+	suite.Run(t, new(ResctlTestSuite))
+}
+
+func TestDiffResources(t *testing.T) {
+	now := time.Now()
+	prev := map[uint]*client.Resource{
+		1: {ID: 1, Name: "a", UpdatedAt: now},
+		2: {ID: 2, Name: "b", UpdatedAt: now},
+	}
+	curr := map[uint]*client.Resource{
+		1: {ID: 1, Name: "a", UpdatedAt: now},
+		2: {ID: 2, Name: "b2", UpdatedAt: now.Add(time.Second)},
+		3: {ID: 3, Name: "c", UpdatedAt: now},
+	}
+
+	events := diffResources(prev, curr)
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Type)
+	}
+	want := []string{eventModified, eventAdded}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("이벤트 %v, 기대값 %v", got, want)
+	}
+
+	events = diffResources(curr, map[uint]*client.Resource{})
+	if len(events) != 3 || events[0].Type != eventDeleted {
+		t.Fatalf("삭제 이벤트가 3개여야 함: %+v", events)
+	}
+}
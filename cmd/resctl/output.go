@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"go_project/pkg/client"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 출력 형식
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// printer는 명령 결과를 선택한 형식으로 출력
+// table은 사람이 읽기 위한 형식이고, json/yaml은 스크립트에서 다시 파싱하기 위한 형식
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	switch format {
+	case outputTable, outputJSON, outputYAML:
+		return &printer{format: format, w: w}, nil
+	}
+	return nil, fmt.Errorf("%w: 지원하지 않는 출력 형식 %q (table, json, yaml)", errUsage, format)
+}
+
+func (p *printer) resources(resources []*client.Resource) error {
+	if p.format != outputTable {
+		if resources == nil {
+			resources = []*client.Resource{}
+		}
+		return p.encode(resources)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tCREATED\tUPDATED")
+	for _, r := range resources {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", r.ID, r.Name, formatTime(r.CreatedAt), formatTime(r.UpdatedAt))
+	}
+	return tw.Flush()
+}
+
+func (p *printer) resource(r *client.Resource) error {
+	if p.format != outputTable {
+		return p.encode(r)
+	}
+	return p.resources([]*client.Resource{r})
+}
+
+func (p *printer) importReport(report *client.ImportReport) error {
+	if p.format != outputTable {
+		return p.encode(report)
+	}
+
+	if report.DryRun {
+		fmt.Fprintln(p.w, "(dry-run: 저장하지 않음)")
+	}
+	fmt.Fprintf(p.w, "전체 %d, 생성 %d, 수정 %d, 실패 %d\n", report.Total, report.Created, report.Updated, report.Failed)
+	if len(report.Errors) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ROW\tERROR")
+	for _, e := range report.Errors {
+		fmt.Fprintf(tw, "%d\t%s\n", e.Row, e.Message)
+	}
+	return tw.Flush()
+}
+
+// event는 watch가 감지한 변경 사항 하나를 출력
+// json은 한 줄에 이벤트 하나(NDJSON), yaml은 문서 구분자(---)로 이벤트를 나눔
+func (p *printer) event(e watchEvent) error {
+	switch p.format {
+	case outputJSON:
+		return json.NewEncoder(p.w).Encode(e)
+	case outputYAML:
+		fmt.Fprintln(p.w, "---")
+		return yaml.NewEncoder(p.w).Encode(e)
+	}
+	_, err := fmt.Fprintf(p.w, "%-8s %d\t%s\t%s\n", e.Type, e.Resource.ID, e.Resource.Name, formatTime(e.Resource.UpdatedAt))
+	return err
+}
+
+func (p *printer) encode(v interface{}) error {
+	if p.format == outputYAML {
+		enc := yaml.NewEncoder(p.w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go_project/pkg/client"
+)
+
+// 변경 종류
+const (
+	eventAdded    = "ADDED"
+	eventModified = "MODIFIED"
+	eventDeleted  = "DELETED"
+)
+
+// watchEvent는 watch가 감지한 리소스 변경
+type watchEvent struct {
+	Type     string           `json:"type" yaml:"type"`
+	Resource *client.Resource `json:"resource" yaml:"resource"`
+}
+
+// diffResources는 이전 목록과 현재 목록을 비교해서 변경 사항을 ID 순으로 반환
+// 수정 여부는 updated_at으로 판단
+func diffResources(prev, curr map[uint]*client.Resource) []watchEvent {
+	var events []watchEvent
+	for id, r := range curr {
+		old, ok := prev[id]
+		switch {
+		case !ok:
+			events = append(events, watchEvent{Type: eventAdded, Resource: r})
+		case !old.UpdatedAt.Equal(r.UpdatedAt) || old.Name != r.Name:
+			events = append(events, watchEvent{Type: eventModified, Resource: r})
+		}
+	}
+	for id, r := range prev {
+		if _, ok := curr[id]; !ok {
+			events = append(events, watchEvent{Type: eventDeleted, Resource: r})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Resource.ID < events[j].Resource.ID })
+	return events
+}
+
+// watch는 목록을 주기적으로 조회해서 바뀐 리소스를 출력
+// 처음 조회한 리소스는 ADDED로 출력하며, Ctrl+C로 끝낼 때까지 계속 실행
+func (a *app) watch(ctx context.Context, args []string) error {
+	var opts commonOptions
+	fs := a.newFlagSet("watch", &opts)
+	interval := fs.Duration("interval", 2*time.Second, "조회 주기")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("%w: --interval은 0보다 커야 합니다", errUsage)
+	}
+
+	return a.withBackend(&opts, func(b backend, p *printer) error {
+		prev := map[uint]*client.Resource{}
+		ticker := time.NewTicker(*interval)
+		defer ticker.Stop()
+
+		for {
+			resources, err := listAll(ctx, b)
+			switch {
+			case ctx.Err() != nil:
+				return nil
+			case err != nil:
+				// 일시적인 오류로 watch를 끝내지 않고 다음 주기에 다시 시도
+				fmt.Fprintf(a.stderr, "조회 실패: %v\n", err)
+			default:
+				curr := make(map[uint]*client.Resource, len(resources))
+				for _, r := range resources {
+					curr[r.ID] = r
+				}
+				for _, e := range diffResources(prev, curr) {
+					if err := p.event(e); err != nil {
+						return err
+					}
+				}
+				prev = curr
+			}
+
+			select {
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.Canceled) {
+					return nil
+				}
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+	})
+}
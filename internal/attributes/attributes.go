@@ -0,0 +1,205 @@
+// Package attributes는 리소스에 붙이는 스키마 없는 JSON 속성을 제공
+//
+// 속성은 DB에 JSONB 열로 저장되며, 경로 필터(예: owner.email=a@example.com)로 조회하고
+// 배포별 JSON Schema로 형식을 검증할 수 있음
+package attributes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Map은 리소스의 속성 (값은 JSON으로 표현할 수 있는 임의의 값)
+type Map map[string]any
+
+// Clone은 중첩된 객체와 배열까지 복사한 Map을 반환 (nil이면 nil)
+func (m Map) Clone() Map {
+	if m == nil {
+		return nil
+	}
+	return cloneValue(map[string]any(m)).(map[string]any)
+}
+
+func cloneValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+		for k, e := range v {
+			c[k] = cloneValue(e)
+		}
+		return c
+	case Map:
+		return map[string]any(v.Clone())
+	case []any:
+		c := make([]any, len(v))
+		for i, e := range v {
+			c[i] = cloneValue(e)
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// Lookup은 path를 따라 내려간 값을 반환
+// PostgreSQL의 #> 연산자와 같이 배열에서는 정수 경로를 인덱스로 사용 (음수는 끝에서부터)
+func (m Map) Lookup(path []string) (any, bool) {
+	var cur any = map[string]any(m)
+	for _, p := range path {
+		switch v := cur.(type) {
+		case map[string]any:
+			next, ok := v[p]
+			if !ok {
+				return nil, false
+			}
+			cur = next
+		case []any:
+			i, err := strconv.Atoi(p)
+			if err != nil {
+				return nil, false
+			}
+			if i < 0 {
+				i += len(v)
+			}
+			if i < 0 || i >= len(v) {
+				return nil, false
+			}
+			cur = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// Text는 값을 PostgreSQL의 #>> 연산자 결과와 같은 문자열로 변환
+// 문자열은 그대로, 그 외의 값은 JSON 표현을 사용하며 null은 값이 없는 것으로 봄
+// (객체와 배열은 공백과 키 순서가 PostgreSQL의 jsonb 표현과 다를 수 있으므로 스칼라 값 비교에 사용)
+func Text(v any) (string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(b), true
+	}
+}
+
+// Value는 DB에 JSON으로 저장 (nil이면 빈 객체)
+func (m Map) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(map[string]any(m))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan은 DB의 JSON 값을 읽음 (숫자는 원래 표기를 유지하도록 json.Number로 읽음)
+func (m *Map) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("속성 열을 읽을 수 없습니다: %T", src)
+	}
+	return m.decode(b)
+}
+
+func (m *Map) decode(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v map[string]any
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	if len(v) == 0 {
+		v = nil
+	}
+	*m = v
+	return nil
+}
+
+// XML은 임의의 중첩 구조를 요소로 표현하기 어려우므로 <attributes>{"owner":"a"}</attributes>처럼 JSON 문자열로 담음
+func (m Map) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	v, err := m.Value()
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(v, start)
+}
+
+func (m *Map) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	if strings.TrimSpace(s) == "" {
+		*m = nil
+		return nil
+	}
+	return m.decode([]byte(s))
+}
+
+// Filter는 경로의 값이 Value와 같은 속성만 남기는 조건
+type Filter struct {
+	Path  []string
+	Value string
+}
+
+// ParseFilter는 점으로 구분한 경로(예: owner.email)와 값으로 Filter를 만듦
+func ParseFilter(path, value string) (Filter, error) {
+	parts := strings.Split(path, ".")
+	for _, p := range parts {
+		if p == "" {
+			return Filter{}, fmt.Errorf("잘못된 속성 경로: %q", path)
+		}
+	}
+	return Filter{Path: parts, Value: value}, nil
+}
+
+// String은 path=value 형식으로 변환
+func (f Filter) String() string {
+	return strings.Join(f.Path, ".") + "=" + f.Value
+}
+
+// Matches는 경로의 값을 문자열로 바꾼 결과가 Value와 같은지 확인 (값이 없거나 null이면 false)
+func (f Filter) Matches(m Map) bool {
+	v, ok := m.Lookup(f.Path)
+	if !ok {
+		return false
+	}
+	text, ok := Text(v)
+	return ok && text == f.Value
+}
+
+// Filters는 모든 조건을 만족해야 하는 Filter 목록
+type Filters []Filter
+
+// Matches는 모든 조건을 만족하는지 확인 (조건이 없으면 true)
+func (fs Filters) Matches(m Map) bool {
+	for _, f := range fs {
+		if !f.Matches(m) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package attributes
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AttributesTestSuite struct {
+	suite.Suite
+}
+
+func (s *AttributesTestSuite) attrs(doc string) Map {
+	var m Map
+	s.Require().NoError(m.Scan(doc))
+	return m
+}
+
+func (s *AttributesTestSuite) TestFilter() {
+	m := s.attrs(`{"owner":{"email":"a@example.com","team":null},"replicas":3,"ratio":1.50,"active":true,"tags":["x","y"]}`)
+	tests := []struct {
+		path  string
+		value string
+		want  bool
+	}{
+		{path: "owner.email", value: "a@example.com", want: true},
+		{path: "owner.email", value: "b@example.com", want: false},
+		{path: "owner.team", value: "null", want: false},
+		{path: "owner.missing", value: "", want: false},
+		{path: "replicas", value: "3", want: true},
+		{path: "ratio", value: "1.50", want: true},
+		{path: "active", value: "true", want: true},
+		{path: "tags.1", value: "y", want: true},
+		{path: "tags.-1", value: "y", want: true},
+		{path: "tags.x", value: "y", want: false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.path+"="+tt.value, func() {
+			f, err := ParseFilter(tt.path, tt.value)
+			s.Require().NoError(err)
+			s.Equal(tt.want, f.Matches(m))
+		})
+	}
+
+	_, err := ParseFilter("owner..email", "a")
+	s.Error(err)
+	s.True(Filters(nil).Matches(nil))
+}
+
+func (s *AttributesTestSuite) TestClone() {
+	m := s.attrs(`{"owner":{"email":"a@example.com"},"tags":["x"]}`)
+	c := m.Clone()
+	c["owner"].(map[string]any)["email"] = "b@example.com"
+	c["tags"].([]any)[0] = "z"
+
+	s.Equal("a@example.com", m["owner"].(map[string]any)["email"])
+	s.Equal("x", m["tags"].([]any)[0])
+	s.Nil(Map(nil).Clone())
+}
+
+func (s *AttributesTestSuite) TestEncoding() {
+	v, err := Map(nil).Value()
+	s.NoError(err)
+	s.Equal("{}", v)
+
+	var m Map
+	s.NoError(m.Scan([]byte("{}")))
+	s.Nil(m)
+
+	type item struct {
+		Attributes Map `xml:"attributes"`
+	}
+	b, err := xml.Marshal(item{Attributes: Map{"owner": "a"}})
+	s.NoError(err)
+	s.Equal(`<item><attributes>{&#34;owner&#34;:&#34;a&#34;}</attributes></item>`, string(b))
+
+	var got item
+	s.NoError(xml.Unmarshal(b, &got))
+	s.Equal(Map{"owner": "a"}, got.Attributes)
+}
+
+func (s *AttributesTestSuite) TestSchema() {
+	schema, err := ParseSchema([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["owner"],
+		"additionalProperties": false,
+		"properties": {
+			"owner": {
+				"type": "object",
+				"required": ["email"],
+				"properties": {"email": {"type": "string", "pattern": "^[^@]+@[^@]+$"}}
+			},
+			"replicas": {"type": "integer", "minimum": 1, "maximum": 10},
+			"tier": {"enum": ["gold", "silver"]},
+			"tags": {"type": "array", "maxItems": 2, "items": {"type": "string", "minLength": 1}},
+			"note": {"type": ["string", "null"]}
+		}
+	}`))
+	s.Require().NoError(err)
+
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{name: "정상", doc: `{"owner":{"email":"a@example.com"},"replicas":3,"tier":"gold","tags":["x"],"note":null}`},
+		{name: "필수_없음", doc: `{}`, wantErr: "속성 owner: 필수입니다"},
+		{name: "중첩_필수_없음", doc: `{"owner":{}}`, wantErr: "속성 owner.email: 필수입니다"},
+		{name: "패턴", doc: `{"owner":{"email":"a"}}`, wantErr: "속성 owner.email: 형식이 맞지 않습니다 (^[^@]+@[^@]+$)"},
+		{name: "정수_아님", doc: `{"owner":{"email":"a@b"},"replicas":1.5}`, wantErr: "속성 replicas: integer 타입이어야 합니다"},
+		{name: "최댓값", doc: `{"owner":{"email":"a@b"},"replicas":11}`, wantErr: "속성 replicas: 10 이하여야 합니다"},
+		{name: "열거형", doc: `{"owner":{"email":"a@b"},"tier":"bronze"}`, wantErr: "속성 tier: 허용되지 않는 값입니다"},
+		{name: "배열_항목", doc: `{"owner":{"email":"a@b"},"tags":["x",""]}`, wantErr: "속성 tags[1]: 1자 이상이어야 합니다"},
+		{name: "배열_길이", doc: `{"owner":{"email":"a@b"},"tags":["x","y","z"]}`, wantErr: "속성 tags: 항목이 2개 이하여야 합니다"},
+		{name: "추가_속성", doc: `{"owner":{"email":"a@b"},"extra":1}`, wantErr: "속성 extra: 허용되지 않는 속성입니다"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// 요청 본문(float64)과 DB에서 읽은 값(json.Number) 모두 같은 결과
+			var fromRequest Map
+			s.Require().NoError(json.Unmarshal([]byte(tt.doc), &fromRequest))
+			for _, m := range []Map{fromRequest, s.attrs(tt.doc)} {
+				err := schema.Validate(m)
+				if tt.wantErr == "" {
+					s.NoError(err)
+					continue
+				}
+				s.EqualError(err, tt.wantErr)
+			}
+		})
+	}
+
+	_, err = ParseSchema([]byte(`{"type":"text"}`))
+	s.Error(err)
+	_, err = ParseSchema([]byte(`{"properties":{"a":{"pattern":"("}}}`))
+	s.Error(err)
+}
+
+func TestAttributesSuite(t *testing.T) {
+	suite.Run(t, new(AttributesTestSuite))
+}
@@ -0,0 +1,314 @@
+package attributes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema는 속성 검증에 사용하는 JSON Schema
+//
+// 지원하는 키워드: type, properties, required, additionalProperties, items, enum,
+// minimum, maximum, minLength, maxLength, pattern, minItems, maxItems
+// 그 외의 키워드($schema, title, description 등)는 무시
+type Schema struct {
+	types                []string
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema // nil이면 허용, denyAll이면 금지
+	items                *Schema
+	enum                 []any
+	minimum, maximum     *float64
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+	minItems, maxItems   *int
+}
+
+// additionalProperties: false를 나타내는 스키마
+var denyAll = &Schema{}
+
+// rawSchema는 JSON Schema 문서를 읽기 위한 중간 형식
+type rawSchema struct {
+	Type                 json.RawMessage       `json:"type"`
+	Properties           map[string]*rawSchema `json:"properties"`
+	Required             []string              `json:"required"`
+	AdditionalProperties json.RawMessage       `json:"additionalProperties"`
+	Items                *rawSchema            `json:"items"`
+	Enum                 []any                 `json:"enum"`
+	Minimum              *float64              `json:"minimum"`
+	Maximum              *float64              `json:"maximum"`
+	MinLength            *int                  `json:"minLength"`
+	MaxLength            *int                  `json:"maxLength"`
+	Pattern              string                `json:"pattern"`
+	MinItems             *int                  `json:"minItems"`
+	MaxItems             *int                  `json:"maxItems"`
+}
+
+var schemaTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true,
+	"integer": true, "boolean": true, "null": true,
+}
+
+// ParseSchema는 JSON Schema 문서를 읽어서 Schema를 만듦
+func ParseSchema(data []byte) (*Schema, error) {
+	var raw rawSchema
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("속성 스키마를 읽을 수 없습니다: %v", err)
+	}
+	s, err := compile(&raw, "")
+	if err != nil {
+		return nil, fmt.Errorf("잘못된 속성 스키마: %v", err)
+	}
+	return s, nil
+}
+
+func compile(raw *rawSchema, path string) (*Schema, error) {
+	s := &Schema{
+		required:  raw.Required,
+		enum:      raw.Enum,
+		minimum:   raw.Minimum,
+		maximum:   raw.Maximum,
+		minLength: raw.MinLength,
+		maxLength: raw.MaxLength,
+		minItems:  raw.MinItems,
+		maxItems:  raw.MaxItems,
+	}
+
+	if len(raw.Type) > 0 {
+		if err := json.Unmarshal(raw.Type, &s.types); err != nil {
+			var t string
+			if err := json.Unmarshal(raw.Type, &t); err != nil {
+				return nil, fmt.Errorf("%stype은 문자열이나 문자열 배열이어야 합니다", at(path))
+			}
+			s.types = []string{t}
+		}
+		for _, t := range s.types {
+			if !schemaTypes[t] {
+				return nil, fmt.Errorf("%s알 수 없는 type: %q", at(path), t)
+			}
+		}
+	}
+
+	if raw.Pattern != "" {
+		re, err := regexp.Compile(raw.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s잘못된 pattern: %v", at(path), err)
+		}
+		s.pattern = re
+	}
+
+	if len(raw.Properties) > 0 {
+		s.properties = make(map[string]*Schema, len(raw.Properties))
+		for name, p := range raw.Properties {
+			ps, err := compile(p, join(path, name))
+			if err != nil {
+				return nil, err
+			}
+			s.properties[name] = ps
+		}
+	}
+
+	if len(raw.AdditionalProperties) > 0 {
+		var allowed bool
+		if err := json.Unmarshal(raw.AdditionalProperties, &allowed); err == nil {
+			if !allowed {
+				s.additionalProperties = denyAll
+			}
+		} else {
+			var ap rawSchema
+			if err := json.Unmarshal(raw.AdditionalProperties, &ap); err != nil {
+				return nil, fmt.Errorf("%sadditionalProperties는 불리언이나 스키마여야 합니다", at(path))
+			}
+			aps, err := compile(&ap, path)
+			if err != nil {
+				return nil, err
+			}
+			s.additionalProperties = aps
+		}
+	}
+
+	if raw.Items != nil {
+		is, err := compile(raw.Items, path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		s.items = is
+	}
+	return s, nil
+}
+
+// Validate는 속성 전체를 검증하고 처음 발견한 문제를 경로와 함께 반환
+// nil인 속성은 빈 객체로 검증
+func (s *Schema) Validate(m Map) error {
+	if m == nil {
+		m = Map{}
+	}
+	return s.validate(map[string]any(m), "")
+}
+
+func (s *Schema) validate(v any, path string) error {
+	if len(s.types) > 0 && !s.hasType(v) {
+		return fmt.Errorf("속성 %s%s 타입이어야 합니다", at(path), strings.Join(s.types, " 또는 "))
+	}
+	if len(s.enum) > 0 && !s.inEnum(v) {
+		return fmt.Errorf("속성 %s허용되지 않는 값입니다", at(path))
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		return s.validateObject(v, path)
+	case []any:
+		if s.minItems != nil && len(v) < *s.minItems {
+			return fmt.Errorf("속성 %s항목이 %d개 이상이어야 합니다", at(path), *s.minItems)
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			return fmt.Errorf("속성 %s항목이 %d개 이하여야 합니다", at(path), *s.maxItems)
+		}
+		if s.items != nil {
+			for i, e := range v {
+				if err := s.items.validate(e, path+"["+strconv.Itoa(i)+"]"); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		n := utf8.RuneCountInString(v)
+		if s.minLength != nil && n < *s.minLength {
+			return fmt.Errorf("속성 %s%d자 이상이어야 합니다", at(path), *s.minLength)
+		}
+		if s.maxLength != nil && n > *s.maxLength {
+			return fmt.Errorf("속성 %s%d자 이하여야 합니다", at(path), *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			return fmt.Errorf("속성 %s형식이 맞지 않습니다 (%s)", at(path), s.pattern)
+		}
+	default:
+		if f, ok := number(v); ok {
+			if s.minimum != nil && f < *s.minimum {
+				return fmt.Errorf("속성 %s%v 이상이어야 합니다", at(path), *s.minimum)
+			}
+			if s.maximum != nil && f > *s.maximum {
+				return fmt.Errorf("속성 %s%v 이하여야 합니다", at(path), *s.maximum)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateObject(v map[string]any, path string) error {
+	for _, name := range s.required {
+		if _, ok := v[name]; !ok {
+			return fmt.Errorf("속성 %s필수입니다", at(join(path, name)))
+		}
+	}
+
+	// 에러 메시지가 항상 같도록 키 순서대로 확인
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		ps, ok := s.properties[k]
+		if !ok {
+			ps = s.additionalProperties
+		}
+		if ps == nil {
+			continue
+		}
+		if ps == denyAll {
+			return fmt.Errorf("속성 %s허용되지 않는 속성입니다", at(join(path, k)))
+		}
+		if err := ps.validate(v[k], join(path, k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) hasType(v any) bool {
+	for _, t := range s.types {
+		switch t {
+		case "object":
+			if _, ok := v.(map[string]any); ok {
+				return true
+			}
+		case "array":
+			if _, ok := v.([]any); ok {
+				return true
+			}
+		case "string":
+			if _, ok := v.(string); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := v.(bool); ok {
+				return true
+			}
+		case "null":
+			if v == nil {
+				return true
+			}
+		case "number":
+			if _, ok := number(v); ok {
+				return true
+			}
+		case "integer":
+			if f, ok := number(v); ok && f == math.Trunc(f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inEnum은 값이 enum 중 하나와 같은지 확인 (숫자는 표기와 관계없이 값으로 비교)
+func (s *Schema) inEnum(v any) bool {
+	for _, e := range s.enum {
+		if f, ok := number(v); ok {
+			if ef, ok := number(e); ok && f == ef {
+				return true
+			}
+			continue
+		}
+		if reflect.DeepEqual(v, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// number는 JSON 숫자 값을 float64로 변환 (DB에서 읽은 값은 json.Number)
+func number(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// at은 에러 메시지 앞에 붙일 경로 ("owner.email: ")
+func at(path string) string {
+	if path == "" {
+		return ""
+	}
+	return path + ": "
+}
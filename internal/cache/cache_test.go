@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CacheTestSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func (s *CacheTestSuite) SetupTest() {
+	s.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *CacheTestSuite) clock() time.Time {
+	return s.now
+}
+
+func (s *CacheTestSuite) TestLRU_Eviction() {
+	c := NewLRU[string, int](2, 0)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Get("a") // a를 최근에 사용한 항목으로 만듦
+	c.Add("c", 3)
+
+	_, ok := c.Get("b")
+	s.False(ok, "가장 오래 쓰지 않은 b를 버려야 함")
+	v, ok := c.Get("a")
+	s.True(ok)
+	s.Equal(1, v)
+	s.Equal(2, c.Len())
+	s.Equal(uint64(1), c.Evictions())
+
+	c.Remove("a")
+	c.Purge()
+	s.Equal(0, c.Len())
+}
+
+func (s *CacheTestSuite) TestLRU_TTL() {
+	c := NewLRU[int, string](10, time.Minute)
+	c.now = s.clock
+	c.Add(1, "기본 TTL")
+	c.AddWithTTL(2, "짧은 TTL", time.Second)
+	c.AddWithTTL(3, "만료 없음", 0)
+
+	s.now = s.now.Add(time.Second)
+	_, ok := c.Get(2)
+	s.False(ok)
+	_, ok = c.Get(1)
+	s.True(ok)
+
+	s.now = s.now.Add(time.Hour)
+	_, ok = c.Get(1)
+	s.False(ok)
+	_, ok = c.Get(3)
+	s.True(ok)
+	s.Equal(1, c.Len(), "만료된 항목은 조회할 때 지움")
+}
+
+func (s *CacheTestSuite) TestMemoryStore() {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	store.(*memoryStore).now = s.clock
+
+	value := []byte("값")
+	s.NoError(store.Set(ctx, "a", value, time.Second))
+	value[0] = 'x'
+	got, err := store.Get(ctx, "a")
+	s.NoError(err)
+	s.Equal([]byte("값"), got, "저장한 뒤 원본을 바꿔도 영향이 없어야 함")
+
+	s.now = s.now.Add(time.Second)
+	_, err = store.Get(ctx, "a")
+	s.ErrorIs(err, ErrMiss)
+
+	s.NoError(store.Set(ctx, "b", nil, 0))
+	s.NoError(store.Delete(ctx, "b"))
+	s.NoError(store.Delete(ctx, "없는_키"))
+	_, err = store.Get(ctx, "b")
+	s.ErrorIs(err, ErrMiss)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = store.Get(canceled, "a")
+	s.ErrorIs(err, context.Canceled)
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
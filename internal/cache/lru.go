@@ -0,0 +1,118 @@
+// Package cache는 조회 결과 캐시에 쓰는 프로세스 내 LRU와, 여러 서버가 함께 쓰는 원격 캐시 인터페이스를 제공
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU는 크기 제한과 항목별 만료 시각이 있는 캐시 (여러 고루틴에서 함께 사용해도 됨)
+// 가득 차면 가장 오래 쓰지 않은 항목부터 버리고, 만료된 항목은 조회할 때 지움
+type LRU[K comparable, V any] struct {
+	mu        sync.Mutex
+	capacity  int
+	ttl       time.Duration
+	items     map[K]*list.Element
+	order     *list.List // 앞쪽이 최근에 사용한 항목
+	evictions uint64
+	now       func() time.Time
+}
+
+type lruEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// NewLRU는 최대 capacity개를 ttl 동안 보관하는 LRU를 생성 (ttl이 0 이하면 만료되지 않음)
+func NewLRU[K comparable, V any](capacity int, ttl time.Duration) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: max(capacity, 1),
+		ttl:      ttl,
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Get은 key의 값을 반환하고 최근에 사용한 항목으로 표시 (없거나 만료되면 false)
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	e := el.Value.(*lruEntry[K, V])
+	if !e.expires.IsZero() && !c.now().Before(e.expires) {
+		c.removeElement(el)
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// Add는 기본 TTL로 값을 저장
+func (c *LRU[K, V]) Add(key K, value V) {
+	c.AddWithTTL(key, value, c.ttl)
+}
+
+// AddWithTTL은 ttl 동안 유지되는 값을 저장 (이미 있으면 바꿈, ttl이 0 이하면 만료되지 않음)
+func (c *LRU[K, V]) AddWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.now().Add(ttl)
+	}
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*lruEntry[K, V])
+		e.value, e.expires = value, expires
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value, expires: expires})
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+		c.evictions++
+	}
+}
+
+// Remove는 key를 지움 (없으면 아무것도 하지 않음)
+func (c *LRU[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+}
+
+// Purge는 모든 항목을 지움
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[K]*list.Element)
+	c.order.Init()
+}
+
+// Len은 저장된 항목 수 (만료됐지만 아직 지우지 않은 항목 포함)
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Evictions는 크기 제한 때문에 버린 항목 수
+func (c *LRU[K, V]) Evictions() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.evictions
+}
+
+func (c *LRU[K, V]) removeElement(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*lruEntry[K, V]).key)
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrMiss는 원격 캐시에 키가 없을 때 반환 (errors.Is로 확인)
+var ErrMiss = errors.New("캐시에 없는 키")
+
+// Store는 여러 서버가 함께 쓰는 원격 캐시 (예: Redis, Memcached)
+// 값은 바이트로 주고받으므로 직렬화는 호출하는 쪽에서 함
+type Store interface {
+	// Get은 key의 값을 반환 (없거나 만료되면 ErrMiss)
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set은 ttl 동안 유지되는 값을 저장 (ttl이 0 이하면 만료되지 않음)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete는 key를 지움 (없어도 에러가 아님)
+	Delete(ctx context.Context, key string) error
+}
+
+type memoryStore struct {
+	mu    sync.Mutex
+	items map[string]memoryItem
+	now   func() time.Time
+}
+
+type memoryItem struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryStore는 프로세스 안에서 원격 캐시를 흉내 내는 Store를 생성 (테스트나 서버가 하나일 때 사용)
+// 원격 캐시처럼 값을 복사해서 보관하므로 저장한 뒤 원본을 바꿔도 영향이 없음
+func NewMemoryStore() Store {
+	return &memoryStore{
+		items: make(map[string]memoryItem),
+		now:   time.Now,
+	}
+}
+
+func (s *memoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.items[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	if !item.expires.IsZero() && !s.now().Before(item.expires) {
+		delete(s.items, key)
+		return nil, ErrMiss
+	}
+	return append([]byte(nil), item.value...), nil
+}
+
+func (s *memoryStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var expires time.Time
+	if ttl > 0 {
+		expires = s.now().Add(ttl)
+	}
+	s.items[key] = memoryItem{value: append([]byte(nil), value...), expires: expires}
+	return nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, key)
+	return nil
+}
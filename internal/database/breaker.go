@@ -0,0 +1,211 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 회로 차단기 기본 설정
+const (
+	DefaultBreakerThreshold   = 5
+	DefaultBreakerOpenTimeout = 10 * time.Second
+)
+
+// BreakerConfig는 회로 차단기 설정
+type BreakerConfig struct {
+	// Threshold는 차단기를 여는 연속 실패 횟수 (0 이하면 DefaultBreakerThreshold)
+	Threshold int
+	// OpenTimeout은 차단기를 연 뒤 복구를 확인하기까지 기다리는 시간 (0 이하면 DefaultBreakerOpenTimeout)
+	OpenTimeout time.Duration
+}
+
+// BreakerState는 회로 차단기 상태
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // 정상, 모든 쿼리를 보냄
+	BreakerOpen                         // DB가 내려간 것으로 보고 쿼리를 보내지 않음
+	BreakerHalfOpen                     // 복구를 확인하는 쿼리 하나만 보냄
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// Breaker는 연결 풀 하나의 회로 차단기
+//
+// 연결 오류와 쿼리 제한 시간(WithQueryTimeout) 초과가 Threshold번 연속되면 열려서 OpenTimeout 동안 쿼리를 보내지 않고
+// ErrCircuitOpen으로 바로 실패시킴
+// OpenTimeout이 지나면 반쯤 열린 상태에서 쿼리(또는 Cluster의 상태 확인) 하나만 보내 보고,
+// 성공하면 닫고 실패하면 다시 염
+// 쿼리 자체의 오류(제약 조건 위반, 없는 행 등)는 DB가 응답한 것이므로 성공으로 셈
+type Breaker struct {
+	name string
+	cfg  BreakerConfig
+	now  func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker는 닫힌 상태의 Breaker를 생성 (name은 상태가 바뀔 때 로그에 남김)
+func NewBreaker(name string, cfg BreakerConfig) *Breaker {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = DefaultBreakerThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = DefaultBreakerOpenTimeout
+	}
+	return &Breaker{name: name, cfg: cfg, now: time.Now}
+}
+
+// Allow는 쿼리를 보내도 되면 nil, 차단기가 열려 있으면 ErrCircuitOpen을 반환
+// nil을 받았으면 결과를 Record로 알려야 함
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		// 복구 확인은 한 번에 하나만
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	}
+	return nil
+}
+
+// Record는 Allow가 허용한 쿼리의 결과를 반영
+// 호출한 쪽의 ctx가 끝나 취소된 쿼리는 성공도 실패도 아닌 것으로 봄
+func (b *Breaker) Record(err error) {
+	failed := unavailable(err)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed && canceled(err) {
+		if b.state == BreakerHalfOpen {
+			b.probing = false
+		}
+		return
+	}
+	switch b.state {
+	case BreakerClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.cfg.Threshold {
+			b.open(err)
+		}
+	case BreakerHalfOpen:
+		b.probing = false
+		if failed {
+			b.open(err)
+			return
+		}
+		b.state = BreakerClosed
+		b.failures = 0
+		log.Printf("%s 회로 차단기 닫힘, DB 복구", b.name)
+	}
+	// 열린 상태에서 도착한 결과는 차단기를 열기 전에 보낸 쿼리이므로 무시
+}
+
+func (b *Breaker) open(err error) {
+	if b.state == BreakerClosed {
+		log.Printf("%s 회로 차단기 열림 (연속 %d회 실패): %v", b.name, b.failures, err)
+	}
+	b.state = BreakerOpen
+	b.openedAt = b.now()
+}
+
+// State는 현재 상태를 반환 (OpenTimeout이 지나도 다음 Allow 전까지는 BreakerOpen)
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// 이 쿼리가 차단기를 거쳤는지 표시하는 gorm 인스턴스 설정 키
+const breakerAllowedKey = "database:breaker_allowed"
+
+// Register는 db의 모든 쿼리가 차단기를 거치도록 gorm 콜백을 등록
+//
+// 차단기가 열려 있으면 쿼리를 보내지 않고 ErrCircuitOpen을 반환하며,
+// DB에 연결할 수 없어 실패한 쿼리의 에러는 ErrUnavailable로 감쌈
+// 트랜잭션 시작(BEGIN)은 gorm 콜백을 거치지 않으므로 막지 않지만 트랜잭션 안의 쿼리는 막음
+func (b *Breaker) Register(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("database:breaker_before_create", b.before),
+		cb.Create().After("*").Register("database:breaker_after_create", b.after),
+		cb.Query().Before("*").Register("database:breaker_before_query", b.before),
+		cb.Query().After("*").Register("database:breaker_after_query", b.after),
+		cb.Update().Before("*").Register("database:breaker_before_update", b.before),
+		cb.Update().After("*").Register("database:breaker_after_update", b.after),
+		cb.Delete().Before("*").Register("database:breaker_before_delete", b.before),
+		cb.Delete().After("*").Register("database:breaker_after_delete", b.after),
+		cb.Row().Before("*").Register("database:breaker_before_row", b.before),
+		cb.Row().After("*").Register("database:breaker_after_row", b.after),
+		cb.Raw().Before("*").Register("database:breaker_before_raw", b.before),
+		cb.Raw().After("*").Register("database:breaker_after_raw", b.after),
+	)
+}
+
+func (b *Breaker) before(db *gorm.DB) {
+	allowed := false
+	if db.Error == nil {
+		if err := b.Allow(); err != nil {
+			db.AddError(err)
+		} else {
+			allowed = true
+		}
+	}
+	db.InstanceSet(breakerAllowedKey, allowed)
+}
+
+func (b *Breaker) after(db *gorm.DB) {
+	if allowed, _ := db.InstanceGet(breakerAllowedKey); allowed != true {
+		return
+	}
+	err := db.Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		err = nil
+	case err != nil && !errors.Is(err, ErrUnavailable) && queryTimedOut(db):
+		err = fmt.Errorf("%w: %w", ErrQueryTimeout, err)
+		db.Error = err
+	case unavailable(err) && !errors.Is(err, ErrUnavailable):
+		db.Error = fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	b.Record(err)
+}
+
+// queryTimedOut은 쿼리가 WithQueryTimeout의 제한 시간에 걸려 취소됐는지 확인
+func queryTimedOut(db *gorm.DB) bool {
+	ctx := db.Statement.Context
+	return ctx != nil && errors.Is(context.Cause(ctx), ErrQueryTimeout)
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewBreaker("primary", BreakerConfig{Threshold: 2, OpenTimeout: 10 * time.Second})
+	b.now = func() time.Time { return now }
+
+	// 연속 실패만 셈 (DB가 응답한 에러는 성공으로 봄)
+	require.NoError(t, b.Allow())
+	b.Record(io.ErrUnexpectedEOF)
+	b.Record(&pgconn.PgError{Code: "23505"})
+	b.Record(io.ErrUnexpectedEOF)
+	assert.Equal(t, BreakerClosed, b.State())
+	b.Record(io.ErrUnexpectedEOF)
+	assert.Equal(t, BreakerOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+	assert.ErrorIs(t, b.Allow(), ErrUnavailable)
+
+	// OpenTimeout이 지나면 한 번에 하나만 복구 확인
+	now = now.Add(10 * time.Second)
+	require.NoError(t, b.Allow())
+	assert.Equal(t, BreakerHalfOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	// 호출한 쪽이 취소한 확인은 결과로 보지 않음
+	b.Record(context.Canceled)
+	assert.Equal(t, BreakerHalfOpen, b.State())
+	require.NoError(t, b.Allow())
+
+	// 확인에 실패하면 다시 열림
+	b.Record(io.ErrUnexpectedEOF)
+	assert.Equal(t, BreakerOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	now = now.Add(10 * time.Second)
+	require.NoError(t, b.Allow())
+	b.Record(nil)
+	assert.Equal(t, BreakerClosed, b.State())
+	require.NoError(t, b.Allow())
+}
+
+func TestBreaker_Register(t *testing.T) {
+	db := openLazy(t)
+	b := NewBreaker("primary", BreakerConfig{Threshold: 2, OpenTimeout: time.Hour})
+	require.NoError(t, b.Register(db))
+
+	// 연결할 수 없어 실패한 쿼리는 ErrUnavailable로 감싸고 차단기가 실패로 셈
+	for range 2 {
+		err := db.Exec("SELECT 1").Error
+		assert.ErrorIs(t, err, ErrUnavailable)
+		assert.NotErrorIs(t, err, ErrCircuitOpen)
+	}
+	assert.Equal(t, BreakerOpen, b.State())
+
+	// 열린 뒤에는 쿼리를 보내지 않고 바로 실패
+	var n int
+	assert.ErrorIs(t, db.Raw("SELECT 1").Scan(&n).Error, ErrCircuitOpen)
+	assert.ErrorIs(t, db.Exec("SELECT 1").Error, ErrCircuitOpen)
+}
@@ -0,0 +1,415 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// DefaultHealthCheckInterval은 Config에 주기를 지정하지 않았을 때 복제본 상태를 확인하는 주기
+const DefaultHealthCheckInterval = 10 * time.Second
+
+// DefaultConnectTimeout은 Config에 지정하지 않았을 때 시작 시 primary 연결을 재시도하는 최대 시간
+const DefaultConnectTimeout = time.Minute
+
+const (
+	// 복제본 상태 확인 한 번의 제한 시간
+	healthCheckTimeout = 2 * time.Second
+
+	// 시작 시 primary 연결 재시도 간격 (0.5초부터 두 배씩, 최대 10초)
+	connectBackoffBase = 500 * time.Millisecond
+	connectBackoffMax  = 10 * time.Second
+)
+
+// Config는 primary와 읽기 복제본 연결 설정
+type Config struct {
+	PrimaryDSN  string
+	ReplicaDSNs []string
+	// HealthCheckInterval은 복제본 상태 확인 주기 (0 이하면 DefaultHealthCheckInterval)
+	HealthCheckInterval time.Duration
+	// Pool은 primary와 복제본 각각의 연결 풀 설정
+	Pool PoolConfig
+	// Breaker는 primary와 복제본 각각의 회로 차단기 설정
+	Breaker BreakerConfig
+	// ConnectTimeout은 시작 시 primary 연결을 재시도하는 최대 시간 (0 이하면 DefaultConnectTimeout)
+	ConnectTimeout time.Duration
+}
+
+// Pool은 primary 또는 복제본 하나의 연결 풀
+type Pool struct {
+	name    string
+	db      *gorm.DB
+	breaker *Breaker
+	healthy atomic.Bool
+
+	routed, healthCheckFailures atomic.Uint64
+}
+
+// PoolStats는 연결 풀 하나의 통계 (서버 시작 후 누적값과 현재 연결 상태)
+type PoolStats struct {
+	Name                string `json:"name"`
+	Healthy             bool   `json:"healthy"`
+	Breaker             string `json:"breaker"`               // 회로 차단기 상태 (closed, open, half-open)
+	Routed              uint64 `json:"routed"`                // 이 풀로 보낸 요청 수 (Reader/Writer 호출 수)
+	HealthCheckFailures uint64 `json:"health_check_failures"` // 상태 확인 실패 횟수 (primary는 확인하지 않음)
+	OpenConnections     int    `json:"open_connections"`
+	InUse               int    `json:"in_use"`
+	Idle                int    `json:"idle"`
+	WaitCount           int64  `json:"wait_count"`
+	WaitDuration        string `json:"wait_duration"`
+}
+
+// Cluster는 쓰기는 primary로, 읽기는 정상인 복제본으로 나눠 보내는 연결 선택기
+//
+// 복제본은 돌아가며 사용하고, 상태 확인에 실패한 복제본은 다시 성공할 때까지 건너뜀
+// 정상인 복제본이 없으면 읽기도 primary로 보냄
+// 요청 세션(WithSession)이 있는 ctx로 Writer를 호출한 뒤에는 같은 세션의 읽기를 primary로 보내서
+// 복제 지연 때문에 방금 쓴 내용이 안 보이는 일이 없게 함
+//
+// 풀마다 회로 차단기(Breaker)가 있어서 연결 오류가 이어지면 쿼리를 보내지 않고 ErrCircuitOpen으로 바로 실패시킴
+// 차단기가 열린 복제본은 읽기에서 건너뛰고, 열린 차단기는 상태 확인 주기마다 ping으로 복구를 확인함
+type Cluster struct {
+	primary  *Pool
+	replicas []*Pool
+	next     atomic.Uint64
+
+	ping func(ctx context.Context, p *Pool) error
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// NewCluster는 이미 연 연결로 기본 회로 차단기 설정의 Cluster를 생성 (복제본은 정상 상태로 시작)
+// 상태 확인은 Start를 호출해야 시작함
+func NewCluster(primary *gorm.DB, replicas ...*gorm.DB) *Cluster {
+	return newCluster(BreakerConfig{}, primary, replicas...)
+}
+
+func newCluster(breaker BreakerConfig, primary *gorm.DB, replicas ...*gorm.DB) *Cluster {
+	c := &Cluster{
+		primary: newPool("primary", primary, breaker),
+		ping:    pingPool,
+		stop:    make(chan struct{}),
+	}
+	for i, db := range replicas {
+		c.replicas = append(c.replicas, newPool(fmt.Sprintf("replica-%d", i+1), db, breaker))
+	}
+	return c
+}
+
+func newPool(name string, db *gorm.DB, breaker BreakerConfig) *Pool {
+	p := &Pool{name: name, db: db, breaker: NewBreaker(name, breaker)}
+	p.healthy.Store(true)
+	if err := p.breaker.Register(db); err != nil {
+		log.Printf("%s 회로 차단기 등록 실패: %v", name, err)
+	}
+	return p
+}
+
+// Open은 cfg의 primary와 복제본에 연결하고 복제본 상태 확인을 시작한 Cluster를 반환
+// primary는 연결될 때까지 cfg.ConnectTimeout 동안 재시도하고 그래도 연결하지 못하면 에러를 반환하지만,
+// 복제본은 연결하지 못해도 비정상 상태로 시작하고 상태 확인으로 복구를 기다림
+func Open(cfg Config) (*Cluster, error) {
+	timeout := cfg.ConnectTimeout
+	if timeout <= 0 {
+		timeout = DefaultConnectTimeout
+	}
+	primary, err := connect(cfg.PrimaryDSN, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Pool.apply(primary); err != nil {
+		return nil, fmt.Errorf("primary 연결 풀 설정 실패: %v", err)
+	}
+
+	replicas := make([]*gorm.DB, 0, len(cfg.ReplicaDSNs))
+	for i, dsn := range cfg.ReplicaDSNs {
+		// 연결은 처음 사용할 때 맺으므로 복제본이 내려가 있어도 여기서는 실패하지 않음
+		db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{DisableAutomaticPing: true})
+		if err != nil {
+			return nil, fmt.Errorf("복제본 %d 설정 오류: %v", i+1, err)
+		}
+		if err := cfg.Pool.apply(db); err != nil {
+			return nil, fmt.Errorf("복제본 %d 연결 풀 설정 실패: %v", i+1, err)
+		}
+		replicas = append(replicas, db)
+	}
+
+	c := newCluster(cfg.Breaker, primary, replicas...)
+	c.CheckHealth(context.Background())
+	interval := cfg.HealthCheckInterval
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+	c.Start(interval)
+	return c, nil
+}
+
+// connect는 primary에 연결될 때까지 지터를 더한 지수 백오프로 재시도
+// 다음 시도가 timeout을 넘기면 마지막 에러를 반환
+func connect(dsn string, timeout time.Duration) (*gorm.DB, error) {
+	deadline := time.Now().Add(timeout)
+	for attempt := 0; ; attempt++ {
+		db, err := openPrimary(dsn)
+		if err == nil {
+			return db, nil
+		}
+		wait := Backoff(attempt, connectBackoffBase, connectBackoffMax)
+		if time.Now().Add(wait).After(deadline) {
+			return nil, err
+		}
+		log.Printf("%v, %s 후 다시 시도", err, wait.Round(time.Millisecond))
+		time.Sleep(wait)
+	}
+}
+
+// Primary는 ctx와 관계없이 primary 연결을 반환 (마이그레이션 등 Cluster를 모르는 코드에 넘길 때 사용)
+func (c *Cluster) Primary() *gorm.DB {
+	return c.primary.db
+}
+
+// Writer는 primary 연결을 반환하고, ctx에 세션이 있으면 이후 같은 세션의 읽기도 primary로 보내도록 표시
+// ctx에 트랜잭션(WithTx)이 있으면 그 트랜잭션을 반환
+func (c *Cluster) Writer(ctx context.Context) *gorm.DB {
+	if s := sessionFrom(ctx); s != nil {
+		s.wrote.Store(true)
+	}
+	if tx := TxFrom(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	c.primary.routed.Add(1)
+	return c.primary.db.WithContext(ctx)
+}
+
+// Reader는 읽기에 쓸 연결을 반환
+// 같은 세션에서 쓰기가 있었거나 정상인 복제본이 없으면 primary를,
+// ctx에 트랜잭션(WithTx)이 있으면 아직 커밋하지 않은 내용도 보이도록 그 트랜잭션을 반환
+func (c *Cluster) Reader(ctx context.Context) *gorm.DB {
+	if tx := TxFrom(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	if s := sessionFrom(ctx); s == nil || !s.wrote.Load() {
+		if p := c.pickReplica(); p != nil {
+			p.routed.Add(1)
+			return p.db.WithContext(ctx)
+		}
+	}
+	c.primary.routed.Add(1)
+	return c.primary.db.WithContext(ctx)
+}
+
+// pickReplica는 정상이고 회로 차단기가 닫힌 복제본을 돌아가며 반환 (없으면 nil)
+func (c *Cluster) pickReplica() *Pool {
+	n := len(c.replicas)
+	if n == 0 {
+		return nil
+	}
+	start := c.next.Add(1)
+	for i := range n {
+		if p := c.replicas[(start+uint64(i))%uint64(n)]; p.healthy.Load() && p.breaker.State() == BreakerClosed {
+			return p
+		}
+	}
+	return nil
+}
+
+// CheckHealth는 모든 복제본의 상태를 한 번 확인하고, 회로 차단기가 열린 primary에는 복구 확인용 ping을 보냄
+// 상태가 바뀐 복제본은 로그로 남김
+func (c *Cluster) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	if c.primary.breaker.State() != BreakerClosed {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.probe(ctx, c.primary)
+		}()
+	}
+	for _, p := range c.replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := c.probe(ctx, p)
+			if err != nil {
+				p.healthCheckFailures.Add(1)
+			}
+			if was := p.healthy.Swap(err == nil); was != (err == nil) {
+				if err != nil {
+					log.Printf("%s 비정상, 읽기를 다른 연결로 보냄: %v", p.name, err)
+				} else {
+					log.Printf("%s 정상으로 복구", p.name)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// probe는 p에 ping을 보내고, 회로 차단기가 열려 있으면 그 결과로 복구 여부를 정함
+func (c *Cluster) probe(ctx context.Context, p *Pool) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	err := c.ping(ctx, p)
+	if p.breaker.State() != BreakerClosed && p.breaker.Allow() == nil {
+		if err != nil {
+			// ping 실패는 에러 종류와 관계없이 DB에 연결할 수 없는 것으로 봄
+			p.breaker.Record(fmt.Errorf("%w: %w", ErrUnavailable, err))
+		} else {
+			p.breaker.Record(nil)
+		}
+	}
+	return err
+}
+
+func pingPool(ctx context.Context, p *Pool) error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Start는 interval마다 CheckHealth를 호출하는 고루틴을 시작 (Close로 멈춤)
+func (c *Cluster) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stop:
+				return
+			case <-ticker.C:
+				c.CheckHealth(context.Background())
+			}
+		}
+	}()
+}
+
+// Close는 상태 확인을 멈추고 모든 연결을 닫음
+func (c *Cluster) Close() error {
+	c.stopOnce.Do(func() { close(c.stop) })
+	var firstErr error
+	for _, p := range append([]*Pool{c.primary}, c.replicas...) {
+		sqlDB, err := p.db.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Stats는 primary와 복제본의 연결 풀 통계를 반환
+func (c *Cluster) Stats() []PoolStats {
+	stats := make([]PoolStats, 0, len(c.replicas)+1)
+	for _, p := range append([]*Pool{c.primary}, c.replicas...) {
+		s := PoolStats{
+			Name:                p.name,
+			Healthy:             p.healthy.Load(),
+			Breaker:             p.breaker.State().String(),
+			Routed:              p.routed.Load(),
+			HealthCheckFailures: p.healthCheckFailures.Load(),
+		}
+		if sqlDB, err := p.db.DB(); err == nil {
+			db := sqlDB.Stats()
+			s.OpenConnections = db.OpenConnections
+			s.InUse = db.InUse
+			s.Idle = db.Idle
+			s.WaitCount = db.WaitCount
+			s.WaitDuration = db.WaitDuration.String()
+		}
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// SessionKey는 요청 세션을 저장하는 컨텍스트 키
+// gin.Context는 문자열 키를 c.Set으로 저장한 값에서 찾으므로 gin 핸들러에서는 c.Set(SessionKey, NewSession())으로도 저장할 수 있음
+const SessionKey = "database.session"
+
+// Session은 요청 하나에서 쓰기가 있었는지 기록 (Cluster.Writer가 표시)
+type Session struct {
+	wrote atomic.Bool
+}
+
+func NewSession() *Session {
+	return &Session{}
+}
+
+// WithSession은 새 요청 세션을 담은 ctx를 반환
+// 이 ctx(또는 파생된 ctx)로 쓴 뒤의 읽기는 primary로 보냄
+func WithSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, SessionKey, NewSession())
+}
+
+// WithPrimary는 쓰기 없이도 읽기를 primary로 보내는 ctx를 반환
+// 복제 지연 때문에 오래된 값을 읽으면 안 되는 조회(예: 캐시 채우기)에 사용
+func WithPrimary(ctx context.Context) context.Context {
+	s := NewSession()
+	s.wrote.Store(true)
+	return context.WithValue(ctx, SessionKey, s)
+}
+
+func sessionFrom(ctx context.Context) *Session {
+	s, _ := ctx.Value(SessionKey).(*Session)
+	return s
+}
+
+type txKey struct{}
+
+// WithTx는 tx를 담은 ctx를 반환
+// 이 ctx로 Writer/Reader를 호출하면 새 연결 대신 tx를 반환하므로, 여러 Recorder의 쓰기를 한 트랜잭션으로 묶을 수 있음
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFrom은 ctx의 트랜잭션을 반환 (없으면 nil)
+func TxFrom(ctx context.Context) *gorm.DB {
+	tx, _ := ctx.Value(txKey{}).(*gorm.DB)
+	return tx
+}
+
+type commitHooksKey struct{}
+
+type commitHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// WithCommitHooks는 AfterCommit으로 등록한 함수를 모으는 ctx와, 트랜잭션을 커밋한 뒤 모은 함수를 실행할 함수를 반환
+// 트랜잭션을 시작하는 쪽이 호출하며, ctx에 이미 모으는 곳이 있으면(바깥 트랜잭션) 그곳에 모이므로 반환한 함수는 아무것도 하지 않음
+func WithCommitHooks(ctx context.Context) (context.Context, func()) {
+	if _, ok := ctx.Value(commitHooksKey{}).(*commitHooks); ok {
+		return ctx, func() {}
+	}
+	h := &commitHooks{}
+	return context.WithValue(ctx, commitHooksKey{}, h), func() {
+		h.mu.Lock()
+		fns := h.fns
+		h.fns = nil
+		h.mu.Unlock()
+		for _, fn := range fns {
+			fn()
+		}
+	}
+}
+
+// AfterCommit은 ctx의 트랜잭션이 커밋된 뒤에 fn을 실행하도록 등록
+// 트랜잭션이 없거나 모으는 곳(WithCommitHooks)이 없으면 바로 실행하고, 트랜잭션을 되돌리면 실행하지 않음
+func AfterCommit(ctx context.Context, fn func()) {
+	h, ok := ctx.Value(commitHooksKey{}).(*commitHooks)
+	if !ok || TxFrom(ctx) == nil {
+		fn()
+		return
+	}
+	h.mu.Lock()
+	h.fns = append(h.fns, fn)
+	h.mu.Unlock()
+}
@@ -0,0 +1,170 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// openLazy는 연결하지 않고 gorm.DB만 생성 (쿼리를 보내지 않는 라우팅 테스트용)
+func openLazy(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=test dbname=test sslmode=disable"), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+	return db
+}
+
+// poolOf는 Reader/Writer가 반환한 연결이 어느 풀의 것인지 찾음
+func poolOf(c *Cluster, db *gorm.DB) string {
+	for _, p := range append([]*Pool{c.primary}, c.replicas...) {
+		if db.Statement.ConnPool == p.db.ConnPool {
+			return p.name
+		}
+	}
+	return ""
+}
+
+func newTestCluster(t *testing.T) (*Cluster, map[string]error) {
+	c := NewCluster(openLazy(t), openLazy(t), openLazy(t))
+	var mu sync.Mutex
+	failures := make(map[string]error)
+	c.ping = func(ctx context.Context, p *Pool) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return failures[p.name]
+	}
+	t.Cleanup(func() { c.Close() })
+	return c, failures
+}
+
+func TestCluster_Routing(t *testing.T) {
+	c, _ := newTestCluster(t)
+	ctx := context.Background()
+
+	// 읽기는 복제본을 돌아가며 사용
+	seen := make(map[string]int)
+	for range 4 {
+		seen[poolOf(c, c.Reader(ctx))]++
+	}
+	assert.Equal(t, map[string]int{"replica-1": 2, "replica-2": 2}, seen)
+	assert.Equal(t, "primary", poolOf(c, c.Writer(ctx)))
+
+	// 세션 없이 쓴 뒤에는 계속 복제본에서 읽음
+	assert.NotEqual(t, "primary", poolOf(c, c.Reader(ctx)))
+
+	stats := c.Stats()
+	require.Len(t, stats, 3)
+	assert.Equal(t, uint64(1), stats[0].Routed)
+	assert.Equal(t, uint64(5), stats[1].Routed+stats[2].Routed)
+}
+
+func TestCluster_Session(t *testing.T) {
+	c, _ := newTestCluster(t)
+	ctx := WithSession(context.Background())
+	other := WithSession(context.Background())
+
+	assert.NotEqual(t, "primary", poolOf(c, c.Reader(ctx)))
+	c.Writer(ctx)
+	// 같은 세션(파생된 ctx 포함)의 이후 읽기는 primary로
+	derived, cancel := context.WithCancel(ctx)
+	defer cancel()
+	assert.Equal(t, "primary", poolOf(c, c.Reader(derived)))
+	assert.NotEqual(t, "primary", poolOf(c, c.Reader(other)))
+}
+
+func TestCluster_WithPrimary(t *testing.T) {
+	c, _ := newTestCluster(t)
+	ctx := WithPrimary(context.Background())
+
+	assert.Equal(t, "primary", poolOf(c, c.Reader(ctx)))
+	assert.NotEqual(t, "primary", poolOf(c, c.Reader(context.Background())))
+}
+
+func TestAfterCommit(t *testing.T) {
+	tx := openLazy(t)
+	var ran []string
+
+	// 트랜잭션 밖이면 바로 실행
+	AfterCommit(context.Background(), func() { ran = append(ran, "no-tx") })
+	assert.Equal(t, []string{"no-tx"}, ran)
+
+	// 트랜잭션 안이면 커밋한 뒤에 실행, 안쪽 트랜잭션은 바깥 트랜잭션에 모음
+	ctx, committed := WithCommitHooks(context.Background())
+	inner, innerCommitted := WithCommitHooks(WithTx(ctx, tx))
+	AfterCommit(inner, func() { ran = append(ran, "tx") })
+	innerCommitted()
+	assert.Equal(t, []string{"no-tx"}, ran)
+	committed()
+	assert.Equal(t, []string{"no-tx", "tx"}, ran)
+	committed()
+	assert.Equal(t, []string{"no-tx", "tx"}, ran, "한 번만 실행")
+}
+
+func TestCluster_Failover(t *testing.T) {
+	c, failures := newTestCluster(t)
+	ctx := context.Background()
+
+	failures["replica-1"] = errors.New("연결 거부")
+	c.CheckHealth(ctx)
+	for range 3 {
+		assert.Equal(t, "replica-2", poolOf(c, c.Reader(ctx)))
+	}
+
+	// 모든 복제본이 비정상이면 primary에서 읽음
+	failures["replica-2"] = errors.New("연결 거부")
+	c.CheckHealth(ctx)
+	assert.Equal(t, "primary", poolOf(c, c.Reader(ctx)))
+
+	// 상태 확인에 다시 성공하면 복구
+	delete(failures, "replica-1")
+	c.CheckHealth(ctx)
+	assert.Equal(t, "replica-1", poolOf(c, c.Reader(ctx)))
+
+	stats := c.Stats()
+	assert.True(t, stats[1].Healthy)
+	assert.False(t, stats[2].Healthy)
+	assert.Equal(t, uint64(2), stats[1].HealthCheckFailures)
+	assert.Equal(t, uint64(2), stats[2].HealthCheckFailures)
+}
+
+func TestCluster_PingFailure(t *testing.T) {
+	// 실제 ping은 연결할 수 없는 복제본을 비정상으로 표시
+	c := NewCluster(openLazy(t), openLazy(t))
+	defer c.Close()
+	c.CheckHealth(context.Background())
+	assert.Equal(t, "primary", poolOf(c, c.Reader(context.Background())))
+}
+
+func TestCluster_Breaker(t *testing.T) {
+	c, failures := newTestCluster(t)
+	ctx := context.Background()
+	now := time.Now()
+	for _, p := range append([]*Pool{c.primary}, c.replicas...) {
+		p.breaker.now = func() time.Time { return now }
+		for range DefaultBreakerThreshold {
+			p.breaker.Record(io.ErrUnexpectedEOF)
+		}
+	}
+	// 차단기가 열린 복제본은 건너뛰고 primary에서 읽음
+	assert.Equal(t, "primary", poolOf(c, c.Reader(ctx)))
+	assert.Equal(t, "open", c.Stats()[0].Breaker)
+
+	// 상태 확인은 OpenTimeout이 지난 뒤 ping으로 복구를 확인
+	c.CheckHealth(ctx)
+	assert.Equal(t, BreakerOpen, c.primary.breaker.State())
+	now = now.Add(DefaultBreakerOpenTimeout)
+	failures["replica-2"] = errors.New("연결 거부")
+	c.CheckHealth(ctx)
+	assert.Equal(t, BreakerClosed, c.primary.breaker.State())
+	assert.Equal(t, BreakerClosed, c.replicas[0].breaker.State())
+	assert.Equal(t, BreakerOpen, c.replicas[1].breaker.State())
+	assert.Equal(t, "replica-1", poolOf(c, c.Reader(ctx)))
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"fmt"
+	"log"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -16,23 +17,40 @@ const (
 	port     = "5432"        // PostgreSQL 포트 번호 (기본값: 5432)
 )
 
-func InitDB() (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Seoul",
+// DefaultDSN은 위 연결 정보로 만든 primary DSN
+func DefaultDSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Seoul",
 		host, user, password, dbname, port)
+}
 
+// InitDB는 DefaultDSN의 primary 하나에만 연결 (복제본을 쓰려면 Open)
+func InitDB() (*gorm.DB, error) {
+	return openPrimary(DefaultDSN())
+}
+
+// openPrimary는 dsn에 연결하고 필요한 확장을 설치
+func openPrimary(dsn string) (*gorm.DB, error) {
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("데이터베이스 연결 실패: %v", err)
 	}
 
+	// 유사 이름 검색(similarity, % 연산자)과 Base.Name의 gin_trgm_ops 인덱스에 필요한 확장
+	// 권한이 없어 설치하지 못하면 관리자가 설치할 때까지 유사 이름 검색만 실패함
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		log.Printf("pg_trgm 확장 설치 실패: %v", err)
+	}
+
 	// 데이터베이스 마이그레이션
 	// 이 부분 활성화시 데이터베이스에 테이블이 없으면 Base 테이블 자동으로 생성
 	// 테이블 있으면 스키마 변경사항 자동으로 반영
+	// (revisions, attachments 테이블은 서버가 시작할 때 recorder.MigrateRevisions, recorder.MigrateAttachments로,
+	// 멱등성 키 테이블은 idempotency.Migrate로 항상 생성)
 	// if err := db.AutoMigrate(&model.Base{}); err != nil {
 	// 	return nil, fmt.Errorf("마이그레이션 실패: %v", err)
 	// }
 
-	fmt.Println("데이터베이스 연결 성공")
+	log.Println("데이터베이스 연결 성공")
 
 	return db, nil
 }
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUnavailable은 DB에 연결할 수 없거나 응답이 없을 때의 에러 (errors.Is로 확인)
+	// 연결 오류와 쿼리 제한 시간 초과는 원래 에러와 함께 이 에러로 감싸서 반환
+	ErrUnavailable = errors.New("데이터베이스를 일시적으로 사용할 수 없습니다")
+	// ErrCircuitOpen은 회로 차단기가 열려 있어 쿼리를 보내지 않았을 때의 에러 (ErrUnavailable을 감쌈)
+	ErrCircuitOpen = fmt.Errorf("%w: 회로 차단기 열림", ErrUnavailable)
+	// ErrQueryTimeout은 WithQueryTimeout의 제한 시간을 넘긴 쿼리의 에러 (ErrUnavailable을 감쌈)
+	ErrQueryTimeout = fmt.Errorf("%w: 쿼리 제한 시간 초과", ErrUnavailable)
+)
+
+// WithQueryTimeout은 쿼리 하나에 제한 시간을 둔 ctx를 반환
+// 이 제한 시간으로 취소된 쿼리는 ErrQueryTimeout으로 실패하고 회로 차단기가 실패로 셈
+// (호출한 쪽의 ctx가 끝나서 취소된 쿼리는 DB 문제가 아니므로 세지 않음)
+func WithQueryTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeoutCause(ctx, d, ErrQueryTimeout)
+}
+
+// PoolConfig는 연결 풀 설정 (0인 값은 database/sql 기본값을 그대로 사용)
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// DefaultPoolConfig는 API 서버에서 사용하는 기본 연결 풀 설정
+var DefaultPoolConfig = PoolConfig{
+	MaxOpenConns:    25,
+	MaxIdleConns:    10,
+	ConnMaxLifetime: 30 * time.Minute,
+	ConnMaxIdleTime: 5 * time.Minute,
+}
+
+// apply는 db의 연결 풀에 설정을 적용
+func (c PoolConfig) apply(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if c.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(c.MaxOpenConns)
+	}
+	if c.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(c.MaxIdleConns)
+	}
+	if c.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(c.ConnMaxLifetime)
+	}
+	if c.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+	}
+	return nil
+}
+
+// Retryable은 같은 쿼리를 다시 실행해도 되는 일시적인 에러인지 확인
+//
+// 연결을 맺지 못했거나 서버에 보내기 전에 실패했거나(pgconn.SafeToRetry), 직렬화 실패·교착 상태로 트랜잭션이 롤백됐거나,
+// 서버가 연결을 받지 않은 경우는 항상 재시도할 수 있음
+// 실행 도중 연결이 끊긴 경우는 쿼리가 반영됐는지 알 수 없으므로 idempotent(조회 등)일 때만 재시도
+// 회로 차단기가 열렸거나 ctx가 끝난 경우는 재시도하지 않음
+func Retryable(err error, idempotent bool) bool {
+	switch {
+	case err == nil, errors.Is(err, ErrCircuitOpen), errors.Is(err, ErrQueryTimeout), canceled(err):
+		return false
+	case safeToRetry(err), connectFailed(err):
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "40001", "40P01", // serialization_failure, deadlock_detected
+			"57P03", "53300": // cannot_connect_now, too_many_connections
+			return true
+		}
+		return idempotent && connectionErrorCode(pgErr.Code)
+	}
+	return idempotent && connectionLost(err)
+}
+
+// unavailable은 DB에 연결할 수 없거나 응답이 없어 생긴 에러인지 확인 (회로 차단기가 실패로 셈)
+func unavailable(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, ErrUnavailable):
+		return true
+	case canceled(err):
+		return false
+	case safeToRetry(err):
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return connectionErrorCode(pgErr.Code) || pgErr.Code == "57P03" || pgErr.Code == "53300"
+	}
+	return connectionLost(err)
+}
+
+// canceled는 ctx가 끝나서 쿼리가 취소됐는지 확인
+func canceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err)
+}
+
+// connectionErrorCode는 연결이 끊겼거나 서버가 내려가는 중임을 뜻하는 SQLSTATE인지 확인
+// (08xxx connection_exception, 57P01 admin_shutdown, 57P02 crash_shutdown)
+func connectionErrorCode(code string) bool {
+	return strings.HasPrefix(code, "08") || code == "57P01" || code == "57P02"
+}
+
+// connectFailed는 연결을 맺지 못했는지 확인 (쿼리를 보내지 않았으므로 쓰기도 재시도할 수 있음)
+func connectFailed(err error) bool {
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr)
+}
+
+// connectionLost는 연결을 맺지 못했거나 쿼리 도중 연결이 끊겼는지 확인
+func connectionLost(err error) bool {
+	var netErr net.Error
+	return connectFailed(err) || errors.As(err, &netErr) ||
+		errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// safeToRetry는 pgconn.SafeToRetry와 같지만 감싼 에러도 확인
+func safeToRetry(err error) bool {
+	var e interface{ SafeToRetry() bool }
+	return errors.As(err, &e) && e.SafeToRetry()
+}
+
+// Backoff는 attempt(0부터)번째 재시도 전에 기다릴 시간을 반환
+// base부터 두 배씩 늘려 limit을 넘지 않게 한 뒤, 여러 요청이 한꺼번에 재시도하지 않도록 절반을 무작위로 줄임
+func Backoff(attempt int, base, limit time.Duration) time.Duration {
+	d := base
+	for i := 0; i < attempt && d < limit; i++ {
+		d *= 2
+	}
+	d = min(d, limit)
+	if d <= 1 {
+		return d
+	}
+	return d/2 + rand.N(d/2)
+}
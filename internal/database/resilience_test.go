@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		read, write bool
+	}{
+		{name: "에러_없음", err: nil},
+		{name: "직렬화_실패", err: &pgconn.PgError{Code: "40001"}, read: true, write: true},
+		{name: "교착_상태", err: fmt.Errorf("%w: %w", ErrUnavailable, &pgconn.PgError{Code: "40P01"}), read: true, write: true},
+		{name: "연결_수_초과", err: &pgconn.PgError{Code: "53300"}, read: true, write: true},
+		{name: "연결_끊김_코드", err: &pgconn.PgError{Code: "08006"}, read: true},
+		{name: "서버_종료", err: &pgconn.PgError{Code: "57P01"}, read: true},
+		{name: "연결_실패", err: &pgconn.ConnectError{}, read: true, write: true},
+		{name: "쿼리_도중_끊김", err: io.ErrUnexpectedEOF, read: true},
+		{name: "제약_조건_위반", err: &pgconn.PgError{Code: "23505"}},
+		{name: "회로_차단기_열림", err: ErrCircuitOpen},
+		{name: "쿼리_제한_시간", err: fmt.Errorf("%w: %w", ErrQueryTimeout, context.DeadlineExceeded)},
+		{name: "요청_취소", err: context.Canceled},
+		{name: "그_외", err: errors.New("알 수 없는 오류")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.read, Retryable(tt.err, true), "조회")
+			assert.Equal(t, tt.write, Retryable(tt.err, false), "쓰기")
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second} {
+		for range 20 {
+			got := Backoff(attempt, 100*time.Millisecond, time.Second)
+			assert.GreaterOrEqual(t, got, want/2, "attempt %d", attempt)
+			assert.Less(t, got, want, "attempt %d", attempt)
+		}
+	}
+	// 아주 많이 재시도해도 넘치지 않음
+	assert.LessOrEqual(t, Backoff(1000, time.Hour, 24*time.Hour), 24*time.Hour)
+}
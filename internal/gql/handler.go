@@ -0,0 +1,125 @@
+// Package gql는 Usecase를 GraphQL(/graphql)로 제공
+//
+// 쿼리와 뮤테이션은 일반 JSON 응답으로, 구독은 Accept: text/event-stream 요청에
+// GraphQL over SSE 형식(event: next / event: complete)으로 응답함
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go_project/internal/database"
+	"go_project/internal/model"
+	"go_project/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// 구독의 변경 확인 주기
+const defaultWatchInterval = 2 * time.Second
+
+type Handler struct {
+	schema *graphql.Schema
+	uc     usecase.Usecase[model.Base]
+}
+
+type Option func(*resolver)
+
+// WithWatchInterval은 구독(resourceChanged)이 변경을 확인하는 주기를 지정
+func WithWatchInterval(d time.Duration) Option {
+	return func(r *resolver) {
+		r.watchInterval = d
+	}
+}
+
+func NewHandler(uc usecase.Usecase[model.Base], opts ...Option) *Handler {
+	r := &resolver{uc: uc, watchInterval: defaultWatchInterval}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return &Handler{
+		schema: graphql.MustParseSchema(schema, r, graphql.MaxDepth(10)),
+		uc:     uc,
+	}
+}
+
+// RegisterRoutes는 /graphql 라우트를 등록 (GET은 쿼리 문자열, POST는 JSON 본문)
+func (h *Handler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/graphql", h.Serve)
+	r.POST("/graphql", h.Serve)
+}
+
+// request는 GraphQL over HTTP 요청 본문
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func (h *Handler) Serve(c *gin.Context) {
+	var req request
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if v := c.Query("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				c.JSON(http.StatusBadRequest, errorResponse("variables가 올바른 JSON이 아닙니다"))
+				return
+			}
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse("잘못된 요청 데이터"))
+		return
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		c.JSON(http.StatusBadRequest, errorResponse("query가 비어 있습니다"))
+		return
+	}
+
+	// 요청마다 로더를 새로 만들어서 같은 요청 안의 resource(id) 조회만 모음
+	// 뮤테이션 뒤의 조회가 복제본의 오래된 값을 읽지 않도록 요청마다 DB 세션도 만듦
+	ctx := withLoader(database.WithSession(c.Request.Context()), newResourceLoader(h.uc.GetMany))
+
+	if strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		h.serveSSE(c, ctx, req)
+		return
+	}
+
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	c.JSON(http.StatusOK, resp)
+}
+
+// serveSSE는 구독(또는 쿼리) 결과를 SSE로 전송
+// 결과가 나올 때마다 next 이벤트를 보내고, 끝나면 complete 이벤트를 보냄
+func (h *Handler) serveSSE(c *gin.Context, ctx context.Context, req request) {
+	responses, err := h.schema.Subscribe(ctx, req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+
+	for resp := range responses {
+		data, err := json.Marshal(resp)
+		if err != nil {
+			break
+		}
+		fmt.Fprintf(c.Writer, "event: next\ndata: %s\n\n", data)
+		c.Writer.Flush()
+	}
+	fmt.Fprint(c.Writer, "event: complete\ndata: \n\n")
+	c.Writer.Flush()
+}
+
+func errorResponse(message string) gin.H {
+	return gin.H{"errors": []gin.H{{"message": message}}}
+}
@@ -0,0 +1,234 @@
+package gql
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"go_project/internal/model"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// countingUsecase는 GetMany 호출 횟수를 세는 Usecase
+type countingUsecase struct {
+	usecase.Usecase[model.Base]
+	getMany int32
+}
+
+func (u *countingUsecase) GetMany(ctx context.Context, ids []uint) ([]*model.Base, error) {
+	atomic.AddInt32(&u.getMany, 1)
+	return u.Usecase.GetMany(ctx, ids)
+}
+
+type gqlResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+type GQLTestSuite struct {
+	suite.Suite
+	uc     *countingUsecase
+	router *gin.Engine
+}
+
+func (s *GQLTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.uc = &countingUsecase{Usecase: usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))}
+	s.router = gin.New()
+	NewHandler(s.uc, WithWatchInterval(10*time.Millisecond)).RegisterRoutes(s.router)
+}
+
+func (s *GQLTestSuite) exec(query string, variables map[string]interface{}) (int, gqlResponse) {
+	body, _ := json.Marshal(request{Query: query, Variables: variables})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	var resp gqlResponse
+	s.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp
+}
+
+func (s *GQLTestSuite) insert(names ...string) {
+	for _, name := range names {
+		s.NoError(s.uc.Insert(context.Background(), &model.Base{Name: name}))
+	}
+}
+
+func (s *GQLTestSuite) TestMutations() {
+	code, resp := s.exec(`mutation($name: String!) { createResource(input: {name: $name}) { id name } }`,
+		map[string]interface{}{"name": "테스트_데이터"})
+	s.Equal(http.StatusOK, code)
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"id":"1","name":"테스트_데이터"}`, string(resp.Data["createResource"]))
+
+	_, resp = s.exec(`mutation { updateResource(id: "1", input: {name: "수정된_데이터"}) { name } }`, nil)
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"name":"수정된_데이터"}`, string(resp.Data["updateResource"]))
+
+	_, resp = s.exec(`mutation { deleteResource(id: "1") }`, nil)
+	s.Empty(resp.Errors)
+	s.JSONEq(`"1"`, string(resp.Data["deleteResource"]))
+
+	// 없는 리소스는 NOT_FOUND 코드
+	_, resp = s.exec(`mutation { deleteResource(id: "1") }`, nil)
+	s.Require().Len(resp.Errors, 1)
+	s.Equal("NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+	_, resp = s.exec(`{ resource(id: "abc") { id } }`, nil)
+	s.Require().Len(resp.Errors, 1)
+	s.Equal("BAD_REQUEST", resp.Errors[0].Extensions["code"])
+}
+
+func (s *GQLTestSuite) TestResource_Batched() {
+	s.insert("a", "b", "c")
+
+	_, resp := s.exec(`{
+		a: resource(id: "1") { name }
+		b: resource(id: "2") { name }
+		c: resource(id: "3") { name }
+		again: resource(id: "1") { name }
+		missing: resource(id: "99") { name }
+	}`, nil)
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"name":"a"}`, string(resp.Data["a"]))
+	s.JSONEq(`{"name":"c"}`, string(resp.Data["c"]))
+	s.JSONEq(`{"name":"a"}`, string(resp.Data["again"]))
+	s.Equal("null", string(resp.Data["missing"]))
+
+	// 다섯 번의 resource(id)가 GetMany 한 번으로 처리됨
+	s.Equal(int32(1), atomic.LoadInt32(&s.uc.getMany))
+}
+
+func (s *GQLTestSuite) TestResources() {
+	s.insert("apple", "banana", "Pineapple", "cherry")
+
+	_, resp := s.exec(`{ resources(filter: {nameContains: "APPLE"}, page: {page: 1, size: 1}) { items { name } total page size } }`, nil)
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"items":[{"name":"apple"}],"total":2,"page":1,"size":1}`, string(resp.Data["resources"]))
+
+	_, resp = s.exec(`{ resources(filter: {ids: ["2", "4"]}) { items { id } total } }`, nil)
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"items":[{"id":"2"},{"id":"4"}],"total":2}`, string(resp.Data["resources"]))
+
+	_, resp = s.exec(`{ resources(page: {page: 0}) { total } }`, nil)
+	s.NotEmpty(resp.Errors)
+}
+
+func (s *GQLTestSuite) TestGet() {
+	s.insert("a")
+	q := url.Values{"query": {`query($id: ID!) { resource(id: $id) { name } }`}, "variables": {`{"id":"1"}`}}
+	req := httptest.NewRequest(http.MethodGet, "/graphql?"+q.Encode(), nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"data":{"resource":{"name":"a"}}}`, w.Body.String())
+}
+
+func (s *GQLTestSuite) TestBadRequest() {
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": ""}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *GQLTestSuite) TestSubscription() {
+	s.insert("a")
+	server := httptest.NewServer(s.router)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	body, _ := json.Marshal(request{Query: `subscription { resourceChanged { type resource { name } } }`})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan string)
+	var once sync.Once
+	go func() {
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			if data, ok := strings.CutPrefix(sc.Text(), "data: "); ok {
+				events <- data
+			}
+		}
+		once.Do(func() { close(events) })
+	}()
+
+	next := func() string {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(2 * time.Second):
+			s.FailNow("이벤트를 받지 못함")
+			return ""
+		}
+	}
+
+	s.JSONEq(`{"data":{"resourceChanged":{"type":"ADDED","resource":{"name":"a"}}}}`, next())
+	s.NoError(s.uc.Modify(context.Background(), 1, &model.Base{Name: "b"}))
+	s.JSONEq(`{"data":{"resourceChanged":{"type":"MODIFIED","resource":{"name":"b"}}}}`, next())
+}
+
+func TestGQLTestSuite(t *testing.T) {
+	suite.Run(t, new(GQLTestSuite))
+}
+
+func TestResourceLoader_MaxBatch(t *testing.T) {
+	var calls [][]uint
+	var mu sync.Mutex
+	l := newResourceLoader(func(ctx context.Context, ids []uint) ([]*model.Base, error) {
+		mu.Lock()
+		calls = append(calls, append([]uint(nil), ids...))
+		mu.Unlock()
+		bases := make([]*model.Base, len(ids))
+		for i, id := range ids {
+			bases[i] = &model.Base{ID: id}
+		}
+		return bases, nil
+	})
+	l.maxBatch = 2
+	l.wait = 50 * time.Millisecond
+
+	var wg sync.WaitGroup
+	for id := uint(1); id <= 3; id++ {
+		wg.Add(1)
+		go func(id uint) {
+			defer wg.Done()
+			m, err := l.Load(context.Background(), id)
+			if err != nil || m.ID != id {
+				t.Errorf("Load(%d) = %v, %v", id, m, err)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	// 2개가 차면 바로, 남은 1개는 대기 시간이 지나면 조회
+	if len(calls) != 2 {
+		t.Fatalf("GetMany 호출 %d회, 기대값 2회: %v", len(calls), calls)
+	}
+}
@@ -0,0 +1,105 @@
+package gql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go_project/internal/model"
+)
+
+const (
+	// 같은 배치로 모을 때까지 기다리는 시간
+	// GraphQL 실행기는 같은 선택 집합의 필드를 동시에 해석하므로 짧게 기다려도 대부분 한 배치로 모임
+	defaultLoaderWait = 2 * time.Millisecond
+	// 배치 하나에 담을 최대 ID 수 (넘으면 바로 조회)
+	defaultLoaderMaxBatch = 100
+)
+
+// resourceLoader는 요청 하나 안에서 resource(id) 조회를 모아 GetMany 한 번으로 처리
+// 같은 ID는 한 번만 조회하고 결과를 요청이 끝날 때까지 재사용함
+type resourceLoader struct {
+	fetch    func(ctx context.Context, ids []uint) ([]*model.Base, error)
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	batch *loaderBatch
+	cache map[uint]*loaderBatch
+}
+
+// loaderBatch는 함께 조회되는 ID 묶음과 그 결과
+type loaderBatch struct {
+	ids     []uint
+	once    sync.Once
+	done    chan struct{}
+	results map[uint]*model.Base
+	err     error
+}
+
+func newResourceLoader(fetch func(ctx context.Context, ids []uint) ([]*model.Base, error)) *resourceLoader {
+	return &resourceLoader{
+		fetch:    fetch,
+		wait:     defaultLoaderWait,
+		maxBatch: defaultLoaderMaxBatch,
+		cache:    map[uint]*loaderBatch{},
+	}
+}
+
+// Load는 id의 리소스를 반환 (없으면 nil, nil)
+func (l *resourceLoader) Load(ctx context.Context, id uint) (*model.Base, error) {
+	l.mu.Lock()
+	b, ok := l.cache[id]
+	if !ok {
+		if l.batch == nil {
+			l.batch = &loaderBatch{done: make(chan struct{})}
+			batch := l.batch
+			time.AfterFunc(l.wait, func() { l.dispatch(ctx, batch) })
+		}
+		b = l.batch
+		b.ids = append(b.ids, id)
+		l.cache[id] = b
+		if len(b.ids) >= l.maxBatch {
+			l.batch = nil
+			go l.dispatch(ctx, b)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.results[id], nil
+}
+
+// dispatch는 배치를 조회 (타이머와 maxBatch 양쪽에서 불릴 수 있으므로 한 번만 실행)
+func (l *resourceLoader) dispatch(ctx context.Context, b *loaderBatch) {
+	b.once.Do(func() {
+		l.mu.Lock()
+		if l.batch == b {
+			l.batch = nil
+		}
+		ids := b.ids
+		l.mu.Unlock()
+
+		bases, err := l.fetch(ctx, ids)
+		b.results = make(map[uint]*model.Base, len(bases))
+		for _, m := range bases {
+			b.results[m.ID] = m
+		}
+		b.err = err
+		close(b.done)
+	})
+}
+
+type loaderKey struct{}
+
+// withLoader는 요청 컨텍스트에 로더를 넣음
+func withLoader(ctx context.Context, l *resourceLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
@@ -0,0 +1,343 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_project/internal/model"
+	"go_project/internal/usecase"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// schema는 /graphql에서 제공하는 스키마
+const schema = `
+schema {
+	query: Query
+	mutation: Mutation
+	subscription: Subscription
+}
+
+scalar Time
+
+type Resource {
+	id: ID!
+	name: String!
+	createdAt: Time!
+	updatedAt: Time!
+	# 없으면 만료되지 않음
+	expiresAt: Time
+}
+
+input ResourceFilter {
+	# 이 ID 중 하나
+	ids: [ID!]
+	# 이름에 포함된 문자열 (대소문자 무시)
+	nameContains: String
+}
+
+input PageInput {
+	# 1부터 시작 (기본 1)
+	page: Int
+	# 한 페이지의 항목 수 (기본 20, 최대 100)
+	size: Int
+}
+
+type ResourcePage {
+	items: [Resource!]!
+	# 필터에 맞는 전체 개수
+	total: Int!
+	page: Int!
+	size: Int!
+}
+
+input ResourceInput {
+	name: String!
+}
+
+enum ResourceEventType {
+	ADDED
+	MODIFIED
+	DELETED
+	# 만료 시각이 지나 조회되지 않게 됨
+	EXPIRED
+}
+
+type ResourceEvent {
+	type: ResourceEventType!
+	resource: Resource!
+}
+
+type Query {
+	# 없으면 null
+	resource(id: ID!): Resource
+	resources(filter: ResourceFilter, page: PageInput): ResourcePage!
+}
+
+type Mutation {
+	createResource(input: ResourceInput!): Resource!
+	updateResource(id: ID!, input: ResourceInput!): Resource!
+	# 삭제한 리소스의 ID를 반환
+	deleteResource(id: ID!): ID!
+}
+
+type Subscription {
+	# 리소스가 생성, 수정, 삭제, 만료될 때마다 전달 (처음에는 현재 리소스를 모두 ADDED로 전달)
+	resourceChanged: ResourceEvent!
+}
+`
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// gqlError는 응답의 errors[].extensions.code로 에러 종류를 알려주는 에러
+type gqlError struct {
+	message string
+	code    string
+}
+
+func (e *gqlError) Error() string {
+	return e.message
+}
+
+func (e *gqlError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func badRequest(message string) error {
+	return &gqlError{message: message, code: "BAD_REQUEST"}
+}
+
+// toError는 Usecase 에러를 GraphQL 에러로 변환 (HTTP API의 respondError와 같은 기준)
+func toError(err error, message string) error {
+	if errors.Is(err, usecase.ErrNotFound) {
+		return &gqlError{message: "리소스를 찾을 수 없습니다", code: "NOT_FOUND"}
+	}
+	if errors.Is(err, usecase.ErrUnavailable) {
+		return &gqlError{message: usecase.ErrUnavailable.Error(), code: "UNAVAILABLE"}
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return &gqlError{message: message, code: "INTERNAL"}
+}
+
+func parseID(id graphql.ID) (uint, error) {
+	n, err := strconv.ParseUint(string(id), 10, 64)
+	if err != nil || n == 0 {
+		return 0, badRequest("잘못된 ID: " + string(id))
+	}
+	return uint(n), nil
+}
+
+// resolver는 스키마의 루트 타입(Query, Mutation, Subscription)을 해석
+type resolver struct {
+	uc            usecase.Usecase[model.Base]
+	watchInterval time.Duration
+}
+
+// loader는 요청 컨텍스트의 로더를 반환 (없으면 배치 없이 조회하는 로더를 새로 만듦)
+func (r *resolver) loader(ctx context.Context) *resourceLoader {
+	if l, ok := ctx.Value(loaderKey{}).(*resourceLoader); ok {
+		return l
+	}
+	l := newResourceLoader(r.uc.GetMany)
+	l.wait = 0
+	return l
+}
+
+func (r *resolver) Resource(ctx context.Context, args struct{ ID graphql.ID }) (*resourceResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	m, err := r.loader(ctx).Load(ctx, id)
+	if err != nil {
+		return nil, toError(err, "리소스 조회 실패")
+	}
+	if m == nil {
+		return nil, nil
+	}
+	return &resourceResolver{m: m}, nil
+}
+
+type resourceFilter struct {
+	IDs          *[]graphql.ID
+	NameContains *string
+}
+
+type pageInput struct {
+	Page *int32
+	Size *int32
+}
+
+func (r *resolver) Resources(ctx context.Context, args struct {
+	Filter *resourceFilter
+	Page   *pageInput
+}) (*pageResolver, error) {
+	opts := model.ListOptions{Page: 1, Size: defaultPageSize}
+	if p := args.Page; p != nil {
+		if p.Page != nil {
+			opts.Page = int(*p.Page)
+		}
+		if p.Size != nil {
+			opts.Size = int(*p.Size)
+		}
+	}
+	if opts.Page < 1 || opts.Size < 1 {
+		return nil, badRequest("page와 size는 1 이상이어야 합니다")
+	}
+	opts.Size = min(opts.Size, maxPageSize)
+
+	if f := args.Filter; f != nil {
+		if f.IDs != nil {
+			// 빈 목록은 "아무 ID에도 해당하지 않음"이므로 조회 없이 빈 결과
+			if len(*f.IDs) == 0 {
+				return &pageResolver{page: opts.Page, size: opts.Size}, nil
+			}
+			for _, gid := range *f.IDs {
+				id, err := parseID(gid)
+				if err != nil {
+					return nil, err
+				}
+				opts.IDs = append(opts.IDs, id)
+			}
+		}
+		if f.NameContains != nil {
+			opts.NameContains = strings.TrimSpace(*f.NameContains)
+		}
+	}
+
+	bases, total, err := r.uc.List(ctx, opts)
+	if err != nil {
+		return nil, toError(err, "리소스 조회 실패")
+	}
+	items := make([]*resourceResolver, len(bases))
+	for i, m := range bases {
+		items[i] = &resourceResolver{m: m}
+	}
+	return &pageResolver{items: items, total: total, page: opts.Page, size: opts.Size}, nil
+}
+
+type resourceInput struct {
+	Name string
+}
+
+func (r *resolver) CreateResource(ctx context.Context, args struct{ Input resourceInput }) (*resourceResolver, error) {
+	m := &model.Base{Name: args.Input.Name}
+	if err := r.uc.Insert(ctx, m); err != nil {
+		return nil, toError(err, "리소스 생성 실패")
+	}
+	return &resourceResolver{m: m}, nil
+}
+
+func (r *resolver) UpdateResource(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input resourceInput
+}) (*resourceResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	m := &model.Base{Name: args.Input.Name}
+	if err := r.uc.Modify(ctx, id, m); err != nil {
+		return nil, toError(err, "리소스 업데이트 실패")
+	}
+	return &resourceResolver{m: m}, nil
+}
+
+func (r *resolver) DeleteResource(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return "", err
+	}
+	if err := r.uc.Remove(ctx, id); err != nil {
+		return "", toError(err, "리소스 삭제 실패")
+	}
+	return args.ID, nil
+}
+
+// ResourceChanged는 Usecase.Watch로 감지한 변경을 구독 채널로 전달
+// 구독이 끝나면(ctx 취소) Watch도 멈추고 채널을 닫음
+func (r *resolver) ResourceChanged(ctx context.Context) (<-chan *eventResolver, error) {
+	ch := make(chan *eventResolver)
+	go func() {
+		defer close(ch)
+		r.uc.Watch(ctx, r.watchInterval, func(e model.Event[model.Base]) error {
+			select {
+			case ch <- &eventResolver{e: e}:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return ch, nil
+}
+
+type resourceResolver struct {
+	m *model.Base
+}
+
+func (r *resourceResolver) ID() graphql.ID {
+	return graphql.ID(strconv.FormatUint(uint64(r.m.ID), 10))
+}
+
+func (r *resourceResolver) Name() string {
+	return r.m.Name
+}
+
+func (r *resourceResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.m.CreatedAt}
+}
+
+func (r *resourceResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.m.UpdatedAt}
+}
+
+func (r *resourceResolver) ExpiresAt() *graphql.Time {
+	if r.m.ExpiresAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.m.ExpiresAt}
+}
+
+type pageResolver struct {
+	items []*resourceResolver
+	total int64
+	page  int
+	size  int
+}
+
+func (p *pageResolver) Items() []*resourceResolver {
+	return p.items
+}
+
+func (p *pageResolver) Total() int32 {
+	return int32(p.total)
+}
+
+func (p *pageResolver) Page() int32 {
+	return int32(p.page)
+}
+
+func (p *pageResolver) Size() int32 {
+	return int32(p.size)
+}
+
+type eventResolver struct {
+	e model.Event[model.Base]
+}
+
+func (e *eventResolver) Type() string {
+	return strings.ToUpper(string(e.e.Type))
+}
+
+func (e *eventResolver) Resource() *resourceResolver {
+	return &resourceResolver{m: e.e.Resource}
+}
@@ -0,0 +1,109 @@
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go_project/internal/database"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Authenticator는 요청의 Bearer 토큰을 검사
+// 유효하지 않으면 에러를 반환하며, 에러는 UNAUTHENTICATED로 응답됨
+type Authenticator func(ctx context.Context, token string) error
+
+type options struct {
+	auth Authenticator
+}
+
+type Option func(*options)
+
+// WithAuth는 모든 RPC에 authorization 메타데이터의 Bearer 토큰 검사를 추가
+// 지정하지 않으면 HTTP API와 같이 인증 없이 동작
+func WithAuth(auth Authenticator) Option {
+	return func(o *options) {
+		o.auth = auth
+	}
+}
+
+// logUnary는 gin의 기본 로거처럼 메서드, 결과 코드, 처리 시간을 기록
+func logUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("[GRPC] %-10s | %13v | %s", status.Code(err), time.Since(start), info.FullMethod)
+	return resp, err
+}
+
+func logStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	log.Printf("[GRPC] %-10s | %13v | %s (stream)", status.Code(err), time.Since(start), info.FullMethod)
+	return err
+}
+
+// sessionUnary는 RPC마다 DB 세션을 만들어서 같은 RPC 안에서 쓴 뒤의 조회는 primary에서 읽게 함
+func sessionUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(database.WithSession(ctx), req)
+}
+
+// recoverUnary는 gin.Recovery처럼 패닉을 INTERNAL 에러로 바꿔서 서버가 죽지 않게 함
+func recoverUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[GRPC] %s 패닉: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, "서버 오류")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func recoverStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[GRPC] %s 패닉: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, "서버 오류")
+		}
+	}()
+	return handler(srv, ss)
+}
+
+func authenticate(ctx context.Context, auth Authenticator) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return status.Error(codes.Unauthenticated, "인증 정보가 없습니다")
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return status.Error(codes.Unauthenticated, "Bearer 토큰이 필요합니다")
+	}
+	if err := auth(ctx, token); err != nil {
+		return status.Error(codes.Unauthenticated, "인증 실패")
+	}
+	return nil
+}
+
+func authUnary(auth Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authenticate(ctx, auth); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authStream(auth Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authenticate(ss.Context(), auth); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
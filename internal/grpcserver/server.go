@@ -0,0 +1,204 @@
+// Package grpcserver는 Usecase를 gRPC ResourceService로 제공
+//
+// HTTP 핸들러와 같은 Usecase를 사용하며, Usecase 에러는 HTTP 상태 코드와 같은 의미의
+// gRPC 코드로 변환함 (ErrNotFound → NOT_FOUND, ErrInvalid → INVALID_ARGUMENT,
+// ErrConflict → FAILED_PRECONDITION, ErrUnavailable → UNAVAILABLE, 그 외 → INTERNAL)
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go_project/internal/model"
+	"go_project/internal/usecase"
+	pb "go_project/pkg/pb/resourcev1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// 목록 조회 시 page만 지정했을 때의 페이지 크기 (HTTP API와 같음)
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	// Watch의 변경 확인 주기
+	defaultWatchInterval = 2 * time.Second
+	minWatchInterval     = 100 * time.Millisecond
+)
+
+type Server struct {
+	pb.UnimplementedResourceServiceServer
+	uc usecase.Usecase[model.Base]
+}
+
+func NewServer(uc usecase.Usecase[model.Base]) *Server {
+	return &Server{uc: uc}
+}
+
+// NewGRPCServer는 로깅, 인증 인터셉터를 설정하고 ResourceService를 등록한 grpc.Server를 생성
+func NewGRPCServer(uc usecase.Usecase[model.Base], opts ...Option) *grpc.Server {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	unary := []grpc.UnaryServerInterceptor{recoverUnary, logUnary, sessionUnary}
+	stream := []grpc.StreamServerInterceptor{recoverStream, logStream}
+	if o.auth != nil {
+		unary = append(unary, authUnary(o.auth))
+		stream = append(stream, authStream(o.auth))
+	}
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	pb.RegisterResourceServiceServer(s, NewServer(uc))
+	return s
+}
+
+func toProto(m *model.Base) *pb.Resource {
+	return &pb.Resource{
+		Id:        uint64(m.ID),
+		Name:      m.Name,
+		CreatedAt: timestamppb.New(m.CreatedAt),
+		UpdatedAt: timestamppb.New(m.UpdatedAt),
+	}
+}
+
+// toStatus는 Usecase 에러를 gRPC 상태로 변환
+func toStatus(err error, message string) error {
+	switch {
+	case errors.Is(err, usecase.ErrNotFound):
+		return status.Error(codes.NotFound, "리소스를 찾을 수 없습니다")
+	case errors.Is(err, usecase.ErrInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, usecase.ErrConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, usecase.ErrUnavailable):
+		// 쿼리 제한 시간 초과도 ErrUnavailable로 감싸지므로 ctx 에러보다 먼저 확인
+		return status.Error(codes.Unavailable, usecase.ErrUnavailable.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Internal, message)
+}
+
+func requireID(id uint64) (uint, error) {
+	if id == 0 {
+		return 0, status.Error(codes.InvalidArgument, "ID를 지정해야 합니다")
+	}
+	return uint(id), nil
+}
+
+func (s *Server) ListResources(ctx context.Context, req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	if req.Page < 0 || req.Size < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page와 size는 0 이상이어야 합니다")
+	}
+
+	var bases []*model.Base
+	var total int64
+	var err error
+	if req.Page == 0 {
+		bases, err = s.uc.GetAll(ctx)
+		total = int64(len(bases))
+	} else {
+		size := int(req.Size)
+		if size == 0 {
+			size = defaultPageSize
+		}
+		bases, total, err = s.uc.List(ctx, model.ListOptions{Page: int(req.Page), Size: min(size, maxPageSize)})
+	}
+	if err != nil {
+		return nil, toStatus(err, "리소스 조회 실패")
+	}
+
+	resp := &pb.ListResourcesResponse{Total: total, Resources: make([]*pb.Resource, len(bases))}
+	for i, b := range bases {
+		resp.Resources[i] = toProto(b)
+	}
+	return resp, nil
+}
+
+func (s *Server) GetResource(ctx context.Context, req *pb.GetResourceRequest) (*pb.Resource, error) {
+	id, err := requireID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.uc.Get(ctx, id)
+	if err != nil {
+		return nil, toStatus(err, "리소스 조회 실패")
+	}
+	return toProto(m), nil
+}
+
+func (s *Server) CreateResource(ctx context.Context, req *pb.CreateResourceRequest) (*pb.Resource, error) {
+	if req.Resource == nil {
+		return nil, status.Error(codes.InvalidArgument, "resource를 지정해야 합니다")
+	}
+	m := &model.Base{Name: req.Resource.Name}
+	if err := s.uc.Insert(ctx, m); err != nil {
+		return nil, toStatus(err, "리소스 생성 실패")
+	}
+	return toProto(m), nil
+}
+
+func (s *Server) UpdateResource(ctx context.Context, req *pb.UpdateResourceRequest) (*pb.Resource, error) {
+	id, err := requireID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Resource == nil {
+		return nil, status.Error(codes.InvalidArgument, "resource를 지정해야 합니다")
+	}
+	m := &model.Base{Name: req.Resource.Name}
+	if err := s.uc.Modify(ctx, id, m); err != nil {
+		return nil, toStatus(err, "리소스 업데이트 실패")
+	}
+	return toProto(m), nil
+}
+
+func (s *Server) DeleteResource(ctx context.Context, req *pb.DeleteResourceRequest) (*emptypb.Empty, error) {
+	id, err := requireID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.uc.Remove(ctx, id); err != nil {
+		return nil, toStatus(err, "리소스 삭제 실패")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// WatchResources는 Usecase.Watch로 감지한 변경 사항을 보냄
+// 클라이언트가 연결을 끊으면 종료
+func (s *Server) WatchResources(req *pb.WatchResourcesRequest, stream pb.ResourceService_WatchResourcesServer) error {
+	interval := defaultWatchInterval
+	if req.Interval != nil {
+		interval = max(req.Interval.AsDuration(), minWatchInterval)
+	}
+
+	ctx := stream.Context()
+	err := s.uc.Watch(ctx, interval, func(e model.Event[model.Base]) error {
+		return stream.Send(&pb.ResourceEvent{Type: eventTypes[e.Type], Resource: toProto(e.Resource)})
+	})
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return toStatus(err, "리소스 조회 실패")
+}
+
+var eventTypes = map[model.EventType]pb.ResourceEvent_Type{
+	model.EventAdded:    pb.ResourceEvent_TYPE_ADDED,
+	model.EventModified: pb.ResourceEvent_TYPE_MODIFIED,
+	model.EventDeleted:  pb.ResourceEvent_TYPE_DELETED,
+	// ResourceEvent.Type에 만료가 없으므로 만료된 리소스는 조회되지 않게 된 것으로 보고 삭제로 전달
+	model.EventExpired: pb.ResourceEvent_TYPE_DELETED,
+}
@@ -0,0 +1,211 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go_project/internal/model"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/usecase"
+	pb "go_project/pkg/pb/resourcev1"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Usecase 에러 변환 확인용 mock (나머지 메서드는 사용하지 않음)
+type mockUsecase struct {
+	usecase.Usecase[model.Base]
+	mock.Mock
+}
+
+func (m *mockUsecase) Get(ctx context.Context, id uint) (*model.Base, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Base), args.Error(1)
+}
+
+type ServerTestSuite struct {
+	suite.Suite
+	uc     usecase.Usecase[model.Base]
+	server *grpc.Server
+	conn   *grpc.ClientConn
+	client pb.ResourceServiceClient
+}
+
+// start는 bufconn 위에서 서버를 실행하고 클라이언트를 연결
+func (s *ServerTestSuite) start(uc usecase.Usecase[model.Base], opts ...Option) {
+	lis := bufconn.Listen(1 << 20)
+	s.server = NewGRPCServer(uc, opts...)
+	go s.server.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Require().NoError(err)
+	s.conn = conn
+	s.client = pb.NewResourceServiceClient(conn)
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.uc = usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))
+	s.start(s.uc)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.conn.Close()
+	s.server.Stop()
+}
+
+func (s *ServerTestSuite) TestCRUD() {
+	ctx := context.Background()
+
+	created, err := s.client.CreateResource(ctx, &pb.CreateResourceRequest{Resource: &pb.Resource{Name: "테스트_데이터"}})
+	s.NoError(err)
+	s.NotZero(created.Id)
+	s.False(created.CreatedAt.AsTime().IsZero())
+
+	got, err := s.client.GetResource(ctx, &pb.GetResourceRequest{Id: created.Id})
+	s.NoError(err)
+	s.Equal("테스트_데이터", got.Name)
+
+	updated, err := s.client.UpdateResource(ctx, &pb.UpdateResourceRequest{Id: created.Id, Resource: &pb.Resource{Name: "수정된_데이터"}})
+	s.NoError(err)
+	s.Equal("수정된_데이터", updated.Name)
+	s.Equal(created.CreatedAt.AsTime(), updated.CreatedAt.AsTime())
+
+	_, err = s.client.DeleteResource(ctx, &pb.DeleteResourceRequest{Id: created.Id})
+	s.NoError(err)
+
+	_, err = s.client.GetResource(ctx, &pb.GetResourceRequest{Id: created.Id})
+	s.Equal(codes.NotFound, status.Code(err))
+	_, err = s.client.UpdateResource(ctx, &pb.UpdateResourceRequest{Id: created.Id, Resource: &pb.Resource{Name: "x"}})
+	s.Equal(codes.NotFound, status.Code(err))
+	_, err = s.client.DeleteResource(ctx, &pb.DeleteResourceRequest{Id: created.Id})
+	s.Equal(codes.NotFound, status.Code(err))
+
+	_, err = s.client.GetResource(ctx, &pb.GetResourceRequest{})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = s.client.CreateResource(ctx, &pb.CreateResourceRequest{})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestList() {
+	ctx := context.Background()
+	for _, name := range []string{"a", "b", "c"} {
+		s.NoError(s.uc.Insert(ctx, &model.Base{Name: name}))
+	}
+
+	all, err := s.client.ListResources(ctx, &pb.ListResourcesRequest{})
+	s.NoError(err)
+	s.Len(all.Resources, 3)
+	s.Equal(int64(3), all.Total)
+
+	page, err := s.client.ListResources(ctx, &pb.ListResourcesRequest{Page: 2, Size: 2})
+	s.NoError(err)
+	s.Len(page.Resources, 1)
+	s.Equal("c", page.Resources[0].Name)
+	s.Equal(int64(3), page.Total)
+
+	_, err = s.client.ListResources(ctx, &pb.ListResourcesRequest{Page: -1})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestWatch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.NoError(s.uc.Insert(ctx, &model.Base{Name: "a"}))
+
+	stream, err := s.client.WatchResources(ctx, &pb.WatchResourcesRequest{Interval: durationpb.New(10 * time.Millisecond)})
+	s.Require().NoError(err)
+
+	e, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Equal(pb.ResourceEvent_TYPE_ADDED, e.Type)
+	s.Equal("a", e.Resource.Name)
+
+	s.NoError(s.uc.Modify(ctx, uint(e.Resource.Id), &model.Base{Name: "a2"}))
+	e, err = stream.Recv()
+	s.Require().NoError(err)
+	s.Equal(pb.ResourceEvent_TYPE_MODIFIED, e.Type)
+	s.Equal("a2", e.Resource.Name)
+
+	s.NoError(s.uc.Remove(ctx, uint(e.Resource.Id)))
+	e, err = stream.Recv()
+	s.Require().NoError(err)
+	s.Equal(pb.ResourceEvent_TYPE_DELETED, e.Type)
+
+	cancel()
+	_, err = stream.Recv()
+	s.Equal(codes.Canceled, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestInternalError() {
+	s.TearDownTest()
+	uc := new(mockUsecase)
+	uc.On("Get", mock.Anything, uint(1)).Return(nil, errors.New("db down"))
+	s.start(uc)
+
+	_, err := s.client.GetResource(context.Background(), &pb.GetResourceRequest{Id: 1})
+	s.Equal(codes.Internal, status.Code(err))
+	// 내부 에러 내용은 클라이언트에 노출하지 않음
+	s.NotContains(status.Convert(err).Message(), "db down")
+}
+
+func (s *ServerTestSuite) TestUnavailable() {
+	s.TearDownTest()
+	uc := new(mockUsecase)
+	uc.On("Get", mock.Anything, uint(1)).Return(nil, fmt.Errorf("조회 실패: %w: %w", usecase.ErrUnavailable, context.DeadlineExceeded))
+	s.start(uc)
+
+	// 쿼리 제한 시간 초과도 DB 장애로 보고 재시도할 수 있는 UNAVAILABLE로 반환
+	_, err := s.client.GetResource(context.Background(), &pb.GetResourceRequest{Id: 1})
+	s.Equal(codes.Unavailable, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestAuth() {
+	s.TearDownTest()
+	s.start(s.uc, WithAuth(func(ctx context.Context, token string) error {
+		if token != "secret" {
+			return errors.New("잘못된 토큰")
+		}
+		return nil
+	}))
+
+	_, err := s.client.ListResources(context.Background(), &pb.ListResourcesRequest{})
+	s.Equal(codes.Unauthenticated, status.Code(err))
+
+	bad := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer wrong")
+	_, err = s.client.ListResources(bad, &pb.ListResourcesRequest{})
+	s.Equal(codes.Unauthenticated, status.Code(err))
+
+	ok := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret")
+	_, err = s.client.ListResources(ok, &pb.ListResourcesRequest{})
+	s.NoError(err)
+
+	// 스트림도 같은 인증을 거침
+	stream, err := s.client.WatchResources(context.Background(), &pb.WatchResourcesRequest{})
+	s.NoError(err)
+	_, err = stream.Recv()
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
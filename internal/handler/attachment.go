@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"errors"
+	"go_project/internal/usecase"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Attachments는 리소스 첨부 파일 핸들러
+type Attachments struct {
+	uc usecase.AttachmentUsecase
+}
+
+func NewAttachments(uc usecase.AttachmentUsecase) *Attachments {
+	return &Attachments{
+		uc: uc,
+	}
+}
+
+// 업로드 요청의 multipart 폼 필드
+type attachmentForm struct {
+	File string `json:"file" format:"binary"`
+}
+
+// Register는 path 아래에 첨부 파일 라우트를 등록
+//
+//	GET    {path}/:id/attachments                - 첨부 파일 목록
+//	POST   {path}/:id/attachments                - 업로드 (multipart, file 필드)
+//	GET    {path}/:id/attachments/:attachmentId  - 내려받기 (파일 내용 그대로 응답)
+//	DELETE {path}/:id/attachments/:attachmentId  - 삭제
+//
+// 내려받기는 응답 형식 협상 대상이 아니므로 Negotiate를 적용하지 않은 raw 그룹에 등록
+func (h *Attachments) Register(negotiated, raw gin.IRoutes, path string) {
+	negotiated.GET(path+"/:id/attachments", h.List)
+	negotiated.POST(path+"/:id/attachments", h.Upload)
+	raw.GET(path+"/:id/attachments/:attachmentId", h.Download)
+	negotiated.DELETE(path+"/:id/attachments/:attachmentId", h.Remove)
+}
+
+// Upload는 요청 본문을 파트 단위로 읽어서 file 필드의 내용을 바로 저장소로 보냄
+// (c.FormFile과 달리 파일 전체를 메모리나 임시 파일에 모으지 않음)
+func (h *Attachments) Upload(c *gin.Context) {
+	resourceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+
+	mr, err := c.Request.MultipartReader()
+	if err != nil {
+		respond(c, http.StatusBadRequest, "multipart/form-data 요청이 필요합니다", nil)
+		return
+	}
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			respond(c, http.StatusBadRequest, "업로드 파일이 없습니다", nil)
+			return
+		}
+		if err != nil {
+			respond(c, http.StatusBadRequest, "잘못된 multipart 요청", nil)
+			return
+		}
+		if part.FormName() != "file" || part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		attachment, err := h.uc.Upload(c, uint(resourceID), part.FileName(), part)
+		part.Close()
+		if err != nil {
+			respondError(c, err, "첨부 파일 업로드 실패")
+			return
+		}
+		respond(c, http.StatusCreated, "성공", attachment)
+		return
+	}
+}
+
+func (h *Attachments) List(c *gin.Context) {
+	resourceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+
+	attachments, err := h.uc.List(c, uint(resourceID))
+	if err != nil {
+		respondError(c, err, "첨부 파일 목록 조회 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", attachments)
+}
+
+// Download는 저장된 내용을 읽는 대로 응답에 기록
+// 내용으로 판별한 Content-Type을 쓰고, 브라우저가 다시 판별하거나 페이지로 열지 않도록
+// nosniff와 attachment 방식의 Content-Disposition을 함께 보냄
+func (h *Attachments) Download(c *gin.Context) {
+	resourceID, id, ok := attachmentIDs(c)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := h.uc.Open(c, resourceID, id)
+	if err != nil {
+		respondError(c, err, "첨부 파일 조회 실패")
+		return
+	}
+	defer content.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})
+	if disposition == "" {
+		// 인코딩할 수 없는 이름이면 이름 없이 내려받게 함
+		disposition = "attachment"
+	}
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    disposition,
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h *Attachments) Remove(c *gin.Context) {
+	resourceID, id, ok := attachmentIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.Remove(c, resourceID, id); err != nil {
+		respondError(c, err, "첨부 파일 삭제 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", nil)
+}
+
+// attachmentIDs는 경로의 리소스 ID와 첨부 파일 ID를 읽음 (잘못된 형식이면 400으로 응답하고 false)
+func attachmentIDs(c *gin.Context) (uint, uint, bool) {
+	resourceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("attachmentId"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 첨부 파일 ID 형식", nil)
+		return 0, 0, false
+	}
+	return uint(resourceID), uint(id), true
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go_project/internal/idempotency"
+	"go_project/internal/model"
+	"go_project/internal/openapi"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/storage"
+	"go_project/internal/usecase"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type AttachmentsTestSuite struct {
+	suite.Suite
+	handler  *Handler
+	router   *gin.Engine
+	resource *model.Base
+}
+
+func (s *AttachmentsTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewRepository(recorder.NewMemoryRecorder[model.Base]())
+	uc := usecase.NewUsecase(repo)
+	auc := usecase.NewAttachmentUsecase(
+		repository.NewAttachmentRepository(recorder.NewMemoryAttachmentRecorder()),
+		repo, storage.NewMemoryStore(), usecase.WithMaxAttachmentSize(1024),
+	)
+
+	s.resource = &model.Base{Name: "첨부_대상"}
+	s.Require().NoError(uc.Insert(context.Background(), s.resource))
+
+	s.handler = NewHandler(uc, WithAttachments(auc), WithIdempotency(idempotency.NewMemoryStore(), 0))
+	s.router = gin.New()
+	s.handler.RegisterAPIRoutes(s.router)
+}
+
+// upload는 file 필드로 content를 올림 (header는 이름, 값 순서로 추가할 요청 헤더)
+func (s *AttachmentsTestSuite) upload(path, filename, content string, header ...string) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	s.Require().NoError(mw.WriteField("description", "파일 앞의 다른 필드는 건너뜀"))
+	fw, err := mw.CreateFormFile("file", filename)
+	s.Require().NoError(err)
+	fw.Write([]byte(content))
+	s.Require().NoError(mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *AttachmentsTestSuite) serve(method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func (s *AttachmentsTestSuite) TestUpload_NoIdempotency() {
+	// 업로드는 본문을 모아 두지 않도록 Idempotency-Key를 처리하지 않으므로 같은 키로 보내도 따로 저장
+	for range 2 {
+		w := s.upload("/api/v1/resources/1/attachments", "a.txt", "hello", "Idempotency-Key", "upload-1")
+		s.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+		s.Empty(w.Header().Get("Idempotent-Replayed"))
+	}
+	w := s.serve(http.MethodGet, "/api/v1/resources/1/attachments")
+	s.Require().Equal(http.StatusOK, w.Code)
+	var resp struct {
+		Data []model.Attachment `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	s.Len(resp.Data, 2)
+}
+
+func (s *AttachmentsTestSuite) TestUploadDownloadDelete() {
+	w := s.upload("/api/v1/resources/1/attachments", "보고서.txt", "hello")
+	s.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Data model.Attachment `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	s.Equal("보고서.txt", created.Data.Filename)
+	s.Equal(int64(5), created.Data.Size)
+	s.NotContains(w.Body.String(), "resources/1/", "저장소 키는 응답에 포함하지 않음")
+
+	w = s.serve(http.MethodGet, "/api/v1/resources/1/attachments")
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), created.Data.Checksum)
+
+	w = s.serve(http.MethodGet, "/api/v1/resources/1/attachments/1")
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("hello", w.Body.String())
+	s.Equal("text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	s.Equal("5", w.Header().Get("Content-Length"))
+	s.Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+	s.Equal("attachment; filename*=utf-8''%EB%B3%B4%EA%B3%A0%EC%84%9C.txt", w.Header().Get("Content-Disposition"))
+
+	s.Equal(http.StatusOK, s.serve(http.MethodDelete, "/api/v1/resources/1/attachments/1").Code)
+	s.Equal(http.StatusNotFound, s.serve(http.MethodGet, "/api/v1/resources/1/attachments/1").Code)
+}
+
+func (s *AttachmentsTestSuite) TestUploadErrors() {
+	tests := []struct {
+		name       string
+		path       string
+		content    string
+		wantStatus int
+	}{
+		{name: "크기_초과", path: "/api/v1/resources/1/attachments", content: strings.Repeat("a", 1025), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "리소스_없음", path: "/api/v1/resources/999/attachments", content: "a", wantStatus: http.StatusNotFound},
+		{name: "잘못된_ID", path: "/api/v1/resources/abc/attachments", content: "a", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			w := s.upload(tt.path, "a.txt", tt.content)
+			s.Equal(tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+
+	// multipart가 아니거나 file 필드가 없는 요청
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/resources/1/attachments", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField("name", "a")
+	mw.Close()
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/resources/1/attachments", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *AttachmentsTestSuite) TestOpenAPI_RoutesMatchSpec() {
+	_, err := openapi.Build(apiInfo, s.router.Routes(), apiPrefix, s.handler.apiOperations())
+	s.NoError(err)
+}
+
+func TestAttachmentsSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentsTestSuite))
+}
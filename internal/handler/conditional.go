@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"go_project/internal/model"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultCacheControl은 WithCacheControl로 정하지 않은 GET 응답의 Cache-Control
+// 브라우저가 응답을 보관하되 쓸 때마다 ETag로 다시 확인하게 해서, 바뀌지 않았으면 304로 본문 전송을 생략함
+const DefaultCacheControl = "private, no-cache"
+
+// WithCacheControl은 route(gin 경로 패턴, 예: /api/v1/resources/:id)의 GET 응답 Cache-Control을 지정
+// 여러 번 지정할 수 있고, 지정하지 않은 경로는 DefaultCacheControl을 사용
+func WithCacheControl(route, value string) Option {
+	return func(h *Handler) {
+		if h.cacheControl == nil {
+			h.cacheControl = make(map[string]string)
+		}
+		h.cacheControl[route] = value
+	}
+}
+
+// 응답의 최종 수정 시각을 저장하는 gin 컨텍스트 키
+const lastModifiedKey = "handler.lastModified"
+
+type lastModified struct {
+	at time.Time
+	// false면 Last-Modified 헤더만 보내고 If-Modified-Since 비교에는 쓰지 않음
+	// (목록은 항목이 삭제돼도 가장 늦은 수정 시각이 그대로일 수 있으므로)
+	validator bool
+}
+
+// setLastModified는 리소스 하나의 응답에 Last-Modified를 붙이도록 수정 시각을 저장
+func setLastModified(c *gin.Context, at time.Time) {
+	c.Set(lastModifiedKey, lastModified{at: at, validator: true})
+}
+
+// setListLastModified는 목록 응답에 가장 늦은 수정 시각을 Last-Modified로 붙이도록 저장
+func setListLastModified[T any, PT model.Model[T]](c *gin.Context, ms []*T) {
+	var latest time.Time
+	for _, m := range ms {
+		if t := PT(m).GetBase().UpdatedAt; t.After(latest) {
+			latest = t
+		}
+	}
+	c.Set(lastModifiedKey, lastModified{at: latest})
+}
+
+// Conditional은 GET 응답 본문의 해시로 강한 ETag를, 핸들러가 저장한 수정 시각으로 Last-Modified를 붙이고
+// If-None-Match 또는 If-Modified-Since가 일치하면 본문 없이 304로 응답하는 미들웨어
+// cacheControl은 경로 패턴별 Cache-Control (없으면 DefaultCacheControl)
+//
+// ETag를 계산하려고 응답을 버퍼에 모으므로 스트리밍 응답 라우트에는 적용하지 않아야 함
+// 응답 형식마다 본문이 다르므로 ETag도 다르고, Vary: Accept를 함께 보냄
+func Conditional(cacheControl map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		buffered := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = buffered
+		c.Next()
+		c.Writer = original
+
+		if buffered.status != http.StatusOK {
+			original.WriteHeader(buffered.status)
+			original.Write(buffered.body.Bytes())
+			return
+		}
+
+		sum := sha256.Sum256(buffered.body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		header := original.Header()
+		header.Set("ETag", etag)
+		header.Add("Vary", "Accept")
+		policy, ok := cacheControl[c.FullPath()]
+		if !ok {
+			policy = DefaultCacheControl
+		}
+		header.Set("Cache-Control", policy)
+
+		value, _ := c.Get(lastModifiedKey)
+		modified, _ := value.(lastModified)
+		if !modified.at.IsZero() {
+			header.Set("Last-Modified", modified.at.UTC().Format(http.TimeFormat))
+		}
+
+		if fresh(c.Request, etag, modified) {
+			// 304에는 본문과 본문 관련 헤더를 보내지 않음
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			original.WriteHeader(http.StatusNotModified)
+			original.WriteHeaderNow()
+			return
+		}
+		original.WriteHeader(http.StatusOK)
+		original.Write(buffered.body.Bytes())
+	}
+}
+
+// fresh는 조건부 요청 헤더로 보아 클라이언트의 사본이 최신인지 판단 (RFC 9110 13.2.2)
+// If-None-Match가 있으면 If-Modified-Since는 보지 않음
+func fresh(r *http.Request, etag string, modified lastModified) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etagMatches(inm, etag)
+	}
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || !modified.validator || modified.at.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// HTTP 날짜는 초 단위이므로 초 미만은 버리고 비교
+	return !modified.at.Truncate(time.Second).After(since)
+}
+
+// etagMatches는 If-None-Match 목록에 etag가 있는지 약한 비교로 확인 (W/ 접두사 무시, *는 항상 일치)
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter는 상태 코드와 본문을 실제 응답에 쓰지 않고 모으는 gin.ResponseWriter
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0
+}
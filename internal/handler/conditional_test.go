@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"go_project/internal/labels"
+	"go_project/internal/model"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConditionalTestSuite struct {
+	suite.Suite
+	uc     usecase.Usecase[model.Base]
+	router *gin.Engine
+}
+
+func (s *ConditionalTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.uc = usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))
+	s.Require().NoError(s.uc.Insert(context.Background(), &model.Base{Name: "web"}))
+
+	s.router = gin.New()
+	NewHandler(s.uc, WithCacheControl("/api/v1/resources/:id/children", "public, max-age=60")).RegisterAPIRoutes(s.router)
+}
+
+func (s *ConditionalTestSuite) get(path string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *ConditionalTestSuite) TestETag() {
+	w := s.get("/api/v1/resources/1", nil)
+	s.Require().Equal(http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	s.Regexp(`^"[0-9a-f]{32}"$`, etag)
+	s.Equal(DefaultCacheControl, w.Header().Get("Cache-Control"))
+	s.Equal("Accept", w.Header().Get("Vary"))
+
+	w = s.get("/api/v1/resources/1", map[string]string{"If-None-Match": `"다른값", ` + etag})
+	s.Equal(http.StatusNotModified, w.Code)
+	s.Empty(w.Body.String())
+	s.Equal(etag, w.Header().Get("ETag"))
+
+	// 형식이 다르면 본문이 다르므로 ETag도 다름
+	w = s.get("/api/v1/resources/1?format=xml", map[string]string{"If-None-Match": etag})
+	s.Equal(http.StatusOK, w.Code)
+	s.NotEqual(etag, w.Header().Get("ETag"))
+
+	// 수정하면 ETag가 바뀜
+	_, err := s.uc.AddLabels(context.Background(), 1, labels.Set{"env": "prod"})
+	s.Require().NoError(err)
+	w = s.get("/api/v1/resources/1", map[string]string{"If-None-Match": etag})
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "prod")
+}
+
+func (s *ConditionalTestSuite) TestLastModified() {
+	w := s.get("/api/v1/resources/1", nil)
+	s.Require().Equal(http.StatusOK, w.Code)
+	lastModified := w.Header().Get("Last-Modified")
+	modified, err := http.ParseTime(lastModified)
+	s.Require().NoError(err)
+
+	tests := []struct {
+		name  string
+		since time.Time
+		want  int
+	}{
+		{name: "같은_시각", since: modified, want: http.StatusNotModified},
+		{name: "이후", since: modified.Add(time.Hour), want: http.StatusNotModified},
+		{name: "이전", since: modified.Add(-time.Second), want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			w := s.get("/api/v1/resources/1", map[string]string{"If-Modified-Since": tt.since.Format(http.TimeFormat)})
+			s.Equal(tt.want, w.Code)
+		})
+	}
+
+	// If-None-Match가 있으면 If-Modified-Since는 보지 않음
+	w = s.get("/api/v1/resources/1", map[string]string{"If-None-Match": `"다른값"`, "If-Modified-Since": lastModified})
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ConditionalTestSuite) TestList() {
+	s.Require().NoError(s.uc.Insert(context.Background(), &model.Base{Name: "db"}))
+	w := s.get("/api/v1/resources", nil)
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.NotEmpty(w.Header().Get("Last-Modified"))
+
+	s.Equal(http.StatusNotModified, s.get("/api/v1/resources", map[string]string{"If-None-Match": w.Header().Get("ETag")}).Code)
+
+	// 목록은 삭제를 수정 시각으로 알 수 없으므로 If-Modified-Since만으로는 304를 보내지 않음
+	s.Require().NoError(s.uc.Remove(context.Background(), 2))
+	w = s.get("/api/v1/resources", map[string]string{"If-Modified-Since": w.Header().Get("Last-Modified")})
+	s.Equal(http.StatusOK, w.Code)
+	s.NotContains(w.Body.String(), `"db"`)
+}
+
+func (s *ConditionalTestSuite) TestCacheControlAndErrors() {
+	w := s.get("/api/v1/resources/1/children", nil)
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("public, max-age=60", w.Header().Get("Cache-Control"))
+
+	// 성공이 아닌 응답은 그대로 전달하고 검증자를 붙이지 않음
+	w = s.get("/api/v1/resources/999", nil)
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Empty(w.Header().Get("ETag"))
+	s.True(strings.HasPrefix(w.Header().Get("Content-Type"), "application/json"))
+	s.Contains(w.Body.String(), `"status":404`)
+}
+
+func TestConditionalSuite(t *testing.T) {
+	suite.Run(t, new(ConditionalTestSuite))
+}
@@ -0,0 +1,670 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"go_project/internal/attributes"
+	"go_project/internal/labels"
+	"go_project/internal/model"
+	"go_project/internal/usecase"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CRUD는 Usecase[T]로 T의 CRUD 라우트를 처리하는 범용 핸들러
+// T는 model.Base를 임베딩한 모델이며, 응답 구조와 에러 처리는 리소스 API와 같음
+type CRUD[T any, PT model.Model[T]] struct {
+	uc usecase.Usecase[T]
+}
+
+// NewCRUD는 uc를 사용하는 CRUD 핸들러를 생성 (T는 uc에서 추론됨)
+func NewCRUD[T any, PT model.Model[T]](uc usecase.Usecase[T]) *CRUD[T, PT] {
+	return &CRUD[T, PT]{
+		uc: uc,
+	}
+}
+
+// Register는 path 아래에 CRUD 라우트를 등록
+//
+//	GET    {path}     - 전체 목록 조회 (?page=1&size=20 으로 페이지 조회)
+//	GET    {path}/:id - 특정 ID 조회
+//	GET    {path}/search?q= - 이름 전문 검색 (관련도 순, 일치한 단어를 <mark>로 감싼 snippet 포함, 결과가 없으면 suggestion 제안)
+//	GET    {path}/similar?name= - 이름이 비슷한 리소스 목록 (오타 허용, 유사도 score 포함)
+//	POST   {path}     - 생성 (비슷한 이름의 리소스가 있으면 응답의 warnings로 알림)
+//	PUT    {path}/:id - 수정
+//	PATCH  {path}/:id - 부분 수정 (본문에 있는 필드만)
+//	DELETE {path}/:id - 삭제 (하위 리소스가 있으면 409, ?cascade=true면 하위 리소스까지 삭제)
+//	POST   {path}:batchCreate|batchUpdate|batchDelete - 일괄 처리
+//	POST   {path}/:id/labels      - 라벨 추가 (본문은 {"key": "value"} 객체)
+//	DELETE {path}/:id/labels/*key - 라벨 삭제 (키에 '/'가 들어갈 수 있으므로 나머지 경로 전체가 키)
+//	GET    {path}/:id/children    - 직계 자식 목록
+//	GET    {path}/:id/ancestors   - 최상위부터 직계 부모까지의 조상 목록 (브레드크럼)
+//	GET    {path}/:id/descendants - 모든 하위 리소스 목록
+//	POST   {path}/:id/move        - 다른 부모 아래로 이동 (본문은 {"parent_id": 3}, null이면 최상위로)
+//	POST   {path}/:id/extend      - 만료 시각 변경 (본문은 {"expires_at": "..."} 또는 {"ttl": "24h"}, 만료를 없애려면 PATCH로 expires_at을 null로)
+//
+// 응답 형식 협상이 필요하면 Negotiate를 적용한 그룹을 넘겨야 함
+func (h *CRUD[T, PT]) Register(r gin.IRoutes, path string) {
+	r.GET(path, h.GetAll)
+	r.GET(path+"/:id", h.Get)
+	r.GET(path+"/search", h.Search)
+	r.GET(path+"/similar", h.Similar)
+	r.POST(path, h.Insert)
+	r.PUT(path+"/:id", h.Modify)
+	r.PATCH(path+"/:id", h.Patch)
+	r.DELETE(path+"/:id", h.Remove)
+	// gin은 세그먼트 중간의 ':'를 파라미터로 해석하므로 하나의 라우트로 받아서 분기
+	r.POST(path+":action", h.Batch)
+	r.POST(path+"/:id/labels", h.AddLabels)
+	r.DELETE(path+"/:id/labels/*key", h.RemoveLabel)
+	r.GET(path+"/:id/children", h.Children)
+	r.GET(path+"/:id/ancestors", h.Ancestors)
+	r.GET(path+"/:id/descendants", h.Descendants)
+	r.POST(path+"/:id/move", h.Move)
+	r.POST(path+"/:id/extend", h.Extend)
+}
+
+// 일괄 생성/수정 요청 본문
+type batchRequest[T any] struct {
+	Mode  model.BatchMode `json:"mode" xml:"mode" yaml:"mode"`
+	Items []*T            `json:"items" xml:"items>item" yaml:"items"`
+}
+
+// 일괄 삭제 요청 본문
+type batchDeleteRequest struct {
+	Mode model.BatchMode `json:"mode" xml:"mode" yaml:"mode"`
+	IDs  []uint          `json:"ids" xml:"ids>id" yaml:"ids"`
+}
+
+// 이동 요청 본문
+type moveRequest struct {
+	ParentID *uint `json:"parent_id" xml:"parent_id" yaml:"parent_id"`
+}
+
+// 만료 시각 변경 요청 본문 (둘 중 하나만 지정)
+type extendRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty" xml:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	TTL       string     `json:"ttl,omitempty" xml:"ttl,omitempty" yaml:"ttl,omitempty"` // 지금부터 남길 시간 (예: 30m, 24h)
+}
+
+// 하위 리소스까지 삭제한 결과
+type removeTreeResponse struct {
+	IDs []uint `json:"ids" xml:"ids>id" yaml:"ids"`
+}
+
+// 일괄 처리 응답의 항목별 상태
+type batchItemResponse struct {
+	Index   int    `json:"index" xml:"index" yaml:"index"`
+	ID      uint   `json:"id,omitempty" xml:"id,omitempty" yaml:"id,omitempty"`
+	Status  int    `json:"status" xml:"status" yaml:"status"`
+	Message string `json:"message" xml:"message" yaml:"message"`
+}
+
+// 일괄 처리 요청 하나에 담을 수 있는 최대 항목 수
+const MaxBatchItems = 1000
+
+// 페이지 크기 기본값과 최댓값
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// 검색 결과 수 기본값과 최댓값
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// GetAll은 page/size 쿼리가 있으면 해당 페이지만, 없으면 전체 목록을 응답
+// 페이지 조회 시 전체 개수는 X-Total-Count 헤더로 전달
+// labelSelector 쿼리(예: env=prod,tier in (a,b))가 있으면 조건에 맞는 리소스만 응답
+// attr.<경로> 쿼리(예: attr.owner.email=a@example.com)가 있으면 속성 값이 같은 리소스만 응답
+func (h *CRUD[T, PT]) GetAll(c *gin.Context) {
+	selector, err := labels.ParseSelector(c.Query("labelSelector"))
+	if err != nil {
+		respond(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	filters, err := attributeFilters(c)
+	if err != nil {
+		respond(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	if c.Query("page") == "" && c.Query("size") == "" {
+		var results []*T
+		if selector.Empty() && len(filters) == 0 {
+			results, err = h.uc.GetAll(c)
+		} else {
+			results, _, err = h.uc.List(c, model.ListOptions{Selector: selector, Attributes: filters})
+		}
+		if err != nil {
+			respondError(c, err, "리소스 목록 조회 실패")
+			return
+		}
+
+		setListLastModified[T, PT](c, results)
+		respond(c, http.StatusOK, "성공", results)
+		return
+	}
+
+	opts, ok := listOptions(c)
+	if !ok {
+		respond(c, http.StatusBadRequest, "잘못된 페이지 파라미터", nil)
+		return
+	}
+	opts.Selector = selector
+	opts.Attributes = filters
+
+	results, total, err := h.uc.List(c, opts)
+	if err != nil {
+		respondError(c, err, "리소스 목록 조회 실패")
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	setListLastModified[T, PT](c, results)
+	respond(c, http.StatusOK, "성공", results)
+}
+
+// 속성 필터 쿼리 파라미터의 접두사
+const attributeQueryPrefix = "attr."
+
+// attributeFilters는 attr.로 시작하는 쿼리 파라미터를 속성 필터로 변환
+// 같은 경로를 여러 번 지정하면 모두 만족해야 함
+func attributeFilters(c *gin.Context) (attributes.Filters, error) {
+	query := c.Request.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if strings.HasPrefix(key, attributeQueryPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	// 같은 요청은 항상 같은 순서의 조건이 되도록 정렬
+	sort.Strings(keys)
+
+	var filters attributes.Filters
+	for _, key := range keys {
+		for _, value := range query[key] {
+			f, err := attributes.ParseFilter(strings.TrimPrefix(key, attributeQueryPrefix), value)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, f)
+		}
+	}
+	return filters, nil
+}
+
+func listOptions(c *gin.Context) (model.ListOptions, bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		return model.ListOptions{}, false
+	}
+	size, err := strconv.Atoi(c.DefaultQuery("size", strconv.Itoa(defaultPageSize)))
+	if err != nil || size < 1 || size > maxPageSize {
+		return model.ListOptions{}, false
+	}
+	return model.ListOptions{Page: page, Size: size}, true
+}
+
+// Search는 q의 단어들이 이름에 (접두사로) 모두 들어 있는 리소스를 관련도 순으로 응답 (?limit=로 개수 제한)
+// 결과가 없으면 검색어와 비슷한 이름을 suggestion으로 함께 응답
+func (h *CRUD[T, PT]) Search(c *gin.Context) {
+	limit, ok := searchLimit(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.uc.Search(c, c.Query("q"), limit)
+	if err != nil {
+		respondError(c, err, "리소스 검색 실패")
+		return
+	}
+	if result.Hits == nil {
+		result.Hits = []model.SearchHit[T]{}
+	}
+
+	respond(c, http.StatusOK, "성공", result)
+}
+
+// Similar는 이름이 name과 비슷한 리소스를 유사도 순으로 응답 (?limit=로 개수 제한)
+func (h *CRUD[T, PT]) Similar(c *gin.Context) {
+	limit, ok := searchLimit(c)
+	if !ok {
+		return
+	}
+
+	matches, err := h.uc.Similar(c, c.Query("name"), limit)
+	if err != nil {
+		respondError(c, err, "유사 이름 검색 실패")
+		return
+	}
+	if matches == nil {
+		matches = []model.FuzzyMatch[T]{}
+	}
+
+	respond(c, http.StatusOK, "성공", matches)
+}
+
+// searchLimit은 limit 쿼리를 읽고, 범위를 벗어나면 400으로 응답한 뒤 false를 반환
+func searchLimit(c *gin.Context) (int, bool) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultSearchLimit)))
+	if err != nil || limit < 1 || limit > maxSearchLimit {
+		respond(c, http.StatusBadRequest, "잘못된 limit 값", nil)
+		return 0, false
+	}
+	return limit, true
+}
+
+func (h *CRUD[T, PT]) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+
+	result, err := h.uc.Get(c, uint(id))
+	if err != nil {
+		respondError(c, err, "리소스 조회 실패")
+		return
+	}
+
+	setLastModified(c, PT(result).GetBase().UpdatedAt)
+	respond(c, http.StatusOK, "성공", result)
+}
+
+func (h *CRUD[T, PT]) Insert(c *gin.Context) {
+	var resource T
+	if !bindBody(c, &resource) || !validLabels(c, PT(&resource).GetBase().Labels) {
+		return
+	}
+
+	ctx, warnings := usecase.WithWarnings(c)
+	if err := h.uc.Insert(ctx, &resource); err != nil {
+		respondError(c, err, "리소스 생성 실패")
+		return
+	}
+
+	respondWithWarnings(c, http.StatusCreated, "성공", resource, warnings.Messages())
+}
+
+func (h *CRUD[T, PT]) Modify(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+
+	var resource T
+	if !bindBody(c, &resource) || !validLabels(c, PT(&resource).GetBase().Labels) {
+		return
+	}
+
+	if err := h.uc.Modify(c, uint(id), &resource); err != nil {
+		respondError(c, err, "리소스 수정 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", resource)
+}
+
+// Patch는 본문에 있는 필드만 기존 리소스에 덮어써서 수정
+// 잠근 최신 리소스 위에 디코딩하므로 그 사이 다른 요청이 바꾼 필드도 본문에 없으면 그대로 유지됨
+func (h *CRUD[T, PT]) Patch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+	b, ok := bodyBinding(c)
+	if !ok {
+		return
+	}
+	// 디코딩은 잠근 뒤에 (다시 실행하면 여러 번) 하므로 본문을 먼저 읽어 둠
+	body, err := c.GetRawData()
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 요청 데이터", nil)
+		return
+	}
+
+	resource, err := h.uc.Update(c, uint(id), func(_ context.Context, m *T) error {
+		if err := b.BindBody(body, m); err != nil {
+			return &badRequestError{message: "잘못된 요청 데이터"}
+		}
+		if err := PT(m).GetBase().Labels.Validate(); err != nil {
+			return &badRequestError{message: err.Error()}
+		}
+		return nil
+	})
+	var bad *badRequestError
+	if errors.As(err, &bad) {
+		respond(c, http.StatusBadRequest, bad.message, nil)
+		return
+	}
+	if err != nil {
+		respondError(c, err, "리소스 수정 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", resource)
+}
+
+// badRequestError는 Usecase에 넘긴 함수 안에서 요청 본문이 잘못됐음을 알리는 에러 (400으로 응답)
+type badRequestError struct {
+	message string
+}
+
+func (e *badRequestError) Error() string {
+	return e.message
+}
+
+func (h *CRUD[T, PT]) Remove(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+	cascade, err := strconv.ParseBool(c.DefaultQuery("cascade", "false"))
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 cascade 값", nil)
+		return
+	}
+
+	if cascade {
+		ids, err := h.uc.RemoveTree(c, uint(id))
+		if err != nil {
+			respondError(c, err, "리소스 삭제 실패")
+			return
+		}
+		respond(c, http.StatusOK, "성공", removeTreeResponse{IDs: ids})
+		return
+	}
+
+	if err := h.uc.Remove(c, uint(id)); err != nil {
+		respondError(c, err, "리소스 삭제 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", nil)
+}
+
+func (h *CRUD[T, PT]) Batch(c *gin.Context) {
+	switch c.Param("action") {
+	case ":batchCreate":
+		h.BatchInsert(c)
+	case ":batchUpdate":
+		h.BatchModify(c)
+	case ":batchDelete":
+		h.BatchRemove(c)
+	default:
+		respond(c, http.StatusNotFound, "지원하지 않는 일괄 작업", nil)
+	}
+}
+
+func (h *CRUD[T, PT]) BatchInsert(c *gin.Context) {
+	var req batchRequest[T]
+	if !bindBody(c, &req) {
+		return
+	}
+	if !validBatch(req.Mode, len(req.Items)) {
+		respond(c, http.StatusBadRequest, "잘못된 요청 데이터", nil)
+		return
+	}
+
+	for _, item := range req.Items {
+		if item == nil {
+			respond(c, http.StatusBadRequest, "잘못된 요청 데이터", nil)
+			return
+		}
+		if !validLabels(c, PT(item).GetBase().Labels) {
+			return
+		}
+	}
+
+	results, err := h.uc.BatchInsert(c, req.Items, batchMode(req.Mode))
+	if err != nil {
+		respondError(c, err, "리소스 일괄 생성 실패")
+		return
+	}
+
+	respondBatch(c, http.StatusCreated, results)
+}
+
+func (h *CRUD[T, PT]) BatchModify(c *gin.Context) {
+	var req batchRequest[T]
+	if !bindBody(c, &req) {
+		return
+	}
+	if !validBatch(req.Mode, len(req.Items)) {
+		respond(c, http.StatusBadRequest, "잘못된 요청 데이터", nil)
+		return
+	}
+	for _, item := range req.Items {
+		if item == nil || PT(item).GetBase().ID == 0 {
+			respond(c, http.StatusBadRequest, "수정할 항목에 ID가 없습니다", nil)
+			return
+		}
+		if !validLabels(c, PT(item).GetBase().Labels) {
+			return
+		}
+	}
+
+	results, err := h.uc.BatchModify(c, req.Items, batchMode(req.Mode))
+	if err != nil {
+		respondError(c, err, "리소스 일괄 수정 실패")
+		return
+	}
+
+	respondBatch(c, http.StatusOK, results)
+}
+
+func (h *CRUD[T, PT]) BatchRemove(c *gin.Context) {
+	var req batchDeleteRequest
+	if !bindBody(c, &req) {
+		return
+	}
+	if !validBatch(req.Mode, len(req.IDs)) {
+		respond(c, http.StatusBadRequest, "잘못된 요청 데이터", nil)
+		return
+	}
+
+	results, err := h.uc.BatchRemove(c, req.IDs, batchMode(req.Mode))
+	if err != nil {
+		respondError(c, err, "리소스 일괄 삭제 실패")
+		return
+	}
+
+	respondBatch(c, http.StatusOK, results)
+}
+
+// AddLabels는 본문의 라벨을 추가하고 수정된 리소스를 응답
+func (h *CRUD[T, PT]) AddLabels(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+
+	var set labels.Set
+	if !bindBody(c, &set) || !validLabels(c, set) {
+		return
+	}
+	if len(set) == 0 {
+		respond(c, http.StatusBadRequest, "추가할 라벨이 없습니다", nil)
+		return
+	}
+
+	result, err := h.uc.AddLabels(c, uint(id), set)
+	if err != nil {
+		respondError(c, err, "라벨 추가 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", result)
+}
+
+// RemoveLabel은 경로의 라벨 키를 삭제하고 수정된 리소스를 응답 (없는 키여도 성공)
+func (h *CRUD[T, PT]) RemoveLabel(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if err := labels.ValidateKey(key); err != nil {
+		respond(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	result, err := h.uc.RemoveLabels(c, uint(id), key)
+	if err != nil {
+		respondError(c, err, "라벨 삭제 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", result)
+}
+
+// Children은 직계 자식 목록을 응답
+func (h *CRUD[T, PT]) Children(c *gin.Context) {
+	h.respondTree(c, h.uc.Children)
+}
+
+// Ancestors는 최상위부터 직계 부모까지의 조상 목록을 응답
+func (h *CRUD[T, PT]) Ancestors(c *gin.Context) {
+	h.respondTree(c, h.uc.Ancestors)
+}
+
+// Descendants는 모든 하위 리소스 목록을 응답 (parent_id로 트리를 구성할 수 있음)
+func (h *CRUD[T, PT]) Descendants(c *gin.Context) {
+	h.respondTree(c, h.uc.Descendants)
+}
+
+// respondTree는 경로의 ID로 트리 조회 함수를 호출해서 결과 목록을 응답
+func (h *CRUD[T, PT]) respondTree(c *gin.Context, fn func(ctx context.Context, id uint) ([]*T, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+
+	results, err := fn(c, uint(id))
+	if err != nil {
+		respondError(c, err, "리소스 목록 조회 실패")
+		return
+	}
+	if results == nil {
+		results = []*T{}
+	}
+
+	setListLastModified[T, PT](c, results)
+	respond(c, http.StatusOK, "성공", results)
+}
+
+// Move는 리소스를 본문의 parent_id 아래로 옮기고 수정된 리소스를 응답
+func (h *CRUD[T, PT]) Move(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+
+	var req moveRequest
+	if !bindBody(c, &req) {
+		return
+	}
+
+	result, err := h.uc.Move(c, uint(id), req.ParentID)
+	if err != nil {
+		respondError(c, err, "리소스 이동 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", result)
+}
+
+// Extend는 본문의 expires_at 또는 지금부터 ttl 뒤로 만료 시각을 바꾸고 수정된 리소스를 응답
+func (h *CRUD[T, PT]) Extend(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+
+	var req extendRequest
+	if !bindBody(c, &req) {
+		return
+	}
+	var expiresAt time.Time
+	switch {
+	case req.ExpiresAt != nil && req.TTL == "":
+		expiresAt = *req.ExpiresAt
+	case req.ExpiresAt == nil && req.TTL != "":
+		ttl, err := time.ParseDuration(req.TTL)
+		if err != nil || ttl <= 0 {
+			respond(c, http.StatusBadRequest, "잘못된 ttl 형식 (예: 30m, 24h)", nil)
+			return
+		}
+		expiresAt = time.Now().Add(ttl)
+	default:
+		respond(c, http.StatusBadRequest, "expires_at과 ttl 중 하나만 지정해야 합니다", nil)
+		return
+	}
+
+	result, err := h.uc.Extend(c, uint(id), expiresAt)
+	if err != nil {
+		respondError(c, err, "만료 시각 변경 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", result)
+}
+
+// validLabels는 라벨 형식을 확인하고, 잘못되었으면 400으로 응답하고 false를 반환
+func validLabels(c *gin.Context, set labels.Set) bool {
+	if err := set.Validate(); err != nil {
+		respond(c, http.StatusBadRequest, err.Error(), nil)
+		return false
+	}
+	return true
+}
+
+// 모드 값과 항목 수가 허용 범위인지 확인
+func validBatch(mode model.BatchMode, n int) bool {
+	if mode != "" && mode != model.BatchModeAtomic && mode != model.BatchModePartial {
+		return false
+	}
+	return n > 0 && n <= MaxBatchItems
+}
+
+// 모드를 지정하지 않으면 atomic으로 처리
+func batchMode(mode model.BatchMode) model.BatchMode {
+	if mode == "" {
+		return model.BatchModeAtomic
+	}
+	return mode
+}
+
+// 항목별 결과를 응답으로 변환
+// 일부 항목이라도 실패하면 207 Multi-Status로 응답
+func respondBatch(c *gin.Context, successStatus int, results []model.BatchResult) {
+	status, message := successStatus, "성공"
+	items := make([]batchItemResponse, len(results))
+	for i, r := range results {
+		items[i] = batchItemResponse{Index: r.Index, ID: r.ID, Status: successStatus, Message: "성공"}
+		if r.Err != nil {
+			items[i].Status = errorStatus(r.Err)
+			items[i].Message = r.Err.Error()
+			status, message = http.StatusMultiStatus, "일부 항목 처리 실패"
+		}
+	}
+
+	respond(c, status, message, items)
+}
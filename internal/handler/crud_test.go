@@ -0,0 +1,355 @@
+package handler
+
+import (
+	"encoding/json"
+	"go_project/internal/attributes"
+	"go_project/internal/model"
+	"go_project/internal/openapi"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// widget은 Base를 임베딩한 두 번째 엔티티
+type widget struct {
+	model.Base
+	Color string `json:"color" xml:"color" yaml:"color"`
+}
+
+type CRUDTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (s *CRUDTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	uc := usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[widget]()))
+	NewCRUD(uc).Register(s.router.Group("/api/v1", Negotiate), "/widgets")
+}
+
+func (s *CRUDTestSuite) do(method, path, body string) (int, string) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w.Code, w.Body.String()
+}
+
+func (s *CRUDTestSuite) TestCRUD() {
+	code, body := s.do(http.MethodPost, "/api/v1/widgets", `{"name":"위젯","color":"red"}`)
+	s.Equal(http.StatusCreated, code)
+	var created struct {
+		Data widget `json:"data"`
+	}
+	s.NoError(json.Unmarshal([]byte(body), &created))
+	s.Equal(uint(1), created.Data.ID)
+	s.Equal("red", created.Data.Color)
+	s.False(created.Data.CreatedAt.IsZero())
+
+	// PATCH는 본문에 있는 필드만 바꿈
+	code, body = s.do(http.MethodPatch, "/api/v1/widgets/1", `{"color":"blue"}`)
+	s.Equal(http.StatusOK, code)
+	s.Contains(body, `"name":"위젯"`)
+	s.Contains(body, `"color":"blue"`)
+
+	code, body = s.do(http.MethodGet, "/api/v1/widgets?page=1&size=10", "")
+	s.Equal(http.StatusOK, code)
+	s.Contains(body, `"color":"blue"`)
+
+	code, _ = s.do(http.MethodPost, "/api/v1/widgets:batchUpdate", `{"items":[{"id":1,"name":"위젯","color":"green"},{"name":"아이디_없음"}]}`)
+	s.Equal(http.StatusBadRequest, code)
+
+	code, _ = s.do(http.MethodDelete, "/api/v1/widgets/1", "")
+	s.Equal(http.StatusOK, code)
+	code, _ = s.do(http.MethodGet, "/api/v1/widgets/1", "")
+	s.Equal(http.StatusNotFound, code)
+}
+
+func (s *CRUDTestSuite) TestLabels() {
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"a","labels":{"env":"prod","tier":"a"}}`)
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"b","labels":{"env":"dev"}}`)
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"c"}`)
+
+	code, _ := s.do(http.MethodPost, "/api/v1/widgets", `{"name":"d","labels":{"env":"잘못된 값"}}`)
+	s.Equal(http.StatusBadRequest, code)
+
+	// 라벨 추가와 삭제 (접두사가 있는 키 포함)
+	code, body := s.do(http.MethodPost, "/api/v1/widgets/3/labels", `{"env":"prod","example.com/team":"infra"}`)
+	s.Equal(http.StatusOK, code)
+	s.Contains(body, `"labels":{"env":"prod","example.com/team":"infra"}`)
+	code, body = s.do(http.MethodDelete, "/api/v1/widgets/3/labels/example.com/team", "")
+	s.Equal(http.StatusOK, code)
+	s.Contains(body, `"labels":{"env":"prod"}`)
+	code, _ = s.do(http.MethodPost, "/api/v1/widgets/99/labels", `{"env":"prod"}`)
+	s.Equal(http.StatusNotFound, code)
+	code, _ = s.do(http.MethodPost, "/api/v1/widgets/3/labels", `{}`)
+	s.Equal(http.StatusBadRequest, code)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "labelSelector=env%3Dprod", want: []string{"a", "c"}},
+		{query: "labelSelector=env!%3Dprod", want: []string{"b"}},
+		{query: "labelSelector=env%20in%20(dev,prod),!tier", want: []string{"b", "c"}},
+		{query: "labelSelector=env%3Dprod&page=1&size=1", want: []string{"a"}},
+	}
+	for _, tt := range tests {
+		s.Run(tt.query, func() {
+			code, body := s.do(http.MethodGet, "/api/v1/widgets?"+tt.query, "")
+			s.Equal(http.StatusOK, code)
+			var resp struct {
+				Data []widget `json:"data"`
+			}
+			s.NoError(json.Unmarshal([]byte(body), &resp))
+			var names []string
+			for _, w := range resp.Data {
+				names = append(names, w.Name)
+			}
+			s.Equal(tt.want, names)
+		})
+	}
+
+	code, _ = s.do(http.MethodGet, "/api/v1/widgets?labelSelector=env%20in%20(a", "")
+	s.Equal(http.StatusBadRequest, code)
+}
+
+func (s *CRUDTestSuite) TestAttributes() {
+	schema, err := attributes.ParseSchema([]byte(`{"properties":{"owner":{"type":"object","properties":{"email":{"type":"string"}}}}}`))
+	s.Require().NoError(err)
+	uc := usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[widget]()), usecase.WithAttributeSchema(schema))
+	s.router = gin.New()
+	NewCRUD(uc).Register(s.router.Group("/api/v1", Negotiate), "/widgets")
+
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"a","attributes":{"owner":{"email":"a@example.com"},"replicas":3}}`)
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"b","attributes":{"owner":{"email":"b@example.com"},"replicas":3}}`)
+
+	code, body := s.do(http.MethodPost, "/api/v1/widgets", `{"name":"c","attributes":{"owner":{"email":1}}}`)
+	s.Equal(http.StatusBadRequest, code)
+	s.Contains(body, "속성 owner.email: string 타입이어야 합니다")
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "attr.owner.email=a%40example.com", want: []string{"a"}},
+		{query: "attr.replicas=3", want: []string{"a", "b"}},
+		{query: "attr.replicas=3&attr.owner.email=b%40example.com&page=1", want: []string{"b"}},
+		{query: "attr.owner.email=c%40example.com", want: nil},
+	}
+	for _, tt := range tests {
+		s.Run(tt.query, func() {
+			code, body := s.do(http.MethodGet, "/api/v1/widgets?"+tt.query, "")
+			s.Equal(http.StatusOK, code)
+			var resp struct {
+				Data []widget `json:"data"`
+			}
+			s.NoError(json.Unmarshal([]byte(body), &resp))
+			var names []string
+			for _, w := range resp.Data {
+				names = append(names, w.Name)
+			}
+			s.Equal(tt.want, names)
+		})
+	}
+
+	code, _ = s.do(http.MethodGet, "/api/v1/widgets?attr.owner..email=a", "")
+	s.Equal(http.StatusBadRequest, code)
+}
+
+func (s *CRUDTestSuite) TestTree() {
+	// 1 ─ 2 ─ 3, 4
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"프로젝트"}`)
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"폴더","parent_id":1}`)
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"항목","parent_id":2}`)
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"다른_프로젝트"}`)
+
+	names := func(path string) []string {
+		code, body := s.do(http.MethodGet, path, "")
+		s.Require().Equal(http.StatusOK, code, body)
+		var resp struct {
+			Data []widget `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal([]byte(body), &resp))
+		names := []string{}
+		for _, w := range resp.Data {
+			names = append(names, w.Name)
+		}
+		return names
+	}
+	s.Equal([]string{"폴더"}, names("/api/v1/widgets/1/children"))
+	s.Equal([]string{}, names("/api/v1/widgets/3/children"))
+	s.Equal([]string{"프로젝트", "폴더"}, names("/api/v1/widgets/3/ancestors"))
+	s.Equal([]string{"폴더", "항목"}, names("/api/v1/widgets/1/descendants"))
+
+	code, _ := s.do(http.MethodGet, "/api/v1/widgets/99/ancestors", "")
+	s.Equal(http.StatusNotFound, code)
+
+	// 순환이 생기거나 없는 부모는 400
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "하위_리소스_아래로_이동", method: http.MethodPost, path: "/api/v1/widgets/1/move", body: `{"parent_id":3}`},
+		{name: "자기_자신_아래로_이동", method: http.MethodPost, path: "/api/v1/widgets/1/move", body: `{"parent_id":1}`},
+		{name: "하위_리소스를_부모로_수정", method: http.MethodPatch, path: "/api/v1/widgets/2", body: `{"parent_id":3}`},
+		{name: "없는_부모로_생성", method: http.MethodPost, path: "/api/v1/widgets", body: `{"name":"고아","parent_id":99}`},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			code, _ := s.do(tt.method, tt.path, tt.body)
+			s.Equal(http.StatusBadRequest, code)
+		})
+	}
+
+	// 하위 트리 이동
+	code, body := s.do(http.MethodPost, "/api/v1/widgets/2/move", `{"parent_id":4}`)
+	s.Equal(http.StatusOK, code)
+	s.Contains(body, `"parent_id":4`)
+	s.Equal([]string{"다른_프로젝트", "폴더"}, names("/api/v1/widgets/3/ancestors"))
+	s.Equal([]string{}, names("/api/v1/widgets/1/descendants"))
+
+	// 하위 리소스가 있으면 cascade 없이는 삭제할 수 없음
+	code, _ = s.do(http.MethodDelete, "/api/v1/widgets/4", "")
+	s.Equal(http.StatusConflict, code)
+	code, body = s.do(http.MethodDelete, "/api/v1/widgets/4?cascade=true", "")
+	s.Equal(http.StatusOK, code)
+	s.Contains(body, `"ids":[4,2,3]`)
+	code, _ = s.do(http.MethodGet, "/api/v1/widgets/3", "")
+	s.Equal(http.StatusNotFound, code)
+
+	// 최상위로 이동
+	code, body = s.do(http.MethodPost, "/api/v1/widgets/1/move", `{"parent_id":null}`)
+	s.Equal(http.StatusOK, code)
+	s.NotContains(body, "parent_id")
+}
+
+func (s *CRUDTestSuite) TestOperations() {
+	ops := crudOperations[widget]("/api/v1/widgets", "Widget", "Widgets", []string{"widgets"})
+	doc, err := openapi.Build(apiInfo, s.router.Routes(), apiPrefix, ops)
+	s.Require().NoError(err)
+
+	s.Equal("createWidget", doc.Paths["/api/v1/widgets"]["post"].OperationID)
+	s.Contains(doc.Components.Schemas, "HandlerWidget")
+	s.Contains(doc.Components.Schemas["HandlerWidget"].Properties, "color")
+	s.Contains(doc.Components.Schemas, "HandlerBatchRequestHandlerWidget")
+}
+
+func (s *CRUDTestSuite) TestSearch() {
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"blue widget","color":"blue"}`)
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"red gadget","color":"red"}`)
+
+	code, body := s.do(http.MethodGet, "/api/v1/widgets/search?q=wid", "")
+	s.Require().Equal(http.StatusOK, code, body)
+	var resp struct {
+		Data model.SearchResult[widget] `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal([]byte(body), &resp))
+	s.Require().Len(resp.Data.Hits, 1)
+	s.Equal("blue", resp.Data.Hits[0].Resource.Color)
+	s.Equal("blue <mark>widget</mark>", resp.Data.Hits[0].Snippet)
+
+	code, body = s.do(http.MethodGet, "/api/v1/widgets/search?q=none", "")
+	s.Equal(http.StatusOK, code)
+	s.Contains(body, `"hits":[]`)
+	s.NotContains(body, "suggestion")
+
+	// 결과가 없으면 비슷한 이름을 제안
+	code, body = s.do(http.MethodGet, "/api/v1/widgets/search?q=red+gadgte", "")
+	s.Equal(http.StatusOK, code)
+	s.Contains(body, `"suggestion":"red gadget"`)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "검색어_없음", path: "/api/v1/widgets/search"},
+		{name: "잘못된_limit", path: "/api/v1/widgets/search?q=a&limit=0"},
+		{name: "limit_초과", path: "/api/v1/widgets/search?q=a&limit=101"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			code, _ := s.do(http.MethodGet, tt.path, "")
+			s.Equal(http.StatusBadRequest, code)
+		})
+	}
+}
+
+func (s *CRUDTestSuite) TestSimilar() {
+	code, body := s.do(http.MethodPost, "/api/v1/widgets", `{"name":"데이터베이스","color":"blue"}`)
+	s.Require().Equal(http.StatusCreated, code, body)
+	s.NotContains(body, "warnings")
+
+	// 비슷한 이름으로 만들면 생성은 되지만 경고가 붙음
+	code, body = s.do(http.MethodPost, "/api/v1/widgets", `{"name":"데이타베이스","color":"red"}`)
+	s.Require().Equal(http.StatusCreated, code, body)
+	var created struct {
+		Warnings []string `json:"warnings"`
+	}
+	s.Require().NoError(json.Unmarshal([]byte(body), &created))
+	s.Require().Len(created.Warnings, 1)
+	s.Contains(created.Warnings[0], `"데이터베이스" (ID 1`)
+
+	code, body = s.do(http.MethodGet, "/api/v1/widgets/similar?name=데이터배이스&limit=1", "")
+	s.Require().Equal(http.StatusOK, code, body)
+	var resp struct {
+		Data []model.FuzzyMatch[widget] `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal([]byte(body), &resp))
+	s.Require().Len(resp.Data, 1)
+	s.Equal("데이터베이스", resp.Data[0].Resource.Name)
+
+	code, _ = s.do(http.MethodGet, "/api/v1/widgets/similar", "")
+	s.Equal(http.StatusBadRequest, code)
+}
+
+func (s *CRUDTestSuite) TestExtend() {
+	s.do(http.MethodPost, "/api/v1/widgets", `{"name":"임시"}`)
+
+	code, body := s.do(http.MethodPost, "/api/v1/widgets/1/extend", `{"ttl":"1h"}`)
+	s.Equal(http.StatusOK, code)
+	var extended struct {
+		Data widget `json:"data"`
+	}
+	s.NoError(json.Unmarshal([]byte(body), &extended))
+	s.Require().NotNil(extended.Data.ExpiresAt)
+	s.WithinDuration(time.Now().Add(time.Hour), *extended.Data.ExpiresAt, time.Minute)
+
+	expiresAt := time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+	code, body = s.do(http.MethodPost, "/api/v1/widgets/1/extend", `{"expires_at":"`+expiresAt+`"}`)
+	s.Equal(http.StatusOK, code)
+	s.Contains(body, `"expires_at":"`+expiresAt+`"`)
+
+	for _, body := range []string{`{}`, `{"ttl":"1h","expires_at":"` + expiresAt + `"}`, `{"ttl":"-1h"}`, `{"ttl":"하루"}`, `{"expires_at":"2000-01-01T00:00:00Z"}`} {
+		code, _ = s.do(http.MethodPost, "/api/v1/widgets/1/extend", body)
+		s.Equal(http.StatusBadRequest, code, body)
+	}
+	code, _ = s.do(http.MethodPost, "/api/v1/widgets/9/extend", `{"ttl":"1h"}`)
+	s.Equal(http.StatusNotFound, code)
+
+	// 이미 지난 만료 시각으로는 생성할 수 없음
+	code, _ = s.do(http.MethodPost, "/api/v1/widgets", `{"name":"만료됨","expires_at":"2000-01-01T00:00:00Z"}`)
+	s.Equal(http.StatusBadRequest, code)
+
+	// PATCH로 expires_at을 null로 바꾸면 만료되지 않음
+	code, body = s.do(http.MethodPatch, "/api/v1/widgets/1", `{"expires_at":null}`)
+	s.Equal(http.StatusOK, code)
+	s.NotContains(body, "expires_at")
+}
+
+func TestCRUDSuite(t *testing.T) {
+	suite.Run(t, new(CRUDTestSuite))
+}
@@ -0,0 +1,605 @@
+package handler
+
+import (
+	"go_project/internal/jobs"
+	"go_project/internal/labels"
+	"go_project/internal/model"
+	"go_project/internal/openapi"
+	"go_project/internal/scheduler"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// API 문서 기본 정보
+var apiInfo = openapi.Info{
+	Title:       "리소스 관리 API",
+	Version:     "1.0.0",
+	Description: "공통 응답 구조 {status, message, data}로 응답하는 리소스 CRUD API",
+}
+
+// 문서화 대상 라우트의 공통 경로
+const apiPrefix = "/api/v1"
+
+// 가져오기 요청의 multipart 폼 필드
+type importForm struct {
+	File   string `json:"file" format:"binary"`
+	Format string `json:"format,omitempty"`
+	DryRun bool   `json:"dry_run,omitempty"`
+	Upsert bool   `json:"upsert,omitempty"`
+}
+
+var (
+	idParam = openapi.Param{Name: "id", In: "path", Type: "integer", Description: "리소스 ID"}
+
+	formatParam = openapi.Param{
+		Name:        "format",
+		In:          "query",
+		Type:        "string",
+		Enum:        []string{formatJSON, formatXML, formatYAML, formatMsgPack},
+		Description: "응답 형식 (Accept 헤더보다 우선)",
+	}
+
+	pageParams = []openapi.Param{
+		{Name: "page", In: "query", Type: "integer", Description: "페이지 번호 (1부터, page/size가 없으면 전체 목록)"},
+		{Name: "size", In: "query", Type: "integer", Description: "페이지 크기 (기본 20, 최대 100)"},
+	}
+
+	labelSelectorParam = openapi.Param{
+		Name: "labelSelector", In: "query", Type: "string",
+		Description: "라벨 셀렉터 (예: env=prod,team!=infra,tier in (a,b),owner,!legacy)",
+	}
+	// 속성 필터는 경로마다 쿼리 이름이 달라지므로 예시 하나로 설명
+	attributeFilterParam = openapi.Param{
+		Name: "attr.owner.email", In: "query", Type: "string",
+		Description: "속성 필터 (attr.<점으로 구분한 경로>=값, 여러 개면 모두 일치)",
+	}
+	cascadeParam        = openapi.Param{Name: "cascade", In: "query", Type: "boolean", Description: "하위 리소스까지 삭제 (기본 false)"}
+	attachmentIDParam   = openapi.Param{Name: "attachmentId", In: "path", Type: "integer", Description: "첨부 파일 ID"}
+	revisionParam       = openapi.Param{Name: "revision", In: "path", Type: "integer", Description: "리비전 번호 (1부터)"}
+	jobIDParam          = openapi.Param{Name: "id", In: "path", Type: "integer", Description: "작업 ID"}
+	taskNameParam       = openapi.Param{Name: "name", In: "path", Type: "string", Description: "예약 작업 이름"}
+	labelKeyParam       = openapi.Param{Name: "key", In: "path", Type: "string", Description: "라벨 키 (접두사 포함, 예: example.com/team)"}
+	idempotencyKeyParam = openapi.Param{
+		Name: "Idempotency-Key", In: "header", Type: "string",
+		Description: "재시도를 한 번만 처리하기 위한 키 (최대 255자, 같은 키의 재시도에는 첫 응답을 Idempotent-Replayed: true와 함께 반환)",
+	}
+
+	errBadRequest       = openapi.Response{Status: http.StatusBadRequest, Description: "잘못된 요청"}
+	errNotFound         = openapi.Response{Status: http.StatusNotFound, Description: "리소스를 찾을 수 없음"}
+	errNotAcceptable    = openapi.Response{Status: http.StatusNotAcceptable, Description: "지원하지 않는 응답 형식"}
+	errUnsupportedMedia = openapi.Response{Status: http.StatusUnsupportedMediaType, Description: "지원하지 않는 Content-Type"}
+	errConflict         = openapi.Response{Status: http.StatusConflict, Description: "하위 리소스가 있어 삭제할 수 없음"}
+	errInternal         = openapi.Response{Status: http.StatusInternalServerError, Description: "서버 오류"}
+	errUnavailable      = openapi.Response{Status: http.StatusServiceUnavailable, Description: "데이터베이스를 일시적으로 사용할 수 없음 (Retry-After 후 재시도)"}
+	notModified         = openapi.Response{Status: http.StatusNotModified, Description: "변경 없음 (If-None-Match 또는 If-Modified-Since와 일치)", NoBody: true}
+
+	// Idempotency-Key를 처리할 때 POST/PATCH에 추가되는 응답
+	idempotencyResponses = []openapi.Response{
+		{Status: http.StatusConflict, Description: "같은 Idempotency-Key의 요청을 처리 중"},
+		{Status: http.StatusUnprocessableEntity, Description: "다른 요청에 사용한 Idempotency-Key"},
+	}
+	errTooManyRequests = openapi.Response{Status: http.StatusTooManyRequests, Description: "속도 제한 또는 하루 쓰기 한도 초과 (Retry-After 후 재시도)"}
+)
+
+// 작업 목록에서 거를 수 있는 상태
+var jobStates = []string{
+	string(jobs.StatePending), string(jobs.StateRunning), string(jobs.StateSucceeded),
+	string(jobs.StateDead), string(jobs.StateCanceled),
+}
+
+// apiOperations는 RegisterAPIRoutes에 등록하는 라우트별 문서 정보
+// 라우트를 추가하거나 바꾸면 여기도 같이 수정해야 함 (TestOpenAPI_RoutesMatchSpec에서 확인)
+func (h *Handler) apiOperations() []openapi.Operation {
+	tags := []string{"resources"}
+
+	ops := []openapi.Operation{
+		{
+			Method: http.MethodGet, Route: "/api/v1/openapi.json",
+			ID: "getOpenAPI", Summary: "OpenAPI 문서", Tags: []string{"docs"},
+			ResponseMIME: []string{"application/json"},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "OpenAPI 3.1 문서", Data: map[string]interface{}{}},
+			},
+		},
+		{
+			Method: http.MethodGet, Route: "/api/v1/resources/export",
+			ID: "exportResources", Summary: "리소스 내보내기", Tags: tags,
+			Params: []openapi.Param{{
+				Name: "format", In: "query", Type: "string", Description: "파일 형식",
+				Enum: []string{"csv", "ndjson", "json"},
+			}},
+			ResponseMIME: []string{"text/csv", "application/x-ndjson", "application/json"},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "내보낸 파일", Data: []*model.Base{}},
+				errBadRequest,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: "/api/v1/resources/import",
+			ID: "importResources", Summary: "리소스 가져오기", Tags: tags,
+			Params:      []openapi.Param{formatParam},
+			Request:     &importForm{},
+			RequestMIME: []string{"multipart/form-data"},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: &model.ImportReport{}},
+				{Status: http.StatusUnprocessableEntity, Description: "행 단위 오류 보고", Data: &model.ImportReport{}},
+				errBadRequest, errNotAcceptable, errInternal,
+			},
+		},
+	}
+	ops = append(ops, crudOperations[model.Base](apiPrefix+"/resources", "Resource", "Resources", tags)...)
+	if h.attachments != nil {
+		ops = append(ops, attachmentOperations(apiPrefix+"/resources", []string{"attachments"})...)
+	}
+	if h.revisions != nil {
+		ops = append(ops, revisionOperations[model.Base](apiPrefix+"/resources", []string{"revisions"})...)
+	}
+	if h.jobs != nil {
+		ops = append(ops, jobOperations(apiPrefix+"/jobs", []string{"jobs"})...)
+	}
+	if h.tasks != nil {
+		ops = append(ops, taskOperations(apiPrefix+"/tasks", []string{"tasks"})...)
+	}
+	for i := range ops {
+		// 서버 오류를 문서화한 작업은 DB를 사용하므로 DB 장애 시 503을 반환할 수 있음
+		if slices.ContainsFunc(ops[i].Responses, func(r openapi.Response) bool { return r.Status == http.StatusInternalServerError }) {
+			ops[i].Responses = withResponses(ops[i].Responses, errUnavailable)
+		}
+	}
+	if h.idempotency != nil {
+		for i := range ops {
+			// Idempotency 미들웨어는 리소스 그룹(리소스, 가져오기, 리비전)에만 적용
+			idempotent := slices.Contains(ops[i].Tags, "resources") || slices.Contains(ops[i].Tags, "revisions")
+			if idempotent && isIdempotentMethod(ops[i].Method) {
+				ops[i] = withIdempotencyKey(ops[i])
+			}
+		}
+	}
+	if h.rateLimit != nil {
+		for i := range ops {
+			ops[i].Responses = withResponses(ops[i].Responses, errTooManyRequests)
+		}
+	}
+	return ops
+}
+
+// withIdempotencyKey는 Idempotency-Key 헤더와 그에 따른 응답을 op 문서에 추가
+func withIdempotencyKey(op openapi.Operation) openapi.Operation {
+	op.Params = append(op.Params[:len(op.Params):len(op.Params)], idempotencyKeyParam)
+	op.Responses = withResponses(op.Responses, idempotencyResponses...)
+	return op
+}
+
+// withResponses는 responses에 extra를 추가한 새 목록을 반환
+// 같은 상태 코드의 응답이 이미 있으면 기존 설명을 유지
+func withResponses(responses []openapi.Response, extra ...openapi.Response) []openapi.Response {
+	documented := make(map[int]bool)
+	for _, r := range responses {
+		documented[r.Status] = true
+	}
+	responses = responses[:len(responses):len(responses)]
+	for _, r := range extra {
+		if !documented[r.Status] {
+			responses = append(responses, r)
+		}
+	}
+	return responses
+}
+
+// attachmentOperations는 Attachments.Register가 path 아래에 등록하는 라우트의 문서 정보
+func attachmentOperations(path string, tags []string) []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Route: path + "/:id/attachments",
+			ID: "listAttachments", Summary: "첨부 파일 목록", Tags: tags,
+			Params: []openapi.Param{idParam, formatParam},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: []*model.Attachment{}},
+				notModified,
+				errBadRequest, errNotFound, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path + "/:id/attachments",
+			ID: "uploadAttachment", Summary: "첨부 파일 업로드 (형식은 내용으로 판별)", Tags: tags,
+			Params:      []openapi.Param{idParam, formatParam},
+			Request:     &attachmentForm{},
+			RequestMIME: []string{"multipart/form-data"},
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Description: "생성됨", Data: &model.Attachment{}},
+				errBadRequest, errNotFound, errNotAcceptable,
+				{Status: http.StatusRequestEntityTooLarge, Description: "허용 크기 초과"},
+				{Status: http.StatusUnsupportedMediaType, Description: "허용되지 않는 파일 형식"},
+				errInternal,
+			},
+		},
+		{
+			Method: http.MethodGet, Route: path + "/:id/attachments/:attachmentId",
+			ID: "downloadAttachment", Summary: "첨부 파일 내려받기", Tags: tags,
+			Params:       []openapi.Param{idParam, attachmentIDParam},
+			ResponseMIME: []string{"application/octet-stream"},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "파일 내용 (Content-Type은 업로드 시 판별한 형식)", Data: ""},
+				errBadRequest, errNotFound, errInternal,
+			},
+		},
+		{
+			Method: http.MethodDelete, Route: path + "/:id/attachments/:attachmentId",
+			ID: "deleteAttachment", Summary: "첨부 파일 삭제", Tags: tags,
+			Params: []openapi.Param{idParam, attachmentIDParam, formatParam},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공"},
+				errBadRequest, errNotFound, errNotAcceptable, errInternal,
+			},
+		},
+	}
+}
+
+// revisionOperations는 Revisions.Register가 path 아래에 등록하는 라우트의 문서 정보
+func revisionOperations[T any](path string, tags []string) []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Route: path + "/:id/revisions",
+			ID: "listRevisions", Summary: "리비전 목록 (최근 리비전부터, 전체 개수는 X-Total-Count)", Tags: tags,
+			Params: append([]openapi.Param{idParam, formatParam}, pageParams...),
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: []*model.ResourceRevision[T]{}},
+				notModified,
+				errBadRequest, errNotFound, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodGet, Route: path + "/:id/revisions/diff",
+			ID: "diffRevisions", Summary: "두 리비전 사이에 바뀐 값 (경로는 JSON Pointer)", Tags: tags,
+			Params: []openapi.Param{
+				idParam,
+				{Name: "from", In: "query", Type: "integer", Description: "비교할 이전 리비전 번호"},
+				{Name: "to", In: "query", Type: "integer", Description: "비교할 이후 리비전 번호"},
+				formatParam,
+			},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: &model.RevisionDiff{}},
+				notModified,
+				errBadRequest, errNotFound, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodGet, Route: path + "/:id/revisions/:revision",
+			ID: "getRevision", Summary: "리비전 조회", Tags: tags,
+			Params: []openapi.Param{idParam, revisionParam, formatParam},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: &model.ResourceRevision[T]{}},
+				notModified,
+				errBadRequest, errNotFound, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path + "/:id/revisions/:revision/rollback",
+			ID: "rollbackRevision", Summary: "리비전으로 롤백 (수정과 같은 검증을 거치고 새 리비전으로 남음)", Tags: tags,
+			Params:  []openapi.Param{idParam, revisionParam, formatParam},
+			Request: &rollbackRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공 (새 리비전)", Data: &model.ResourceRevision[T]{}},
+				errBadRequest, errNotFound, errNotAcceptable, errUnsupportedMedia,
+				{Status: http.StatusConflict, Description: "expected_revision 이후에 수정됨"},
+				errInternal,
+			},
+		},
+	}
+}
+
+// jobOperations는 Jobs.Register가 path 아래에 등록하는 라우트의 문서 정보
+func jobOperations(path string, tags []string) []openapi.Operation {
+	jsonOnly := []string{"application/json"}
+	errJobNotFound := openapi.Response{Status: http.StatusNotFound, Description: "작업을 찾을 수 없음"}
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Route: path,
+			ID: "listJobs", Summary: "작업 목록 (최근 작업부터)", Tags: tags,
+			Params: append([]openapi.Param{
+				{Name: "queue", In: "query", Type: "string", Description: "큐 이름"},
+				{Name: "state", In: "query", Type: "string", Description: "작업 상태", Enum: jobStates},
+				{Name: "type", In: "query", Type: "string", Description: "작업 종류"},
+			}, pageParams...),
+			ResponseMIME: jsonOnly,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공 (전체 개수는 X-Total-Count 헤더)", Data: []*jobs.Job{}},
+				errBadRequest, errInternal,
+			},
+		},
+		{
+			Method: http.MethodGet, Route: path + "/:id",
+			ID: "getJob", Summary: "작업 조회", Tags: tags,
+			Params:       []openapi.Param{jobIDParam},
+			ResponseMIME: jsonOnly,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: &jobs.Job{}},
+				errBadRequest, errJobNotFound, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path + "/:id/retry",
+			ID: "retryJob", Summary: "dead 또는 canceled 작업을 처음부터 다시 실행", Tags: tags,
+			Params:       []openapi.Param{jobIDParam},
+			ResponseMIME: jsonOnly,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: &jobs.Job{}},
+				errBadRequest, errJobNotFound,
+				{Status: http.StatusConflict, Description: "재실행할 수 없는 상태이거나 같은 고유 키의 작업이 대기 중"},
+				errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path + "/:id/cancel",
+			ID: "cancelJob", Summary: "대기 중이거나 실행 중인 작업 취소", Tags: tags,
+			Params:       []openapi.Param{jobIDParam},
+			ResponseMIME: jsonOnly,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: &jobs.Job{}},
+				errBadRequest, errJobNotFound,
+				{Status: http.StatusConflict, Description: "이미 끝난 작업"},
+				errInternal,
+			},
+		},
+	}
+}
+
+// taskOperations는 Tasks.Register가 path 아래에 등록하는 라우트의 문서 정보
+func taskOperations(path string, tags []string) []openapi.Operation {
+	jsonOnly := []string{"application/json"}
+	errTaskNotFound := openapi.Response{Status: http.StatusNotFound, Description: "등록되지 않은 예약 작업"}
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Route: path,
+			ID: "listTasks", Summary: "예약 작업 목록", Tags: tags,
+			ResponseMIME: jsonOnly,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: []scheduler.TaskInfo{}},
+				errInternal,
+			},
+		},
+		{
+			Method: http.MethodGet, Route: path + "/:name/runs",
+			ID: "listTaskRuns", Summary: "예약 작업 실행 기록 (최근 것부터)", Tags: tags,
+			Params:       append([]openapi.Param{taskNameParam}, pageParams...),
+			ResponseMIME: jsonOnly,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공 (전체 개수는 X-Total-Count 헤더)", Data: []*scheduler.Run{}},
+				errBadRequest, errTaskNotFound, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path + "/:name/run",
+			ID: "runTask", Summary: "예약 작업 바로 실행 (이 요청을 받은 서버에서 실행)", Tags: tags,
+			Params:       []openapi.Param{taskNameParam},
+			ResponseMIME: jsonOnly,
+			Responses: []openapi.Response{
+				{Status: http.StatusAccepted, Description: "실행 시작", Data: &scheduler.Run{}},
+				errTaskNotFound,
+				{Status: http.StatusConflict, Description: "이미 실행 중"},
+				errInternal,
+			},
+		},
+	}
+}
+
+// crudOperations는 CRUD.Register가 path 아래에 등록하는 라우트의 문서 정보
+// singular/plural은 operationId에 쓰는 이름 (예: Resource, Resources)
+func crudOperations[T any](path, singular, plural string, tags []string) []openapi.Operation {
+	batchResults := []batchItemResponse{}
+
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Route: path,
+			ID: "list" + plural, Summary: "전체 리소스 목록 조회 (페이지 조회 시 X-Total-Count 헤더에 전체 개수)", Tags: tags,
+			Params: append([]openapi.Param{formatParam, labelSelectorParam, attributeFilterParam}, pageParams...),
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: []*T{}},
+				notModified,
+				errBadRequest, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodGet, Route: path + "/search",
+			ID: "search" + plural, Summary: "이름 전문 검색 (관련도 순, snippet은 일치한 단어를 <mark>로 감싼 이름, 결과가 없으면 suggestion 제안)", Tags: tags,
+			Params: []openapi.Param{
+				{Name: "q", In: "query", Type: "string", Required: true, Description: "검색어 (단어마다 접두사로 일치, 모두 일치해야 함)"},
+				{Name: "limit", In: "query", Type: "integer", Description: "최대 결과 수 (기본 20, 최대 100)"},
+				formatParam,
+			},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: &model.SearchResult[T]{}},
+				notModified,
+				errBadRequest, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodGet, Route: path + "/similar",
+			ID: "similar" + plural, Summary: "이름이 비슷한 리소스 목록 (오타 허용, 유사도 순)", Tags: tags,
+			Params: []openapi.Param{
+				{Name: "name", In: "query", Type: "string", Required: true, Description: "비교할 이름"},
+				{Name: "limit", In: "query", Type: "integer", Description: "최대 결과 수 (기본 20, 최대 100)"},
+				formatParam,
+			},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: []model.FuzzyMatch[T]{}},
+				notModified,
+				errBadRequest, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodGet, Route: path + "/:id",
+			ID: "get" + singular, Summary: "특정 ID의 리소스 조회", Tags: tags,
+			Params: []openapi.Param{idParam, formatParam},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: new(T)},
+				notModified,
+				errBadRequest, errNotFound, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path,
+			ID: "create" + singular, Summary: "새로운 리소스 생성 (비슷한 이름의 리소스가 있으면 warnings로 알림)", Tags: tags,
+			Params:  []openapi.Param{formatParam},
+			Request: new(T),
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Description: "생성됨", Data: new(T)},
+				errBadRequest, errNotAcceptable, errUnsupportedMedia, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPut, Route: path + "/:id",
+			ID: "update" + singular, Summary: "특정 ID의 리소스 수정", Tags: tags,
+			Params:  []openapi.Param{idParam, formatParam},
+			Request: new(T),
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: new(T)},
+				errBadRequest, errNotFound, errNotAcceptable, errUnsupportedMedia, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPatch, Route: path + "/:id",
+			ID: "patch" + singular, Summary: "특정 ID의 리소스 부분 수정 (본문에 있는 필드만)", Tags: tags,
+			Params:  []openapi.Param{idParam, formatParam},
+			Request: new(T),
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: new(T)},
+				errBadRequest, errNotFound, errNotAcceptable, errUnsupportedMedia, errInternal,
+			},
+		},
+		{
+			Method: http.MethodDelete, Route: path + "/:id",
+			ID: "delete" + singular, Summary: "특정 ID의 리소스 삭제 (cascade=true면 하위 리소스까지 삭제하고 삭제한 ID 목록을 응답)", Tags: tags,
+			Params: []openapi.Param{idParam, cascadeParam, formatParam},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: &removeTreeResponse{}},
+				errBadRequest, errNotFound, errNotAcceptable, errConflict, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path + ":action", Path: path + ":batchCreate",
+			ID: "batchCreate" + plural, Summary: "리소스 일괄 생성", Tags: tags,
+			Params:  []openapi.Param{formatParam},
+			Request: &batchRequest[T]{},
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Description: "전체 성공", Data: batchResults},
+				{Status: http.StatusMultiStatus, Description: "partial 모드에서 일부 항목 실패", Data: batchResults},
+				errBadRequest, errNotAcceptable, errUnsupportedMedia, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path + ":action", Path: path + ":batchUpdate",
+			ID: "batchUpdate" + plural, Summary: "리소스 일괄 수정", Tags: tags,
+			Params:  []openapi.Param{formatParam},
+			Request: &batchRequest[T]{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "전체 성공", Data: batchResults},
+				{Status: http.StatusMultiStatus, Description: "partial 모드에서 일부 항목 실패", Data: batchResults},
+				errBadRequest, errNotAcceptable, errUnsupportedMedia, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path + ":action", Path: path + ":batchDelete",
+			ID: "batchDelete" + plural, Summary: "리소스 일괄 삭제", Tags: tags,
+			Params:  []openapi.Param{formatParam},
+			Request: &batchDeleteRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "전체 성공", Data: batchResults},
+				{Status: http.StatusMultiStatus, Description: "partial 모드에서 일부 항목 실패", Data: batchResults},
+				errBadRequest, errNotAcceptable, errUnsupportedMedia, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path + "/:id/labels",
+			ID: "add" + singular + "Labels", Summary: "라벨 추가 (같은 키는 값을 바꿈)", Tags: tags,
+			Params:  []openapi.Param{idParam, formatParam},
+			Request: labels.Set{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: new(T)},
+				errBadRequest, errNotFound, errNotAcceptable, errUnsupportedMedia, errInternal,
+			},
+		},
+		{
+			Method: http.MethodDelete, Route: path + "/:id/labels/*key",
+			ID: "remove" + singular + "Label", Summary: "라벨 삭제 (없는 키여도 성공)", Tags: tags,
+			Params: []openapi.Param{idParam, labelKeyParam, formatParam},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: new(T)},
+				errBadRequest, errNotFound, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodGet, Route: path + "/:id/children",
+			ID: "list" + singular + "Children", Summary: "직계 자식 목록 조회", Tags: tags,
+			Params: []openapi.Param{idParam, formatParam},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: []*T{}},
+				notModified,
+				errBadRequest, errNotFound, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodGet, Route: path + "/:id/ancestors",
+			ID: "list" + singular + "Ancestors", Summary: "최상위부터 직계 부모까지의 조상 목록 조회 (브레드크럼)", Tags: tags,
+			Params: []openapi.Param{idParam, formatParam},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: []*T{}},
+				notModified,
+				errBadRequest, errNotFound, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodGet, Route: path + "/:id/descendants",
+			ID: "list" + singular + "Descendants", Summary: "모든 하위 리소스 목록 조회", Tags: tags,
+			Params: []openapi.Param{idParam, formatParam},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: []*T{}},
+				notModified,
+				errBadRequest, errNotFound, errNotAcceptable, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path + "/:id/move",
+			ID: "move" + singular, Summary: "다른 부모 아래로 이동 (parent_id가 null이면 최상위로, 하위 리소스도 함께 이동)", Tags: tags,
+			Params:  []openapi.Param{idParam, formatParam},
+			Request: &moveRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: new(T)},
+				errBadRequest, errNotFound, errNotAcceptable, errUnsupportedMedia, errInternal,
+			},
+		},
+		{
+			Method: http.MethodPost, Route: path + "/:id/extend",
+			ID: "extend" + singular, Summary: "만료 시각 변경 (expires_at 또는 지금부터의 ttl 중 하나, 이미 만료된 리소스는 404)", Tags: tags,
+			Params:  []openapi.Param{idParam, formatParam},
+			Request: &extendRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "성공", Data: new(T)},
+				errBadRequest, errNotFound, errNotAcceptable, errUnsupportedMedia, errInternal,
+			},
+		},
+	}
+}
+
+// OpenAPI는 엔진에 등록된 라우트로 OpenAPI 문서를 생성해서 응답하는 핸들러를 반환
+// 라우트 등록이 모두 끝난 뒤에 생성해야 하므로 첫 요청 시 한 번만 생성
+func (h *Handler) OpenAPI(r *gin.Engine) gin.HandlerFunc {
+	var (
+		once sync.Once
+		doc  *openapi.Document
+		err  error
+	)
+	return func(c *gin.Context) {
+		once.Do(func() {
+			doc, err = openapi.Build(apiInfo, r.Routes(), apiPrefix, h.apiOperations())
+		})
+		if err != nil {
+			respond(c, http.StatusInternalServerError, "OpenAPI 문서 생성 실패", nil)
+			return
+		}
+		c.JSON(http.StatusOK, doc)
+	}
+}
@@ -1,22 +1,56 @@
 package handler
 
 import (
+	"fmt"
+	"go_project/internal/database"
 	"go_project/internal/model"
+	"go_project/internal/transfer"
 	"go_project/internal/usecase"
+	"log"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Handler struct {
-	uc usecase.Usecase
+	uc           usecase.Usecase[model.Base]
+	resources    *CRUD[model.Base, *model.Base]
+	attachments  *Attachments
+	revisions    *Revisions[model.Base]
+	jobs         *Jobs              // nil이면 작업 큐 관리 라우트를 등록하지 않음 (WithJobs)
+	tasks        *Tasks             // nil이면 예약 작업 라우트를 등록하지 않음 (WithScheduler)
+	cacheControl map[string]string  // 경로 패턴별 GET 응답 Cache-Control (WithCacheControl)
+	idempotency  *idempotencyConfig // nil이면 Idempotency-Key를 처리하지 않음 (WithIdempotency)
+	rateLimit    *RateLimitConfig   // nil이면 속도 제한과 쓰기 한도를 적용하지 않음 (WithRateLimit)
 }
 
-func NewHandler(uc usecase.Usecase) *Handler {
-	return &Handler{
-		uc: uc,
+type Option func(*Handler)
+
+// WithAttachments는 리소스 첨부 파일 라우트(/api/v1/resources/:id/attachments)를 등록하도록 지정
+// 지정하지 않으면 첨부 파일 라우트는 등록하지 않음
+func WithAttachments(uc usecase.AttachmentUsecase) Option {
+	return func(h *Handler) {
+		h.attachments = NewAttachments(uc)
+	}
+}
+
+// WithRevisions는 리소스 리비전 라우트(/api/v1/resources/:id/revisions)를 등록하도록 지정
+// 지정하지 않으면 리비전 라우트는 등록하지 않음
+func WithRevisions(uc usecase.RevisionUsecase[model.Base]) Option {
+	return func(h *Handler) {
+		h.revisions = NewRevisions(uc)
+	}
+}
+
+func NewHandler(uc usecase.Usecase[model.Base], opts ...Option) *Handler {
+	h := &Handler{
+		uc:        uc,
+		resources: NewCRUD(uc),
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 func (h *Handler) RegisterRoutes(r *gin.Engine) {
@@ -29,159 +63,168 @@ func (h *Handler) RegisterRoutes(r *gin.Engine) {
 		c.HTML(http.StatusOK, "index.html", nil)
 	})
 
+	// API 문서 페이지 (/api/v1/openapi.json을 읽어서 렌더링)
+	r.GET("/docs", func(c *gin.Context) {
+		c.HTML(http.StatusOK, "docs.html", nil)
+	})
+
+	h.RegisterAPIRoutes(r)
+}
+
+// RegisterAPIRoutes는 템플릿/정적 파일 없이 API 라우트만 등록
+func (h *Handler) RegisterAPIRoutes(r *gin.Engine) {
 	// API 라우트
-	api := r.Group("/api")
+	api := r.Group("/api", readYourWrites)
 	{
 		v1 := api.Group("/v1")
+		if h.rateLimit != nil {
+			v1.Use(RateLimit(*h.rateLimit))
+		}
 		{
-			// 최종 엔드포인트 URL들:
-			// GET    /api/v1/resources     - 전체 리소스 목록 조회
+			// 최종 엔드포인트 URL들 (CRUD와 일괄 처리는 CRUD.Register로 등록):
+			// GET    /api/v1/resources     - 전체 리소스 목록 조회 (?page=1&size=20 으로 페이지 조회)
 			// GET    /api/v1/resources/:id - 특정 ID의 리소스 조회 (예: /api/v1/resources/1)
 			// POST   /api/v1/resources     - 새로운 리소스 생성
 			// PUT    /api/v1/resources/:id - 특정 ID의 리소스 수정 (예: /api/v1/resources/1)
+			// PATCH  /api/v1/resources/:id - 특정 ID의 리소스 부분 수정 (본문에 있는 필드만)
 			// DELETE /api/v1/resources/:id - 특정 ID의 리소스 삭제 (예: /api/v1/resources/1)
-			v1.GET("/resources", h.GetAll)
-			v1.GET("/resources/:id", h.Get)
-			v1.POST("/resources", h.Insert)
-			v1.PUT("/resources/:id", h.Modify)
-			v1.DELETE("/resources/:id", h.Remove)
+			// POST   /api/v1/resources:batchCreate - 리소스 일괄 생성
+			// POST   /api/v1/resources:batchUpdate - 리소스 일괄 수정
+			// POST   /api/v1/resources:batchDelete - 리소스 일괄 삭제
+			// GET    /api/v1/resources/search?q=     - 이름 전문 검색 (결과가 없으면 비슷한 이름 제안)
+			// GET    /api/v1/resources/similar?name= - 이름이 비슷한 리소스 목록 (오타 허용)
+			// GET    /api/v1/resources/export?format=csv|ndjson|json - 리소스 내보내기
+			// POST   /api/v1/resources/import - 리소스 가져오기 (multipart, file/format 필드)
+			// GET|POST   /api/v1/resources/:id/attachments - 첨부 파일 목록/업로드 (WithAttachments로 생성한 경우)
+			// GET|DELETE /api/v1/resources/:id/attachments/:attachmentId - 첨부 파일 내려받기/삭제
+			// GET    /api/v1/resources/:id/revisions - 리비전 목록 (WithRevisions로 생성한 경우, ?page=&size=)
+			// GET    /api/v1/resources/:id/revisions/diff?from=&to= - 두 리비전 사이에 바뀐 값
+			// GET    /api/v1/resources/:id/revisions/:revision - 리비전 조회
+			// POST   /api/v1/resources/:id/revisions/:revision/rollback - 리비전으로 롤백 (새 리비전으로 남음)
+			// GET    /api/v1/jobs          - 작업 큐 목록 (WithJobs로 생성한 경우, ?queue=&state=&type=&page=&size=)
+			// GET    /api/v1/jobs/:id      - 작업 조회
+			// POST   /api/v1/jobs/:id/retry  - dead/canceled 작업 재실행
+			// POST   /api/v1/jobs/:id/cancel - 대기 중이거나 실행 중인 작업 취소
+			// GET    /api/v1/tasks         - 예약 작업 목록 (WithScheduler로 생성한 경우)
+			// GET    /api/v1/tasks/:name/runs - 예약 작업 실행 기록
+			// POST   /api/v1/tasks/:name/run  - 예약 작업 바로 실행
+			// GET    /api/v1/openapi.json  - OpenAPI 3.1 문서 (라우트를 바꾸면 docs.go도 수정)
+			//
+			// 응답 형식은 Accept 헤더 또는 ?format=json|xml|yaml|msgpack 으로 선택
+			// (내보내기는 format 파라미터를 파일 형식으로 사용하므로 협상 대상에서 제외, 작업 큐와 예약 작업 라우트는 JSON만 응답)
+			// 협상하는 라우트의 GET 응답에는 ETag/Last-Modified/Cache-Control을 붙이고 조건부 요청이면 304로 응답
+			// WithIdempotency로 생성하면 리소스, 가져오기, 리비전 라우트의 POST/PATCH는 Idempotency-Key 헤더로 재시도를 한 번만 처리
+			// (키는 요청 주체별로 따로 보관, 본문을 모두 읽어야 하므로 첨부 파일 라우트에는 적용하지 않음)
+			// WithRateLimit으로 생성하면 모든 라우트에 주체별 속도 제한과 하루 쓰기 한도를 적용 (넘으면 429)
+			v1.GET("/openapi.json", h.OpenAPI(r))
+			v1.GET("/resources/export", h.Export)
+			if h.jobs != nil {
+				h.jobs.Register(v1, "/jobs")
+			}
+			if h.tasks != nil {
+				h.tasks.Register(v1, "/tasks")
+			}
+
+			negotiated := v1.Group("", Negotiate, Conditional(h.cacheControl))
+			if h.attachments != nil {
+				h.attachments.Register(negotiated, v1, "/resources")
+			}
+			resources := negotiated
+			if h.idempotency != nil {
+				principal := DefaultPrincipal
+				if h.rateLimit != nil {
+					principal = h.rateLimit.Principal
+				}
+				resources = negotiated.Group("", Idempotency(h.idempotency.store, h.idempotency.ttl, principal))
+			}
+			resources.POST("/resources/import", h.Import)
+			h.resources.Register(resources, "/resources")
+			if h.revisions != nil {
+				h.revisions.Register(resources, "/resources")
+			}
 		}
 	}
 }
 
-func (h *Handler) GetAll(c *gin.Context) {
-	results, err := h.uc.GetAll(c)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  http.StatusInternalServerError,
-			"message": "리소스 목록 조회 실패",
-			"data":    nil,
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"status":  http.StatusOK,
-		"message": "성공",
-		"data":    results,
-	})
+// readYourWrites는 요청마다 DB 세션을 만들어서, 요청 안에서 쓴 뒤의 조회는 복제본 대신 primary에서 읽게 함
+// (핸들러는 gin.Context를 그대로 ctx로 넘기므로 c.Set으로 저장한 세션을 Recorder까지 찾을 수 있음)
+func readYourWrites(c *gin.Context) {
+	c.Set(database.SessionKey, database.NewSession())
+	c.Next()
 }
 
-func (h *Handler) Get(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+func (h *Handler) Export(c *gin.Context) {
+	format, err := transfer.ParseFormat(c.DefaultQuery("format", string(transfer.FormatJSON)))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  http.StatusBadRequest,
-			"message": "잘못된 ID 형식",
-			"data":    nil,
-		})
+		respond(c, http.StatusBadRequest, "지원하지 않는 형식", nil)
 		return
 	}
 
-	result, err := h.uc.Get(c, uint(id))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  http.StatusInternalServerError,
-			"message": "리소스 조회 실패",
-			"data":    nil,
-		})
-		return
-	}
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="resources.%s"`, format))
+	c.Status(http.StatusOK)
 
-	c.JSON(http.StatusOK, gin.H{
-		"status":  http.StatusOK,
-		"message": "성공",
-		"data":    result,
+	// 행 단위로 바로 응답에 기록하므로 전송이 시작된 뒤에는 상태 코드를 바꿀 수 없음
+	w := transfer.NewWriter(c.Writer, format)
+	err = h.uc.Export(c, func(m *model.Base) error {
+		return w.Write(m)
 	})
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		log.Printf("리소스 내보내기 중단: %v", err)
+		c.Abort()
+	}
 }
 
-func (h *Handler) Insert(c *gin.Context) {
-	var resource model.Base
-	if err := c.ShouldBindJSON(&resource); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  http.StatusBadRequest,
-			"message": "잘못된 요청 데이터",
-			"data":    nil,
-		})
+func (h *Handler) Import(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		respond(c, http.StatusBadRequest, "업로드 파일이 없습니다", nil)
 		return
 	}
 
-	if err := h.uc.Insert(c, &resource); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  http.StatusInternalServerError,
-			"message": "리소스 생성 실패",
-			"data":    nil,
-		})
-		return
+	// format을 지정하지 않으면 파일 확장자로 판단
+	var format transfer.Format
+	// (쿼리의 format은 응답 형식 지정에 쓰이므로 파일 형식은 폼 필드로만 받음)
+	if f := c.PostForm("format"); f != "" {
+		format, err = transfer.ParseFormat(f)
+	} else {
+		format, err = transfer.FormatFromFilename(file.Filename)
 	}
-
-	c.JSON(http.StatusCreated, gin.H{
-		"status":  http.StatusCreated,
-		"message": "성공",
-		"data":    resource,
-	})
-}
-
-func (h *Handler) Modify(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  http.StatusBadRequest,
-			"message": "잘못된 ID 형식",
-			"data":    nil,
-		})
+		respond(c, http.StatusBadRequest, "지원하지 않는 형식", nil)
 		return
 	}
 
-	var resource model.Base
-	if err := c.ShouldBindJSON(&resource); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  http.StatusBadRequest,
-			"message": "잘못된 요청 데이터",
-			"data":    nil,
-		})
+	f, err := file.Open()
+	if err != nil {
+		respond(c, http.StatusBadRequest, "업로드 파일을 열 수 없습니다", nil)
 		return
 	}
+	defer f.Close()
 
-	if err := h.uc.Modify(c, uint(id), &resource); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  http.StatusInternalServerError,
-			"message": "리소스 수정 실패",
-			"data":    nil,
-		})
+	rows, err := transfer.ReadAll(f, format)
+	if err != nil {
+		respond(c, http.StatusBadRequest, err.Error(), nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status":  http.StatusOK,
-		"message": "성공",
-		"data":    resource,
-	})
-}
-
-func (h *Handler) Remove(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	opts := model.ImportOptions{
+		DryRun: c.DefaultPostForm("dry_run", c.Query("dry_run")) == "true",
+		Upsert: c.DefaultPostForm("upsert", c.Query("upsert")) == "true",
+	}
+	report, err := h.uc.Import(c, rows, opts)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  http.StatusBadRequest,
-			"message": "잘못된 ID 형식",
-			"data":    nil,
-		})
+		respondError(c, err, "리소스 가져오기 실패")
 		return
 	}
 
-	if err := h.uc.Remove(c, uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  http.StatusInternalServerError,
-			"message": "리소스 삭제 실패",
-			"data":    nil,
-		})
+	if report.Failed > 0 {
+		respond(c, http.StatusUnprocessableEntity, "일부 행 처리 실패", report)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status":  http.StatusOK,
-		"message": "성공",
-		"data":    nil,
-	})
+	respond(c, http.StatusOK, "성공", report)
 }
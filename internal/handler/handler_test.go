@@ -4,22 +4,32 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"fmt"
+	"go_project/internal/labels"
 	"go_project/internal/model"
+	"go_project/internal/openapi"
+	"go_project/internal/usecase"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v3"
 )
 
 // 응답 구조체 추가
 type response struct {
-	Status  int         `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
+	Status  int         `json:"status" xml:"status" yaml:"status"`
+	Message string      `json:"message" xml:"message" yaml:"message"`
+	Data    interface{} `json:"data" xml:"-" yaml:"data"`
 }
 
 // Usecase 모의 객체 정의
@@ -47,11 +57,122 @@ func (m *mockUsecase) Modify(ctx context.Context, id uint, model *model.Base) er
 	return args.Error(0)
 }
 
+// Update는 Get처럼 설정한 리소스에 fn을 적용해서 돌려줌
+func (m *mockUsecase) Update(ctx context.Context, id uint, fn func(context.Context, *model.Base) error) (*model.Base, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	resource := args.Get(0).(*model.Base)
+	if err := fn(ctx, resource); err != nil {
+		return nil, err
+	}
+	return resource, args.Error(1)
+}
+
 func (m *mockUsecase) Remove(ctx context.Context, id uint) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *mockUsecase) BatchInsert(ctx context.Context, models []*model.Base, mode model.BatchMode) ([]model.BatchResult, error) {
+	args := m.Called(ctx, models, mode)
+	return args.Get(0).([]model.BatchResult), args.Error(1)
+}
+
+func (m *mockUsecase) BatchModify(ctx context.Context, models []*model.Base, mode model.BatchMode) ([]model.BatchResult, error) {
+	args := m.Called(ctx, models, mode)
+	return args.Get(0).([]model.BatchResult), args.Error(1)
+}
+
+func (m *mockUsecase) BatchRemove(ctx context.Context, ids []uint, mode model.BatchMode) ([]model.BatchResult, error) {
+	args := m.Called(ctx, ids, mode)
+	return args.Get(0).([]model.BatchResult), args.Error(1)
+}
+
+func (m *mockUsecase) Export(ctx context.Context, fn func(*model.Base) error) error {
+	args := m.Called(ctx, fn)
+	return args.Error(0)
+}
+
+func (m *mockUsecase) Import(ctx context.Context, rows []model.ImportRow[model.Base], opts model.ImportOptions) (*model.ImportReport, error) {
+	args := m.Called(ctx, rows, opts)
+	return args.Get(0).(*model.ImportReport), args.Error(1)
+}
+
+func (m *mockUsecase) List(ctx context.Context, opts model.ListOptions) ([]*model.Base, int64, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).([]*model.Base), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockUsecase) GetMany(ctx context.Context, ids []uint) ([]*model.Base, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockUsecase) Watch(ctx context.Context, interval time.Duration, fn func(model.Event[model.Base]) error) error {
+	args := m.Called(ctx, interval, fn)
+	return args.Error(0)
+}
+
+func (m *mockUsecase) AddLabels(ctx context.Context, id uint, set labels.Set) (*model.Base, error) {
+	args := m.Called(ctx, id, set)
+	return args.Get(0).(*model.Base), args.Error(1)
+}
+
+func (m *mockUsecase) RemoveLabels(ctx context.Context, id uint, keys ...string) (*model.Base, error) {
+	args := m.Called(ctx, id, keys)
+	return args.Get(0).(*model.Base), args.Error(1)
+}
+
+func (m *mockUsecase) Children(ctx context.Context, id uint) ([]*model.Base, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockUsecase) Ancestors(ctx context.Context, id uint) ([]*model.Base, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockUsecase) Search(ctx context.Context, query string, limit int) (*model.SearchResult[model.Base], error) {
+	args := m.Called(ctx, query, limit)
+	return args.Get(0).(*model.SearchResult[model.Base]), args.Error(1)
+}
+
+func (m *mockUsecase) Similar(ctx context.Context, name string, limit int) ([]model.FuzzyMatch[model.Base], error) {
+	args := m.Called(ctx, name, limit)
+	return args.Get(0).([]model.FuzzyMatch[model.Base]), args.Error(1)
+}
+
+func (m *mockUsecase) Extend(ctx context.Context, id uint, expiresAt time.Time) (*model.Base, error) {
+	args := m.Called(ctx, id, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Base), args.Error(1)
+}
+
+func (m *mockUsecase) PurgeExpired(ctx context.Context, before time.Time, archive func([]*model.Base) error) (int64, error) {
+	args := m.Called(ctx, before, archive)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockUsecase) Descendants(ctx context.Context, id uint) ([]*model.Base, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockUsecase) Move(ctx context.Context, id uint, parentID *uint) (*model.Base, error) {
+	args := m.Called(ctx, id, parentID)
+	return args.Get(0).(*model.Base), args.Error(1)
+}
+
+func (m *mockUsecase) RemoveTree(ctx context.Context, id uint) ([]uint, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]uint), args.Error(1)
+}
+
 type HandlerTestSuite struct {
 	suite.Suite
 	mockUc  *mockUsecase
@@ -70,17 +191,7 @@ func (s *HandlerTestSuite) setupRouter() *gin.Engine {
 	router.Use(gin.Recovery())
 
 	// API 라우트만 등록
-	api := router.Group("/api")
-	{
-		v1 := api.Group("/v1")
-		{
-			v1.GET("/resources", s.handler.GetAll)
-			v1.GET("/resources/:id", s.handler.Get)
-			v1.POST("/resources", s.handler.Insert)
-			v1.PUT("/resources/:id", s.handler.Modify)
-			v1.DELETE("/resources/:id", s.handler.Remove)
-		}
-	}
+	s.handler.RegisterAPIRoutes(router)
 
 	return router
 }
@@ -176,6 +287,19 @@ func (s *HandlerTestSuite) TestGet() {
 				Data:    nil,
 			},
 		},
+		{
+			name: "실패_케이스_DB_장애",
+			id:   "1",
+			mockFn: func(m *mockUsecase) {
+				m.On("Get", mock.Anything, uint(1)).
+					Return((*model.Base)(nil), fmt.Errorf("조회 실패: %w", usecase.ErrUnavailable))
+			},
+			want: &response{
+				Status:  http.StatusServiceUnavailable,
+				Message: "데이터베이스를 일시적으로 사용할 수 없습니다",
+				Data:    nil,
+			},
+		},
 		{
 			name: "실패_케이스_잘못된_ID",
 			id:   "invalid",
@@ -261,6 +385,18 @@ func (s *HandlerTestSuite) TestGetAll() {
 				Data:    nil,
 			},
 		},
+		{
+			name: "실패_케이스_DB_장애",
+			mockFn: func(m *mockUsecase) {
+				m.On("GetAll", mock.Anything).
+					Return([]*model.Base(nil), fmt.Errorf("목록 조회 실패: %w", usecase.ErrUnavailable))
+			},
+			want: &response{
+				Status:  http.StatusServiceUnavailable,
+				Message: "데이터베이스를 일시적으로 사용할 수 없습니다",
+				Data:    nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -421,6 +557,518 @@ func (s *HandlerTestSuite) TestRemove() {
 	}
 }
 
+func (s *HandlerTestSuite) TestBatch() {
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		mockFn func(*mockUsecase)
+		want   *response
+	}{
+		{
+			name: "성공_케이스_일괄_생성",
+			path: "/api/v1/resources:batchCreate",
+			body: `{"items":[{"name":"데이터1"},{"name":"데이터2"}]}`,
+			mockFn: func(m *mockUsecase) {
+				m.On("BatchInsert", mock.Anything, mock.AnythingOfType("[]*model.Base"), model.BatchModeAtomic).
+					Return([]model.BatchResult{{Index: 0, ID: 1}, {Index: 1, ID: 2}}, nil)
+			},
+			want: &response{Status: http.StatusCreated, Message: "성공"},
+		},
+		{
+			name: "부분_실패_케이스_일괄_수정",
+			path: "/api/v1/resources:batchUpdate",
+			body: `{"mode":"partial","items":[{"id":1,"name":"수정1"},{"id":2,"name":"수정2"}]}`,
+			mockFn: func(m *mockUsecase) {
+				m.On("BatchModify", mock.Anything, mock.AnythingOfType("[]*model.Base"), model.BatchModePartial).
+					Return([]model.BatchResult{{Index: 0, ID: 1}, {Index: 1, ID: 2, Err: errors.New("데이터 없음")}}, nil)
+			},
+			want: &response{Status: http.StatusMultiStatus, Message: "일부 항목 처리 실패"},
+		},
+		{
+			name: "실패_케이스_일괄_삭제",
+			path: "/api/v1/resources:batchDelete",
+			body: `{"ids":[1,2]}`,
+			mockFn: func(m *mockUsecase) {
+				m.On("BatchRemove", mock.Anything, []uint{1, 2}, model.BatchModeAtomic).
+					Return([]model.BatchResult(nil), errors.New("삭제 오류"))
+			},
+			want: &response{Status: http.StatusInternalServerError, Message: "리소스 일괄 삭제 실패"},
+		},
+		{
+			name:   "실패_케이스_ID_없는_수정",
+			path:   "/api/v1/resources:batchUpdate",
+			body:   `{"items":[{"name":"수정1"}]}`,
+			mockFn: func(m *mockUsecase) {},
+			want:   &response{Status: http.StatusBadRequest, Message: "수정할 항목에 ID가 없습니다"},
+		},
+		{
+			name:   "실패_케이스_빈_요청",
+			path:   "/api/v1/resources:batchCreate",
+			body:   `{"items":[]}`,
+			mockFn: func(m *mockUsecase) {},
+			want:   &response{Status: http.StatusBadRequest, Message: "잘못된 요청 데이터"},
+		},
+		{
+			name:   "실패_케이스_알_수_없는_작업",
+			path:   "/api/v1/resources:batchUpsert",
+			body:   `{}`,
+			mockFn: func(m *mockUsecase) {},
+			want:   &response{Status: http.StatusNotFound, Message: "지원하지 않는 일괄 작업"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.mockFn(s.mockUc)
+
+			router := s.setupRouter()
+
+			// HTTP 요청 생성
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// 라우터를 통한 요청 처리
+			router.ServeHTTP(w, req)
+
+			// 응답 검증
+			s.Equal(tt.want.Status, w.Code)
+			var got response
+			s.NoError(json.NewDecoder(w.Body).Decode(&got))
+			s.Equal(tt.want.Status, got.Status)
+			s.Equal(tt.want.Message, got.Message)
+			s.mockUc.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *HandlerTestSuite) TestExport() {
+	tests := []struct {
+		name            string
+		query           string
+		mockFn          func(*mockUsecase)
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:  "성공_케이스_ndjson",
+			query: "?format=ndjson",
+			mockFn: func(m *mockUsecase) {
+				m.On("Export", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						fn := args.Get(1).(func(*model.Base) error)
+						fn(&model.Base{ID: 1, Name: "데이터1"})
+						fn(&model.Base{ID: 2, Name: "데이터2"})
+					}).
+					Return(nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantBody:        `{"id":1,"name":"데이터1"`,
+		},
+		{
+			name:       "실패_케이스_지원하지_않는_형식",
+			query:      "?format=xlsx",
+			mockFn:     func(m *mockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.mockFn(s.mockUc)
+
+			router := s.setupRouter()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/resources/export"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			s.Equal(tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				s.Equal(tt.wantContentType, w.Header().Get("Content-Type"))
+				s.Contains(w.Body.String(), tt.wantBody)
+				s.Equal(2, bytes.Count(w.Body.Bytes(), []byte("\n")))
+			}
+		})
+	}
+}
+
+func (s *HandlerTestSuite) TestImport() {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		fields   map[string]string
+		mockFn   func(*mockUsecase)
+		want     *response
+	}{
+		{
+			name:     "성공_케이스_csv_dry_run",
+			filename: "resources.csv",
+			content:  "id,name\n,데이터1\n,데이터2\n",
+			fields:   map[string]string{"dry_run": "true"},
+			mockFn: func(m *mockUsecase) {
+				m.On("Import", mock.Anything, mock.MatchedBy(func(rows []model.ImportRow[model.Base]) bool {
+					return len(rows) == 2 && rows[0].Line == 2 && rows[1].Resource.Name == "데이터2"
+				}), model.ImportOptions{DryRun: true}).
+					Return(&model.ImportReport{DryRun: true, Total: 2, Created: 2}, nil)
+			},
+			want: &response{Status: http.StatusOK, Message: "성공"},
+		},
+		{
+			name:     "실패_케이스_검증_오류",
+			filename: "resources.ndjson",
+			content:  "{\"name\":\"\"}\n",
+			fields:   map[string]string{"upsert": "true"},
+			mockFn: func(m *mockUsecase) {
+				m.On("Import", mock.Anything, mock.IsType([]model.ImportRow[model.Base]{}), model.ImportOptions{Upsert: true}).
+					Return(&model.ImportReport{Total: 1, Failed: 1, Errors: []model.ImportError{{Row: 1, Message: "이름이 비어 있습니다"}}}, nil)
+			},
+			want: &response{Status: http.StatusUnprocessableEntity, Message: "일부 행 처리 실패"},
+		},
+		{
+			name:     "실패_케이스_지원하지_않는_확장자",
+			filename: "resources.xlsx",
+			content:  "binary",
+			mockFn:   func(m *mockUsecase) {},
+			want:     &response{Status: http.StatusBadRequest, Message: "지원하지 않는 형식"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.mockFn(s.mockUc)
+
+			router := s.setupRouter()
+
+			// multipart 요청 본문 생성
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			fw, _ := mw.CreateFormFile("file", tt.filename)
+			fw.Write([]byte(tt.content))
+			for k, v := range tt.fields {
+				mw.WriteField(k, v)
+			}
+			mw.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/resources/import", &body)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			s.Equal(tt.want.Status, w.Code)
+			var got response
+			s.NoError(json.NewDecoder(w.Body).Decode(&got))
+			s.Equal(tt.want.Message, got.Message)
+			s.mockUc.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *HandlerTestSuite) TestNegotiate() {
+	tests := []struct {
+		name            string
+		query           string
+		accept          string
+		wantStatus      int
+		wantContentType string
+		decode          func([]byte, *response) error
+	}{
+		{
+			name:            "Accept_없음_JSON",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json; charset=utf-8",
+			decode:          func(b []byte, r *response) error { return json.Unmarshal(b, r) },
+		},
+		{
+			name:            "Accept_XML",
+			accept:          "application/xml",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/xml; charset=utf-8",
+			decode:          func(b []byte, r *response) error { return xml.Unmarshal(b, r) },
+		},
+		{
+			name:            "Accept_q값_우선순위",
+			accept:          "application/json;q=0.5, application/yaml",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/yaml; charset=utf-8",
+			decode:          func(b []byte, r *response) error { return yaml.Unmarshal(b, r) },
+		},
+		{
+			name:            "format_파라미터가_Accept보다_우선",
+			query:           "?format=msgpack",
+			accept:          "application/json",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/msgpack; charset=utf-8",
+			decode: func(b []byte, r *response) error {
+				var mh codec.MsgpackHandle
+				return codec.NewDecoderBytes(b, &mh).Decode(r)
+			},
+		},
+		{
+			name:       "지원하지_않는_Accept",
+			accept:     "text/html",
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:       "지원하지_않는_format",
+			query:      "?format=csv",
+			wantStatus: http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			s.mockUc.On("Get", mock.Anything, uint(1)).
+				Return(&model.Base{ID: 1, Name: "테스트_데이터"}, nil).Maybe()
+
+			router := s.setupRouter()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/resources/1"+tt.query, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			s.Equal(tt.wantStatus, w.Code)
+			if tt.decode == nil {
+				s.mockUc.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+				return
+			}
+			s.Equal(tt.wantContentType, w.Header().Get("Content-Type"))
+			var got response
+			s.NoError(tt.decode(w.Body.Bytes(), &got))
+			s.Equal(http.StatusOK, got.Status)
+			s.Equal("성공", got.Message)
+		})
+	}
+}
+
+func (s *HandlerTestSuite) TestInsert_ContentType() {
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		wantStatus  int
+	}{
+		{
+			name:        "XML_요청",
+			contentType: "application/xml",
+			body:        []byte(`<resource><name>테스트_데이터</name></resource>`),
+			wantStatus:  http.StatusCreated,
+		},
+		{
+			name:        "YAML_요청",
+			contentType: "application/yaml",
+			body:        []byte("name: 테스트_데이터\n"),
+			wantStatus:  http.StatusCreated,
+		},
+		{
+			name:        "MessagePack_요청",
+			contentType: "application/msgpack",
+			body: func() []byte {
+				var b []byte
+				var mh codec.MsgpackHandle
+				codec.NewEncoderBytes(&b, &mh).Encode(map[string]string{"name": "테스트_데이터"})
+				return b
+			}(),
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:        "지원하지_않는_Content_Type",
+			contentType: "text/plain",
+			body:        []byte("테스트_데이터"),
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			s.mockUc.On("Insert", mock.Anything, mock.MatchedBy(func(m *model.Base) bool {
+				return m.Name == "테스트_데이터"
+			})).Return(nil).Maybe()
+
+			router := s.setupRouter()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/resources", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			s.Equal(tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusUnsupportedMediaType {
+				s.mockUc.AssertNotCalled(s.T(), "Insert", mock.Anything, mock.Anything)
+			} else {
+				s.mockUc.AssertExpectations(s.T())
+			}
+		})
+	}
+}
+
+// 라우트를 추가/변경하고 docs.go의 문서를 갱신하지 않으면 실패
+func (s *HandlerTestSuite) TestOpenAPI_RoutesMatchSpec() {
+	router := s.setupRouter()
+
+	_, err := openapi.Build(apiInfo, router.Routes(), apiPrefix, s.handler.apiOperations())
+	s.NoError(err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var doc openapi.Document
+	s.NoError(json.NewDecoder(w.Body).Decode(&doc))
+	s.Equal("3.1.0", doc.OpenAPI)
+
+	// 등록된 모든 API 라우트가 문서에 있는지 확인
+	for _, route := range router.Routes() {
+		if route.Path == "/api/v1/resources:action" {
+			continue
+		}
+		path := openapi.ConvertPath(route.Path)
+		s.Contains(doc.Paths, path)
+		s.Contains(doc.Paths[path], strings.ToLower(route.Method))
+	}
+	for _, action := range []string{"batchCreate", "batchUpdate", "batchDelete"} {
+		s.Contains(doc.Paths, "/api/v1/resources:"+action)
+	}
+	s.Contains(doc.Components.Schemas, "ModelBase")
+}
+
+func (s *HandlerTestSuite) TestGetAll_Page() {
+	tests := []struct {
+		name      string
+		query     string
+		mockFn    func(*mockUsecase)
+		want      *response
+		wantTotal string
+	}{
+		{
+			name:  "성공_케이스",
+			query: "?page=2&size=1",
+			mockFn: func(m *mockUsecase) {
+				m.On("List", mock.Anything, model.ListOptions{Page: 2, Size: 1}).
+					Return([]*model.Base{{ID: 2, Name: "데이터2"}}, int64(3), nil)
+			},
+			want:      &response{Status: http.StatusOK, Message: "성공"},
+			wantTotal: "3",
+		},
+		{
+			name:  "성공_케이스_기본_크기",
+			query: "?page=1",
+			mockFn: func(m *mockUsecase) {
+				m.On("List", mock.Anything, model.ListOptions{Page: 1, Size: defaultPageSize}).
+					Return([]*model.Base{}, int64(0), nil)
+			},
+			want:      &response{Status: http.StatusOK, Message: "성공"},
+			wantTotal: "0",
+		},
+		{
+			name:   "실패_케이스_크기_초과",
+			query:  "?size=1000",
+			mockFn: func(m *mockUsecase) {},
+			want:   &response{Status: http.StatusBadRequest, Message: "잘못된 페이지 파라미터"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.mockFn(s.mockUc)
+
+			router := s.setupRouter()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/resources"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			s.Equal(tt.want.Status, w.Code)
+			s.Equal(tt.wantTotal, w.Header().Get("X-Total-Count"))
+			var got response
+			s.NoError(json.NewDecoder(w.Body).Decode(&got))
+			s.Equal(tt.want.Message, got.Message)
+			s.mockUc.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *HandlerTestSuite) TestPatch() {
+	tests := []struct {
+		name   string
+		id     string
+		body   string
+		mockFn func(*mockUsecase)
+		want   *response
+	}{
+		{
+			name: "성공_케이스",
+			id:   "1",
+			body: `{"name":"수정된_데이터"}`,
+			mockFn: func(m *mockUsecase) {
+				m.On("Update", mock.Anything, uint(1)).
+					Return(&model.Base{ID: 1, Name: "기존_데이터"}, nil)
+			},
+			want: &response{Status: http.StatusOK, Message: "성공"},
+		},
+		{
+			name: "실패_케이스_없는_데이터",
+			id:   "999",
+			body: `{"name":"수정된_데이터"}`,
+			mockFn: func(m *mockUsecase) {
+				m.On("Update", mock.Anything, uint(999)).
+					Return(nil, fmt.Errorf("업데이트 실패: %w", usecase.ErrNotFound))
+			},
+			want: &response{Status: http.StatusNotFound, Message: "리소스를 찾을 수 없습니다"},
+		},
+		{
+			name: "실패_케이스_잘못된_본문",
+			id:   "1",
+			body: `{"name":`,
+			mockFn: func(m *mockUsecase) {
+				m.On("Update", mock.Anything, uint(1)).
+					Return(&model.Base{ID: 1, Name: "기존_데이터"}, nil)
+			},
+			want: &response{Status: http.StatusBadRequest, Message: "잘못된 요청 데이터"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.mockFn(s.mockUc)
+
+			router := s.setupRouter()
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/resources/"+tt.id, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			s.Equal(tt.want.Status, w.Code)
+			var got response
+			s.NoError(json.NewDecoder(w.Body).Decode(&got))
+			s.Equal(tt.want.Message, got.Message)
+			s.mockUc.AssertExpectations(s.T())
+		})
+	}
+}
+
 func TestHandlerSuite(t *testing.T) {
 	suite.Run(t, new(HandlerTestSuite))
 }
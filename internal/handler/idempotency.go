@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go_project/internal/idempotency"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultIdempotencyTTL은 WithIdempotency에 기간을 지정하지 않았을 때 같은 키의 재시도에 저장된 응답을 돌려주는 기간
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// 처리 중인 키를 다른 요청이 가져갈 수 없는 최대 시간
+// 서버가 처리 도중 종료되면 이 시간이 지난 뒤에 같은 키로 다시 처리할 수 있음
+const idempotencyLockTimeout = 5 * time.Minute
+
+// Idempotency-Key를 보낸 요청 본문의 최대 크기 (해시를 계산하려고 본문 전체를 메모리에 읽음)
+const maxIdempotentBodySize = 32 << 20
+
+type idempotencyConfig struct {
+	store idempotency.Store
+	ttl   time.Duration
+}
+
+// WithIdempotency는 POST/PATCH 요청의 Idempotency-Key 헤더를 처리하도록 지정
+// 같은 키로 다시 온 요청에는 첫 응답을 그대로 돌려주며, ttl이 지나면 키를 다시 사용할 수 있음 (0 이하면 DefaultIdempotencyTTL)
+// 지정하지 않으면 Idempotency-Key 헤더는 무시함
+func WithIdempotency(store idempotency.Store, ttl time.Duration) Option {
+	return func(h *Handler) {
+		if ttl <= 0 {
+			ttl = DefaultIdempotencyTTL
+		}
+		h.idempotency = &idempotencyConfig{store: store, ttl: ttl}
+	}
+}
+
+// isIdempotentMethod는 Idempotency-Key를 처리하는 메서드인지 확인
+func isIdempotentMethod(method string) bool {
+	return method == http.MethodPost || method == http.MethodPatch
+}
+
+// Idempotency는 Idempotency-Key 헤더가 있는 POST/PATCH 요청을 주체(principal)와 키당 한 번만 처리하는 미들웨어
+//
+// 키는 principal이 정한 요청 주체별로 따로 보관하므로 다른 주체가 같은 키를 보내도 저장된 응답을 받지 못함
+// 키를 처음 받으면 요청을 처리하고 요청 해시와 응답(상태 코드, Content-Type, 본문)을 store에 저장
+// 같은 키와 같은 요청(메서드, 경로, 본문)이 다시 오면 처리하지 않고 저장된 응답을 Idempotent-Replayed: true와 함께 돌려줌
+// 같은 키로 다른 요청이 오면 422, 첫 요청을 아직 처리 중이면 409로 응답
+// 5xx 응답은 저장하지 않으므로 같은 키로 다시 처리할 수 있음
+// 저장된 응답은 처음 협상한 형식 그대로 돌려주므로 재시도할 때 Accept를 바꿔도 형식은 바뀌지 않음
+func Idempotency(store idempotency.Store, ttl time.Duration, principal func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" || !isIdempotentMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(key) > idempotency.MaxKeyLength {
+			respond(c, http.StatusBadRequest, "Idempotency-Key가 너무 깁니다", nil)
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				respond(c, http.StatusRequestEntityTooLarge, "Idempotency-Key를 사용하기에는 요청 본문이 너무 큽니다", nil)
+			} else {
+				respond(c, http.StatusBadRequest, "요청 본문을 읽을 수 없습니다", nil)
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		key = idempotency.ScopedKey(principal(c), key)
+
+		hash := idempotency.HashRequest(c.Request.Method, c.Request.URL.RequestURI(), body)
+		token, existing, err := store.Reserve(c, &idempotency.Record{
+			Key:         key,
+			RequestHash: hash,
+			ExpiresAt:   time.Now().Add(min(ttl, idempotencyLockTimeout)),
+		})
+		if err != nil {
+			log.Printf("멱등성 키 예약 실패: %v", err)
+			respond(c, http.StatusInternalServerError, "요청 처리 실패", nil)
+			c.Abort()
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.RequestHash != hash:
+				respond(c, http.StatusUnprocessableEntity, "다른 요청에 사용한 Idempotency-Key입니다", nil)
+			case !existing.Completed:
+				c.Header("Retry-After", "1")
+				respond(c, http.StatusConflict, "같은 Idempotency-Key의 요청을 처리 중입니다", nil)
+			default:
+				if existing.ContentType != "" {
+					c.Header("Content-Type", existing.ContentType)
+				}
+				c.Header("Idempotent-Replayed", "true")
+				c.Status(existing.Status)
+				c.Writer.Write(existing.Body)
+			}
+			c.Abort()
+			return
+		}
+
+		// 핸들러가 패닉을 일으키거나 응답을 저장하지 못해도 키가 처리 중으로 남지 않도록 해제
+		// (요청이 취소돼도 저장소 작업은 끝내야 하므로 취소를 떼어 냄)
+		// 처리가 idempotencyLockTimeout보다 오래 걸려 다른 요청이 키를 다시 예약했으면 토큰이 달라서
+		// 그 요청의 예약을 지우거나 응답을 덮어쓰지 않음
+		ctx := context.WithoutCancel(c)
+		completed := false
+		defer func() {
+			if !completed {
+				if err := store.Release(ctx, key, token); err != nil {
+					log.Printf("멱등성 키 해제 실패: %v", err)
+				}
+			}
+		}()
+
+		original := c.Writer
+		buffered := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = buffered
+		c.Next()
+		c.Writer = original
+
+		if buffered.status < http.StatusInternalServerError {
+			err := store.Complete(ctx, &idempotency.Record{
+				Key:         key,
+				Token:       token,
+				Status:      buffered.status,
+				ContentType: original.Header().Get("Content-Type"),
+				Body:        buffered.body.Bytes(),
+				ExpiresAt:   time.Now().Add(ttl),
+			})
+			switch {
+			case errors.Is(err, idempotency.ErrLockLost):
+				log.Printf("멱등성 키 응답 저장 안 함: %v", err)
+				completed = true
+			case err != nil:
+				log.Printf("멱등성 키 응답 저장 실패: %v", err)
+			default:
+				completed = true
+			}
+		}
+		original.WriteHeader(buffered.status)
+		original.Write(buffered.body.Bytes())
+	}
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"go_project/internal/idempotency"
+	"go_project/internal/model"
+	"go_project/internal/openapi"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyTestSuite struct {
+	suite.Suite
+	uc     usecase.Usecase[model.Base]
+	store  idempotency.Store
+	router *gin.Engine
+}
+
+func (s *IdempotencyTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.uc = usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))
+	s.store = idempotency.NewMemoryStore()
+	s.router = gin.New()
+	NewHandler(s.uc, WithIdempotency(s.store, 50*time.Millisecond)).RegisterAPIRoutes(s.router)
+}
+
+func (s *IdempotencyTestSuite) send(method, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *IdempotencyTestSuite) count() int {
+	all, err := s.uc.GetAll(context.Background())
+	s.Require().NoError(err)
+	return len(all)
+}
+
+func (s *IdempotencyTestSuite) TestReplay() {
+	first := s.send(http.MethodPost, "/api/v1/resources", "key-1", `{"name":"web"}`)
+	s.Require().Equal(http.StatusCreated, first.Code)
+	s.Empty(first.Header().Get("Idempotent-Replayed"))
+
+	retry := s.send(http.MethodPost, "/api/v1/resources", "key-1", `{"name":"web"}`)
+	s.Equal(http.StatusCreated, retry.Code)
+	s.Equal("true", retry.Header().Get("Idempotent-Replayed"))
+	s.Equal(first.Body.String(), retry.Body.String())
+	s.Equal(first.Header().Get("Content-Type"), retry.Header().Get("Content-Type"))
+	s.Equal(1, s.count())
+
+	// 키가 없거나 다르면 따로 처리
+	s.Equal(http.StatusCreated, s.send(http.MethodPost, "/api/v1/resources", "", `{"name":"web"}`).Code)
+	s.Equal(http.StatusCreated, s.send(http.MethodPost, "/api/v1/resources", "key-2", `{"name":"web"}`).Code)
+	s.Equal(3, s.count())
+
+	// 기간이 지나면 같은 키로 다시 처리
+	time.Sleep(60 * time.Millisecond)
+	w := s.send(http.MethodPost, "/api/v1/resources", "key-1", `{"name":"web"}`)
+	s.Equal(http.StatusCreated, w.Code)
+	s.Empty(w.Header().Get("Idempotent-Replayed"))
+	s.Equal(4, s.count())
+}
+
+func (s *IdempotencyTestSuite) TestScopedByPrincipal() {
+	send := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/resources", strings.NewReader(`{"name":"web"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "key-1")
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w
+	}
+
+	s.Require().Equal(http.StatusCreated, send("10.0.0.1:1234").Code)
+	// 다른 주체가 같은 키를 보내면 첫 응답을 돌려주지 않고 따로 처리
+	w := send("10.0.0.2:1234")
+	s.Equal(http.StatusCreated, w.Code)
+	s.Empty(w.Header().Get("Idempotent-Replayed"))
+	s.Equal("true", send("10.0.0.1:5678").Header().Get("Idempotent-Replayed"))
+	s.Equal(2, s.count())
+}
+
+func (s *IdempotencyTestSuite) TestMismatch() {
+	s.Require().Equal(http.StatusCreated, s.send(http.MethodPost, "/api/v1/resources", "key-1", `{"name":"web"}`).Code)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "다른_본문", method: http.MethodPost, path: "/api/v1/resources", body: `{"name":"db"}`},
+		{name: "다른_경로", method: http.MethodPatch, path: "/api/v1/resources/1", body: `{"name":"web"}`},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			w := s.send(tt.method, tt.path, "key-1", tt.body)
+			s.Equal(http.StatusUnprocessableEntity, w.Code)
+		})
+	}
+	s.Equal(1, s.count())
+}
+
+func (s *IdempotencyTestSuite) TestInFlight() {
+	body := `{"name":"web"}`
+	_, _, err := s.store.Reserve(context.Background(), &idempotency.Record{
+		Key:         idempotency.ScopedKey("ip:192.0.2.1", "key-1"),
+		RequestHash: idempotency.HashRequest(http.MethodPost, "/api/v1/resources", []byte(body)),
+		ExpiresAt:   time.Now().Add(time.Minute),
+	})
+	s.Require().NoError(err)
+
+	w := s.send(http.MethodPost, "/api/v1/resources", "key-1", body)
+	s.Equal(http.StatusConflict, w.Code)
+	s.Equal("1", w.Header().Get("Retry-After"))
+	s.Equal(0, s.count())
+}
+
+func (s *IdempotencyTestSuite) TestLockExpired() {
+	// 예약은 보관 기간(200ms)과 idempotencyLockTimeout 중 짧은 쪽이 지나면 만료됨
+	store := idempotency.NewMemoryStore()
+	var calls atomic.Int32
+	unblock := []chan struct{}{make(chan struct{}), make(chan struct{})}
+	r := gin.New()
+	r.POST("/slow", Idempotency(store, 200*time.Millisecond, DefaultPrincipal), func(c *gin.Context) {
+		n := calls.Add(1)
+		if int(n) <= len(unblock) {
+			<-unblock[n-1]
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": n})
+	})
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/slow", strings.NewReader(`{}`))
+		req.Header.Set("Idempotency-Key", "key-1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	sendAsync := func() <-chan *httptest.ResponseRecorder {
+		ch := make(chan *httptest.ResponseRecorder, 1)
+		go func() { ch <- send() }()
+		return ch
+	}
+
+	first := sendAsync()
+	s.Require().Eventually(func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	// 첫 요청을 처리하는 동안 예약이 만료되어 재시도가 키를 가져감
+	time.Sleep(250 * time.Millisecond)
+	second := sendAsync()
+	s.Require().Eventually(func() bool { return calls.Load() == 2 }, time.Second, time.Millisecond)
+
+	// 늦게 끝난 첫 요청은 응답은 받지만 재시도의 예약을 덮어쓰지 않음
+	close(unblock[0])
+	s.Equal(http.StatusCreated, (<-first).Code)
+	s.Equal(http.StatusConflict, send().Code, "재시도가 아직 처리 중")
+
+	close(unblock[1])
+	s.Equal(http.StatusCreated, (<-second).Code)
+	w := send()
+	s.Equal("true", w.Header().Get("Idempotent-Replayed"))
+	s.JSONEq(`{"call":2}`, w.Body.String())
+}
+
+func (s *IdempotencyTestSuite) TestNotStored() {
+	// 4xx 응답도 저장해서 그대로 돌려줌
+	first := s.send(http.MethodPatch, "/api/v1/resources/99", "key-1", `{"name":"web"}`)
+	s.Require().Equal(http.StatusNotFound, first.Code)
+	retry := s.send(http.MethodPatch, "/api/v1/resources/99", "key-1", `{"name":"web"}`)
+	s.Equal(http.StatusNotFound, retry.Code)
+	s.Equal("true", retry.Header().Get("Idempotent-Replayed"))
+
+	// GET은 키를 무시
+	w := s.send(http.MethodGet, "/api/v1/resources", "key-2", "")
+	s.Equal(http.StatusOK, w.Code)
+	s.Empty(w.Header().Get("Idempotent-Replayed"))
+
+	s.Equal(http.StatusBadRequest, s.send(http.MethodPost, "/api/v1/resources", strings.Repeat("k", 256), `{"name":"web"}`).Code)
+}
+
+func (s *IdempotencyTestSuite) TestOpenAPI() {
+	w := s.send(http.MethodGet, "/api/v1/openapi.json", "", "")
+	s.Require().Equal(http.StatusOK, w.Code)
+	var doc openapi.Document
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &doc))
+
+	create := doc.Paths["/api/v1/resources"]["post"]
+	s.Contains(create.Parameters, openapi.ParameterObject{
+		Name: "Idempotency-Key", In: "header", Description: idempotencyKeyParam.Description,
+		Schema: &openapi.Schema{Type: "string"},
+	})
+	s.Contains(create.Responses, "409")
+	s.Contains(create.Responses, "422")
+	for _, p := range doc.Paths["/api/v1/resources"]["get"].Parameters {
+		s.NotEqual("Idempotency-Key", p.Name)
+	}
+}
+
+func TestIdempotencySuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyTestSuite))
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"go_project/internal/jobs"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithJobs는 작업 큐 관리 라우트(/api/v1/jobs)를 등록하도록 지정
+// 지정하지 않으면 작업 큐 라우트는 등록하지 않음
+func WithJobs(store jobs.Store) Option {
+	return func(h *Handler) {
+		h.jobs = NewJobs(store)
+	}
+}
+
+// Jobs는 작업 큐 관리 핸들러 (실패한 작업 확인, 재실행, 취소)
+type Jobs struct {
+	store jobs.Store
+}
+
+func NewJobs(store jobs.Store) *Jobs {
+	return &Jobs{
+		store: store,
+	}
+}
+
+// Register는 path 아래에 작업 큐 관리 라우트를 등록
+//
+//	GET  {path}            - 작업 목록 (?queue=&state=&type=&page=&size=, 최근 작업부터)
+//	GET  {path}/:id        - 작업 조회
+//	POST {path}/:id/retry  - dead 또는 canceled 작업을 처음부터 다시 실행
+//	POST {path}/:id/cancel - 대기 중이거나 실행 중인 작업 취소
+func (h *Jobs) Register(r gin.IRoutes, path string) {
+	r.GET(path, h.List)
+	r.GET(path+"/:id", h.Get)
+	r.POST(path+"/:id/retry", h.Retry)
+	r.POST(path+"/:id/cancel", h.Cancel)
+}
+
+func (h *Jobs) List(c *gin.Context) {
+	page, ok := listOptions(c)
+	if !ok {
+		respond(c, http.StatusBadRequest, "잘못된 페이지 파라미터", nil)
+		return
+	}
+	opts := jobs.ListOptions{
+		Queue: c.Query("queue"),
+		State: jobs.State(c.Query("state")),
+		Type:  c.Query("type"),
+		Page:  page.Page,
+		Size:  page.Size,
+	}
+	if opts.State != "" && !opts.State.Valid() {
+		respond(c, http.StatusBadRequest, "잘못된 작업 상태", nil)
+		return
+	}
+
+	list, total, err := h.store.List(c, opts)
+	if err != nil {
+		jobError(c, err, "작업 목록 조회 실패")
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	respond(c, http.StatusOK, "성공", list)
+}
+
+func (h *Jobs) Get(c *gin.Context) {
+	id, ok := jobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.store.Get(c, id)
+	if err != nil {
+		jobError(c, err, "작업 조회 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", job)
+}
+
+func (h *Jobs) Retry(c *gin.Context) {
+	id, ok := jobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.store.Retry(c, id, time.Now())
+	if err != nil {
+		jobError(c, err, "작업 재실행 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", job)
+}
+
+func (h *Jobs) Cancel(c *gin.Context) {
+	id, ok := jobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.store.Cancel(c, id, time.Now())
+	if err != nil {
+		jobError(c, err, "작업 취소 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", job)
+}
+
+// jobID는 경로의 작업 ID를 읽고, 잘못된 형식이면 400으로 응답하고 false를 반환
+func jobID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// jobError는 작업 저장소 에러를 상태 코드로 변환해서 응답
+// 작업이 없으면 404, 상태가 맞지 않거나 같은 고유 키의 작업이 있으면 409, 나머지는 respondError와 같음
+func jobError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		respond(c, http.StatusNotFound, err.Error(), nil)
+	case errors.Is(err, jobs.ErrInvalidState), errors.Is(err, jobs.ErrDuplicate):
+		respond(c, http.StatusConflict, err.Error(), nil)
+	default:
+		respondError(c, err, message)
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"go_project/internal/jobs"
+	"go_project/internal/model"
+	"go_project/internal/openapi"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobsTestSuite struct {
+	suite.Suite
+	store   jobs.Store
+	handler *Handler
+	router  *gin.Engine
+}
+
+func (s *JobsTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	uc := usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))
+	s.store = jobs.NewMemoryStore()
+	s.handler = NewHandler(uc, WithJobs(s.store))
+	s.router = gin.New()
+	s.handler.RegisterAPIRoutes(s.router)
+}
+
+func (s *JobsTestSuite) serve(method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+// dead는 한 번 실행해서 dead 상태가 된 작업을 만듦
+func (s *JobsTestSuite) dead(jobType string) *jobs.Job {
+	ctx := context.Background()
+	job, err := jobs.Enqueue(ctx, s.store, jobType, map[string]string{"to": "a@example.com"}, jobs.WithMaxAttempts(1))
+	s.Require().NoError(err)
+	claimed, err := s.store.Claim(ctx, jobs.DefaultQueue, time.Now(), time.Minute)
+	s.Require().NoError(err)
+	s.Require().NoError(s.store.Fail(ctx, claimed, "SMTP 연결 실패", nil, time.Now()))
+	return job
+}
+
+func (s *JobsTestSuite) TestList() {
+	s.dead("email.send")
+	jobs.Enqueue(context.Background(), s.store, "report.build", nil)
+
+	w := s.serve(http.MethodGet, "/api/v1/jobs?state=dead")
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	s.Equal("1", w.Header().Get("X-Total-Count"))
+	var resp struct {
+		Data []*jobs.Job `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	s.Require().Len(resp.Data, 1)
+	s.Equal("email.send", resp.Data[0].Type)
+	s.Equal("SMTP 연결 실패", resp.Data[0].LastError)
+	s.JSONEq(`{"to":"a@example.com"}`, string(resp.Data[0].Payload))
+
+	w = s.serve(http.MethodGet, "/api/v1/jobs?page=1&size=1")
+	s.Equal("2", w.Header().Get("X-Total-Count"))
+
+	tests := []string{"/api/v1/jobs?state=unknown", "/api/v1/jobs?page=0", "/api/v1/jobs/abc"}
+	for _, path := range tests {
+		s.Run(path, func() {
+			s.Equal(http.StatusBadRequest, s.serve(http.MethodGet, path).Code)
+		})
+	}
+}
+
+func (s *JobsTestSuite) TestRetryAndCancel() {
+	job := s.dead("email.send")
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		state  jobs.State
+	}{
+		{"조회", http.MethodGet, "/api/v1/jobs/1", http.StatusOK, jobs.StateDead},
+		{"dead_작업_취소", http.MethodPost, "/api/v1/jobs/1/cancel", http.StatusConflict, ""},
+		{"재실행", http.MethodPost, "/api/v1/jobs/1/retry", http.StatusOK, jobs.StatePending},
+		{"대기_중_재실행", http.MethodPost, "/api/v1/jobs/1/retry", http.StatusConflict, ""},
+		{"취소", http.MethodPost, "/api/v1/jobs/1/cancel", http.StatusOK, jobs.StateCanceled},
+		{"없는_작업", http.MethodPost, "/api/v1/jobs/99/retry", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			w := s.serve(tt.method, tt.path)
+			s.Require().Equal(tt.status, w.Code, w.Body.String())
+			if tt.state == "" {
+				return
+			}
+			var resp struct {
+				Data jobs.Job `json:"data"`
+			}
+			s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+			s.Equal(job.ID, resp.Data.ID)
+			s.Equal(tt.state, resp.Data.State)
+		})
+	}
+}
+
+func (s *JobsTestSuite) TestNotRegisteredWithoutOption() {
+	router := gin.New()
+	NewHandler(s.handler.uc).RegisterAPIRoutes(router)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil))
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *JobsTestSuite) TestOpenAPI_RoutesMatchSpec() {
+	doc, err := openapi.Build(apiInfo, s.router.Routes(), apiPrefix, s.handler.apiOperations())
+	s.Require().NoError(err)
+	s.Contains(doc.Paths, "/api/v1/jobs/{id}/retry")
+	s.Contains(doc.Components.Schemas, "JobsJob")
+}
+
+func TestJobsSuite(t *testing.T) {
+	suite.Run(t, new(JobsTestSuite))
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"go_project/internal/ratelimit"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 속도 제한 그룹 (그룹마다 버킷을 따로 둠)
+const (
+	RateLimitResources = "resources" // 리소스/첨부 파일 조회와 수정
+	RateLimitBulk      = "bulk"      // 내보내기, 가져오기, 일괄 처리
+	RateLimitDocs      = "docs"      // OpenAPI 문서
+)
+
+// 경로 패턴별 속도 제한 그룹 (없으면 RateLimitResources)
+var rateLimitGroups = map[string]string{
+	apiPrefix + "/openapi.json":     RateLimitDocs,
+	apiPrefix + "/resources/export": RateLimitBulk,
+	apiPrefix + "/resources/import": RateLimitBulk,
+	apiPrefix + "/resources:action": RateLimitBulk,
+}
+
+// RateLimitConfig는 속도 제한과 쓰기 한도 설정
+type RateLimitConfig struct {
+	Store ratelimit.Store
+	// Limits는 그룹(RateLimitResources 등)별 한도, 없는 그룹은 제한하지 않음
+	Limits map[string]ratelimit.Limit
+	// DailyWriteQuota는 주체별 하루(UTC) 쓰기 요청(POST/PUT/PATCH/DELETE) 수 한도, 0이면 제한하지 않음
+	// 성공한(4xx, 5xx가 아닌) 요청만 세고, Idempotency-Key로 저장된 응답을 돌려준 재시도는 세지 않음
+	DailyWriteQuota int64
+	// Principal은 요청의 주체를 정하는 함수, nil이면 DefaultPrincipal (클라이언트 IP)
+	// 인증을 붙이면 검증한 사용자나 API 키로 주체를 정하는 함수를 지정 (요청 헤더의 값을 그대로 쓰면 값을 바꿔 가며 제한을 피할 수 있음)
+	Principal func(c *gin.Context) string
+}
+
+// WithRateLimit은 /api/v1 아래 모든 라우트에 속도 제한과 쓰기 한도를 적용하도록 지정
+func WithRateLimit(cfg RateLimitConfig) Option {
+	return func(h *Handler) {
+		if cfg.Principal == nil {
+			cfg.Principal = DefaultPrincipal
+		}
+		h.rateLimit = &cfg
+	}
+}
+
+// DefaultPrincipal은 클라이언트 IP를 요청의 주체로 정함
+// 이 API는 인증을 하지 않으므로 속도 제한, 쓰기 한도, 멱등성 키는 IP별로 적용하고
+// Authorization이나 X-API-Key 헤더는 보지 않음 (검증하지 않은 값을 바꿔 가며 보내 제한을 피하지 못하도록)
+// 클라이언트 IP는 gin.Engine.SetTrustedProxies로 지정한 프록시가 보낸 X-Forwarded-For만 믿고, 그 외에는 연결한 주소를 사용
+func DefaultPrincipal(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// isWriteMethod는 쓰기 한도에 포함하는 메서드인지 확인
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// RateLimit은 주체별, 그룹별 토큰 버킷으로 요청 수를 제한하고 쓰기 요청의 하루 한도를 적용하는 미들웨어
+//
+// 제한하는 그룹의 응답에는 RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy 헤더를 붙이고
+// 한도를 넘으면 429와 Retry-After(초)로 응답
+// 저장소 오류가 나면 요청을 막지 않고 기록만 남김 (저장소 장애로 API 전체가 멈추지 않도록)
+func RateLimit(cfg RateLimitConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := cfg.Principal(c)
+
+		group, ok := rateLimitGroups[c.FullPath()]
+		if !ok {
+			group = RateLimitResources
+		}
+		if limit, ok := cfg.Limits[group]; ok {
+			res, err := cfg.Store.Allow(c, "rate:"+group+":"+principal, limit)
+			if err != nil {
+				log.Printf("속도 제한 확인 실패: %v", err)
+			} else {
+				setRateLimitHeaders(c, limit, res)
+				if !res.Allowed {
+					tooManyRequests(c, res.RetryAfter, "요청이 너무 많습니다. 잠시 후 다시 시도하세요")
+					return
+				}
+			}
+		}
+
+		if cfg.DailyWriteQuota > 0 && isWriteMethod(c.Request.Method) {
+			now := time.Now().UTC()
+			key := "quota:" + now.Format(time.DateOnly) + ":" + principal
+			tomorrow := now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+			// 동시에 온 요청이 함께 한도를 넘지 않도록 먼저 세고, 세지 않을 요청이면 처리한 뒤 되돌림
+			used, err := cfg.Store.Increment(c, key, 1, tomorrow)
+			if err != nil {
+				log.Printf("쓰기 한도 확인 실패: %v", err)
+				c.Next()
+				return
+			}
+			if used > cfg.DailyWriteQuota {
+				undoQuota(c, cfg.Store, key, tomorrow)
+				tooManyRequests(c, tomorrow.Sub(now), fmt.Sprintf("하루 쓰기 한도(%d회)를 넘었습니다", cfg.DailyWriteQuota))
+				return
+			}
+			c.Next()
+			if c.Writer.Status() >= http.StatusBadRequest || c.Writer.Header().Get("Idempotent-Replayed") == "true" {
+				undoQuota(c, cfg.Store, key, tomorrow)
+			}
+			return
+		}
+		c.Next()
+	}
+}
+
+// undoQuota는 먼저 센 쓰기 요청 하나를 한도에서 뺌 (요청이 취소돼도 되돌리도록 취소를 떼어 냄)
+func undoQuota(c *gin.Context, store ratelimit.Store, key string, expiresAt time.Time) {
+	if _, err := store.Increment(context.WithoutCancel(c), key, -1, expiresAt); err != nil {
+		log.Printf("쓰기 한도 되돌리기 실패: %v", err)
+	}
+}
+
+// setRateLimitHeaders는 IETF RateLimit 헤더 초안의 형식으로 버킷 상태를 응답 헤더에 기록
+// RateLimit-Reset은 버킷이 가득 찰 때까지 남은 초, RateLimit-Policy의 w는 빈 버킷이 가득 차는 데 걸리는 초
+func setRateLimitHeaders(c *gin.Context, limit ratelimit.Limit, res ratelimit.Result) {
+	c.Header("RateLimit-Limit", strconv.Itoa(res.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+	c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Burst, ceilSeconds(limit.Window())))
+}
+
+func tooManyRequests(c *gin.Context, retryAfter time.Duration, message string) {
+	c.Header("Retry-After", strconv.Itoa(max(1, ceilSeconds(retryAfter))))
+	respond(c, http.StatusTooManyRequests, message, nil)
+	c.Abort()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"go_project/internal/idempotency"
+	"go_project/internal/model"
+	"go_project/internal/openapi"
+	"go_project/internal/ratelimit"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (s *RateLimitTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	uc := usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))
+	s.Require().NoError(uc.Insert(context.Background(), &model.Base{Name: "web"}))
+
+	s.router = gin.New()
+	NewHandler(uc, WithRateLimit(RateLimitConfig{
+		Store: ratelimit.NewMemoryStore(),
+		Limits: map[string]ratelimit.Limit{
+			RateLimitResources: {Rate: 0.01, Burst: 3},
+			RateLimitBulk:      {Rate: 0.01, Burst: 1},
+		},
+		DailyWriteQuota: 2,
+	})).RegisterAPIRoutes(s.router)
+}
+
+func (s *RateLimitTestSuite) send(method, path string, header map[string]string) *httptest.ResponseRecorder {
+	return s.sendFrom("10.0.0.1:1234", method, path, header)
+}
+
+func (s *RateLimitTestSuite) sendFrom(remoteAddr, method, path string, header map[string]string) *httptest.ResponseRecorder {
+	var body *strings.Reader
+	if method == http.MethodGet {
+		body = strings.NewReader("")
+	} else {
+		body = strings.NewReader(`{"name":"db"}`)
+	}
+	req := httptest.NewRequest(method, path, body)
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = remoteAddr
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *RateLimitTestSuite) TestRateLimit() {
+	for i := range 3 {
+		w := s.send(http.MethodGet, "/api/v1/resources/1", nil)
+		s.Require().Equal(http.StatusOK, w.Code)
+		s.Equal("3", w.Header().Get("RateLimit-Limit"))
+		s.Equal(strconv.Itoa(2-i), w.Header().Get("RateLimit-Remaining"))
+		s.Equal("3;w=300", w.Header().Get("RateLimit-Policy"))
+	}
+
+	w := s.send(http.MethodGet, "/api/v1/resources/1", nil)
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.Equal("100", w.Header().Get("Retry-After"))
+	s.Equal("0", w.Header().Get("RateLimit-Remaining"))
+	s.Contains(w.Body.String(), `"status":429`)
+
+	// 주체(IP)와 그룹이 다르면 버킷이 따로
+	s.Equal(http.StatusOK, s.sendFrom("10.0.0.2:1234", http.MethodGet, "/api/v1/resources/1", nil).Code)
+	// API 키를 바꿔 보내도 IP의 버킷을 사용
+	s.Equal(http.StatusTooManyRequests, s.send(http.MethodGet, "/api/v1/resources/1", map[string]string{"X-API-Key": "other"}).Code)
+	s.Equal(http.StatusOK, s.send(http.MethodGet, "/api/v1/resources/export", nil).Code)
+	s.Equal(http.StatusTooManyRequests, s.send(http.MethodGet, "/api/v1/resources/export", nil).Code)
+
+	// 한도가 없는 그룹은 제한하지 않음
+	w = s.send(http.MethodGet, "/api/v1/openapi.json", nil)
+	s.Equal(http.StatusOK, w.Code)
+	s.Empty(w.Header().Get("RateLimit-Limit"))
+}
+
+func (s *RateLimitTestSuite) TestDailyWriteQuota() {
+	// 속도 제한 없이 쓰기 한도만 적용하고 Idempotency-Key를 처리
+	uc := usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))
+	s.Require().NoError(uc.Insert(context.Background(), &model.Base{Name: "web"}))
+	s.router = gin.New()
+	NewHandler(uc,
+		WithRateLimit(RateLimitConfig{Store: ratelimit.NewMemoryStore(), DailyWriteQuota: 2}),
+		WithIdempotency(idempotency.NewMemoryStore(), time.Hour),
+	).RegisterAPIRoutes(s.router)
+
+	// 실패한 쓰기 요청과 저장된 응답을 돌려준 재시도는 한도에 포함하지 않음
+	idempotent := map[string]string{"Idempotency-Key": "key-1"}
+	s.Equal(http.StatusCreated, s.send(http.MethodPost, "/api/v1/resources", idempotent).Code)
+	s.Equal("true", s.send(http.MethodPost, "/api/v1/resources", idempotent).Header().Get("Idempotent-Replayed"))
+	s.Equal(http.StatusNotFound, s.send(http.MethodDelete, "/api/v1/resources/99", nil).Code)
+	s.Equal(http.StatusNotFound, s.send(http.MethodPatch, "/api/v1/resources/99", nil).Code)
+	s.Equal(http.StatusOK, s.send(http.MethodPatch, "/api/v1/resources/1", nil).Code)
+
+	w := s.send(http.MethodPatch, "/api/v1/resources/1", nil)
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	s.Require().NoError(err)
+	s.True(retryAfter > 0 && retryAfter <= 24*60*60)
+	s.Contains(w.Body.String(), "하루 쓰기 한도(2회)")
+}
+
+func (s *RateLimitTestSuite) TestOpenAPI() {
+	w := s.send(http.MethodGet, "/api/v1/openapi.json", nil)
+	s.Require().Equal(http.StatusOK, w.Code)
+	var doc openapi.Document
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &doc))
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			s.Contains(op.Responses, "429", "%s %s", method, path)
+		}
+	}
+}
+
+func TestDefaultPrincipal(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		header     map[string]string
+		remoteAddr string
+		want       string
+	}{
+		{name: "IP", want: "ip:10.0.0.1"},
+		{name: "API_키", header: map[string]string{"X-API-Key": "secret"}, want: "ip:10.0.0.1"},
+		{name: "Bearer_토큰", header: map[string]string{"Authorization": "Bearer secret"}, want: "ip:10.0.0.1"},
+		{name: "믿지_않는_프록시", header: map[string]string{"X-Forwarded-For": "203.0.113.9"}, want: "ip:10.0.0.1"},
+		{name: "믿는_프록시", header: map[string]string{"X-Forwarded-For": "203.0.113.9"}, remoteAddr: "10.1.0.1:1234", want: "ip:203.0.113.9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, e := gin.CreateTestContext(httptest.NewRecorder())
+			if err := e.SetTrustedProxies([]string{"10.1.0.0/16"}); err != nil {
+				t.Fatal(err)
+			}
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			c.Request.RemoteAddr = "10.0.0.1:1234"
+			if tt.remoteAddr != "" {
+				c.Request.RemoteAddr = tt.remoteAddr
+			}
+			for k, v := range tt.header {
+				c.Request.Header.Set(k, v)
+			}
+			if got := DefaultPrincipal(c); got != tt.want {
+				t.Errorf("DefaultPrincipal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
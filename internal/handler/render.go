@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"encoding/xml"
+	"errors"
+	"go_project/internal/usecase"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+)
+
+// Response는 모든 API 응답의 공통 구조
+// 형식(JSON, XML, YAML, MessagePack)과 관계없이 같은 status/message/data 구조로 직렬화됨
+// 요청은 처리했지만 알릴 내용이 있으면 warnings에 담음 (없으면 생략)
+type Response struct {
+	XMLName  xml.Name    `json:"-" xml:"response" yaml:"-" codec:"-"`
+	Status   int         `json:"status" xml:"status" yaml:"status"`
+	Message  string      `json:"message" xml:"message" yaml:"message"`
+	Data     interface{} `json:"data" xml:"data,omitempty" yaml:"data"`
+	Warnings []string    `json:"warnings,omitempty" xml:"warnings>warning,omitempty" yaml:"warnings,omitempty"`
+}
+
+// 응답/요청 본문 형식
+const (
+	formatJSON    = "json"
+	formatXML     = "xml"
+	formatYAML    = "yaml"
+	formatMsgPack = "msgpack"
+)
+
+// 협상된 응답 형식을 저장하는 gin 컨텍스트 키
+const formatKey = "handler.format"
+
+// MIME 타입별 형식 (Accept와 Content-Type 모두에 사용)
+var mimeFormats = map[string]string{
+	"application/json":        formatJSON,
+	"application/xml":         formatXML,
+	"text/xml":                formatXML,
+	"application/yaml":        formatYAML,
+	"application/x-yaml":      formatYAML,
+	"text/yaml":               formatYAML,
+	"application/msgpack":     formatMsgPack,
+	"application/x-msgpack":   formatMsgPack,
+	"application/vnd.msgpack": formatMsgPack,
+}
+
+// Accept 와일드카드 매칭 시 우선순위 (앞쪽이 우선)
+var offeredMIMEs = []string{
+	"application/json",
+	"application/xml",
+	"text/xml",
+	"application/yaml",
+	"application/x-yaml",
+	"text/yaml",
+	"application/msgpack",
+	"application/x-msgpack",
+	"application/vnd.msgpack",
+}
+
+// Negotiate는 ?format= 또는 Accept 헤더로 응답 형식을 정하는 미들웨어
+// 맞는 형식이 없으면 406으로 응답하고 요청 처리를 중단
+func Negotiate(c *gin.Context) {
+	format, ok := negotiateFormat(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotAcceptable, Response{
+			Status:  http.StatusNotAcceptable,
+			Message: "지원하지 않는 응답 형식",
+			Data:    nil,
+		})
+		return
+	}
+	c.Set(formatKey, format)
+	c.Next()
+}
+
+func negotiateFormat(c *gin.Context) (string, bool) {
+	// ?format= 이 Accept 헤더보다 우선
+	if f := c.Query("format"); f != "" {
+		switch f = strings.ToLower(f); f {
+		case formatJSON, formatXML, formatYAML, formatMsgPack:
+			return f, true
+		}
+		return "", false
+	}
+
+	accept := c.GetHeader("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return formatJSON, true
+	}
+	for _, mimeType := range parseAccept(accept) {
+		for _, offered := range offeredMIMEs {
+			if mimeMatches(mimeType, offered) {
+				return mimeFormats[offered], true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseAccept는 Accept 헤더를 q 값이 높은 순으로 정렬한 MIME 타입 목록으로 변환
+// q=0 인 항목은 허용하지 않는다는 뜻이므로 제외
+func parseAccept(header string) []string {
+	type acceptItem struct {
+		mimeType string
+		q        float64
+	}
+
+	var items []acceptItem
+	for _, part := range strings.Split(header, ",") {
+		mimeType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		items = append(items, acceptItem{mimeType: mimeType, q: q})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].q > items[j].q })
+
+	mimeTypes := make([]string, len(items))
+	for i, item := range items {
+		mimeTypes[i] = item.mimeType
+	}
+	return mimeTypes
+}
+
+func mimeMatches(accepted, offered string) bool {
+	if accepted == "*/*" || accepted == offered {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(accepted, "/*"); ok {
+		return strings.HasPrefix(offered, prefix+"/")
+	}
+	return false
+}
+
+// respond는 협상된 형식으로 공통 응답 구조를 기록
+// Negotiate 미들웨어를 거치지 않은 요청은 JSON으로 응답
+func respond(c *gin.Context, status int, message string, data interface{}) {
+	respondWithWarnings(c, status, message, data, nil)
+}
+
+// respondWithWarnings는 respond와 같지만 경고가 있으면 warnings 필드로 함께 응답
+func respondWithWarnings(c *gin.Context, status int, message string, data interface{}, warnings []string) {
+	body := Response{Status: status, Message: message, Data: data, Warnings: warnings}
+	switch c.GetString(formatKey) {
+	case formatXML:
+		c.Render(status, render.XML{Data: body})
+	case formatYAML:
+		c.Render(status, render.YAML{Data: body})
+	case formatMsgPack:
+		c.Render(status, render.MsgPack{Data: body})
+	default:
+		c.JSON(status, body)
+	}
+}
+
+// errorStatus는 Usecase 에러를 HTTP 상태 코드로 변환
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, usecase.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, usecase.ErrUnsupportedType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, usecase.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// DB를 사용할 수 없을 때 재시도를 권하는 시간 (초, 회로 차단기가 복구를 확인하는 주기와 비슷하게)
+const unavailableRetryAfter = "10"
+
+// respondError는 에러 종류에 맞는 상태 코드로 응답
+// 리소스가 없으면 404, 검증 실패(400), 충돌(409), 크기 초과(413), 형식 오류(415)는 에러 내용을,
+// DB를 사용할 수 없으면 Retry-After와 함께 503, 그 외에는 message와 함께 500
+func respondError(c *gin.Context, err error, message string) {
+	status := errorStatus(err)
+	switch status {
+	case http.StatusNotFound:
+		message = "리소스를 찾을 수 없습니다"
+	case http.StatusServiceUnavailable:
+		c.Header("Retry-After", unavailableRetryAfter)
+		message = usecase.ErrUnavailable.Error()
+	case http.StatusBadRequest, http.StatusConflict, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType:
+		message = err.Error()
+	}
+	respond(c, status, message, nil)
+}
+
+// bindBody는 Content-Type에 맞는 형식으로 요청 본문을 디코딩
+// 지원하지 않는 Content-Type이면 415, 디코딩에 실패하면 400으로 응답하고 false를 반환
+func bindBody(c *gin.Context, obj interface{}) bool {
+	b, ok := bodyBinding(c)
+	if !ok {
+		return false
+	}
+	if err := c.ShouldBindWith(obj, b); err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 요청 데이터", nil)
+		return false
+	}
+	return true
+}
+
+// bodyBinding은 Content-Type에 맞는 본문 디코더를 반환 (Content-Type이 없으면 JSON)
+// 지원하지 않는 Content-Type이면 415로 응답하고 false를 반환
+func bodyBinding(c *gin.Context) (binding.BindingBody, bool) {
+	format := formatJSON
+	if contentType := c.ContentType(); contentType != "" {
+		f, ok := mimeFormats[contentType]
+		if !ok {
+			respond(c, http.StatusUnsupportedMediaType, "지원하지 않는 Content-Type", nil)
+			return nil, false
+		}
+		format = f
+	}
+
+	switch format {
+	case formatXML:
+		return binding.XML, true
+	case formatYAML:
+		return binding.YAML, true
+	case formatMsgPack:
+		return binding.MsgPack, true
+	default:
+		return binding.JSON, true
+	}
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"go_project/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Revisions는 리소스 리비전 핸들러
+type Revisions[T any] struct {
+	uc usecase.RevisionUsecase[T]
+}
+
+func NewRevisions[T any](uc usecase.RevisionUsecase[T]) *Revisions[T] {
+	return &Revisions[T]{
+		uc: uc,
+	}
+}
+
+// 롤백 요청 본문 (생략 가능)
+type rollbackRequest struct {
+	// 지정하면 마지막 리비전 번호가 이 값일 때만 롤백 (다르면 409)
+	ExpectedRevision int `json:"expected_revision,omitempty" xml:"expected_revision,omitempty" yaml:"expected_revision,omitempty"`
+}
+
+// Register는 path 아래에 리비전 라우트를 등록
+//
+//	GET  {path}/:id/revisions                     - 리비전 목록 (최근 리비전부터, ?page=&size=)
+//	GET  {path}/:id/revisions/diff?from=&to=      - 두 리비전 사이에 바뀐 값
+//	GET  {path}/:id/revisions/:revision           - 리비전 조회
+//	POST {path}/:id/revisions/:revision/rollback  - 리비전으로 롤백 (새 리비전으로 남음)
+func (h *Revisions[T]) Register(r gin.IRoutes, path string) {
+	r.GET(path+"/:id/revisions", h.List)
+	r.GET(path+"/:id/revisions/diff", h.Diff)
+	r.GET(path+"/:id/revisions/:revision", h.Get)
+	r.POST(path+"/:id/revisions/:revision/rollback", h.Rollback)
+}
+
+// List는 리비전 한 페이지를 응답하고 전체 개수는 X-Total-Count 헤더로 전달
+func (h *Revisions[T]) List(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+	opts, ok := listOptions(c)
+	if !ok {
+		respond(c, http.StatusBadRequest, "잘못된 페이지 파라미터", nil)
+		return
+	}
+
+	revs, total, err := h.uc.List(c, uint(id), opts.Page, opts.Size)
+	if err != nil {
+		respondError(c, err, "리비전 목록 조회 실패")
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	respond(c, http.StatusOK, "성공", revs)
+}
+
+func (h *Revisions[T]) Get(c *gin.Context) {
+	id, number, ok := revisionParams(c)
+	if !ok {
+		return
+	}
+
+	rev, err := h.uc.Get(c, id, number)
+	if err != nil {
+		respondError(c, err, "리비전 조회 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", rev)
+}
+
+// Diff는 from 리비전에서 to 리비전으로 바뀐 값을 응답 (둘 다 필요)
+func (h *Revisions[T]) Diff(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return
+	}
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil || from < 1 || to < 1 {
+		respond(c, http.StatusBadRequest, "from과 to에 리비전 번호를 지정해야 합니다", nil)
+		return
+	}
+
+	diff, err := h.uc.Diff(c, uint(id), from, to)
+	if err != nil {
+		respondError(c, err, "리비전 비교 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", diff)
+}
+
+// Rollback은 리소스를 리비전의 내용으로 되돌리고, 그 결과로 남은 새 리비전을 응답
+func (h *Revisions[T]) Rollback(c *gin.Context) {
+	id, number, ok := revisionParams(c)
+	if !ok {
+		return
+	}
+	var req rollbackRequest
+	if c.Request.Body != http.NoBody && c.Request.ContentLength != 0 && !bindBody(c, &req) {
+		return
+	}
+
+	rev, err := h.uc.Rollback(c, id, number, req.ExpectedRevision)
+	if err != nil {
+		respondError(c, err, "리비전 롤백 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", rev)
+}
+
+// revisionParams는 경로의 리소스 ID와 리비전 번호를 읽고, 잘못되었으면 400으로 응답하고 false를 반환
+func revisionParams(c *gin.Context) (uint, int, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respond(c, http.StatusBadRequest, "잘못된 ID 형식", nil)
+		return 0, 0, false
+	}
+	number, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || number < 1 {
+		respond(c, http.StatusBadRequest, "잘못된 리비전 번호", nil)
+		return 0, 0, false
+	}
+	return uint(id), number, true
+}
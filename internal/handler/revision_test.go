@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"go_project/internal/model"
+	"go_project/internal/openapi"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type RevisionsTestSuite struct {
+	suite.Suite
+	handler  *Handler
+	router   *gin.Engine
+	uc       usecase.Usecase[model.Base]
+	resource *model.Base
+}
+
+func (s *RevisionsTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	revisions := repository.NewRevisionRepository(recorder.NewMemoryRevisionRecorder())
+	repo := repository.NewVersionedRepository(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()), revisions, recorder.NewMemoryTransactor())
+	s.uc = usecase.NewUsecase(repo)
+
+	s.resource = &model.Base{Name: "v1"}
+	s.Require().NoError(s.uc.Insert(context.Background(), s.resource))
+	s.Require().NoError(s.uc.Modify(context.Background(), s.resource.ID, &model.Base{Name: "v2"}))
+
+	s.handler = NewHandler(s.uc, WithRevisions(usecase.NewRevisionUsecase(revisions, s.uc)))
+	s.router = gin.New()
+	s.handler.RegisterAPIRoutes(s.router)
+}
+
+func (s *RevisionsTestSuite) serve(method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *RevisionsTestSuite) TestListAndGet() {
+	w := s.serve(http.MethodGet, "/api/v1/resources/1/revisions?size=1", "")
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	s.Equal("2", w.Header().Get("X-Total-Count"))
+	var list struct {
+		Data []model.ResourceRevision[model.Base] `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &list))
+	s.Require().Len(list.Data, 1)
+	s.Equal(2, list.Data[0].Revision)
+	s.Equal("v2", list.Data[0].Resource.Name)
+
+	w = s.serve(http.MethodGet, "/api/v1/resources/1/revisions/1", "")
+	s.Require().Equal(http.StatusOK, w.Code)
+	var got struct {
+		Data model.ResourceRevision[model.Base] `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &got))
+	s.Equal("v1", got.Data.Resource.Name)
+
+	s.Equal(http.StatusNotFound, s.serve(http.MethodGet, "/api/v1/resources/1/revisions/3", "").Code)
+	s.Equal(http.StatusNotFound, s.serve(http.MethodGet, "/api/v1/resources/9/revisions", "").Code)
+	s.Equal(http.StatusBadRequest, s.serve(http.MethodGet, "/api/v1/resources/1/revisions/0", "").Code)
+	s.Equal(http.StatusBadRequest, s.serve(http.MethodGet, "/api/v1/resources/1/revisions?page=0", "").Code)
+}
+
+func (s *RevisionsTestSuite) TestDiff() {
+	w := s.serve(http.MethodGet, "/api/v1/resources/1/revisions/diff?from=1&to=2", "")
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	var diff struct {
+		Data model.RevisionDiff `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &diff))
+	s.Contains(diff.Data.Changes, model.FieldChange{Path: "/name", From: "v1", To: "v2"})
+
+	// 바뀐 값은 끝 값만 담으므로 XML로도 응답할 수 있음
+	w = s.serve(http.MethodGet, "/api/v1/resources/1/revisions/diff?from=1&to=2&format=xml", "")
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "<path>/name</path>")
+
+	s.Equal(http.StatusBadRequest, s.serve(http.MethodGet, "/api/v1/resources/1/revisions/diff?from=1", "").Code)
+	s.Equal(http.StatusNotFound, s.serve(http.MethodGet, "/api/v1/resources/1/revisions/diff?from=1&to=5", "").Code)
+}
+
+func (s *RevisionsTestSuite) TestRollback() {
+	// 마지막 리비전이 다르면 충돌
+	w := s.serve(http.MethodPost, "/api/v1/resources/1/revisions/1/rollback", `{"expected_revision":1}`)
+	s.Equal(http.StatusConflict, w.Code, w.Body.String())
+
+	w = s.serve(http.MethodPost, "/api/v1/resources/1/revisions/1/rollback", `{"expected_revision":2}`)
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	var rev struct {
+		Data model.ResourceRevision[model.Base] `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &rev))
+	s.Equal(3, rev.Data.Revision)
+	s.Require().NotNil(rev.Data.RollbackOf)
+	s.Equal(1, *rev.Data.RollbackOf)
+
+	got, err := s.uc.Get(context.Background(), s.resource.ID)
+	s.Require().NoError(err)
+	s.Equal("v1", got.Name)
+
+	// 본문 없이도 롤백할 수 있음
+	s.Equal(http.StatusOK, s.serve(http.MethodPost, "/api/v1/resources/1/revisions/2/rollback", "").Code)
+	s.Equal(http.StatusBadRequest, s.serve(http.MethodPost, "/api/v1/resources/1/revisions/2/rollback", `{`).Code)
+	s.Equal(http.StatusNotFound, s.serve(http.MethodPost, "/api/v1/resources/1/revisions/9/rollback", "").Code)
+}
+
+func (s *RevisionsTestSuite) TestOpenAPI_RoutesMatchSpec() {
+	doc, err := openapi.Build(apiInfo, s.router.Routes(), apiPrefix, s.handler.apiOperations())
+	s.Require().NoError(err)
+	s.Contains(doc.Paths, "/api/v1/resources/{id}/revisions/{revision}/rollback")
+}
+
+func TestRevisionsSuite(t *testing.T) {
+	suite.Run(t, new(RevisionsTestSuite))
+}
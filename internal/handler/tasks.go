@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"go_project/internal/scheduler"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithScheduler는 예약 작업 라우트(/api/v1/tasks)를 등록하도록 지정
+// 지정하지 않으면 예약 작업 라우트는 등록하지 않음
+func WithScheduler(s *scheduler.Scheduler) Option {
+	return func(h *Handler) {
+		h.tasks = NewTasks(s)
+	}
+}
+
+// Tasks는 예약 작업 핸들러 (상태와 실행 기록 조회, 직접 실행)
+type Tasks struct {
+	scheduler *scheduler.Scheduler
+}
+
+func NewTasks(s *scheduler.Scheduler) *Tasks {
+	return &Tasks{
+		scheduler: s,
+	}
+}
+
+// Register는 path 아래에 예약 작업 라우트를 등록
+//
+//	GET  {path}            - 작업 목록 (실행 주기, 다음 실행 시각, 마지막 실행 기록)
+//	GET  {path}/:name/runs - 실행 기록 (?page=&size=, 최근 것부터)
+//	POST {path}/:name/run  - 바로 실행 (끝날 때까지 기다리지 않고 202로 실행 기록을 응답)
+func (h *Tasks) Register(r gin.IRoutes, path string) {
+	r.GET(path, h.List)
+	r.GET(path+"/:name/runs", h.Runs)
+	r.POST(path+"/:name/run", h.Trigger)
+}
+
+func (h *Tasks) List(c *gin.Context) {
+	tasks, err := h.scheduler.Tasks(c)
+	if err != nil {
+		taskError(c, err, "예약 작업 목록 조회 실패")
+		return
+	}
+
+	respond(c, http.StatusOK, "성공", tasks)
+}
+
+func (h *Tasks) Runs(c *gin.Context) {
+	opts, ok := listOptions(c)
+	if !ok {
+		respond(c, http.StatusBadRequest, "잘못된 페이지 파라미터", nil)
+		return
+	}
+
+	runs, total, err := h.scheduler.Runs(c, c.Param("name"), opts.Page, opts.Size)
+	if err != nil {
+		taskError(c, err, "실행 기록 조회 실패")
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	respond(c, http.StatusOK, "성공", runs)
+}
+
+func (h *Tasks) Trigger(c *gin.Context) {
+	run, err := h.scheduler.Trigger(c, c.Param("name"))
+	if err != nil {
+		taskError(c, err, "예약 작업 실행 실패")
+		return
+	}
+
+	respond(c, http.StatusAccepted, "실행 시작", run)
+}
+
+// taskError는 스케줄러 에러를 상태 코드로 변환해서 응답
+// 등록되지 않은 작업이면 404, 이미 실행 중이면 409, 나머지는 respondError와 같음
+func taskError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, scheduler.ErrUnknownTask):
+		respond(c, http.StatusNotFound, err.Error(), nil)
+	case errors.Is(err, scheduler.ErrAlreadyRunning):
+		respond(c, http.StatusConflict, err.Error(), nil)
+	default:
+		respondError(c, err, message)
+	}
+}
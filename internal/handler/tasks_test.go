@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go_project/internal/model"
+	"go_project/internal/openapi"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/scheduler"
+	"go_project/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type TasksTestSuite struct {
+	suite.Suite
+	scheduler *scheduler.Scheduler
+	release   chan struct{}
+	handler   *Handler
+	router    *gin.Engine
+}
+
+func (s *TasksTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	uc := usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))
+	s.release = make(chan struct{})
+	s.scheduler = scheduler.New(scheduler.NewMemoryHistory(), scheduler.NewLocalLeader())
+	s.scheduler.Register("export", func(ctx context.Context) error {
+		<-s.release
+		return errors.New("디스크 가득 참")
+	})
+	s.Require().NoError(s.scheduler.Schedule("export", "0 3 * * *"))
+
+	s.handler = NewHandler(uc, WithScheduler(s.scheduler))
+	s.router = gin.New()
+	s.handler.RegisterAPIRoutes(s.router)
+}
+
+func (s *TasksTestSuite) serve(method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func (s *TasksTestSuite) TestTriggerAndHistory() {
+	w := s.serve(http.MethodPost, "/api/v1/tasks/export/run")
+	s.Require().Equal(http.StatusAccepted, w.Code, w.Body.String())
+	var started struct {
+		Data scheduler.Run `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &started))
+	s.Equal(scheduler.StatusRunning, started.Data.Status)
+	s.Equal(scheduler.TriggerManual, started.Data.Trigger)
+
+	s.Equal(http.StatusConflict, s.serve(http.MethodPost, "/api/v1/tasks/export/run").Code)
+	close(s.release)
+
+	var runs struct {
+		Data []scheduler.Run `json:"data"`
+	}
+	s.Eventually(func() bool {
+		w = s.serve(http.MethodGet, "/api/v1/tasks/export/runs?page=1&size=10")
+		return json.Unmarshal(w.Body.Bytes(), &runs) == nil && len(runs.Data) == 1 && runs.Data[0].Status != scheduler.StatusRunning
+	}, time.Second, 5*time.Millisecond)
+	s.Equal("1", w.Header().Get("X-Total-Count"))
+	s.Equal(scheduler.StatusFailed, runs.Data[0].Status)
+	s.Equal("디스크 가득 참", runs.Data[0].Error)
+
+	w = s.serve(http.MethodGet, "/api/v1/tasks")
+	s.Require().Equal(http.StatusOK, w.Code)
+	var tasks struct {
+		Data []scheduler.TaskInfo `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &tasks))
+	s.Require().Len(tasks.Data, 1)
+	s.Equal("0 3 * * *", tasks.Data[0].Schedule)
+	s.NotNil(tasks.Data[0].NextRun)
+	s.Require().NotNil(tasks.Data[0].LastRun)
+	s.Equal(started.Data.ID, tasks.Data[0].LastRun.ID)
+}
+
+func (s *TasksTestSuite) TestErrors() {
+	tests := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodPost, "/api/v1/tasks/unknown/run", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/tasks/unknown/runs", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/tasks/export/runs?size=0", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		s.Run(tt.path, func() {
+			s.Equal(tt.status, s.serve(tt.method, tt.path).Code)
+		})
+	}
+}
+
+func (s *TasksTestSuite) TestOpenAPI_RoutesMatchSpec() {
+	doc, err := openapi.Build(apiInfo, s.router.Routes(), apiPrefix, s.handler.apiOperations())
+	s.Require().NoError(err)
+	s.Contains(doc.Paths, "/api/v1/tasks/{name}/run")
+	s.Contains(doc.Components.Schemas, "SchedulerRun")
+}
+
+func TestTasksSuite(t *testing.T) {
+	suite.Run(t, new(TasksTestSuite))
+}
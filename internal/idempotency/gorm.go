@@ -0,0 +1,98 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// Migrate는 idempotency_keys 테이블과 인덱스를 생성하거나 Record의 변경 사항을 반영
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&Record{}); err != nil {
+		return fmt.Errorf("idempotency_keys 테이블 마이그레이션 실패: %w", err)
+	}
+	return nil
+}
+
+// NewGormStore는 idempotency_keys 테이블을 사용하는 Store를 생성 (테이블은 Migrate로 생성)
+// 키 하나를 여러 서버가 동시에 예약해도 기본 키 충돌로 한 요청만 성공함
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+// 예약하려던 키가 조회 직전에 지워진 경우(처리 중이던 요청이 실패해서 Release) 다시 시도할 횟수
+const reserveAttempts = 3
+
+func (s *gormStore) Reserve(ctx context.Context, rec *Record) (string, *Record, error) {
+	db := s.db.WithContext(ctx)
+	for range reserveAttempts {
+		// 만료된 기록은 지우고 새로 예약
+		if err := db.Where("key = ? AND expires_at <= ?", rec.Key, time.Now()).Delete(&Record{}).Error; err != nil {
+			return "", nil, fmt.Errorf("만료된 멱등성 키 삭제 실패: %v", err)
+		}
+
+		r := *rec
+		r.Completed = false
+		r.Token = newToken()
+		res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&r)
+		if res.Error != nil {
+			return "", nil, fmt.Errorf("멱등성 키 예약 실패: %v", res.Error)
+		}
+		if res.RowsAffected == 1 {
+			return r.Token, nil, nil
+		}
+
+		var existing Record
+		err := db.Where("key = ?", rec.Key).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("멱등성 키 조회 실패: %v", err)
+		}
+		return "", &existing, nil
+	}
+	return "", nil, fmt.Errorf("멱등성 키 예약 실패: %s", rec.Key)
+}
+
+func (s *gormStore) Complete(ctx context.Context, rec *Record) error {
+	res := s.db.WithContext(ctx).Model(&Record{}).
+		Where("key = ? AND token = ? AND completed = ?", rec.Key, rec.Token, false).
+		Updates(map[string]interface{}{
+			"completed":    true,
+			"status":       rec.Status,
+			"content_type": rec.ContentType,
+			"body":         rec.Body,
+			"expires_at":   rec.ExpiresAt,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("멱등성 키 응답 저장 실패: %v", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func (s *gormStore) Release(ctx context.Context, key, token string) error {
+	if err := s.db.WithContext(ctx).Where("key = ? AND token = ? AND completed = ?", key, token, false).Delete(&Record{}).Error; err != nil {
+		return fmt.Errorf("멱등성 키 해제 실패: %v", err)
+	}
+	return nil
+}
+
+func (s *gormStore) Purge(ctx context.Context, now time.Time) (int64, error) {
+	res := s.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&Record{})
+	if res.Error != nil {
+		return 0, fmt.Errorf("만료된 멱등성 키 삭제 실패: %v", res.Error)
+	}
+	return res.RowsAffected, nil
+}
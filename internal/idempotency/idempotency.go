@@ -0,0 +1,84 @@
+// Package idempotency는 Idempotency-Key 헤더로 받은 요청의 처리 결과를 저장하는 Store와 구현체를 제공
+//
+// 같은 키로 다시 온 요청은 저장된 응답을 그대로 돌려주도록, 키마다 요청 본문의 해시와 응답 전체를 보관함
+// DB(NewGormStore)와 메모리(NewMemoryStore) 구현을 지원
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// MaxKeyLength는 Idempotency-Key의 최대 길이
+const MaxKeyLength = 255
+
+// ErrLockLost는 처리하는 동안 예약이 만료되어 다른 요청이 키를 가져갔을 때 Complete가 반환 (errors.Is로 확인)
+var ErrLockLost = errors.New("멱등성 키 예약이 만료되어 다른 요청이 가져갔습니다")
+
+// Record는 Idempotency-Key 하나의 처리 상태와 응답
+type Record struct {
+	Key         string `gorm:"primaryKey;size:255"`
+	RequestHash string `gorm:"size:64;not null"`
+	// Token은 키를 예약한 요청을 구분하는 값 (Reserve가 매번 새로 만들고, Complete와 Release는 같은 값일 때만 반영)
+	Token string `gorm:"size:32;not null;default:''"`
+	// false면 첫 요청을 아직 처리 중 (Status, ContentType, Body는 비어 있음)
+	Completed   bool `gorm:"not null;default:false"`
+	Status      int
+	ContentType string `gorm:"size:255"`
+	Body        []byte
+	CreatedAt   time.Time
+	// 처리 중이면 처리가 끝나지 않아도 다른 요청이 키를 가져갈 수 있는 시각, 완료됐으면 응답을 보관하는 기한
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+func (Record) TableName() string {
+	return "idempotency_keys"
+}
+
+// Store는 Idempotency-Key별 처리 기록 저장소
+// 여러 서버가 같은 저장소를 쓰면 서버가 달라도 같은 키의 요청을 한 번만 처리함
+type Store interface {
+	// Reserve는 rec.Key가 없거나 만료됐으면 rec을 처리 중으로 저장하고 새 예약 토큰을 반환
+	// 이미 있으면 저장하지 않고 있는 기록을 반환 (토큰은 빈 문자열)
+	Reserve(ctx context.Context, rec *Record) (token string, existing *Record, err error)
+	// Complete는 rec.Token으로 예약한 처리 중인 rec.Key에 응답(Status, ContentType, Body)과 보관 기한(ExpiresAt)을 저장
+	// 예약이 만료되어 다른 요청이 키를 가져갔으면 저장하지 않고 ErrLockLost를 반환
+	Complete(ctx context.Context, rec *Record) error
+	// Release는 token으로 예약한 처리 중인 key를 지워서 같은 키로 다시 처리할 수 있게 함
+	// 완료된 키나 다른 요청이 다시 예약한 키는 지우지 않음
+	Release(ctx context.Context, key, token string) error
+	// Purge는 now까지 만료된 기록을 지우고 지운 수를 반환
+	Purge(ctx context.Context, now time.Time) (int64, error)
+}
+
+// newToken은 예약 토큰을 생성
+func newToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ScopedKey는 scope(예: 요청 주체)별로 따로 보관하도록 Idempotency-Key를 저장소 키로 바꿈
+// 길이가 정해진 해시이므로 scope와 key가 길어도 Record.Key의 길이를 넘지 않음
+func ScopedKey(scope, key string) string {
+	h := sha256.New()
+	h.Write([]byte(scope))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(key))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// HashRequest는 같은 요청인지 비교하기 위한 해시를 계산 (메서드와 경로가 다르면 본문이 같아도 다른 요청)
+func HashRequest(method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(uri))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
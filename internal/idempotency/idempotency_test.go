@@ -0,0 +1,109 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore().(*memoryStore)
+	s.now = func() time.Time { return now }
+
+	token, existing, err := s.Reserve(ctx, &Record{Key: "k", RequestHash: "h1", ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+	assert.NotEmpty(t, token)
+
+	// 처리 중인 키는 처리 중인 기록을 반환
+	other, existing, err := s.Reserve(ctx, &Record{Key: "k", RequestHash: "h2", ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.Empty(t, other)
+	assert.False(t, existing.Completed)
+	assert.Equal(t, "h1", existing.RequestHash)
+
+	body := []byte(`{"status":201}`)
+	require.NoError(t, s.Complete(ctx, &Record{Key: "k", Token: token, Status: 201, ContentType: "application/json", Body: body, ExpiresAt: now.Add(time.Hour)}))
+	body[0] = 'x'
+
+	_, existing, err = s.Reserve(ctx, &Record{Key: "k", RequestHash: "h1", ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.True(t, existing.Completed)
+	assert.Equal(t, 201, existing.Status)
+	assert.Equal(t, `{"status":201}`, string(existing.Body))
+
+	// 완료된 키는 해제하지 않음
+	require.NoError(t, s.Release(ctx, "k", token))
+	_, existing, _ = s.Reserve(ctx, &Record{Key: "k", RequestHash: "h1", ExpiresAt: now.Add(time.Minute)})
+	assert.NotNil(t, existing)
+
+	// 보관 기한이 지나면 다시 예약할 수 있음
+	now = now.Add(time.Hour)
+	token, existing, err = s.Reserve(ctx, &Record{Key: "k", RequestHash: "h2", ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// 처리 중인 키는 해제하면 다시 예약할 수 있음
+	require.NoError(t, s.Release(ctx, "k", token))
+	_, existing, _ = s.Reserve(ctx, &Record{Key: "k", RequestHash: "h3", ExpiresAt: now.Add(time.Minute)})
+	assert.Nil(t, existing)
+}
+
+func TestMemoryStore_LockExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore().(*memoryStore)
+	s.now = func() time.Time { return now }
+
+	first, _, err := s.Reserve(ctx, &Record{Key: "k", RequestHash: "h", ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	// 첫 요청의 예약이 만료되어 다시 온 요청이 키를 가져감
+	now = now.Add(2 * time.Minute)
+	second, existing, err := s.Reserve(ctx, &Record{Key: "k", RequestHash: "h", ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	require.Nil(t, existing)
+	require.NotEqual(t, first, second)
+
+	// 늦게 끝난 첫 요청은 다시 온 요청의 예약을 지우거나 응답을 덮어쓰지 못함
+	require.NoError(t, s.Release(ctx, "k", first))
+	err = s.Complete(ctx, &Record{Key: "k", Token: first, Status: 201, ExpiresAt: now.Add(time.Hour)})
+	assert.ErrorIs(t, err, ErrLockLost)
+	_, existing, _ = s.Reserve(ctx, &Record{Key: "k", RequestHash: "h", ExpiresAt: now.Add(time.Minute)})
+	require.NotNil(t, existing)
+	assert.False(t, existing.Completed)
+
+	require.NoError(t, s.Complete(ctx, &Record{Key: "k", Token: second, Status: 200, ExpiresAt: now.Add(time.Hour)}))
+	_, existing, _ = s.Reserve(ctx, &Record{Key: "k", RequestHash: "h", ExpiresAt: now.Add(time.Minute)})
+	require.NotNil(t, existing)
+	assert.Equal(t, 200, existing.Status)
+}
+
+func TestMemoryStore_Purge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	s := NewMemoryStore()
+	s.Reserve(ctx, &Record{Key: "old", ExpiresAt: now.Add(time.Second)})
+	s.Reserve(ctx, &Record{Key: "new", ExpiresAt: now.Add(time.Hour)})
+
+	n, err := s.Purge(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	_, existing, _ := s.Reserve(ctx, &Record{Key: "new", ExpiresAt: now.Add(time.Hour)})
+	assert.NotNil(t, existing)
+}
+
+func TestHashRequest(t *testing.T) {
+	body := []byte(`{"name":"web"}`)
+	assert.Equal(t, HashRequest("POST", "/a", body), HashRequest("POST", "/a", body))
+	assert.NotEqual(t, HashRequest("POST", "/a", body), HashRequest("PATCH", "/a", body))
+	assert.NotEqual(t, HashRequest("POST", "/a", body), HashRequest("POST", "/b", body))
+	assert.NotEqual(t, HashRequest("POST", "/a", body), HashRequest("POST", "/a", []byte(`{"name":"db"}`)))
+}
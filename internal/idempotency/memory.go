@@ -0,0 +1,93 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+	now     func() time.Time
+}
+
+// NewMemoryStore는 메모리에 저장하는 Store를 생성 (테스트나 서버가 하나일 때 사용)
+// 만료된 기록은 같은 키로 다시 요청하거나 Purge를 호출할 때 지움
+func NewMemoryStore() Store {
+	return &memoryStore{
+		records: make(map[string]Record),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryStore) Reserve(ctx context.Context, rec *Record) (string, *Record, error) {
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if existing, ok := s.records[rec.Key]; ok && now.Before(existing.ExpiresAt) {
+		return "", copyRecord(existing), nil
+	}
+	r := *copyRecord(*rec)
+	r.Completed = false
+	r.Token = newToken()
+	r.CreatedAt = now
+	s.records[rec.Key] = r
+	return r.Token, nil, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, rec *Record) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.records[rec.Key]
+	if !ok || existing.Token != rec.Token || existing.Completed {
+		return ErrLockLost
+	}
+	existing.Completed = true
+	existing.Status = rec.Status
+	existing.ContentType = rec.ContentType
+	existing.Body = append([]byte(nil), rec.Body...)
+	existing.ExpiresAt = rec.ExpiresAt
+	s.records[rec.Key] = existing
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, key, token string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.records[key]; ok && existing.Token == token && !existing.Completed {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+func (s *memoryStore) Purge(ctx context.Context, now time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for key, r := range s.records {
+		if !now.Before(r.ExpiresAt) {
+			delete(s.records, key)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// copyRecord는 저장한 뒤 원본을 바꿔도 영향이 없도록 Body까지 복사
+func copyRecord(r Record) *Record {
+	r.Body = append([]byte(nil), r.Body...)
+	return &r
+}
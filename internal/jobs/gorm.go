@@ -0,0 +1,252 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore는 jobs 테이블을 사용하는 Store를 생성
+// 작업자는 SELECT ... FOR UPDATE SKIP LOCKED로 작업을 가져가므로 여러 서버가 같은 큐를 나눠서 처리할 수 있음
+// 테이블은 Migrate로 생성 (이 패키지가 database를 가져오므로 database.InitDB의 마이그레이션 목록에는 넣지 않음)
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+// Migrate는 jobs 테이블과 인덱스를 생성하거나 Job의 변경 사항을 반영
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&Job{}); err != nil {
+		return fmt.Errorf("jobs 테이블 마이그레이션 실패: %w", err)
+	}
+	return nil
+}
+
+// 같은 고유 키의 작업이 조회 직전에 끝난 경우 다시 저장을 시도할 횟수
+const enqueueAttempts = 3
+
+// idx_jobs_unique_key의 조건 (ON CONFLICT가 부분 인덱스를 찾을 수 있도록 인덱스와 같은 식을 씀)
+var activeCondition = clause.Expr{SQL: "state IN ('pending', 'running')"}
+
+func (s *gormStore) Enqueue(ctx context.Context, job *Job) (*Job, error) {
+	if err := prepare(job, time.Now()); err != nil {
+		return nil, err
+	}
+	db := s.db.WithContext(ctx)
+	if job.UniqueKey == nil {
+		if err := db.Create(job).Error; err != nil {
+			return nil, fmt.Errorf("작업 저장 실패: %w", err)
+		}
+		return job, nil
+	}
+
+	for range enqueueAttempts {
+		res := db.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "unique_key"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{activeCondition}},
+			DoNothing:   true,
+		}).Create(job)
+		if res.Error != nil {
+			return nil, fmt.Errorf("작업 저장 실패: %w", res.Error)
+		}
+		if res.RowsAffected == 1 {
+			return job, nil
+		}
+
+		var existing Job
+		err := db.Where("unique_key = ?", *job.UniqueKey).Where(activeCondition).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("작업 조회 실패: %w", err)
+		}
+		return &existing, ErrDuplicate
+	}
+	return nil, fmt.Errorf("작업 저장 실패: %s", *job.UniqueKey)
+}
+
+// 실행할 차례가 된 가장 오래된 작업 하나를 잠그고 실행 중으로 바꿈
+// lease가 지난 실행 중 작업은 실행 횟수가 남았을 때만 다시 가져감
+// (다른 작업자가 잠근 행은 건너뛰므로 작업자끼리 기다리지 않음)
+const claimQuery = `UPDATE jobs SET state = @running, attempts = attempts + 1, locked_until = @locked_until, updated_at = @now
+WHERE id = (
+	SELECT id FROM jobs
+	WHERE queue = @queue AND (
+		(state = @pending AND run_at <= @now) OR
+		(state = @running AND locked_until < @now AND attempts < max_attempts))
+	ORDER BY run_at, id
+	LIMIT 1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// lease가 지났지만 실행 횟수를 모두 쓴 작업을 dead로 옮김
+const expireQuery = `UPDATE jobs SET state = @dead, locked_until = NULL, last_error = @cause, finished_at = @now, updated_at = @now
+WHERE queue = @queue AND state = @running AND locked_until < @now AND attempts >= max_attempts`
+
+func (s *gormStore) Claim(ctx context.Context, queue string, now time.Time, lease time.Duration) (*Job, error) {
+	db := s.db.WithContext(ctx)
+	err := db.Exec(expireQuery, map[string]interface{}{
+		"queue":   queue,
+		"running": StateRunning,
+		"dead":    StateDead,
+		"cause":   errLeaseExpired,
+		"now":     now,
+	}).Error
+	if err != nil {
+		return nil, fmt.Errorf("실행 횟수를 다 쓴 작업 정리 실패: %w", err)
+	}
+
+	var jobs []*Job
+	err = db.Raw(claimQuery, map[string]interface{}{
+		"queue":        queue,
+		"pending":      StatePending,
+		"running":      StateRunning,
+		"now":          now,
+		"locked_until": now.Add(lease),
+	}).Scan(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("작업 가져오기 실패: %w", err)
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return jobs[0], nil
+}
+
+// finish는 job을 가져간 작업자가 아직 실행 권한을 가지고 있을 때만 values로 갱신
+func (s *gormStore) finish(ctx context.Context, job *Job, values map[string]interface{}) error {
+	res := s.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? AND state = ? AND attempts = ?", job.ID, StateRunning, job.Attempts).
+		Updates(values)
+	if res.Error != nil {
+		return fmt.Errorf("작업 결과 저장 실패: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (s *gormStore) Complete(ctx context.Context, job *Job, now time.Time) error {
+	return s.finish(ctx, job, map[string]interface{}{
+		"state":        StateSucceeded,
+		"locked_until": nil,
+		"finished_at":  now,
+		"updated_at":   now,
+	})
+}
+
+func (s *gormStore) Fail(ctx context.Context, job *Job, cause string, runAt *time.Time, now time.Time) error {
+	values := map[string]interface{}{
+		"last_error":   cause,
+		"locked_until": nil,
+		"updated_at":   now,
+	}
+	if runAt == nil {
+		values["state"] = StateDead
+		values["finished_at"] = now
+	} else {
+		values["state"] = StatePending
+		values["run_at"] = *runAt
+	}
+	return s.finish(ctx, job, values)
+}
+
+func (s *gormStore) Get(ctx context.Context, id uint) (*Job, error) {
+	var job Job
+	err := s.db.WithContext(ctx).First(&job, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("작업 조회 실패: %w", err)
+	}
+	return &job, nil
+}
+
+func (s *gormStore) List(ctx context.Context, opts ListOptions) ([]*Job, int64, error) {
+	db := s.db.WithContext(ctx).Model(&Job{})
+	if opts.Queue != "" {
+		db = db.Where("queue = ?", opts.Queue)
+	}
+	if opts.State != "" {
+		db = db.Where("state = ?", opts.State)
+	}
+	if opts.Type != "" {
+		db = db.Where("type = ?", opts.Type)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("작업 개수 조회 실패: %w", err)
+	}
+	jobs := []*Job{}
+	q := db.Order("id DESC").Offset(opts.Offset())
+	if opts.Size > 0 {
+		q = q.Limit(opts.Size)
+	}
+	if err := q.Find(&jobs).Error; err != nil {
+		return nil, 0, fmt.Errorf("작업 목록 조회 실패: %w", err)
+	}
+	return jobs, total, nil
+}
+
+// transition은 from 상태인 작업만 values로 갱신하고 갱신한 작업을 반환
+// 갱신하지 못했으면 작업이 없는지(ErrNotFound) 상태가 다른지(ErrInvalidState) 구분
+func (s *gormStore) transition(ctx context.Context, id uint, from []State, values map[string]interface{}) (*Job, error) {
+	res := s.db.WithContext(ctx).Model(&Job{}).Where("id = ? AND state IN ?", id, from).Updates(values)
+	if res.Error != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(res.Error, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return nil, ErrDuplicate
+		}
+		return nil, fmt.Errorf("작업 상태 변경 실패: %w", res.Error)
+	}
+	job, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrInvalidState
+	}
+	return job, nil
+}
+
+func (s *gormStore) Retry(ctx context.Context, id uint, now time.Time) (*Job, error) {
+	return s.transition(ctx, id, []State{StateDead, StateCanceled}, map[string]interface{}{
+		"state":       StatePending,
+		"attempts":    0,
+		"run_at":      now,
+		"finished_at": nil,
+		"updated_at":  now,
+	})
+}
+
+func (s *gormStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	res := s.db.WithContext(ctx).
+		Where("state IN ? AND finished_at < ?", []State{StateSucceeded, StateDead, StateCanceled}, before).
+		Delete(&Job{})
+	if res.Error != nil {
+		return 0, fmt.Errorf("끝난 작업 삭제 실패: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
+
+func (s *gormStore) Cancel(ctx context.Context, id uint, now time.Time) (*Job, error) {
+	return s.transition(ctx, id, []State{StatePending, StateRunning}, map[string]interface{}{
+		"state":        StateCanceled,
+		"locked_until": nil,
+		"finished_at":  now,
+		"updated_at":   now,
+	})
+}
@@ -0,0 +1,273 @@
+// Package jobs는 요청 처리와 별도로 실행할 작업(정리, 내보내기, 웹훅 전송, 재색인 등)을 저장하는 큐와 작업자를 제공
+//
+// 작업은 큐(Queue)에 넣고, Runner가 큐마다 정한 수의 작업자로 꺼내서 종류(Type)별 Handler로 실행함
+// 실패한 작업은 지수 백오프로 다시 실행하고, MaxAttempts번 실패하면 dead 상태로 옮겨서 관리자가 확인하게 함
+// DB(NewGormStore)와 메모리(NewMemoryStore) 저장소를 지원
+package jobs
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// State는 작업 상태
+type State string
+
+const (
+	StatePending   State = "pending"   // 실행 대기 (RunAt 이후 실행, 재시도 대기 포함)
+	StateRunning   State = "running"   // 작업자가 실행 중
+	StateSucceeded State = "succeeded" // 성공
+	StateDead      State = "dead"      // 재시도 횟수를 다 쓰거나 다시 시도해도 소용없는 에러로 실패
+	StateCanceled  State = "canceled"  // 관리자가 취소
+)
+
+// Valid는 알려진 상태인지 확인
+func (s State) Valid() bool {
+	switch s {
+	case StatePending, StateRunning, StateSucceeded, StateDead, StateCanceled:
+		return true
+	}
+	return false
+}
+
+// active는 UniqueKey가 겹치면 안 되는 상태인지 확인 (대기 중이거나 실행 중)
+func (s State) active() bool {
+	return s == StatePending || s == StateRunning
+}
+
+const (
+	// DefaultQueue는 큐를 지정하지 않은 작업의 큐
+	DefaultQueue = "default"
+	// DefaultMaxAttempts는 MaxAttempts를 지정하지 않은 작업의 최대 실행 횟수
+	DefaultMaxAttempts = 5
+)
+
+var (
+	// ErrNotFound는 작업이 없을 때 반환 (errors.Is로 확인)
+	ErrNotFound = errors.New("작업을 찾을 수 없습니다")
+	// ErrDuplicate는 같은 UniqueKey의 작업이 이미 대기 중이거나 실행 중일 때 반환
+	ErrDuplicate = errors.New("같은 고유 키의 작업이 이미 있습니다")
+	// ErrInvalidState는 현재 상태에서 할 수 없는 작업(예: 성공한 작업 취소)일 때 반환
+	ErrInvalidState = errors.New("현재 상태에서 할 수 없는 요청입니다")
+	// ErrLeaseLost는 작업자가 실행을 마쳤을 때 작업이 이미 취소됐거나 다른 작업자가 가져간 경우 반환
+	ErrLeaseLost = errors.New("작업 실행 권한을 잃었습니다")
+)
+
+// errLeaseExpired는 lease 안에 끝나지 않은 작업을 더 실행하지 않고 dead로 옮길 때 남기는 에러
+// (작업자가 멈추거나 서버가 종료되어 매번 결과를 저장하지 못한 작업)
+const errLeaseExpired = "실행 시간(lease) 안에 끝나지 않았고 최대 실행 횟수에 도달했습니다"
+
+// Job은 큐에 넣은 작업 하나
+type Job struct {
+	ID    uint   `gorm:"primaryKey" json:"id"`
+	Queue string `gorm:"size:64;not null;index:idx_jobs_ready,priority:1" json:"queue"`
+	Type  string `gorm:"size:128;not null" json:"type"`
+	// Payload는 Handler에 넘길 JSON 인자
+	Payload Payload `gorm:"type:jsonb;not null;default:'{}'" json:"payload"`
+	State   State   `gorm:"size:16;not null;index:idx_jobs_ready,priority:2" json:"state"`
+	// UniqueKey가 같은 작업은 대기 중이거나 실행 중인 것이 하나만 있을 수 있음 (끝난 작업은 상관없음)
+	UniqueKey   *string `gorm:"size:255;uniqueIndex:idx_jobs_unique_key,where:state IN ('pending'\\,'running')" json:"unique_key,omitempty"`
+	Attempts    int     `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts int     `gorm:"not null" json:"max_attempts"`
+	// RunAt 이후에 실행 (재시도는 다음 실행 시각으로 바뀜)
+	RunAt time.Time `gorm:"not null;index:idx_jobs_ready,priority:3" json:"run_at"`
+	// 실행 중인 작업자가 이 시각까지 끝내지 못하면 다른 작업자가 다시 가져감
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	LastError   string     `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// ListOptions는 작업 목록 조회 조건 (빈 값은 조건 없음)
+type ListOptions struct {
+	Queue string
+	State State
+	Type  string
+	Page  int // 1부터 시작 (0이면 전체)
+	Size  int
+}
+
+// Offset은 Page와 Size로 건너뛸 작업 수를 계산
+func (o ListOptions) Offset() int {
+	if o.Page <= 1 || o.Size <= 0 {
+		return 0
+	}
+	return (o.Page - 1) * o.Size
+}
+
+// Store는 작업 저장소
+// 여러 서버가 같은 저장소를 쓰면 작업 하나는 한 작업자만 가져감
+type Store interface {
+	// Enqueue는 job을 대기 상태로 저장하고 ID와 CreatedAt을 채운 job을 반환
+	// 같은 UniqueKey의 작업이 대기 중이거나 실행 중이면 저장하지 않고 그 작업과 ErrDuplicate를 반환
+	Enqueue(ctx context.Context, job *Job) (*Job, error)
+	// Claim은 queue에서 now까지 실행할 차례가 된 가장 오래된 작업을 실행 중으로 바꾸고 반환 (없으면 nil)
+	// Attempts를 1 늘리고 lease 동안 다른 작업자가 가져가지 못하게 함 (lease가 지나도 끝나지 않으면 다시 가져감)
+	// lease가 지난 작업이 이미 MaxAttempts번 실행됐으면 다시 가져가지 않고 dead 상태로 옮김 (errLeaseExpired)
+	Claim(ctx context.Context, queue string, now time.Time, lease time.Duration) (*Job, error)
+	// Complete는 Claim으로 가져온 job을 성공으로 표시
+	// 그 사이 취소됐거나 다른 작업자가 다시 가져갔으면 ErrLeaseLost
+	Complete(ctx context.Context, job *Job, now time.Time) error
+	// Fail은 Claim으로 가져온 job을 실패로 표시
+	// runAt이 nil이면 dead 상태로 옮기고, 아니면 runAt에 다시 실행하도록 대기 상태로 되돌림
+	Fail(ctx context.Context, job *Job, cause string, runAt *time.Time, now time.Time) error
+
+	// Get은 ID로 작업을 조회
+	Get(ctx context.Context, id uint) (*Job, error)
+	// List는 ID 역순(최근 작업부터)으로 한 페이지를 조회하고 전체 개수를 함께 반환
+	List(ctx context.Context, opts ListOptions) ([]*Job, int64, error)
+	// Retry는 dead 또는 canceled 작업을 실행 횟수를 0으로 되돌려 now에 다시 실행하도록 대기 상태로 바꿈
+	Retry(ctx context.Context, id uint, now time.Time) (*Job, error)
+	// Cancel은 대기 중이거나 실행 중인 작업을 취소 (실행 중인 작업은 끝나도 결과를 저장하지 않음)
+	Cancel(ctx context.Context, id uint, now time.Time) (*Job, error)
+	// Purge는 before 전에 끝난(succeeded, dead, canceled) 작업을 지우고 지운 수를 반환
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EnqueueOption은 Enqueue로 넣을 작업의 설정
+type EnqueueOption func(*Job)
+
+// WithQueue는 작업을 넣을 큐를 지정 (기본 DefaultQueue)
+func WithQueue(queue string) EnqueueOption {
+	return func(j *Job) {
+		j.Queue = queue
+	}
+}
+
+// WithRunAt은 t 이후에 실행하도록 예약
+func WithRunAt(t time.Time) EnqueueOption {
+	return func(j *Job) {
+		j.RunAt = t
+	}
+}
+
+// WithDelay는 지금부터 d 뒤에 실행하도록 예약
+func WithDelay(d time.Duration) EnqueueOption {
+	return func(j *Job) {
+		j.RunAt = time.Now().Add(d)
+	}
+}
+
+// WithUniqueKey는 같은 key의 작업이 대기 중이거나 실행 중이면 새로 넣지 않도록 함
+func WithUniqueKey(key string) EnqueueOption {
+	return func(j *Job) {
+		j.UniqueKey = &key
+	}
+}
+
+// WithMaxAttempts는 dead 상태로 옮기기 전까지 실행할 최대 횟수를 지정 (기본 DefaultMaxAttempts)
+func WithMaxAttempts(n int) EnqueueOption {
+	return func(j *Job) {
+		j.MaxAttempts = n
+	}
+}
+
+// Enqueue는 payload를 JSON으로 인코딩해서 jobType 작업을 store에 넣음
+// 같은 고유 키의 작업이 이미 있으면 그 작업과 ErrDuplicate를 반환
+func Enqueue(ctx context.Context, store Store, jobType string, payload any, opts ...EnqueueOption) (*Job, error) {
+	p, err := NewPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{Type: jobType, Payload: p}
+	for _, opt := range opts {
+		opt(job)
+	}
+	return store.Enqueue(ctx, job)
+}
+
+// Payload는 jsonb 열에 저장하는 작업 인자 (JSON으로 응답할 때도 그대로 씀)
+type Payload json.RawMessage
+
+// NewPayload는 v를 JSON으로 인코딩한 Payload를 반환
+func NewPayload(v any) (Payload, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("작업 인자 인코딩 실패: %v", err)
+	}
+	return Payload(b), nil
+}
+
+func (p Payload) MarshalJSON() ([]byte, error) {
+	if len(p) == 0 {
+		return []byte("{}"), nil
+	}
+	return p, nil
+}
+
+func (p *Payload) UnmarshalJSON(b []byte) error {
+	*p = append((*p)[:0], b...)
+	return nil
+}
+
+// Value는 JSON 문자열로 저장
+func (p Payload) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return "{}", nil
+	}
+	return string(p), nil
+}
+
+// Scan은 DB의 JSON 값을 읽음
+func (p *Payload) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*p = nil
+	case []byte:
+		*p = append(Payload(nil), v...)
+	case string:
+		*p = Payload(v)
+	default:
+		return fmt.Errorf("작업 인자 열을 읽을 수 없습니다: %T", src)
+	}
+	return nil
+}
+
+// prepare는 저장할 작업의 빈 값을 기본값으로 채우고 대기 상태로 초기화
+func prepare(job *Job, now time.Time) error {
+	if job.Type == "" {
+		return errors.New("작업 종류를 지정해야 합니다")
+	}
+	if job.Queue == "" {
+		job.Queue = DefaultQueue
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = DefaultMaxAttempts
+	}
+	if job.RunAt.IsZero() {
+		job.RunAt = now
+	}
+	if len(job.Payload) == 0 {
+		job.Payload = Payload("{}")
+	}
+	job.State = StatePending
+	job.Attempts = 0
+	job.LockedUntil = nil
+	job.FinishedAt = nil
+	job.LastError = ""
+	return nil
+}
+
+// clone은 저장소 밖으로 내보낼 복사본을 만듦 (포인터 필드와 Payload를 공유하지 않음)
+func (j *Job) clone() *Job {
+	c := *j
+	c.Payload = append(Payload(nil), j.Payload...)
+	if j.UniqueKey != nil {
+		key := *j.UniqueKey
+		c.UniqueKey = &key
+	}
+	if j.LockedUntil != nil {
+		t := *j.LockedUntil
+		c.LockedUntil = &t
+	}
+	if j.FinishedAt != nil {
+		t := *j.FinishedAt
+		c.FinishedAt = &t
+	}
+	return &c
+}
@@ -0,0 +1,322 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore는 시각을 now로 고정한 메모리 저장소를 생성
+func newTestStore(now *time.Time) *memoryStore {
+	s := NewMemoryStore().(*memoryStore)
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestMemoryStore_ClaimOrder(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newTestStore(&now)
+
+	later, err := Enqueue(ctx, s, "t", nil, WithRunAt(now.Add(time.Hour)))
+	require.NoError(t, err)
+	first, _ := Enqueue(ctx, s, "t", map[string]int{"n": 1})
+	second, _ := Enqueue(ctx, s, "t", nil)
+	other, _ := Enqueue(ctx, s, "t", nil, WithQueue("bulk"))
+	assert.Equal(t, DefaultQueue, first.Queue)
+	assert.Equal(t, DefaultMaxAttempts, first.MaxAttempts)
+	assert.Equal(t, StatePending, first.State)
+
+	// 큐마다 오래된 작업부터, 예약 시각이 지나지 않은 작업은 가져가지 않음
+	job, err := s.Claim(ctx, DefaultQueue, now, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, s.Complete(ctx, job, now))
+	assert.Equal(t, first.ID, job.ID)
+	assert.Equal(t, StateRunning, job.State)
+	assert.Equal(t, 1, job.Attempts)
+	assert.JSONEq(t, `{"n":1}`, string(job.Payload))
+	job, _ = s.Claim(ctx, DefaultQueue, now, time.Minute)
+	require.NoError(t, s.Complete(ctx, job, now))
+	assert.Equal(t, second.ID, job.ID)
+	job, _ = s.Claim(ctx, DefaultQueue, now, time.Minute)
+	assert.Nil(t, job)
+	job, _ = s.Claim(ctx, "bulk", now, time.Minute)
+	assert.Equal(t, other.ID, job.ID)
+
+	job, _ = s.Claim(ctx, DefaultQueue, now.Add(time.Hour), time.Minute)
+	assert.Equal(t, later.ID, job.ID)
+
+	_, err = s.Enqueue(ctx, &Job{})
+	assert.Error(t, err)
+}
+
+func TestMemoryStore_Lease(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newTestStore(&now)
+	Enqueue(ctx, s, "t", nil)
+
+	stale, _ := s.Claim(ctx, DefaultQueue, now, time.Minute)
+	job, _ := s.Claim(ctx, DefaultQueue, now.Add(30*time.Second), time.Minute)
+	assert.Nil(t, job)
+
+	// lease가 지나면 다른 작업자가 다시 가져가고, 처음 작업자는 결과를 저장하지 못함
+	job, _ = s.Claim(ctx, DefaultQueue, now.Add(2*time.Minute), time.Minute)
+	require.NotNil(t, job)
+	assert.Equal(t, 2, job.Attempts)
+	assert.ErrorIs(t, s.Complete(ctx, stale, now), ErrLeaseLost)
+	require.NoError(t, s.Complete(ctx, job, now))
+
+	got, _ := s.Get(ctx, job.ID)
+	assert.Equal(t, StateSucceeded, got.State)
+	assert.NotNil(t, got.FinishedAt)
+	assert.Nil(t, got.LockedUntil)
+}
+
+func TestMemoryStore_LeaseExhausted(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newTestStore(&now)
+	queued, err := Enqueue(ctx, s, "t", nil, WithMaxAttempts(2))
+	require.NoError(t, err)
+
+	// 작업자가 매번 lease 안에 끝내지 못해도 MaxAttempts번까지만 다시 가져감
+	for i := range 2 {
+		job, err := s.Claim(ctx, DefaultQueue, now.Add(time.Duration(i)*2*time.Minute), time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, job)
+		assert.Equal(t, i+1, job.Attempts)
+	}
+	job, err := s.Claim(ctx, DefaultQueue, now.Add(10*time.Minute), time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, job)
+
+	got, _ := s.Get(ctx, queued.ID)
+	assert.Equal(t, StateDead, got.State)
+	assert.Equal(t, errLeaseExpired, got.LastError)
+	assert.Nil(t, got.LockedUntil)
+	assert.NotNil(t, got.FinishedAt)
+}
+
+func TestMemoryStore_UniqueKey(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newTestStore(&now)
+
+	first, err := Enqueue(ctx, s, "t", nil, WithUniqueKey("k"))
+	require.NoError(t, err)
+	existing, err := Enqueue(ctx, s, "t", nil, WithUniqueKey("k"))
+	assert.ErrorIs(t, err, ErrDuplicate)
+	assert.Equal(t, first.ID, existing.ID)
+
+	// 실행 중에도 중복, 끝나면 같은 키로 다시 넣을 수 있음
+	job, _ := s.Claim(ctx, DefaultQueue, now, time.Minute)
+	_, err = Enqueue(ctx, s, "t", nil, WithUniqueKey("k"))
+	assert.ErrorIs(t, err, ErrDuplicate)
+	require.NoError(t, s.Fail(ctx, job, "실패", nil, now))
+	second, err := Enqueue(ctx, s, "t", nil, WithUniqueKey("k"))
+	require.NoError(t, err)
+
+	// 같은 키의 작업이 대기 중이면 dead 작업을 다시 실행할 수 없음
+	_, err = s.Retry(ctx, first.ID, now)
+	assert.ErrorIs(t, err, ErrDuplicate)
+	s.Cancel(ctx, second.ID, now)
+	retried, err := s.Retry(ctx, first.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, StatePending, retried.State)
+	assert.Zero(t, retried.Attempts)
+	assert.Nil(t, retried.FinishedAt)
+}
+
+func TestMemoryStore_AdminTransitions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newTestStore(&now)
+	job, _ := Enqueue(ctx, s, "t", nil)
+
+	_, err := s.Retry(ctx, job.ID, now)
+	assert.ErrorIs(t, err, ErrInvalidState)
+
+	claimed, _ := s.Claim(ctx, DefaultQueue, now, time.Minute)
+	canceled, err := s.Cancel(ctx, job.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, StateCanceled, canceled.State)
+	// 취소된 작업은 실행이 끝나도 결과를 저장하지 않음
+	assert.ErrorIs(t, s.Complete(ctx, claimed, now), ErrLeaseLost)
+	_, err = s.Cancel(ctx, job.ID, now)
+	assert.ErrorIs(t, err, ErrInvalidState)
+
+	// 끝난 작업만 지움
+	Enqueue(ctx, s, "t", nil)
+	n, err := s.Purge(ctx, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	_, total, _ := s.List(ctx, ListOptions{})
+	assert.Equal(t, int64(1), total)
+
+	_, err = s.Get(ctx, 99)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = s.Cancel(ctx, 99, now)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = s.Retry(ctx, 99, now)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemoryStore_List(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newTestStore(&now)
+	for _, typ := range []string{"a", "b", "a", "a"} {
+		Enqueue(ctx, s, typ, nil)
+	}
+	Enqueue(ctx, s, "a", nil, WithQueue("bulk"))
+
+	tests := []struct {
+		name  string
+		opts  ListOptions
+		ids   []uint
+		total int64
+	}{
+		{"전체", ListOptions{}, []uint{5, 4, 3, 2, 1}, 5},
+		{"페이지", ListOptions{Page: 2, Size: 2}, []uint{3, 2}, 5},
+		{"범위_밖", ListOptions{Page: 9, Size: 2}, []uint{}, 5},
+		{"종류", ListOptions{Type: "a", Queue: DefaultQueue}, []uint{4, 3, 1}, 3},
+		{"상태", ListOptions{State: StateRunning}, []uint{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, total, err := s.List(ctx, tt.opts)
+			require.NoError(t, err)
+			ids := []uint{}
+			for _, j := range list {
+				ids = append(ids, j.ID)
+			}
+			assert.Equal(t, tt.ids, ids)
+			assert.Equal(t, tt.total, total)
+		})
+	}
+}
+
+// newTestRunner는 시각을 now로 고정한 Runner를 생성
+func newTestRunner(s Store, now *time.Time, opts ...Option) *Runner {
+	r := NewRunner(s, append([]Option{WithRetryBackoff(time.Minute, time.Hour)}, opts...)...)
+	r.now = func() time.Time { return *now }
+	return r
+}
+
+func TestRunner_RetryAndDead(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newTestStore(&now)
+	r := newTestRunner(s, &now)
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+	var got []string
+	Register(r, "greet", func(ctx context.Context, p payload) error {
+		got = append(got, p.Name)
+		return errors.New("일시적인 실패")
+	})
+	job, _ := Enqueue(ctx, s, "greet", payload{Name: "web"}, WithMaxAttempts(3))
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		ran, err := r.runNext(ctx, DefaultQueue)
+		require.NoError(t, err)
+		require.True(t, ran)
+
+		stored, _ := s.Get(ctx, job.ID)
+		assert.Equal(t, attempt, stored.Attempts)
+		assert.Equal(t, "일시적인 실패", stored.LastError)
+		if attempt < 3 {
+			// 백오프 전에는 다시 가져가지 않음
+			assert.Equal(t, StatePending, stored.State)
+			assert.True(t, stored.RunAt.After(now))
+			ran, _ = r.runNext(ctx, DefaultQueue)
+			assert.False(t, ran)
+			now = stored.RunAt
+		} else {
+			assert.Equal(t, StateDead, stored.State)
+		}
+	}
+	assert.Equal(t, []string{"web", "web", "web"}, got)
+}
+
+func TestRunner_Permanent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newTestStore(&now)
+	r := newTestRunner(s, &now)
+
+	r.Handle("fail", func(ctx context.Context, job *Job) error {
+		return Permanent(errors.New("잘못된 인자"))
+	})
+	r.Handle("panic", func(ctx context.Context, job *Job) error {
+		panic("버그")
+	})
+	Register(r, "typed", func(ctx context.Context, p struct{ N int }) error { return nil })
+
+	tests := []struct {
+		name    string
+		typ     string
+		payload any
+		state   State
+		errText string
+	}{
+		{"Permanent", "fail", nil, StateDead, "잘못된 인자"},
+		{"등록되지_않은_종류", "unknown", nil, StateDead, "등록되지 않은 작업 종류"},
+		{"디코딩_실패", "typed", "문자열", StateDead, "작업 인자 디코딩 실패"},
+		{"패닉은_재시도", "panic", nil, StatePending, "패닉"},
+		{"성공", "typed", map[string]int{"N": 1}, StateSucceeded, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := Enqueue(ctx, s, tt.typ, tt.payload)
+			require.NoError(t, err)
+			ran, err := r.runNext(ctx, DefaultQueue)
+			require.NoError(t, err)
+			require.True(t, ran)
+
+			stored, _ := s.Get(ctx, job.ID)
+			assert.Equal(t, tt.state, stored.State)
+			assert.Contains(t, stored.LastError, tt.errText)
+		})
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	s := NewMemoryStore()
+	r := NewRunner(s, WithConcurrency(DefaultQueue, 3), WithConcurrency("bulk", 1), WithPollInterval(10*time.Millisecond))
+
+	var done atomic.Int32
+	r.Handle("t", func(ctx context.Context, job *Job) error {
+		done.Add(1)
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(finished)
+	}()
+
+	for range 5 {
+		Enqueue(ctx, s, "t", nil)
+	}
+	Enqueue(ctx, s, "t", nil, WithQueue("bulk"))
+	// 작업자가 없는 큐의 작업은 실행하지 않음
+	Enqueue(ctx, s, "t", nil, WithQueue("other"))
+
+	assert.Eventually(t, func() bool { return done.Load() == 6 }, time.Second, 5*time.Millisecond)
+	cancel()
+	<-finished
+
+	pending, _, _ := s.List(context.Background(), ListOptions{State: StatePending})
+	require.Len(t, pending, 1)
+	assert.Equal(t, "other", pending[0].Queue)
+}
@@ -0,0 +1,258 @@
+package jobs
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryStore struct {
+	mu     sync.Mutex
+	jobs   map[uint]*Job
+	nextID uint
+	now    func() time.Time
+}
+
+// NewMemoryStore는 메모리에 저장하는 Store를 생성 (테스트나 서버가 하나일 때 사용)
+// 끝난 작업은 Purge를 호출해야 지워짐
+func NewMemoryStore() Store {
+	return &memoryStore{
+		jobs:   make(map[uint]*Job),
+		nextID: 1,
+		now:    time.Now,
+	}
+}
+
+func (s *memoryStore) Enqueue(ctx context.Context, job *Job) (*Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if err := prepare(job, now); err != nil {
+		return nil, err
+	}
+	if existing := s.activeWithKey(job.UniqueKey, 0); existing != nil {
+		return existing.clone(), ErrDuplicate
+	}
+	job.ID = s.nextID
+	s.nextID++
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	s.jobs[job.ID] = job.clone()
+	return job, nil
+}
+
+// activeWithKey는 key가 같은 대기 중이거나 실행 중인 작업을 찾음 (except는 제외할 ID)
+func (s *memoryStore) activeWithKey(key *string, except uint) *Job {
+	if key == nil {
+		return nil
+	}
+	for _, j := range s.jobs {
+		if j.ID != except && j.State.active() && j.UniqueKey != nil && *j.UniqueKey == *key {
+			return j
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) Claim(ctx context.Context, queue string, now time.Time, lease time.Duration) (*Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next *Job
+	for _, j := range s.jobs {
+		if j.Queue != queue {
+			continue
+		}
+		if expired(j, now) && j.Attempts >= j.MaxAttempts {
+			j.State = StateDead
+			j.LockedUntil = nil
+			j.LastError = errLeaseExpired
+			j.FinishedAt = &now
+			j.UpdatedAt = now
+			continue
+		}
+		if !ready(j, now) {
+			continue
+		}
+		if next == nil || j.RunAt.Before(next.RunAt) || (j.RunAt.Equal(next.RunAt) && j.ID < next.ID) {
+			next = j
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+	lockedUntil := now.Add(lease)
+	next.State = StateRunning
+	next.Attempts++
+	next.LockedUntil = &lockedUntil
+	next.UpdatedAt = now
+	return next.clone(), nil
+}
+
+// ready는 실행할 차례가 된 대기 작업이거나, 작업자가 lease 안에 끝내지 못했고 실행 횟수가 남은 작업인지 확인
+func ready(j *Job, now time.Time) bool {
+	switch j.State {
+	case StatePending:
+		return !j.RunAt.After(now)
+	case StateRunning:
+		return expired(j, now) && j.Attempts < j.MaxAttempts
+	}
+	return false
+}
+
+// expired는 실행 중인 작업의 lease가 지났는지 확인
+func expired(j *Job, now time.Time) bool {
+	return j.State == StateRunning && j.LockedUntil != nil && j.LockedUntil.Before(now)
+}
+
+// claimed는 job을 가져간 작업자가 아직 실행 권한을 가지고 있으면 저장된 작업을 반환
+func (s *memoryStore) claimed(job *Job) (*Job, error) {
+	j, ok := s.jobs[job.ID]
+	if !ok || j.State != StateRunning || j.Attempts != job.Attempts {
+		return nil, ErrLeaseLost
+	}
+	return j, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, job *Job, now time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, err := s.claimed(job)
+	if err != nil {
+		return err
+	}
+	j.State = StateSucceeded
+	j.LockedUntil = nil
+	j.FinishedAt = &now
+	j.UpdatedAt = now
+	return nil
+}
+
+func (s *memoryStore) Fail(ctx context.Context, job *Job, cause string, runAt *time.Time, now time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, err := s.claimed(job)
+	if err != nil {
+		return err
+	}
+	j.LastError = cause
+	j.LockedUntil = nil
+	j.UpdatedAt = now
+	if runAt == nil {
+		j.State = StateDead
+		j.FinishedAt = &now
+	} else {
+		j.State = StatePending
+		j.RunAt = *runAt
+	}
+	return nil
+}
+
+func (s *memoryStore) Get(ctx context.Context, id uint) (*Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return j.clone(), nil
+}
+
+func (s *memoryStore) List(ctx context.Context, opts ListOptions) ([]*Job, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	matched := []*Job{}
+	for _, j := range s.jobs {
+		if (opts.Queue == "" || j.Queue == opts.Queue) && (opts.State == "" || j.State == opts.State) &&
+			(opts.Type == "" || j.Type == opts.Type) {
+			matched = append(matched, j.clone())
+		}
+	}
+	sort.Slice(matched, func(a, b int) bool { return matched[a].ID > matched[b].ID })
+
+	total := int64(len(matched))
+	start := min(opts.Offset(), len(matched))
+	end := len(matched)
+	if opts.Size > 0 {
+		end = min(start+opts.Size, end)
+	}
+	return matched[start:end], total, nil
+}
+
+func (s *memoryStore) Retry(ctx context.Context, id uint, now time.Time) (*Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if j.State != StateDead && j.State != StateCanceled {
+		return nil, ErrInvalidState
+	}
+	if s.activeWithKey(j.UniqueKey, j.ID) != nil {
+		return nil, ErrDuplicate
+	}
+	j.State = StatePending
+	j.Attempts = 0
+	j.RunAt = now
+	j.FinishedAt = nil
+	j.UpdatedAt = now
+	return j.clone(), nil
+}
+
+func (s *memoryStore) Cancel(ctx context.Context, id uint, now time.Time) (*Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if !j.State.active() {
+		return nil, ErrInvalidState
+	}
+	j.State = StateCanceled
+	j.LockedUntil = nil
+	j.FinishedAt = &now
+	j.UpdatedAt = now
+	return j.clone(), nil
+}
+
+func (s *memoryStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for id, j := range s.jobs {
+		if j.FinishedAt != nil && j.FinishedAt.Before(before) {
+			delete(s.jobs, id)
+			n++
+		}
+	}
+	return n, nil
+}
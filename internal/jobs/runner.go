@@ -0,0 +1,230 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go_project/internal/database"
+)
+
+// Handler는 작업 하나를 실행
+// 에러를 반환하면 MaxAttempts까지 백오프 후 다시 실행하고, Permanent로 감싼 에러는 바로 dead 상태로 옮김
+type Handler func(ctx context.Context, job *Job) error
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent는 다시 실행해도 성공할 수 없는 에러(잘못된 인자 등)로 표시해서 재시도하지 않게 함
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Runner 기본 설정
+const (
+	DefaultPollInterval = time.Second
+	DefaultLease        = 5 * time.Minute
+	DefaultRetryBase    = 10 * time.Second
+	DefaultRetryLimit   = time.Hour
+)
+
+// Option은 Runner 설정
+type Option func(*Runner)
+
+// WithConcurrency는 queue를 n개의 작업자로 처리 (지정한 큐만 처리하며, 하나도 지정하지 않으면 DefaultQueue를 작업자 하나로 처리)
+func WithConcurrency(queue string, n int) Option {
+	return func(r *Runner) {
+		r.queues[queue] = n
+	}
+}
+
+// WithPollInterval은 큐가 비었을 때 다시 확인하기까지 기다리는 시간을 지정
+func WithPollInterval(d time.Duration) Option {
+	return func(r *Runner) {
+		r.poll = d
+	}
+}
+
+// WithLease는 작업 하나의 실행 제한 시간을 지정
+// 작업자가 이 시간 안에 끝내지 못하고 죽으면 다른 작업자가 작업을 다시 가져감
+func WithLease(d time.Duration) Option {
+	return func(r *Runner) {
+		r.lease = d
+	}
+}
+
+// WithRetryBackoff는 실패한 작업을 다시 실행하기까지 기다리는 시간을 지정 (base부터 두 배씩 늘려 limit까지)
+func WithRetryBackoff(base, limit time.Duration) Option {
+	return func(r *Runner) {
+		r.retryBase = base
+		r.retryLimit = limit
+	}
+}
+
+// Runner는 큐에서 작업을 가져와 종류별 Handler로 실행하는 작업자 묶음
+type Runner struct {
+	store      Store
+	queues     map[string]int
+	poll       time.Duration
+	lease      time.Duration
+	retryBase  time.Duration
+	retryLimit time.Duration
+	now        func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewRunner는 store의 작업을 실행하는 Runner를 생성 (Handle로 작업 종류를 등록한 뒤 Run으로 시작)
+func NewRunner(store Store, opts ...Option) *Runner {
+	r := &Runner{
+		store:      store,
+		queues:     make(map[string]int),
+		poll:       DefaultPollInterval,
+		lease:      DefaultLease,
+		retryBase:  DefaultRetryBase,
+		retryLimit: DefaultRetryLimit,
+		now:        time.Now,
+		handlers:   make(map[string]Handler),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if len(r.queues) == 0 {
+		r.queues[DefaultQueue] = 1
+	}
+	return r
+}
+
+// Handle은 jobType 작업을 실행할 Handler를 등록 (같은 종류를 다시 등록하면 바꿈)
+// 등록하지 않은 종류의 작업은 실행하지 않고 dead 상태로 옮김
+func (r *Runner) Handle(jobType string, h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[jobType] = h
+}
+
+// Register는 작업 인자를 P로 디코딩해서 fn을 호출하는 Handler를 등록
+// 인자를 디코딩할 수 없는 작업은 재시도하지 않음
+func Register[P any](r *Runner, jobType string, fn func(ctx context.Context, payload P) error) {
+	r.Handle(jobType, func(ctx context.Context, job *Job) error {
+		var p P
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return Permanent(fmt.Errorf("작업 인자 디코딩 실패: %v", err))
+		}
+		return fn(ctx, p)
+	})
+}
+
+func (r *Runner) handler(jobType string) Handler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.handlers[jobType]
+}
+
+// Run은 큐마다 정한 수의 작업자를 실행하고 ctx가 끝나면 실행 중인 작업이 끝날 때까지 기다린 뒤 반환
+// 실행 중인 작업의 ctx도 함께 취소되므로 Handler는 ctx를 확인해야 함
+func (r *Runner) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for queue, n := range r.queues {
+		for range n {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r.work(ctx, queue)
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+func (r *Runner) work(ctx context.Context, queue string) {
+	for ctx.Err() == nil {
+		ran, err := r.runNext(ctx, queue)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("작업 가져오기 실패 (%s 큐): %v", queue, err)
+		}
+		if ran {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(r.poll):
+		}
+	}
+}
+
+// runNext는 queue에서 작업 하나를 가져와 실행 (가져올 작업이 없으면 false)
+func (r *Runner) runNext(ctx context.Context, queue string) (bool, error) {
+	job, err := r.store.Claim(ctx, queue, r.now(), r.lease)
+	if err != nil || job == nil {
+		return false, err
+	}
+	r.execute(ctx, job)
+	return true, nil
+}
+
+// execute는 job을 실행하고 결과를 저장
+func (r *Runner) execute(ctx context.Context, job *Job) {
+	err := r.call(ctx, job)
+	// 서버가 종료되는 중이어도 결과는 저장
+	saveCtx := context.WithoutCancel(ctx)
+	now := r.now()
+	if err == nil {
+		err = r.store.Complete(saveCtx, job, now)
+	} else {
+		var runAt *time.Time
+		var perm *permanentError
+		switch {
+		case ctx.Err() != nil:
+			// 종료 때문에 중단된 작업은 다른 작업자가 바로 이어서 실행
+			runAt = &now
+		case !errors.As(err, &perm) && job.Attempts < job.MaxAttempts:
+			next := now.Add(database.Backoff(job.Attempts-1, r.retryBase, r.retryLimit))
+			runAt = &next
+		}
+		if runAt == nil {
+			log.Printf("작업 %d(%s) 실패, dead 상태로 옮김 (%d/%d회): %v", job.ID, job.Type, job.Attempts, job.MaxAttempts, err)
+		} else {
+			log.Printf("작업 %d(%s) 실패, %s에 다시 실행 (%d/%d회): %v", job.ID, job.Type, runAt.Format(time.RFC3339), job.Attempts, job.MaxAttempts, err)
+		}
+		err = r.store.Fail(saveCtx, job, err.Error(), runAt, now)
+	}
+	if errors.Is(err, ErrLeaseLost) {
+		log.Printf("작업 %d(%s)이 취소됐거나 다른 작업자가 가져가서 결과를 저장하지 않음", job.ID, job.Type)
+	} else if err != nil {
+		log.Printf("작업 %d(%s) 결과 저장 실패: %v", job.ID, job.Type, err)
+	}
+}
+
+// call은 lease를 제한 시간으로 Handler를 실행하고 패닉은 에러로 바꿈
+func (r *Runner) call(ctx context.Context, job *Job) (err error) {
+	h := r.handler(job.Type)
+	if h == nil {
+		return Permanent(fmt.Errorf("등록되지 않은 작업 종류: %s", job.Type))
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.lease)
+	defer cancel()
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("작업 실행 중 패닉: %v", p)
+		}
+	}()
+	return h(ctx, job)
+}
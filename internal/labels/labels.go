@@ -0,0 +1,185 @@
+// Package labels는 리소스에 붙이는 key/value 라벨과 Kubernetes 형식의 라벨 셀렉터를 제공
+//
+// 라벨 키는 "[접두사/]이름" 형식이며 (예: env, example.com/team)
+// 이름과 값은 영숫자로 시작하고 끝나는 63자 이하의 영숫자, '-', '_', '.' 조합 (값은 비어 있어도 됨)
+package labels
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Set은 리소스의 라벨 (DB에는 JSONB 열로 저장)
+type Set map[string]string
+
+const (
+	maxNameLength   = 63
+	maxPrefixLength = 253
+)
+
+var (
+	namePattern   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	prefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// ValidateKey는 라벨 키 형식을 확인
+func ValidateKey(key string) error {
+	prefix, name, hasPrefix := strings.Cut(key, "/")
+	if !hasPrefix {
+		prefix, name = "", key
+	}
+	if hasPrefix && (prefix == "" || len(prefix) > maxPrefixLength || !prefixPattern.MatchString(prefix)) {
+		return fmt.Errorf("잘못된 라벨 키 접두사: %q", key)
+	}
+	if len(name) > maxNameLength || !namePattern.MatchString(name) {
+		return fmt.Errorf("잘못된 라벨 키: %q", key)
+	}
+	return nil
+}
+
+// ValidateValue는 라벨 값 형식을 확인 (빈 값 허용)
+func ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > maxNameLength || !namePattern.MatchString(value) {
+		return fmt.Errorf("잘못된 라벨 값: %q", value)
+	}
+	return nil
+}
+
+// Validate는 모든 키와 값의 형식을 확인
+func (s Set) Validate() error {
+	for _, key := range s.Keys() {
+		if err := ValidateKey(key); err != nil {
+			return err
+		}
+		if err := ValidateValue(s[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Keys는 정렬된 키 목록을 반환
+func (s Set) Keys() []string {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String은 키 순으로 "k1=v1,k2=v2" 형식을 반환 (ParseSet으로 다시 읽을 수 있음)
+func (s Set) String() string {
+	pairs := make([]string, 0, len(s))
+	for _, k := range s.Keys() {
+		pairs = append(pairs, k+"="+s[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// ParseSet은 "k1=v1,k2=v2" 형식을 Set으로 변환 (빈 문자열은 nil)
+func ParseSet(str string) (Set, error) {
+	if strings.TrimSpace(str) == "" {
+		return nil, nil
+	}
+	s := Set{}
+	for _, pair := range strings.Split(str, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("라벨은 key=value 형식이어야 합니다: %q", pair)
+		}
+		s[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Clone은 복사본을 반환 (nil은 nil)
+func (s Set) Clone() Set {
+	if s == nil {
+		return nil
+	}
+	c := make(Set, len(s))
+	for k, v := range s {
+		c[k] = v
+	}
+	return c
+}
+
+// Value는 DB에 JSON 객체로 저장 (라벨이 없으면 빈 객체)
+func (s Set) Value() (driver.Value, error) {
+	if s == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(map[string]string(s))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan은 DB의 JSON 값을 읽음
+func (s *Set) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("라벨 열을 읽을 수 없습니다: %T", src)
+	}
+	var m map[string]string
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	if len(m) == 0 {
+		m = nil
+	}
+	*s = m
+	return nil
+}
+
+// xmlLabel은 XML의 <label key="env">prod</label> 요소
+type xmlLabel struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// MarshalXML은 encoding/xml이 맵을 지원하지 않으므로 <label key="...">값</label> 목록으로 기록
+func (s Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	items := make([]xmlLabel, 0, len(s))
+	for _, k := range s.Keys() {
+		items = append(items, xmlLabel{Key: k, Value: s[k]})
+	}
+	return e.EncodeElement(struct {
+		Labels []xmlLabel `xml:"label"`
+	}{items}, start)
+}
+
+func (s *Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v struct {
+		Labels []xmlLabel `xml:"label"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	*s = make(Set, len(v.Labels))
+	for _, l := range v.Labels {
+		(*s)[l.Key] = l.Value
+	}
+	return nil
+}
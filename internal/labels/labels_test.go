@@ -0,0 +1,128 @@
+package labels
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LabelsTestSuite struct {
+	suite.Suite
+}
+
+func (s *LabelsTestSuite) TestParseSelector() {
+	tests := []struct {
+		name    string
+		input   string
+		want    Selector
+		wantErr bool
+	}{
+		{name: "빈_셀렉터", input: " ", want: nil},
+		{
+			name:  "모든_연산자",
+			input: "env=prod, team!=infra,tier in (a, b),stage notin (dev),owner,!legacy,app==web",
+			want: Selector{
+				{Key: "env", Operator: Equals, Values: []string{"prod"}},
+				{Key: "team", Operator: NotEquals, Values: []string{"infra"}},
+				{Key: "tier", Operator: In, Values: []string{"a", "b"}},
+				{Key: "stage", Operator: NotIn, Values: []string{"dev"}},
+				{Key: "owner", Operator: Exists},
+				{Key: "legacy", Operator: DoesNotExist},
+				{Key: "app", Operator: Equals, Values: []string{"web"}},
+			},
+		},
+		{
+			name:  "접두사가_있는_키",
+			input: "example.com/team=infra",
+			want:  Selector{{Key: "example.com/team", Operator: Equals, Values: []string{"infra"}}},
+		},
+		{name: "괄호_불일치", input: "tier in (a,b", wantErr: true},
+		{name: "빈_조건", input: "env=prod,,team=a", wantErr: true},
+		{name: "잘못된_키", input: "-env=prod", wantErr: true},
+		{name: "잘못된_값", input: "env=prod!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got, err := ParseSelector(tt.input)
+			if tt.wantErr {
+				s.Error(err)
+				return
+			}
+			s.NoError(err)
+			s.Equal(tt.want, got)
+
+			// String으로 다시 파싱해도 같은 셀렉터
+			again, err := ParseSelector(got.String())
+			s.NoError(err)
+			s.Equal(got, again)
+		})
+	}
+}
+
+func (s *LabelsTestSuite) TestMatches() {
+	set := Set{"env": "prod", "tier": "a"}
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{selector: "", want: true},
+		{selector: "env=prod", want: true},
+		{selector: "env=dev", want: false},
+		{selector: "team!=infra", want: true},
+		{selector: "env!=prod", want: false},
+		{selector: "tier in (a,b)", want: true},
+		{selector: "team in (a)", want: false},
+		{selector: "team notin (infra)", want: true},
+		{selector: "tier notin (a)", want: false},
+		{selector: "env,!legacy", want: true},
+		{selector: "env,legacy", want: false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.selector, func() {
+			selector, err := ParseSelector(tt.selector)
+			s.Require().NoError(err)
+			s.Equal(tt.want, selector.Matches(set))
+		})
+	}
+}
+
+func (s *LabelsTestSuite) TestSet() {
+	set, err := ParseSet("team=infra, env=prod")
+	s.NoError(err)
+	s.Equal(Set{"env": "prod", "team": "infra"}, set)
+	s.Equal("env=prod,team=infra", set.String())
+
+	_, err = ParseSet("env")
+	s.Error(err)
+	s.Error(Set{"Example.com/team": "a"}.Validate())
+
+	// DB 저장 형식
+	v, err := Set(nil).Value()
+	s.NoError(err)
+	s.Equal("{}", v)
+	var scanned Set
+	s.NoError(scanned.Scan([]byte(`{"env":"prod"}`)))
+	s.Equal(Set{"env": "prod"}, scanned)
+	s.NoError(scanned.Scan("{}"))
+	s.Nil(scanned)
+}
+
+func (s *LabelsTestSuite) TestXML() {
+	type item struct {
+		Labels Set `xml:"labels"`
+	}
+	b, err := xml.Marshal(item{Labels: Set{"env": "prod", "tier": "a"}})
+	s.NoError(err)
+	s.Equal(`<item><labels><label key="env">prod</label><label key="tier">a</label></labels></item>`, string(b))
+
+	var got item
+	s.NoError(xml.Unmarshal(b, &got))
+	s.Equal(Set{"env": "prod", "tier": "a"}, got.Labels)
+}
+
+func TestLabelsSuite(t *testing.T) {
+	suite.Run(t, new(LabelsTestSuite))
+}
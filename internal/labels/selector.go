@@ -0,0 +1,183 @@
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Operator는 셀렉터 조건의 비교 방식
+type Operator string
+
+const (
+	Equals       Operator = "="     // key=value, key==value
+	NotEquals    Operator = "!="    // key!=value (키가 없어도 만족)
+	In           Operator = "in"    // key in (a,b)
+	NotIn        Operator = "notin" // key notin (a,b) (키가 없어도 만족)
+	Exists       Operator = "exists"
+	DoesNotExist Operator = "!"
+)
+
+// Requirement는 셀렉터의 조건 하나
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string // Equals, NotEquals는 값 하나, Exists, DoesNotExist는 비어 있음
+}
+
+// Selector는 모든 조건을 만족해야 하는(AND) 라벨 셀렉터
+type Selector []Requirement
+
+// Empty는 조건이 없는지 확인 (조건이 없으면 모든 리소스와 일치)
+func (s Selector) Empty() bool {
+	return len(s) == 0
+}
+
+// Matches는 라벨이 모든 조건을 만족하는지 확인
+func (s Selector) Matches(set Set) bool {
+	for _, r := range s {
+		if !r.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Requirement) Matches(set Set) bool {
+	value, ok := set[r.Key]
+	switch r.Operator {
+	case Equals:
+		return ok && value == r.Values[0]
+	case NotEquals:
+		return !ok || value != r.Values[0]
+	case In:
+		return ok && contains(r.Values, value)
+	case NotIn:
+		return !ok || !contains(r.Values, value)
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// String은 ParseSelector로 다시 읽을 수 있는 형식을 반환
+func (s Selector) String() string {
+	parts := make([]string, len(s))
+	for i, r := range s {
+		switch r.Operator {
+		case Equals, NotEquals:
+			parts[i] = r.Key + string(r.Operator) + r.Values[0]
+		case In, NotIn:
+			parts[i] = fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ","))
+		case Exists:
+			parts[i] = r.Key
+		case DoesNotExist:
+			parts[i] = "!" + r.Key
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+var setPattern = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+
+// ParseSelector는 Kubernetes 형식의 셀렉터를 파싱 (빈 문자열은 빈 셀렉터)
+//
+//	env=prod,team!=infra,tier in (a,b),owner,!legacy
+func ParseSelector(str string) (Selector, error) {
+	parts, err := splitRequirements(str)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Selector
+	for _, part := range parts {
+		r, err := parseRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, r)
+	}
+	return s, nil
+}
+
+// splitRequirements는 괄호 밖의 쉼표로 조건을 나눔
+func splitRequirements(str string) ([]string, error) {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range str {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("잘못된 라벨 셀렉터: 괄호가 맞지 않습니다")
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, str[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("잘못된 라벨 셀렉터: 괄호가 맞지 않습니다")
+	}
+	parts = append(parts, str[start:])
+
+	// 빈 셀렉터는 허용하지만 "a=b,,c=d"처럼 빈 조건은 허용하지 않음
+	if len(parts) == 1 && strings.TrimSpace(parts[0]) == "" {
+		return nil, nil
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+		if parts[i] == "" {
+			return nil, fmt.Errorf("잘못된 라벨 셀렉터: 빈 조건이 있습니다")
+		}
+	}
+	return parts, nil
+}
+
+func parseRequirement(part string) (Requirement, error) {
+	var r Requirement
+	switch {
+	case setPattern.MatchString(part):
+		m := setPattern.FindStringSubmatch(part)
+		r = Requirement{Key: m[1], Operator: Operator(m[2])}
+		for _, v := range strings.Split(m[3], ",") {
+			r.Values = append(r.Values, strings.TrimSpace(v))
+		}
+	case strings.Contains(part, "!="):
+		key, value, _ := strings.Cut(part, "!=")
+		r = Requirement{Key: strings.TrimSpace(key), Operator: NotEquals, Values: []string{strings.TrimSpace(value)}}
+	case strings.Contains(part, "="):
+		key, value, _ := strings.Cut(part, "=")
+		value = strings.TrimPrefix(value, "=")
+		r = Requirement{Key: strings.TrimSpace(key), Operator: Equals, Values: []string{strings.TrimSpace(value)}}
+	case strings.HasPrefix(part, "!"):
+		r = Requirement{Key: strings.TrimSpace(part[1:]), Operator: DoesNotExist}
+	default:
+		r = Requirement{Key: part, Operator: Exists}
+	}
+
+	if err := ValidateKey(r.Key); err != nil {
+		return Requirement{}, fmt.Errorf("잘못된 라벨 셀렉터: %v", err)
+	}
+	for _, v := range r.Values {
+		if err := ValidateValue(v); err != nil {
+			return Requirement{}, fmt.Errorf("잘못된 라벨 셀렉터: %v", err)
+		}
+	}
+	return r, nil
+}
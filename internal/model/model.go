@@ -1,13 +1,191 @@
 package model
 
 import (
+	"go_project/internal/attributes"
+	"go_project/internal/labels"
 	"time"
 )
 
 // 기본 모델 구조체
 type Base struct {
-	ID        uint      `gorm:"primarykey" json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uint           `gorm:"primarykey" json:"id" xml:"id" yaml:"id"`
+	Name       string         `gorm:"index:,type:gin,expression:to_tsvector('simple'\\,name);index:idx_bases_name_trgm,type:gin,expression:name gin_trgm_ops" json:"name" xml:"name" yaml:"name"` // 전문 검색, 유사도 검색(pg_trgm)용 GIN 인덱스
+	ParentID   *uint          `gorm:"index" json:"parent_id,omitempty" xml:"parent_id,omitempty" yaml:"parent_id,omitempty"`                                                                      // 없으면 최상위 리소스
+	Labels     labels.Set     `gorm:"type:jsonb;not null;default:'{}'" json:"labels,omitempty" xml:"labels,omitempty" yaml:"labels,omitempty"`
+	Attributes attributes.Map `gorm:"type:jsonb;not null;default:'{}'" json:"attributes,omitempty" xml:"attributes,omitempty" yaml:"attributes,omitempty"`
+	CreatedAt  time.Time      `json:"created_at" xml:"created_at" yaml:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at" xml:"updated_at" yaml:"updated_at"`
+	ExpiresAt  *time.Time     `gorm:"index" json:"expires_at,omitempty" xml:"expires_at,omitempty" yaml:"expires_at,omitempty"` // 이 시각부터 조회되지 않고 정리 작업이 삭제함 (없으면 만료되지 않음)
+}
+
+// GetBase는 모델에 임베딩된 Base를 반환
+func (b *Base) GetBase() *Base {
+	return b
+}
+
+// Expired는 now에 만료된 리소스인지 확인
+func (b *Base) Expired(now time.Time) bool {
+	return b.ExpiresAt != nil && !b.ExpiresAt.After(now)
+}
+
+// Model은 Base를 임베딩한 모델 T의 포인터 타입 제약
+// Base를 임베딩하면 GetBase가 승격되므로 따로 구현하지 않아도 만족함
+//
+//	type Project struct {
+//		model.Base
+//		Owner string
+//	}
+//
+// 범용 계층은 [T any, PT Model[T]] 형태로 선언하고, 호출할 때는 T만 지정하면 PT는 추론됨
+// (예: recorder.NewMemoryRecorder[Project]())
+type Model[T any] interface {
+	*T
+	GetBase() *Base
+}
+
+// BatchMode는 일괄 처리 방식
+type BatchMode string
+
+const (
+	BatchModeAtomic  BatchMode = "atomic"  // 전부 성공하거나 전부 실패
+	BatchModePartial BatchMode = "partial" // 항목별로 처리하고 결과를 보고
+)
+
+// BatchResult는 일괄 처리에서 항목 하나의 처리 결과
+type BatchResult struct {
+	Index int   // 요청 배열에서의 위치
+	ID    uint  // 처리된 리소스 ID
+	Err   error // 실패 시 에러 (성공이면 nil)
+}
+
+// ImportOptions는 가져오기 동작 옵션
+type ImportOptions struct {
+	DryRun bool // 검증만 하고 저장하지 않음
+	Upsert bool // 같은 이름의 리소스가 있으면 생성 대신 수정
+}
+
+// ImportRow는 가져올 파일의 한 행
+type ImportRow[T any] struct {
+	Line     int   // 파일에서의 행 번호 (JSON 배열은 항목 순번)
+	Resource *T    // 파싱된 리소스
+	Err      error // 파싱 실패 시 에러
+}
+
+// ImportError는 가져오기 중 실패한 행 정보
+type ImportError struct {
+	Row     int    `json:"row" xml:"row" yaml:"row"`
+	Message string `json:"message" xml:"message" yaml:"message"`
+}
+
+// ImportReport는 가져오기 결과 요약
+type ImportReport struct {
+	DryRun  bool          `json:"dry_run" xml:"dry_run" yaml:"dry_run"`
+	Total   int           `json:"total" xml:"total" yaml:"total"`
+	Created int           `json:"created" xml:"created" yaml:"created"`
+	Updated int           `json:"updated" xml:"updated" yaml:"updated"`
+	Failed  int           `json:"failed" xml:"failed" yaml:"failed"`
+	Errors  []ImportError `json:"errors" xml:"errors>error" yaml:"errors"`
+}
+
+// ListOptions는 페이지 단위 목록 조회 조건
+type ListOptions struct {
+	Page int // 1부터 시작
+	Size int // 한 페이지의 항목 수 (0이면 전체)
+
+	// 필터 (비어 있으면 적용하지 않음)
+	IDs          []uint             // 이 ID 중 하나
+	NameContains string             // 이름에 포함된 문자열 (대소문자 무시)
+	Selector     labels.Selector    // 라벨 셀렉터의 모든 조건을 만족
+	Attributes   attributes.Filters // 속성 경로의 값이 모두 일치
+	ParentID     *uint              // 이 리소스의 직계 자식
+}
+
+// Offset은 Page와 Size로 건너뛸 행 수를 계산
+func (o ListOptions) Offset() int {
+	if o.Page < 1 {
+		return 0
+	}
+	return (o.Page - 1) * o.Size
+}
+
+// EventType은 리소스 변경 종류
+type EventType string
+
+const (
+	EventAdded    EventType = "added"
+	EventModified EventType = "modified"
+	EventDeleted  EventType = "deleted"
+	EventExpired  EventType = "expired" // 만료 시각이 지나 조회되지 않게 됨
+)
+
+// Event는 리소스 하나의 변경 사항
+type Event[T any] struct {
+	Type     EventType
+	Resource *T // 삭제, 만료 이벤트는 마지막으로 조회한 값
+}
+
+// Attachment는 리소스에 첨부된 파일의 메타데이터 (내용은 storage.BlobStore에 저장)
+type Attachment struct {
+	ID          uint      `gorm:"primarykey" json:"id" xml:"id" yaml:"id"`
+	ResourceID  uint      `gorm:"index;not null" json:"resource_id" xml:"resource_id" yaml:"resource_id"`
+	Filename    string    `gorm:"not null" json:"filename" xml:"filename" yaml:"filename"`
+	ContentType string    `gorm:"not null" json:"content_type" xml:"content_type" yaml:"content_type"` // 내용으로 판별한 MIME 타입
+	Size        int64     `gorm:"not null" json:"size" xml:"size" yaml:"size"`                         // 바이트 수
+	Checksum    string    `gorm:"not null" json:"checksum" xml:"checksum" yaml:"checksum"`             // sha256:<16진수>
+	StorageKey  string    `gorm:"not null;uniqueIndex" json:"-" xml:"-" yaml:"-"`                      // BlobStore 키
+	CreatedAt   time.Time `json:"created_at" xml:"created_at" yaml:"created_at"`
+}
+
+// Revision은 리소스를 생성하거나 수정할 때마다 남기는 리소스 전체의 스냅샷
+// Number는 리소스마다 1부터 차례로 매김
+type Revision struct {
+	ID         uint   `gorm:"primarykey"`
+	ResourceID uint   `gorm:"not null;uniqueIndex:idx_revisions_resource_number,priority:1"`
+	Number     int    `gorm:"not null;uniqueIndex:idx_revisions_resource_number,priority:2"`
+	Snapshot   string `gorm:"type:jsonb;not null"` // 저장한 리소스를 JSON으로 직렬화한 값
+	RollbackOf *int   // 롤백으로 남긴 리비전이면 되돌린 리비전 번호
+	CreatedAt  time.Time
+}
+
+// ResourceRevision은 스냅샷을 리소스로 복원한 리비전 (API 응답용)
+type ResourceRevision[T any] struct {
+	Revision   int       `json:"revision" xml:"revision" yaml:"revision"`
+	Resource   *T        `json:"resource" xml:"resource" yaml:"resource"`
+	RollbackOf *int      `json:"rollback_of,omitempty" xml:"rollback_of,omitempty" yaml:"rollback_of,omitempty"`
+	CreatedAt  time.Time `json:"created_at" xml:"created_at" yaml:"created_at"`
+}
+
+// FieldChange는 두 리비전 사이에 바뀐 값 하나
+// Path는 JSON Pointer(RFC 6901, 예: /labels/env, /attributes/tags/0)이고, 없던 값이나 지운 값은 nil
+type FieldChange struct {
+	Path string      `json:"path" xml:"path" yaml:"path"`
+	From interface{} `json:"from" xml:"from,omitempty" yaml:"from"`
+	To   interface{} `json:"to" xml:"to,omitempty" yaml:"to"`
+}
+
+// RevisionDiff는 From 리비전에서 To 리비전으로 바뀐 값 목록 (경로 순)
+type RevisionDiff struct {
+	From    int           `json:"from" xml:"from" yaml:"from"`
+	To      int           `json:"to" xml:"to" yaml:"to"`
+	Changes []FieldChange `json:"changes" xml:"changes>change" yaml:"changes"`
+}
+
+// SearchHit는 전문 검색 결과 하나
+type SearchHit[T any] struct {
+	Resource *T      `json:"resource" xml:"resource" yaml:"resource"`
+	Rank     float64 `json:"rank" xml:"rank" yaml:"rank"`          // 클수록 관련도가 높음 (저장소마다 척도가 다름)
+	Snippet  string  `json:"snippet" xml:"snippet" yaml:"snippet"` // 일치한 단어를 <mark></mark>로 감싼 이름
+}
+
+// SearchResult는 전문 검색 응답
+// 결과가 없으면 검색어와 비슷한 이름을 Suggestion으로 제안 ("혹시 ...을(를) 찾으셨나요?")
+type SearchResult[T any] struct {
+	Hits       []SearchHit[T] `json:"hits" xml:"hits>hit" yaml:"hits"`
+	Suggestion string         `json:"suggestion,omitempty" xml:"suggestion,omitempty" yaml:"suggestion,omitempty"`
+}
+
+// FuzzyMatch는 이름 유사도 검색 결과 하나
+type FuzzyMatch[T any] struct {
+	Resource *T      `json:"resource" xml:"resource" yaml:"resource"`
+	Score    float64 `json:"score" xml:"score" yaml:"score"` // 0~1, 클수록 비슷함 (저장소마다 계산 방식이 조금 다름)
 }
@@ -0,0 +1,385 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Document는 OpenAPI 3.1 문서의 최상위 구조
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem은 메서드(소문자)별 Operation
+type PathItem map[string]*OperationObject
+
+type OperationObject struct {
+	OperationID string                    `json:"operationId"`
+	Summary     string                    `json:"summary"`
+	Tags        []string                  `json:"tags,omitempty"`
+	Parameters  []ParameterObject         `json:"parameters,omitempty"`
+	RequestBody *RequestBodyObject        `json:"requestBody,omitempty"`
+	Responses   map[string]ResponseObject `json:"responses"`
+}
+
+type ParameterObject struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBodyObject struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type ResponseObject struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema는 JSON Schema(2020-12)의 필요한 부분만 표현
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	MaxItems    *int               `json:"maxItems,omitempty"`
+}
+
+// Param은 경로/쿼리/폼 파라미터 설명
+type Param struct {
+	Name        string
+	In          string // path, query
+	Description string
+	Required    bool
+	Type        string // string, integer, boolean
+	Enum        []string
+}
+
+// Response는 상태 코드별 응답 설명
+// Data는 응답 본문 data 필드의 예시 값 (타입만 사용, nil이면 data는 null)
+type Response struct {
+	Status      int
+	Description string
+	Data        interface{}
+	NoBody      bool // 본문이 없는 응답 (예: 304)
+}
+
+// Operation은 gin에 등록된 라우트 하나에 대한 문서 정보
+type Operation struct {
+	Method string // gin에 등록한 HTTP 메서드
+	Route  string // gin에 등록한 경로 (예: /api/v1/resources/:id)
+	// Path는 문서에 쓸 경로, 비어 있으면 Route의 :param을 {param}으로 바꿔서 사용
+	// 하나의 라우트가 여러 경로를 처리하는 경우(예: /resources:action)에 지정
+	Path        string
+	ID          string
+	Summary     string
+	Tags        []string
+	Params      []Param
+	Request     interface{} // 요청 본문 예시 값 (nil이면 본문 없음)
+	RequestMIME []string    // 요청 본문 MIME 타입 (비어 있으면 Negotiated)
+	Responses   []Response
+	// ResponseMIME이 비어 있으면 Negotiated, 공통 응답 구조(status/message/data)로 감싸서 문서화
+	// 지정하면 응답 본문을 그대로(Data 타입) 문서화
+	ResponseMIME []string
+}
+
+// Negotiated는 공통 응답 구조를 렌더링할 수 있는 MIME 타입 목록
+var Negotiated = []string{"application/json", "application/xml", "application/yaml", "application/msgpack"}
+
+// Build는 등록된 라우트와 문서 정보를 대조해서 OpenAPI 문서를 생성
+// prefix 아래에 등록된 라우트 중 문서가 없는 라우트가 있거나,
+// 등록되지 않은 라우트에 대한 문서가 있으면 에러를 반환
+func Build(info Info, routes gin.RoutesInfo, prefix string, ops []Operation) (*Document, error) {
+	registered := make(map[string]bool)
+	for _, r := range routes {
+		if strings.HasPrefix(r.Path, prefix) {
+			registered[r.Method+" "+r.Path] = true
+		}
+	}
+
+	documented := make(map[string]bool)
+	var problems []string
+	for _, op := range ops {
+		key := op.Method + " " + op.Route
+		if !registered[key] {
+			problems = append(problems, "등록되지 않은 라우트의 문서: "+key)
+		}
+		documented[key] = true
+	}
+	for key := range registered {
+		if !documented[key] {
+			problems = append(problems, "문서가 없는 라우트: "+key)
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("라우트와 OpenAPI 문서 불일치:\n%s", strings.Join(problems, "\n"))
+	}
+
+	g := &generator{schemas: make(map[string]*Schema)}
+	doc := &Document{
+		OpenAPI: "3.1.0",
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+	}
+	for _, op := range ops {
+		path := op.Path
+		if path == "" {
+			path = ConvertPath(op.Route)
+		}
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(PathItem)
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = g.operation(op)
+	}
+	doc.Components.Schemas = g.schemas
+	return doc, nil
+}
+
+// ConvertPath는 gin 경로의 :param, *param을 OpenAPI의 {param} 형식으로 변환
+func ConvertPath(route string) string {
+	segments := strings.Split(route, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+type generator struct {
+	schemas map[string]*Schema
+}
+
+func (g *generator) operation(op Operation) *OperationObject {
+	o := &OperationObject{
+		OperationID: op.ID,
+		Summary:     op.Summary,
+		Tags:        op.Tags,
+		Responses:   make(map[string]ResponseObject),
+	}
+
+	for _, p := range op.Params {
+		schema := &Schema{Type: p.Type, Enum: p.Enum}
+		o.Parameters = append(o.Parameters, ParameterObject{
+			Name:        p.Name,
+			In:          p.In,
+			Description: p.Description,
+			Required:    p.Required || p.In == "path",
+			Schema:      schema,
+		})
+	}
+
+	if op.Request != nil {
+		mimes := op.RequestMIME
+		if len(mimes) == 0 {
+			mimes = Negotiated
+		}
+		schema := g.schemaOf(reflect.TypeOf(op.Request))
+		o.RequestBody = &RequestBodyObject{Required: true, Content: make(map[string]MediaType)}
+		for _, m := range mimes {
+			o.RequestBody.Content[m] = MediaType{Schema: schema}
+		}
+	}
+
+	for _, r := range op.Responses {
+		resp := ResponseObject{Description: r.Description, Content: make(map[string]MediaType)}
+		if r.NoBody {
+			resp.Content = nil
+		} else if len(op.ResponseMIME) == 0 {
+			envelope := g.envelope(r.Data)
+			for _, m := range Negotiated {
+				resp.Content[m] = MediaType{Schema: envelope}
+			}
+		} else if r.Data != nil {
+			schema := g.schemaOf(reflect.TypeOf(r.Data))
+			for _, m := range op.ResponseMIME {
+				resp.Content[m] = MediaType{Schema: schema}
+			}
+		} else {
+			// 공통 응답 구조가 아닌 라우트의 에러 응답은 JSON 공통 구조로 문서화
+			resp.Content["application/json"] = MediaType{Schema: g.envelope(nil)}
+		}
+		o.Responses[fmt.Sprintf("%d", r.Status)] = resp
+	}
+	return o
+}
+
+// envelope는 공통 응답 구조(status/message/data, 선택 항목 warnings) 스키마를 생성
+func (g *generator) envelope(data interface{}) *Schema {
+	dataSchema := &Schema{Type: "null"}
+	if data != nil {
+		dataSchema = g.schemaOf(reflect.TypeOf(data))
+	}
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"status":  {Type: "integer", Description: "HTTP 상태 코드"},
+			"message": {Type: "string"},
+			"data":    dataSchema,
+			"warnings": {
+				Type: "array", Items: &Schema{Type: "string"},
+				Description: "요청은 처리했지만 알릴 내용 (없으면 생략)",
+			},
+		},
+		Required: []string{"status", "message", "data"},
+	}
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// schemaOf는 Go 타입을 JSON 직렬화 결과 기준의 스키마로 변환
+// 이름 있는 구조체는 components.schemas에 등록하고 $ref로 참조
+func (g *generator) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0.0
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		// json.RawMessage처럼 JSON을 그대로 담는 바이트 슬라이스는 어떤 값이든 될 수 있음
+		if t.Elem().Kind() == reflect.Uint8 && t.Implements(marshalerType) {
+			return &Schema{}
+		}
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := schemaName(t)
+		if _, ok := g.schemas[name]; !ok {
+			// 재귀 참조를 위해 먼저 자리를 잡아 둠
+			g.schemas[name] = &Schema{}
+			*g.schemas[name] = *g.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	// interface{} 등 타입을 알 수 없는 값
+	return &Schema{}
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		// 이름 없는 임베딩 구조체는 필드를 펼쳐서 합침
+		if f.Anonymous && name == "" {
+			embedded := g.structSchema(derefType(f.Type))
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		prop := g.schemaOf(f.Type)
+		// 문자열 필드의 세부 형식 (예: 업로드 파일은 format:"binary")
+		if format := f.Tag.Get("format"); format != "" && prop.Ref == "" {
+			prop.Format = format
+		}
+		s.Properties[name] = prop
+		if !strings.Contains(opts, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// schemaName은 패키지 이름을 붙여 타입 이름 충돌을 피함 (예: model.Base -> ModelBase)
+// 제네릭 타입은 타입 인자 이름을 뒤에 붙임 (예: handler.batchRequest[model.Base] -> HandlerBatchRequestModelBase)
+func schemaName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	name, args, _ := strings.Cut(t.Name(), "[")
+	name = qualifiedName(pkg, name)
+	for _, arg := range strings.Split(strings.TrimSuffix(args, "]"), ",") {
+		if arg == "" {
+			continue
+		}
+		// 타입 인자는 "go_project/internal/model.Base" 처럼 전체 경로로 표시됨
+		arg = strings.TrimLeft(arg[strings.LastIndex(arg, "/")+1:], "*[]")
+		argPkg, argName, ok := strings.Cut(arg, ".")
+		if !ok {
+			argPkg, argName = "", arg
+		}
+		name += qualifiedName(argPkg, argName)
+	}
+	return name
+}
+
+func qualifiedName(pkg, name string) string {
+	if pkg == "" {
+		return strings.ToUpper(name[:1]) + name[1:]
+	}
+	return strings.ToUpper(pkg[:1]) + pkg[1:] + strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type sample struct {
+	ID        uint            `json:"id"`
+	Name      string          `json:"name"`
+	Tags      []string        `json:"tags,omitempty"`
+	Hidden    string          `json:"-"`
+	CreatedAt time.Time       `json:"created_at"`
+	Child     *sample         `json:"child,omitempty"`
+	Extra     json.RawMessage `json:"extra,omitempty"`
+}
+
+type page[T any] struct {
+	Items []*T `json:"items"`
+}
+
+type OpenAPITestSuite struct {
+	suite.Suite
+}
+
+func (s *OpenAPITestSuite) routes() gin.RoutesInfo {
+	return gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/api/v1/samples"},
+		{Method: http.MethodGet, Path: "/api/v1/samples/:id"},
+		{Method: http.MethodGet, Path: "/"},
+	}
+}
+
+func (s *OpenAPITestSuite) TestBuild() {
+	ops := []Operation{
+		{
+			Method: http.MethodGet, Route: "/api/v1/samples", ID: "listSamples",
+			Responses: []Response{{Status: http.StatusOK, Data: []*sample{}}},
+		},
+		{
+			Method: http.MethodGet, Route: "/api/v1/samples/:id", ID: "getSample",
+			Params:    []Param{{Name: "id", In: "path", Type: "integer"}},
+			Responses: []Response{{Status: http.StatusOK, Data: &sample{}}},
+		},
+	}
+
+	doc, err := Build(Info{Title: "테스트", Version: "1"}, s.routes(), "/api", ops)
+
+	s.NoError(err)
+	s.Equal("3.1.0", doc.OpenAPI)
+	s.Contains(doc.Paths, "/api/v1/samples/{id}")
+
+	op := doc.Paths["/api/v1/samples/{id}"]["get"]
+	s.True(op.Parameters[0].Required)
+	envelope := op.Responses["200"].Content["application/json"].Schema
+	s.Equal("#/components/schemas/OpenapiSample", envelope.Properties["data"].Ref)
+
+	schema := doc.Components.Schemas["OpenapiSample"]
+	s.Equal("object", schema.Type)
+	s.Equal("string", schema.Properties["created_at"].Type)
+	s.Equal("date-time", schema.Properties["created_at"].Format)
+	s.Equal("array", schema.Properties["tags"].Type)
+	s.Equal("#/components/schemas/OpenapiSample", schema.Properties["child"].Ref)
+	s.Empty(schema.Properties["extra"].Type)
+	s.NotContains(schema.Properties, "Hidden")
+	s.ElementsMatch([]string{"id", "name", "created_at"}, schema.Required)
+}
+
+func (s *OpenAPITestSuite) TestBuild_Drift() {
+	tests := []struct {
+		name string
+		ops  []Operation
+	}{
+		{
+			name: "문서가_없는_라우트",
+			ops: []Operation{
+				{Method: http.MethodGet, Route: "/api/v1/samples"},
+			},
+		},
+		{
+			name: "등록되지_않은_라우트의_문서",
+			ops: []Operation{
+				{Method: http.MethodGet, Route: "/api/v1/samples"},
+				{Method: http.MethodGet, Route: "/api/v1/samples/:id"},
+				{Method: http.MethodDelete, Route: "/api/v1/samples/:id"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, err := Build(Info{}, s.routes(), "/api", tt.ops)
+			s.Error(err)
+		})
+	}
+}
+
+func (s *OpenAPITestSuite) TestConvertPath() {
+	s.Equal("/api/v1/samples/{id}", ConvertPath("/api/v1/samples/:id"))
+	s.Equal("/static/{filepath}", ConvertPath("/static/*filepath"))
+	s.Equal("/api/v1/samples", ConvertPath("/api/v1/samples"))
+}
+
+func (s *OpenAPITestSuite) TestSchemaName_Generic() {
+	s.Equal("OpenapiPageOpenapiSample", schemaName(reflect.TypeOf(page[sample]{})))
+	s.Equal("OpenapiPageTimeTime", schemaName(reflect.TypeOf(page[time.Time]{})))
+}
+
+func TestOpenAPISuite(t *testing.T) {
+	suite.Run(t, new(OpenAPITestSuite))
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 이 횟수만큼 호출할 때마다 가득 찬 버킷과 만료된 카운터를 정리
+const sweepInterval = 1024
+
+type memoryStore struct {
+	mu       sync.Mutex
+	buckets  map[string]*bucket
+	counters map[string]*counter
+	calls    int
+	now      func() time.Time
+}
+
+type bucket struct {
+	limit   Limit
+	tokens  float64
+	updated time.Time
+}
+
+type counter struct {
+	value   int64
+	expires time.Time
+}
+
+// NewMemoryStore는 프로세스 메모리에 상태를 보관하는 Store를 생성 (서버가 하나일 때 사용)
+// 가득 찬 버킷은 없는 버킷과 같으므로 주기적으로 지워서 클라이언트가 많아도 메모리가 계속 늘지 않게 함
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets:  make(map[string]*bucket),
+		counters: make(map[string]*counter),
+		now:      time.Now,
+	}
+}
+
+func (s *memoryStore) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok || b.limit != limit {
+		// 처음 보는 키이거나 설정이 바뀌었으면 가득 찬 버킷에서 시작
+		b = &bucket{limit: limit, tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	res, tokens := take(limit, b.tokens, b.updated, now)
+	b.tokens, b.updated = tokens, now
+	return res, nil
+}
+
+func (s *memoryStore) Increment(ctx context.Context, key string, n int64, expiresAt time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+
+	c, ok := s.counters[key]
+	if !ok || !now.Before(c.expires) {
+		c = &counter{expires: expiresAt}
+		s.counters[key] = c
+	}
+	c.value += n
+	return c.value, nil
+}
+
+// sweep은 sweepInterval번 호출될 때마다 다시 채워져 가득 찬 버킷과 만료된 카운터를 지움 (s.mu를 잡은 상태에서 호출)
+func (s *memoryStore) sweep(now time.Time) {
+	s.calls++
+	if s.calls < sweepInterval {
+		return
+	}
+	s.calls = 0
+	for key, b := range s.buckets {
+		if refill(b.limit, b.tokens, b.updated, now) >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+	for key, c := range s.counters {
+		if !now.Before(c.expires) {
+			delete(s.counters, key)
+		}
+	}
+}
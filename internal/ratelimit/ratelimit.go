@@ -0,0 +1,110 @@
+// Package ratelimit은 토큰 버킷 속도 제한과 기간별 사용량 카운터를 저장하는 Store와 구현체를 제공
+//
+// 상태는 Store에 보관하므로, 서버가 여러 대이면 Redis 같은 공용 저장소로 Store를 구현해서 같은 한도를 나눠 쓸 수 있음
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit은 토큰 버킷 설정
+// 버킷은 Burst개의 토큰으로 시작해서 초당 Rate개씩 채워지며, 요청 하나에 토큰 하나를 씀
+type Limit struct {
+	Rate  float64 // 초당 채워지는 토큰 수
+	Burst int     // 버킷 크기 (연속으로 보낼 수 있는 최대 요청 수)
+}
+
+// Window는 빈 버킷이 가득 찰 때까지 걸리는 시간
+func (l Limit) Window() time.Duration {
+	if l.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// Result는 토큰을 꺼낸 결과
+type Result struct {
+	Allowed    bool
+	Limit      int           // 버킷 크기
+	Remaining  int           // 남은 토큰 수
+	ResetAfter time.Duration // 버킷이 가득 찰 때까지 남은 시간
+	RetryAfter time.Duration // 거부됐을 때 다음 토큰이 생길 때까지 남은 시간
+}
+
+// Store는 속도 제한 상태 저장소
+type Store interface {
+	// Allow는 key의 버킷(없으면 limit으로 새로 만든 가득 찬 버킷)에서 토큰 하나를 꺼냄
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+	// Increment는 key의 카운터를 n만큼 늘리고 늘린 값을 반환
+	// 카운터가 없거나 만료됐으면 0에서 시작하고 expiresAt에 사라지게 함
+	Increment(ctx context.Context, key string, n int64, expiresAt time.Time) (int64, error)
+}
+
+// take는 tokens개가 updated에 있던 버킷을 now까지 채우고 토큰 하나를 꺼낸 결과와 남은 토큰 수를 반환
+// Store 구현들이 같은 계산을 쓰도록 분리
+func take(limit Limit, tokens float64, updated, now time.Time) (Result, float64) {
+	burst := float64(limit.Burst)
+	tokens = refill(limit, tokens, updated, now)
+
+	res := Result{Limit: limit.Burst}
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else if limit.Rate > 0 {
+		res.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+	res.Remaining = int(math.Floor(tokens))
+	if limit.Rate > 0 {
+		res.ResetAfter = seconds((burst - tokens) / limit.Rate)
+	}
+	return res, tokens
+}
+
+// refill은 updated에 tokens개였던 버킷을 now까지 채운 토큰 수를 반환 (버킷 크기를 넘지 않음)
+func refill(limit Limit, tokens float64, updated, now time.Time) float64 {
+	if elapsed := now.Sub(updated).Seconds(); elapsed > 0 {
+		tokens = math.Min(float64(limit.Burst), tokens+elapsed*limit.Rate)
+	}
+	return tokens
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
+
+// ParseLimits는 "그룹=초당요청수:버킷크기" 목록을 쉼표로 구분한 문자열을 해석 (예: "resources=20:40,bulk=0.5:5")
+// 버킷 크기를 생략하면 초당 요청 수를 올림한 값을 사용
+func ParseLimits(s string) (map[string]Limit, error) {
+	limits := make(map[string]Limit)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		group, spec, ok := strings.Cut(item, "=")
+		group = strings.TrimSpace(group)
+		if !ok || group == "" {
+			return nil, fmt.Errorf("잘못된 속도 제한 형식: %q (그룹=초당요청수:버킷크기)", item)
+		}
+
+		rateStr, burstStr, hasBurst := strings.Cut(spec, ":")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("잘못된 초당 요청 수: %q", item)
+		}
+		burst := int(math.Ceil(rate))
+		if hasBurst {
+			burst, err = strconv.Atoi(strings.TrimSpace(burstStr))
+			if err != nil || burst <= 0 {
+				return nil, fmt.Errorf("잘못된 버킷 크기: %q", item)
+			}
+		}
+		limits[group] = Limit{Rate: rate, Burst: burst}
+	}
+	return limits, nil
+}
@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Allow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore().(*memoryStore)
+	s.now = func() time.Time { return now }
+	limit := Limit{Rate: 2, Burst: 3}
+
+	for i := range 3 {
+		res, err := s.Allow(ctx, "a", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 2-i, res.Remaining)
+	}
+
+	res, _ := s.Allow(ctx, "a", limit)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, res.ResetAfter)
+
+	// 다른 키는 버킷이 따로
+	res, _ = s.Allow(ctx, "b", limit)
+	assert.True(t, res.Allowed)
+
+	// 0.5초에 토큰 하나가 채워짐
+	now = now.Add(500 * time.Millisecond)
+	res, _ = s.Allow(ctx, "a", limit)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	// 오래 기다려도 버킷 크기를 넘지 않음
+	now = now.Add(time.Hour)
+	res, _ = s.Allow(ctx, "a", limit)
+	assert.Equal(t, 2, res.Remaining)
+}
+
+func TestMemoryStore_Increment(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore().(*memoryStore)
+	s.now = func() time.Time { return now }
+
+	for want := int64(1); want <= 3; want++ {
+		got, err := s.Increment(ctx, "q", 1, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	// 만료되면 0부터 다시 셈
+	now = now.Add(time.Hour)
+	got, _ := s.Increment(ctx, "q", 5, now.Add(time.Hour))
+	assert.Equal(t, int64(5), got)
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	s := NewMemoryStore().(*memoryStore)
+	s.now = func() time.Time { return now }
+
+	s.Allow(ctx, "idle", Limit{Rate: 1, Burst: 1})
+	s.Increment(ctx, "expired", 1, now.Add(time.Second))
+	now = now.Add(time.Minute)
+	for range sweepInterval {
+		s.Allow(ctx, "busy", Limit{Rate: 0.001, Burst: 1})
+	}
+
+	assert.NotContains(t, s.buckets, "idle")
+	assert.NotContains(t, s.counters, "expired")
+	assert.Contains(t, s.buckets, "busy")
+}
+
+func TestParseLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]Limit
+		wantErr bool
+	}{
+		{
+			name:  "여러_그룹",
+			input: "resources=20:40, bulk=0.5:5",
+			want:  map[string]Limit{"resources": {Rate: 20, Burst: 40}, "bulk": {Rate: 0.5, Burst: 5}},
+		},
+		{name: "버킷_생략", input: "docs=2.5", want: map[string]Limit{"docs": {Rate: 2.5, Burst: 3}}},
+		{name: "빈_값", input: "", want: map[string]Limit{}},
+		{name: "그룹_없음", input: "20:40", wantErr: true},
+		{name: "잘못된_속도", input: "resources=0:1", wantErr: true},
+		{name: "잘못된_버킷", input: "resources=1:x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLimits(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
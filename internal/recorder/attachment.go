@@ -0,0 +1,121 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+	"go_project/internal/model"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentRecorder는 첨부 파일 메타데이터를 저장하는 인터페이스 (파일 내용은 저장하지 않음)
+type AttachmentRecorder interface {
+	Insert(ctx context.Context, a *model.Attachment) error
+	Get(ctx context.Context, id uint) (*model.Attachment, error)
+	ListByResource(ctx context.Context, resourceID uint) ([]*model.Attachment, error)
+	Remove(ctx context.Context, a *model.Attachment) error
+}
+
+type attachmentRecorder struct {
+	db *gorm.DB
+}
+
+// MigrateAttachments는 attachments 테이블과 인덱스를 생성하거나 Attachment의 변경 사항을 반영
+func MigrateAttachments(db *gorm.DB) error {
+	if err := db.AutoMigrate(&model.Attachment{}); err != nil {
+		return fmt.Errorf("attachments 테이블 마이그레이션 실패: %w", err)
+	}
+	return nil
+}
+
+// NewAttachmentRecorder는 attachments 테이블을 사용하는 AttachmentRecorder를 생성 (테이블은 MigrateAttachments로 생성)
+func NewAttachmentRecorder(db *gorm.DB) AttachmentRecorder {
+	return &attachmentRecorder{db: db}
+}
+
+func (r *attachmentRecorder) Insert(ctx context.Context, a *model.Attachment) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *attachmentRecorder) Get(ctx context.Context, id uint) (*model.Attachment, error) {
+	var a model.Attachment
+	if err := r.db.WithContext(ctx).First(&a, id).Error; err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *attachmentRecorder) ListByResource(ctx context.Context, resourceID uint) ([]*model.Attachment, error) {
+	var as []*model.Attachment
+	if err := r.db.WithContext(ctx).Where("resource_id = ?", resourceID).Order("id").Find(&as).Error; err != nil {
+		return nil, err
+	}
+	return as, nil
+}
+
+func (r *attachmentRecorder) Remove(ctx context.Context, a *model.Attachment) error {
+	return r.db.WithContext(ctx).Delete(a).Error
+}
+
+// memoryAttachmentRecorder는 메모리에 저장하는 AttachmentRecorder (없는 행은 gorm.ErrRecordNotFound)
+type memoryAttachmentRecorder struct {
+	mu     sync.RWMutex
+	rows   map[uint]model.Attachment
+	nextID uint
+}
+
+// NewMemoryAttachmentRecorder는 메모리 AttachmentRecorder를 생성
+func NewMemoryAttachmentRecorder() AttachmentRecorder {
+	return &memoryAttachmentRecorder{
+		rows:   make(map[uint]model.Attachment),
+		nextID: 1,
+	}
+}
+
+func (r *memoryAttachmentRecorder) Insert(ctx context.Context, a *model.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a.ID == 0 {
+		a.ID = r.nextID
+	}
+	if a.ID >= r.nextID {
+		r.nextID = a.ID + 1
+	}
+	a.CreatedAt = time.Now()
+	r.rows[a.ID] = *a
+	return nil
+}
+
+func (r *memoryAttachmentRecorder) Get(ctx context.Context, id uint) (*model.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	row, ok := r.rows[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &row, nil
+}
+
+func (r *memoryAttachmentRecorder) ListByResource(ctx context.Context, resourceID uint) ([]*model.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	as := []*model.Attachment{}
+	for _, row := range r.rows {
+		if row.ResourceID == resourceID {
+			row := row
+			as = append(as, &row)
+		}
+	}
+	sort.Slice(as, func(i, j int) bool { return as[i].ID < as[j].ID })
+	return as, nil
+}
+
+func (r *memoryAttachmentRecorder) Remove(ctx context.Context, a *model.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.rows, a.ID)
+	return nil
+}
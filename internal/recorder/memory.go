@@ -0,0 +1,522 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+	"go_project/internal/model"
+	"go_project/internal/search"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryRecorder는 DB 없이 메모리에 저장하는 Recorder 구현
+// 테스트나 로컬 실행용이며, 없는 행은 gorm과 같은 gorm.ErrRecordNotFound로 알림
+// 값으로 저장하고 복사본을 돌려주므로 호출자가 반환값을 수정해도 저장된 값은 바뀌지 않음
+// (Base의 부모 ID, 라벨, 속성은 따로 복사하지만, T에 직접 추가한 슬라이스나 맵 필드의 내용은 공유됨)
+type memoryRecorder[T any, PT model.Model[T]] struct {
+	// 쓰기는 writeMu를 먼저 잡으므로, Update가 읽고 fn을 호출해서 저장할 때까지 다른 쓰기는 기다림
+	// (fn 안에서 조회할 수 있도록 Update는 그동안 mu를 잡지 않음)
+	writeMu sync.Mutex
+	mu      sync.RWMutex
+	rows    map[uint]T
+	nextID  uint
+	index   *search.Index // 이름 전문 검색용 역색인 (rows와 같은 잠금 안에서 갱신)
+}
+
+// NewMemoryRecorder는 T를 저장하는 메모리 Recorder를 생성 (예: NewMemoryRecorder[model.Base]())
+func NewMemoryRecorder[T any, PT model.Model[T]]() Recorder[T] {
+	return &memoryRecorder[T, PT]{
+		rows:   make(map[uint]T),
+		nextID: 1,
+		index:  search.NewIndex(),
+	}
+}
+
+// base는 m에 임베딩된 Base를 반환
+func base[T any, PT model.Model[T]](m *T) *model.Base {
+	return PT(m).GetBase()
+}
+
+// copyOf는 저장하거나 돌려줄 m의 복사본을 만듦
+func copyOf[T any, PT model.Model[T]](m *T) T {
+	c := *m
+	b := base[T, PT](&c)
+	b.Labels = b.Labels.Clone()
+	b.Attributes = b.Attributes.Clone()
+	if b.ParentID != nil {
+		parentID := *b.ParentID
+		b.ParentID = &parentID
+	}
+	if b.ExpiresAt != nil {
+		expiresAt := *b.ExpiresAt
+		b.ExpiresAt = &expiresAt
+	}
+	return c
+}
+
+// lockWrite는 쓰기 잠금을 잡고 푸는 함수를 반환
+func (r *memoryRecorder[T, PT]) lockWrite() func() {
+	r.writeMu.Lock()
+	r.mu.Lock()
+	return func() {
+		r.mu.Unlock()
+		r.writeMu.Unlock()
+	}
+}
+
+// insertLocked는 ID와 생성/수정 시각을 채워서 저장 (호출자가 잠금을 잡고 있어야 함)
+func (r *memoryRecorder[T, PT]) insertLocked(m *T) {
+	now := time.Now()
+	b := base[T, PT](m)
+	if b.ID == 0 {
+		b.ID = r.nextID
+	}
+	if b.ID >= r.nextID {
+		r.nextID = b.ID + 1
+	}
+	b.CreatedAt, b.UpdatedAt = now, now
+	r.rows[b.ID] = copyOf[T, PT](m)
+	r.index.Add(b.ID, b.Name)
+}
+
+// sortedLocked는 ID 순으로 정렬된 복사본 목록을 반환
+func (r *memoryRecorder[T, PT]) sortedLocked() []*T {
+	ms := make([]*T, 0, len(r.rows))
+	for _, row := range r.rows {
+		row := copyOf[T, PT](&row)
+		ms = append(ms, &row)
+	}
+	sort.Slice(ms, func(i, j int) bool { return base[T, PT](ms[i]).ID < base[T, PT](ms[j]).ID })
+	return ms
+}
+
+// unexpiredLocked는 sortedLocked에서 만료된 리소스를 뺀 목록을 반환
+func (r *memoryRecorder[T, PT]) unexpiredLocked() []*T {
+	return withoutExpired[T, PT](r.sortedLocked(), time.Now())
+}
+
+// withoutExpired는 now에 만료되지 않은 항목만 남김
+func withoutExpired[T any, PT model.Model[T]](ms []*T, now time.Time) []*T {
+	return slices.DeleteFunc(ms, func(m *T) bool { return base[T, PT](m).Expired(now) })
+}
+
+// checkParentsLocked는 models의 부모가 저장돼 있는지 확인 (같이 저장하는 models 중의 부모는 insertLocked가 매길 ID로 확인)
+func (r *memoryRecorder[T, PT]) checkParentsLocked(models ...*T) error {
+	saving := make(map[uint]bool, len(models))
+	next := r.nextID
+	for _, m := range models {
+		id := base[T, PT](m).ID
+		if id == 0 {
+			id = next
+		}
+		if id >= next {
+			next = id + 1
+		}
+		saving[id] = true
+	}
+	for _, m := range models {
+		if p := base[T, PT](m).ParentID; p != nil && !saving[*p] {
+			if _, ok := r.rows[*p]; !ok {
+				return fmt.Errorf("%w: %d", ErrParentNotFound, *p)
+			}
+		}
+	}
+	return nil
+}
+
+// childOfLocked는 ids 중 하나를 부모로 두고 ids에 없는 만료되지 않은 하위 리소스를 찾음 (없으면 nil)
+func (r *memoryRecorder[T, PT]) childOfLocked(ids []uint) *HasChildrenError {
+	removing := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		removing[id] = true
+	}
+	now := time.Now()
+	var found *HasChildrenError
+	for _, row := range r.rows {
+		b := base[T, PT](&row)
+		if b.ParentID == nil || !removing[*b.ParentID] || removing[b.ID] || b.Expired(now) {
+			continue
+		}
+		if found == nil || b.ID < found.Child {
+			found = &HasChildrenError{ID: *b.ParentID, Child: b.ID}
+		}
+	}
+	return found
+}
+
+func (r *memoryRecorder[T, PT]) Insert(ctx context.Context, m *T) error {
+	defer r.lockWrite()()
+	if err := r.checkParentsLocked(m); err != nil {
+		return err
+	}
+	r.insertLocked(m)
+	return nil
+}
+
+func (r *memoryRecorder[T, PT]) Get(ctx context.Context, id uint) (*T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	row, ok := r.rows[id]
+	if !ok || base[T, PT](&row).Expired(time.Now()) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	row = copyOf[T, PT](&row)
+	return &row, nil
+}
+
+func (r *memoryRecorder[T, PT]) GetAll(ctx context.Context) ([]*T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.unexpiredLocked(), nil
+}
+
+// Modify는 gorm의 Save처럼 없는 ID면 새로 저장
+func (r *memoryRecorder[T, PT]) Modify(ctx context.Context, m *T) error {
+	defer r.lockWrite()()
+	if err := r.checkParentsLocked(m); err != nil {
+		return err
+	}
+	r.modifyLocked(m)
+	return nil
+}
+
+// modifyLocked는 m을 저장하고 수정 시각을 채움 (호출자가 잠금을 잡고 있어야 함)
+func (r *memoryRecorder[T, PT]) modifyLocked(m *T) {
+	b := base[T, PT](m)
+	old, ok := r.rows[b.ID]
+	if !ok {
+		r.insertLocked(m)
+		return
+	}
+	if b.CreatedAt.IsZero() {
+		b.CreatedAt = base[T, PT](&old).CreatedAt
+	}
+	b.UpdatedAt = time.Now()
+	r.rows[b.ID] = copyOf[T, PT](m)
+	r.index.Add(b.ID, b.Name)
+}
+
+func (r *memoryRecorder[T, PT]) Update(ctx context.Context, id uint, fn func(ctx context.Context, m *T) error) (*T, error) {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	m, err := r.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(ctx, m); err != nil {
+		return nil, err
+	}
+	base[T, PT](m).ID = id
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.checkParentsLocked(m); err != nil {
+		return nil, err
+	}
+	r.modifyLocked(m)
+	return m, nil
+}
+
+func (r *memoryRecorder[T, PT]) Remove(ctx context.Context, m *T) error {
+	defer r.lockWrite()()
+	id := base[T, PT](m).ID
+	if err := r.childOfLocked([]uint{id}); err != nil {
+		return err
+	}
+	delete(r.rows, id)
+	r.index.Remove(id)
+	return nil
+}
+
+func (r *memoryRecorder[T, PT]) BatchInsert(ctx context.Context, models []*T) error {
+	defer r.lockWrite()()
+	if err := r.checkParentsLocked(models...); err != nil {
+		return err
+	}
+	for _, m := range models {
+		r.insertLocked(m)
+	}
+	return nil
+}
+
+func (r *memoryRecorder[T, PT]) BatchModify(ctx context.Context, models []*T) error {
+	defer r.lockWrite()()
+	// 하나라도 없으면 아무것도 바꾸지 않음
+	for _, m := range models {
+		if _, ok := r.rows[base[T, PT](m).ID]; !ok {
+			return gorm.ErrRecordNotFound
+		}
+	}
+	if err := r.checkParentsLocked(models...); err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, m := range models {
+		b := base[T, PT](m)
+		old := r.rows[b.ID]
+		b.CreatedAt = base[T, PT](&old).CreatedAt
+		b.UpdatedAt = now
+		r.rows[b.ID] = copyOf[T, PT](m)
+		r.index.Add(b.ID, b.Name)
+	}
+	return nil
+}
+
+func (r *memoryRecorder[T, PT]) BatchRemove(ctx context.Context, ids []uint) error {
+	defer r.lockWrite()()
+	for _, id := range ids {
+		if _, ok := r.rows[id]; !ok {
+			return gorm.ErrRecordNotFound
+		}
+	}
+	if err := r.childOfLocked(ids); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		delete(r.rows, id)
+		r.index.Remove(id)
+	}
+	return nil
+}
+
+func (r *memoryRecorder[T, PT]) GetByName(ctx context.Context, name string) (*T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, row := range r.unexpiredLocked() {
+		if base[T, PT](row).Name == name {
+			return row, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// Stream은 호출 시점의 스냅샷을 순회하므로 fn 안에서 Recorder를 다시 호출해도 됨
+func (r *memoryRecorder[T, PT]) Stream(ctx context.Context, fn func(*T) error) error {
+	r.mu.RLock()
+	ms := r.unexpiredLocked()
+	r.mu.RUnlock()
+
+	for _, m := range ms {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *memoryRecorder[T, PT]) List(ctx context.Context, opts model.ListOptions) ([]*T, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ms := filterModels[T, PT](r.unexpiredLocked(), opts)
+	total := int64(len(ms))
+
+	start := min(opts.Offset(), len(ms))
+	end := len(ms)
+	if opts.Size > 0 {
+		end = min(start+opts.Size, len(ms))
+	}
+	return ms[start:end], total, nil
+}
+
+func (r *memoryRecorder[T, PT]) GetMany(ctx context.Context, ids []uint) ([]*T, error) {
+	if len(ids) == 0 {
+		return []*T{}, nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return filterModels[T, PT](r.unexpiredLocked(), model.ListOptions{IDs: ids}), nil
+}
+
+// filterModels는 ListOptions의 필터 조건에 맞는 항목만 남김
+func filterModels[T any, PT model.Model[T]](ms []*T, opts model.ListOptions) []*T {
+	if len(opts.IDs) == 0 && opts.NameContains == "" && opts.Selector.Empty() && len(opts.Attributes) == 0 &&
+		opts.ParentID == nil {
+		return ms
+	}
+	ids := make(map[uint]bool, len(opts.IDs))
+	for _, id := range opts.IDs {
+		ids[id] = true
+	}
+	needle := strings.ToLower(opts.NameContains)
+
+	filtered := ms[:0]
+	for _, m := range ms {
+		b := base[T, PT](m)
+		if len(ids) > 0 && !ids[b.ID] {
+			continue
+		}
+		if needle != "" && !strings.Contains(strings.ToLower(b.Name), needle) {
+			continue
+		}
+		if opts.ParentID != nil && (b.ParentID == nil || *b.ParentID != *opts.ParentID) {
+			continue
+		}
+		if !opts.Selector.Matches(b.Labels) || !opts.Attributes.Matches(b.Attributes) {
+			continue
+		}
+		filtered = append(filtered, m)
+	}
+	return filtered
+}
+
+// Descendants는 id 아래의 모든 하위 리소스를 ID 순으로 반환 (id 자신은 제외)
+// 만료된 리소스는 결과에서 빠지지만 그 아래의 하위 리소스는 포함
+func (r *memoryRecorder[T, PT]) Descendants(ctx context.Context, id uint) ([]*T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ms := r.sortedLocked()
+
+	children := make(map[uint][]*T)
+	for _, m := range ms {
+		if parentID := base[T, PT](m).ParentID; parentID != nil {
+			children[*parentID] = append(children[*parentID], m)
+		}
+	}
+
+	// 너비 우선으로 내려가며, 방문한 ID는 다시 보지 않으므로 순환이 있어도 끝남
+	seen := map[uint]bool{id: true}
+	var found []*T
+	queue := []uint{id}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, child := range children[next] {
+			childID := base[T, PT](child).ID
+			if seen[childID] {
+				continue
+			}
+			seen[childID] = true
+			found = append(found, child)
+			queue = append(queue, childID)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return base[T, PT](found[i]).ID < base[T, PT](found[j]).ID })
+	return withoutExpired[T, PT](found, time.Now()), nil
+}
+
+// Ancestors는 id의 조상을 최상위부터 직계 부모 순으로 반환 (id 자신은 제외)
+// 만료된 조상은 결과에서 빠지지만 그 위의 조상은 포함
+func (r *memoryRecorder[T, PT]) Ancestors(ctx context.Context, id uint) ([]*T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var chain []*T
+	seen := map[uint]bool{id: true}
+	row, ok := r.rows[id]
+	for ok {
+		parentID := base[T, PT](&row).ParentID
+		if parentID == nil || seen[*parentID] {
+			break
+		}
+		seen[*parentID] = true
+		row, ok = r.rows[*parentID]
+		if ok {
+			parent := copyOf[T, PT](&row)
+			chain = append(chain, &parent)
+		}
+	}
+	slices.Reverse(chain)
+	return withoutExpired[T, PT](chain, time.Now()), nil
+}
+
+// Search는 역색인으로 검색 (관련도는 단어 등장 비율과 희소성으로 계산하므로 PostgreSQL의 ts_rank와 값이 다름)
+func (r *memoryRecorder[T, PT]) Search(ctx context.Context, query string, limit int) ([]model.SearchHit[T], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	found := r.index.Search(query, limit)
+	hits := make([]model.SearchHit[T], 0, len(found))
+	for _, h := range found {
+		row := r.rows[h.ID]
+		// 만료된 리소스도 색인에 남아 있으므로 건너뜀 (limit보다 적게 반환될 수 있음)
+		if base[T, PT](&row).Expired(now) {
+			continue
+		}
+		row = copyOf[T, PT](&row)
+		hits = append(hits, model.SearchHit[T]{Resource: &row, Rank: h.Rank, Snippet: h.Snippet})
+	}
+	return hits, nil
+}
+
+// Similar는 모든 이름과 search.Similarity로 비교 (한글 오타에 강하도록 편집 거리도 보므로 pg_trgm보다 점수가 높을 수 있음)
+func (r *memoryRecorder[T, PT]) Similar(ctx context.Context, name string, threshold float64, limit int) ([]model.FuzzyMatch[T], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	matches := []model.FuzzyMatch[T]{}
+	for _, row := range r.rows {
+		if base[T, PT](&row).Expired(now) {
+			continue
+		}
+		if score := search.Similarity(base[T, PT](&row).Name, name); score >= threshold && score > 0 {
+			row := copyOf[T, PT](&row)
+			matches = append(matches, model.FuzzyMatch[T]{Resource: &row, Score: score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return base[T, PT](matches[i].Resource).ID < base[T, PT](matches[j].Resource).ID
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+func (r *memoryRecorder[T, PT]) Expired(ctx context.Context, before time.Time) ([]*T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	parents := r.liveParentsLocked(before)
+	return slices.DeleteFunc(r.sortedLocked(), func(m *T) bool {
+		b := base[T, PT](m)
+		return !b.Expired(before) || parents[b.ID]
+	}), nil
+}
+
+func (r *memoryRecorder[T, PT]) RemoveExpired(ctx context.Context, ids []uint, before time.Time) ([]uint, error) {
+	defer r.lockWrite()()
+	parents := r.liveParentsLocked(before)
+	var removed []uint
+	for _, id := range ids {
+		if row, ok := r.rows[id]; ok && base[T, PT](&row).Expired(before) && !parents[id] {
+			delete(r.rows, id)
+			r.index.Remove(id)
+			removed = append(removed, id)
+		}
+	}
+	return removed, nil
+}
+
+// liveParentsLocked는 before에 만료되지 않은 하위 리소스가 있는 리소스의 ID를 반환
+func (r *memoryRecorder[T, PT]) liveParentsLocked(before time.Time) map[uint]bool {
+	parents := make(map[uint]bool)
+	for _, row := range r.rows {
+		if b := base[T, PT](&row); b.ParentID != nil && !b.Expired(before) {
+			parents[*b.ParentID] = true
+		}
+	}
+	return parents
+}
+
+func (r *memoryRecorder[T, PT]) Extend(ctx context.Context, id uint, expiresAt time.Time) (*T, error) {
+	defer r.lockWrite()()
+	row, ok := r.rows[id]
+	if !ok || base[T, PT](&row).Expired(time.Now()) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	b := base[T, PT](&row)
+	b.ExpiresAt = &expiresAt
+	b.UpdatedAt = time.Now()
+	r.rows[id] = copyOf[T, PT](&row)
+	return &row, nil
+}
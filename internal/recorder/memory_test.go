@@ -0,0 +1,401 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+	"go_project/internal/attributes"
+	"go_project/internal/labels"
+	"go_project/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MemoryRecorderTestSuite struct {
+	suite.Suite
+	recorder Recorder[model.Base]
+}
+
+func (s *MemoryRecorderTestSuite) SetupTest() {
+	s.recorder = NewMemoryRecorder[model.Base]()
+}
+
+func (s *MemoryRecorderTestSuite) TestCRUD() {
+	ctx := context.Background()
+
+	// 생성
+	m := &model.Base{Name: "테스트_데이터"}
+	s.NoError(s.recorder.Insert(ctx, m))
+	s.NotZero(m.ID)
+	s.NotZero(m.CreatedAt)
+
+	// 조회 결과를 수정해도 저장된 값은 바뀌지 않음
+	got, err := s.recorder.Get(ctx, m.ID)
+	s.NoError(err)
+	got.Name = "바꾼_값"
+	again, _ := s.recorder.Get(ctx, m.ID)
+	s.Equal("테스트_데이터", again.Name)
+
+	// 수정
+	m.Name = "수정된_데이터"
+	s.NoError(s.recorder.Modify(ctx, m))
+	got, _ = s.recorder.Get(ctx, m.ID)
+	s.Equal("수정된_데이터", got.Name)
+
+	// 삭제
+	s.NoError(s.recorder.Remove(ctx, m))
+	_, err = s.recorder.Get(ctx, m.ID)
+	s.True(errors.Is(err, gorm.ErrRecordNotFound))
+}
+
+func (s *MemoryRecorderTestSuite) TestBatch_AllOrNothing() {
+	ctx := context.Background()
+	ms := []*model.Base{{Name: "일괄1"}, {Name: "일괄2"}}
+	s.NoError(s.recorder.BatchInsert(ctx, ms))
+
+	err := s.recorder.BatchModify(ctx, []*model.Base{
+		{ID: ms[0].ID, Name: "수정1"},
+		{ID: 999, Name: "없는_데이터"},
+	})
+	s.True(errors.Is(err, gorm.ErrRecordNotFound))
+	got, _ := s.recorder.Get(ctx, ms[0].ID)
+	s.Equal("일괄1", got.Name)
+
+	err = s.recorder.BatchRemove(ctx, []uint{ms[0].ID, 999})
+	s.True(errors.Is(err, gorm.ErrRecordNotFound))
+	all, _ := s.recorder.GetAll(ctx)
+	s.Len(all, 2)
+
+	s.NoError(s.recorder.BatchRemove(ctx, []uint{ms[0].ID, ms[1].ID}))
+	all, _ = s.recorder.GetAll(ctx)
+	s.Empty(all)
+}
+
+func (s *MemoryRecorderTestSuite) TestList() {
+	ctx := context.Background()
+	for _, name := range []string{"데이터1", "데이터2", "데이터3"} {
+		s.recorder.Insert(ctx, &model.Base{Name: name})
+	}
+
+	tests := []struct {
+		name      string
+		opts      model.ListOptions
+		wantNames []string
+	}{
+		{name: "첫_페이지", opts: model.ListOptions{Page: 1, Size: 2}, wantNames: []string{"데이터1", "데이터2"}},
+		{name: "마지막_페이지", opts: model.ListOptions{Page: 2, Size: 2}, wantNames: []string{"데이터3"}},
+		{name: "범위_밖", opts: model.ListOptions{Page: 5, Size: 2}, wantNames: nil},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got, total, err := s.recorder.List(ctx, tt.opts)
+			s.NoError(err)
+			s.Equal(int64(3), total)
+			var names []string
+			for _, m := range got {
+				names = append(names, m.Name)
+			}
+			s.Equal(tt.wantNames, names)
+		})
+	}
+}
+
+func (s *MemoryRecorderTestSuite) TestGetByNameAndStream() {
+	ctx := context.Background()
+	s.recorder.Insert(ctx, &model.Base{Name: "데이터1"})
+	s.recorder.Insert(ctx, &model.Base{Name: "데이터2"})
+
+	got, err := s.recorder.GetByName(ctx, "데이터2")
+	s.NoError(err)
+	s.Equal("데이터2", got.Name)
+
+	var names []string
+	err = s.recorder.Stream(ctx, func(m *model.Base) error {
+		names = append(names, m.Name)
+		return nil
+	})
+	s.NoError(err)
+	s.Equal([]string{"데이터1", "데이터2"}, names)
+}
+
+func (s *MemoryRecorderTestSuite) TestFilterAndGetMany() {
+	ctx := context.Background()
+	for _, name := range []string{"apple", "banana", "Pineapple"} {
+		s.recorder.Insert(ctx, &model.Base{Name: name})
+	}
+
+	got, total, err := s.recorder.List(ctx, model.ListOptions{Page: 1, Size: 10, NameContains: "APPLE"})
+	s.NoError(err)
+	s.Equal(int64(2), total)
+	s.Len(got, 2)
+
+	got, total, _ = s.recorder.List(ctx, model.ListOptions{Page: 1, Size: 10, IDs: []uint{2, 3}, NameContains: "apple"})
+	s.Equal(int64(1), total)
+	s.Equal("Pineapple", got[0].Name)
+
+	// 없는 ID는 결과에서 빠지고, 빈 목록은 빈 결과
+	many, err := s.recorder.GetMany(ctx, []uint{3, 1, 99})
+	s.NoError(err)
+	s.Len(many, 2)
+	many, _ = s.recorder.GetMany(ctx, nil)
+	s.Empty(many)
+}
+
+func (s *MemoryRecorderTestSuite) TestLabels() {
+	ctx := context.Background()
+	m := &model.Base{Name: "a", Labels: labels.Set{"env": "prod"}}
+	s.NoError(s.recorder.Insert(ctx, m))
+	s.NoError(s.recorder.Insert(ctx, &model.Base{Name: "b"}))
+
+	// 호출자가 라벨 맵을 수정해도 저장된 값은 바뀌지 않음
+	m.Labels["env"] = "dev"
+	got, _ := s.recorder.Get(ctx, m.ID)
+	s.Equal("prod", got.Labels["env"])
+	got.Labels["env"] = "dev"
+	again, _ := s.recorder.Get(ctx, m.ID)
+	s.Equal("prod", again.Labels["env"])
+
+	selector, _ := labels.ParseSelector("env notin (dev)")
+	list, total, err := s.recorder.List(ctx, model.ListOptions{Selector: selector})
+	s.NoError(err)
+	s.Equal(int64(2), total)
+	s.Len(list, 2)
+
+	selector, _ = labels.ParseSelector("env")
+	list, _, _ = s.recorder.List(ctx, model.ListOptions{Selector: selector})
+	s.Len(list, 1)
+	s.Equal("a", list[0].Name)
+}
+
+func (s *MemoryRecorderTestSuite) TestAttributes() {
+	ctx := context.Background()
+	m := &model.Base{Name: "a", Attributes: attributes.Map{"owner": map[string]any{"email": "a@example.com"}}}
+	s.NoError(s.recorder.Insert(ctx, m))
+	s.NoError(s.recorder.Insert(ctx, &model.Base{Name: "b", Attributes: attributes.Map{"owner": map[string]any{"email": "b@example.com"}}}))
+
+	// 중첩된 값도 복사되므로 호출자가 수정해도 저장된 값은 바뀌지 않음
+	m.Attributes["owner"].(map[string]any)["email"] = "c@example.com"
+
+	f, _ := attributes.ParseFilter("owner.email", "a@example.com")
+	list, total, err := s.recorder.List(ctx, model.ListOptions{Attributes: attributes.Filters{f}})
+	s.NoError(err)
+	s.Equal(int64(1), total)
+	s.Equal("a", list[0].Name)
+}
+
+func (s *MemoryRecorderTestSuite) TestTree() {
+	ctx := context.Background()
+	parent := func(id uint) *uint { return &id }
+	s.NoError(s.recorder.BatchInsert(ctx, []*model.Base{
+		{Name: "1"},
+		{Name: "2", ParentID: parent(1)},
+		{Name: "3", ParentID: parent(2)},
+		{Name: "4", ParentID: parent(1)},
+		// Recorder는 순환을 막지 않으므로 순환이 있어도 조회가 끝나야 함
+		{ID: 10, Name: "10", ParentID: parent(11)},
+		{ID: 11, Name: "11", ParentID: parent(10)},
+	}))
+
+	names := func(ms []*model.Base) []string {
+		var names []string
+		for _, m := range ms {
+			names = append(names, m.Name)
+		}
+		return names
+	}
+
+	descendants, err := s.recorder.Descendants(ctx, 1)
+	s.NoError(err)
+	s.Equal([]string{"2", "3", "4"}, names(descendants))
+	ancestors, err := s.recorder.Ancestors(ctx, 3)
+	s.NoError(err)
+	s.Equal([]string{"1", "2"}, names(ancestors))
+	children, _, err := s.recorder.List(ctx, model.ListOptions{ParentID: parent(1)})
+	s.NoError(err)
+	s.Equal([]string{"2", "4"}, names(children))
+
+	descendants, _ = s.recorder.Descendants(ctx, 10)
+	s.Equal([]string{"11"}, names(descendants))
+	ancestors, _ = s.recorder.Ancestors(ctx, 10)
+	s.Equal([]string{"11"}, names(ancestors))
+}
+
+func (s *MemoryRecorderTestSuite) TestTree_Integrity() {
+	ctx := context.Background()
+	parent := func(id uint) *uint { return &id }
+	s.NoError(s.recorder.BatchInsert(ctx, []*model.Base{
+		{Name: "1"},
+		{Name: "2", ParentID: parent(1)},
+		{Name: "3"},
+	}))
+
+	// 하위 리소스가 있으면 삭제하지 않음
+	var children *HasChildrenError
+	s.ErrorAs(s.recorder.Remove(ctx, &model.Base{ID: 1}), &children)
+	s.Equal(HasChildrenError{ID: 1, Child: 2}, *children)
+	s.ErrorAs(s.recorder.BatchRemove(ctx, []uint{1, 3}), &children)
+	_, err := s.recorder.Get(ctx, 3)
+	s.NoError(err, "하나라도 삭제할 수 없으면 아무것도 삭제하지 않음")
+
+	// 없는 부모 아래에는 저장하지 않음
+	s.ErrorIs(s.recorder.Insert(ctx, &model.Base{Name: "4", ParentID: parent(99)}), ErrParentNotFound)
+	_, err = s.recorder.Update(ctx, 3, func(ctx context.Context, m *model.Base) error {
+		m.ParentID = parent(99)
+		return nil
+	})
+	s.ErrorIs(err, ErrParentNotFound)
+
+	// 하위 리소스와 함께 삭제하면 삭제됨
+	s.NoError(s.recorder.BatchRemove(ctx, []uint{1, 2}))
+	s.ErrorIs(s.recorder.Insert(ctx, &model.Base{Name: "5", ParentID: parent(1)}), ErrParentNotFound)
+}
+
+func (s *MemoryRecorderTestSuite) TestSearch() {
+	ctx := context.Background()
+	s.NoError(s.recorder.BatchInsert(ctx, []*model.Base{
+		{Name: "web-server"},
+		{Name: "web server backup"},
+		{Name: "database"},
+	}))
+
+	hits, err := s.recorder.Search(ctx, "web serv", 10)
+	s.NoError(err)
+	s.Require().Len(hits, 2)
+	s.Equal("web-server", hits[0].Resource.Name)
+	s.Equal("<mark>web</mark>-<mark>server</mark>", hits[0].Snippet)
+	s.Greater(hits[0].Rank, hits[1].Rank)
+
+	// 수정과 삭제가 색인에 반영되어야 함
+	m, _ := s.recorder.Get(ctx, 3)
+	m.Name = "web database"
+	s.NoError(s.recorder.Modify(ctx, m))
+	s.NoError(s.recorder.Remove(ctx, &model.Base{ID: 1}))
+	hits, err = s.recorder.Search(ctx, "web", 10)
+	s.NoError(err)
+	s.Len(hits, 2)
+	for _, h := range hits {
+		s.NotEqual(uint(1), h.Resource.ID)
+	}
+
+	hits, err = s.recorder.Search(ctx, "없는단어", 10)
+	s.NoError(err)
+	s.Empty(hits)
+}
+
+func (s *MemoryRecorderTestSuite) TestSimilar() {
+	ctx := context.Background()
+	s.NoError(s.recorder.BatchInsert(ctx, []*model.Base{
+		{Name: "웹서버"},
+		{Name: "web-server"},
+		{Name: "database"},
+	}))
+
+	matches, err := s.recorder.Similar(ctx, "web-sever", 0.3, 10)
+	s.NoError(err)
+	s.Require().Len(matches, 1)
+	s.Equal("web-server", matches[0].Resource.Name)
+
+	matches, err = s.recorder.Similar(ctx, "웹서브", 0.3, 10)
+	s.NoError(err)
+	s.Require().NotEmpty(matches)
+	s.Equal("웹서버", matches[0].Resource.Name)
+
+	matches, err = s.recorder.Similar(ctx, "", 0, 10)
+	s.NoError(err)
+	s.Empty(matches)
+}
+
+func (s *MemoryRecorderTestSuite) TestExpired() {
+	ctx := context.Background()
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	s.NoError(s.recorder.BatchInsert(ctx, []*model.Base{
+		{Name: "web 만료됨", ExpiresAt: &past},
+		{Name: "web 남음", ExpiresAt: &future},
+		{Name: "web 영구"},
+	}))
+
+	// 만료된 리소스는 모든 조회에서 빠짐
+	_, err := s.recorder.Get(ctx, 1)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	_, err = s.recorder.GetByName(ctx, "web 만료됨")
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	all, err := s.recorder.GetAll(ctx)
+	s.NoError(err)
+	s.Len(all, 2)
+	page, total, err := s.recorder.List(ctx, model.ListOptions{})
+	s.NoError(err)
+	s.Len(page, 2)
+	s.Equal(int64(2), total)
+	many, err := s.recorder.GetMany(ctx, []uint{1, 2})
+	s.NoError(err)
+	s.Require().Len(many, 1)
+	s.Equal(uint(2), many[0].ID)
+	hits, err := s.recorder.Search(ctx, "web", 10)
+	s.NoError(err)
+	s.Len(hits, 2)
+
+	expired, err := s.recorder.Expired(ctx, time.Now())
+	s.NoError(err)
+	s.Require().Len(expired, 1)
+	s.Equal(uint(1), expired[0].ID)
+
+	// 아직 만료되지 않은 리소스는 지우지 않음
+	removed, err := s.recorder.RemoveExpired(ctx, []uint{1, 2, 3}, time.Now())
+	s.NoError(err)
+	s.Equal([]uint{1}, removed)
+	expired, err = s.recorder.Expired(ctx, future.Add(time.Second))
+	s.NoError(err)
+	s.Require().Len(expired, 1)
+	s.Equal(uint(2), expired[0].ID)
+}
+
+func (s *MemoryRecorderTestSuite) TestExpired_LiveChildren() {
+	ctx := context.Background()
+	past := time.Now().Add(-time.Minute)
+	parent := &model.Base{Name: "부모", ExpiresAt: &past}
+	s.Require().NoError(s.recorder.Insert(ctx, parent))
+	child := &model.Base{Name: "자식", ParentID: &parent.ID}
+	s.Require().NoError(s.recorder.Insert(ctx, child))
+
+	// 만료되지 않은 하위 리소스가 있으면 지울 수 없음
+	expired, err := s.recorder.Expired(ctx, time.Now())
+	s.NoError(err)
+	s.Empty(expired)
+	removed, err := s.recorder.RemoveExpired(ctx, []uint{parent.ID}, time.Now())
+	s.NoError(err)
+	s.Empty(removed)
+
+	// 하위 리소스도 만료되면 함께 지움
+	s.Require().NoError(s.recorder.Modify(ctx, &model.Base{ID: child.ID, Name: "자식", ParentID: &parent.ID, ExpiresAt: &past}))
+	expired, err = s.recorder.Expired(ctx, time.Now())
+	s.NoError(err)
+	s.Len(expired, 2)
+	removed, err = s.recorder.RemoveExpired(ctx, []uint{parent.ID, child.ID}, time.Now())
+	s.NoError(err)
+	s.ElementsMatch([]uint{parent.ID, child.ID}, removed)
+}
+
+func (s *MemoryRecorderTestSuite) TestExtend() {
+	ctx := context.Background()
+	soon, later := time.Now().Add(time.Minute), time.Now().Add(time.Hour)
+	m := &model.Base{Name: "임시", Labels: labels.Set{"env": "dev"}, ExpiresAt: &soon}
+	s.Require().NoError(s.recorder.Insert(ctx, m))
+
+	got, err := s.recorder.Extend(ctx, m.ID, later)
+	s.Require().NoError(err)
+	s.True(later.Equal(*got.ExpiresAt))
+	s.Equal("dev", got.Labels["env"])
+
+	// 이미 만료된 리소스는 되살리지 않음
+	past := time.Now().Add(-time.Second)
+	s.Require().NoError(s.recorder.Modify(ctx, &model.Base{ID: m.ID, Name: "임시", ExpiresAt: &past}))
+	_, err = s.recorder.Extend(ctx, m.ID, later)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func TestMemoryRecorderSuite(t *testing.T) {
+	suite.Run(t, new(MemoryRecorderTestSuite))
+}
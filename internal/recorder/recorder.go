@@ -2,54 +2,778 @@ package recorder
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"go_project/internal/database"
+	"go_project/internal/labels"
 	"go_project/internal/model"
+	"go_project/internal/search"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Recorder는 DB와 직접 상호작용하는 인터페이스
-type Recorder interface {
-	Insert(ctx context.Context, model *model.Base) error
-	Get(ctx context.Context, id uint) (*model.Base, error)
-	GetAll(ctx context.Context) ([]*model.Base, error)
-	Modify(ctx context.Context, model *model.Base) error
-	Remove(ctx context.Context, model *model.Base) error
+// T는 model.Base를 임베딩한 모델 (예: Recorder[model.Base])
+// 쓰기는 저장하는 리소스의 부모가 없으면 ErrParentNotFound, Remove와 BatchRemove는 함께 삭제하지 않는 하위 리소스가 있으면
+// HasChildrenError를 반환하고 아무것도 바꾸지 않음 (부모를 확인하고 삭제하는 동안 서로 기다리므로 부모 없는 리소스가 남지 않음)
+type Recorder[T any] interface {
+	Insert(ctx context.Context, model *T) error
+	Get(ctx context.Context, id uint) (*T, error)
+	GetAll(ctx context.Context) ([]*T, error)
+	Modify(ctx context.Context, model *T) error
+	// Update는 id인 리소스를 잠가서 읽고 fn으로 바꾼 값을 저장한 뒤 반환 (fn이 에러를 반환하면 저장하지 않음)
+	// 읽은 뒤 저장하기 전에 다른 쓰기가 끼어들지 않으므로 일부만 바꾸는 수정에 사용
+	Update(ctx context.Context, id uint, fn func(ctx context.Context, model *T) error) (*T, error)
+	Remove(ctx context.Context, model *T) error
+	BatchInsert(ctx context.Context, models []*T) error
+	BatchModify(ctx context.Context, models []*T) error
+	BatchRemove(ctx context.Context, ids []uint) error
+	GetByName(ctx context.Context, name string) (*T, error)
+	Stream(ctx context.Context, fn func(*T) error) error
+	List(ctx context.Context, opts model.ListOptions) ([]*T, int64, error)
+	GetMany(ctx context.Context, ids []uint) ([]*T, error)
+	Descendants(ctx context.Context, id uint) ([]*T, error)
+	Ancestors(ctx context.Context, id uint) ([]*T, error)
+	Searcher[T]
+	Expirer[T]
 }
 
-type recorder struct {
+// Searcher는 리소스 이름 검색 인터페이스
+//
+// Search는 검색어의 단어마다 접두사가 일치하는 단어가 이름에 있는 리소스를 관련도 순으로 최대 limit개 반환
+// Similar는 이름이 name과 threshold(0~1) 이상 비슷한 리소스를 유사도 순으로 최대 limit개 반환 (오타 허용)
+type Searcher[T any] interface {
+	Search(ctx context.Context, query string, limit int) ([]model.SearchHit[T], error)
+	Similar(ctx context.Context, name string, threshold float64, limit int) ([]model.FuzzyMatch[T], error)
+}
+
+// Expirer는 만료된 리소스 정리 인터페이스
+//
+// 만료 시각(Base.ExpiresAt)이 지난 리소스는 다른 메서드의 조회 결과에서 빠지고 이 인터페이스로만 조회할 수 있음
+// Expired는 before까지 만료됐고 before에 만료되지 않은 하위 리소스가 없는(지울 수 있는) 리소스를 ID 순으로 반환
+// RemoveExpired는 ids 중 삭제할 때도 Expired의 조건에 맞는 리소스만 삭제하고 삭제한 ID를 반환
+// (그 사이 만료 시각을 늦췄거나 하위 리소스가 생긴 리소스는 남김)
+// Extend는 id가 아직 만료되지 않았을 때만 만료 시각을 expiresAt으로 바꾸고 바뀐 리소스를 반환
+// (없거나 이미 만료됐으면 gorm.ErrRecordNotFound, 다른 필드는 바꾸지 않음)
+type Expirer[T any] interface {
+	Expired(ctx context.Context, before time.Time) ([]*T, error)
+	RemoveExpired(ctx context.Context, ids []uint, before time.Time) ([]uint, error)
+	Extend(ctx context.Context, id uint, expiresAt time.Time) (*T, error)
+}
+
+// 일괄 생성 시 한 번의 INSERT에 담을 행 수
+const batchSize = 100
+
+// ErrParentNotFound는 저장하려는 리소스의 부모(Base.ParentID)가 없을 때 반환 (errors.Is로 확인)
+// 부모를 확인한 뒤 저장하기 전에 다른 요청이 부모를 삭제한 경우
+var ErrParentNotFound = errors.New("부모 리소스가 없습니다")
+
+// HasChildrenError는 Remove, BatchRemove로 삭제하려는 리소스에 함께 삭제하지 않는 (만료되지 않은) 하위 리소스가 있을 때 반환
+// 삭제할 리소스를 잠근 뒤 같은 트랜잭션에서 확인하므로, 확인한 뒤에 생긴 하위 리소스가 부모 없이 남지 않음
+type HasChildrenError struct {
+	ID    uint // 삭제하려던 리소스
+	Child uint // 남아 있는 하위 리소스
+}
+
+func (e *HasChildrenError) Error() string {
+	return fmt.Sprintf("하위 리소스(%d)가 있어 리소스(%d)를 삭제할 수 없습니다", e.Child, e.ID)
+}
+
+// Conn은 요청마다 읽기와 쓰기에 쓸 DB 연결을 고르는 인터페이스 (database.Cluster가 구현)
+type Conn interface {
+	// Primary는 스키마 해석 등 쿼리 외의 용도로 쓸 primary 연결을 반환
+	Primary() *gorm.DB
+	// Reader는 ctx를 적용한 읽기 연결을 반환 (복제본일 수 있음)
+	Reader(ctx context.Context) *gorm.DB
+	// Writer는 ctx를 적용한 primary 연결을 반환
+	Writer(ctx context.Context) *gorm.DB
+}
+
+// singleConn은 읽기와 쓰기에 같은 연결을 쓰는 Conn (ctx에 트랜잭션이 있으면 트랜잭션)
+type singleConn struct {
 	db *gorm.DB
 }
 
-func NewRecorder(db *gorm.DB) Recorder {
-	return &recorder{
-		db: db,
+func (c singleConn) Primary() *gorm.DB                   { return c.db }
+func (c singleConn) Reader(ctx context.Context) *gorm.DB { return c.Writer(ctx) }
+func (c singleConn) Writer(ctx context.Context) *gorm.DB {
+	if tx := database.TxFrom(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return c.db.WithContext(ctx)
+}
+
+// 재시도 간격의 최댓값
+const maxRetryWait = 2 * time.Second
+
+// Option은 Recorder 설정
+type Option func(*options)
+
+type options struct {
+	queryTimeout time.Duration
+	attempts     int
+	retryWait    time.Duration
+}
+
+// WithQueryTimeout은 쿼리 하나의 제한 시간을 설정 (재시도마다 새로 적용하며 Stream에는 적용하지 않음)
+// 제한 시간을 넘긴 쿼리는 database.ErrQueryTimeout으로 실패
+func WithQueryTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.queryTimeout = d
+	}
+}
+
+// WithRetry는 일시적인 DB 에러(database.Retryable)로 실패한 쿼리를 최대 attempts번까지 실행하도록 설정
+// 재시도 간격은 wait부터 두 배씩 늘리고(최대 2초) 지터를 더함
+// 쓰기는 서버에 반영되지 않은 것이 확실한 에러만 재시도하고, Stream은 재시도하지 않음
+func WithRetry(attempts int, wait time.Duration) Option {
+	return func(o *options) {
+		o.attempts = attempts
+		o.retryWait = wait
+	}
+}
+
+type recorder[T any, PT model.Model[T]] struct {
+	conn Conn
+	runner
+}
+
+// NewRecorder는 T의 테이블을 사용하는 Recorder를 생성 (예: NewRecorder[model.Base](db))
+func NewRecorder[T any, PT model.Model[T]](db *gorm.DB, opts ...Option) Recorder[T] {
+	return NewReplicatedRecorder[T, PT](singleConn{db: db}, opts...)
+}
+
+// NewReplicatedRecorder는 조회(Get, GetAll, List, GetMany, Stream, Search, Similar)는 conn.Reader로,
+// 나머지(쓰기와 쓰기 전 검증에 쓰는 조회)는 conn.Writer로 보내는 Recorder를 생성
+// (예: NewReplicatedRecorder[model.Base](cluster))
+func NewReplicatedRecorder[T any, PT model.Model[T]](conn Conn, opts ...Option) Recorder[T] {
+	return &recorder[T, PT]{
+		conn:   conn,
+		runner: newRunner(opts),
+	}
+}
+
+// runner는 Option의 쿼리 제한 시간과 재시도를 적용해 쿼리를 실행 (Recorder 구현마다 임베딩)
+type runner struct {
+	opts options
+}
+
+func newRunner(opts []Option) runner {
+	r := runner{opts: options{attempts: 1}}
+	for _, opt := range opts {
+		opt(&r.opts)
+	}
+	return r
+}
+
+// run은 쿼리 제한 시간을 적용해 fn을 실행하고, 재시도할 수 있는 에러면 기다렸다가 다시 실행
+// 재시도할 때마다 Reader/Writer로 연결을 다시 고르므로 fn 안에서 연결을 가져와야 하고,
+// fn은 처음부터 다시 실행되므로 결과를 담을 변수를 매번 새로 채워야 함
+// ctx에 트랜잭션이 있으면 실패한 트랜잭션에서는 다시 실행할 수 없으므로 재시도하지 않음
+func (r runner) run(ctx context.Context, idempotent bool, fn func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := r.attempt(ctx, fn)
+		if attempt >= r.opts.attempts || database.TxFrom(ctx) != nil || !database.Retryable(err, idempotent) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(database.Backoff(attempt-1, r.opts.retryWait, maxRetryWait)):
+		}
+	}
+}
+
+func (r runner) attempt(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.opts.queryTimeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, r.opts.queryTimeout)
+	defer cancel()
+	err := fn(ctx)
+	// 회로 차단기를 거치지 않는 연결(NewRecorder)에서도 제한 시간 초과를 같은 에러로 반환
+	if err != nil && !errors.Is(err, database.ErrUnavailable) && errors.Is(context.Cause(ctx), database.ErrQueryTimeout) {
+		return fmt.Errorf("%w: %w", database.ErrQueryTimeout, err)
+	}
+	return err
+}
+
+func (r *recorder[T, PT]) Insert(ctx context.Context, model *T) error {
+	return r.run(ctx, false, func(ctx context.Context) error {
+		if PT(model).GetBase().ParentID == nil {
+			return r.conn.Writer(ctx).Create(model).Error
+		}
+		return r.conn.Writer(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := r.lockParents(tx, model); err != nil {
+				return err
+			}
+			return tx.Create(model).Error
+		})
+	})
+}
+
+func (r *recorder[T, PT]) Get(ctx context.Context, id uint) (*T, error) {
+	var m T
+	err := r.run(ctx, true, func(ctx context.Context) error {
+		return r.conn.Reader(ctx).Scopes(unexpired).First(&m, id).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *recorder[T, PT]) GetAll(ctx context.Context) ([]*T, error) {
+	var ms []*T
+	err := r.run(ctx, true, func(ctx context.Context) error {
+		ms = nil
+		return r.conn.Reader(ctx).Scopes(unexpired).Find(&ms).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+func (r *recorder[T, PT]) Modify(ctx context.Context, model *T) error {
+	return r.run(ctx, false, func(ctx context.Context) error {
+		if PT(model).GetBase().ParentID == nil {
+			return r.conn.Writer(ctx).Save(model).Error
+		}
+		return r.conn.Writer(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := r.lockParents(tx, model); err != nil {
+				return err
+			}
+			return tx.Save(model).Error
+		})
+	})
+}
+
+// lockParents는 models의 부모를 SELECT ... FOR KEY SHARE로 잠가서 tx가 끝날 때까지 removeLeaves가 부모를 삭제하지 못하게 함
+// 부모가 이미 삭제됐으면 ErrParentNotFound (같이 저장하는 models 중에 ID가 정해진 부모는 잠그지 않음)
+func (r *recorder[T, PT]) lockParents(tx *gorm.DB, models ...*T) error {
+	saving := make(map[uint]bool, len(models))
+	for _, m := range models {
+		if id := PT(m).GetBase().ID; id != 0 {
+			saving[id] = true
+		}
+	}
+	var ids []uint
+	for _, m := range models {
+		if p := PT(m).GetBase().ParentID; p != nil && !saving[*p] && !slices.Contains(ids, *p) {
+			ids = append(ids, *p)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	var found []uint
+	err := tx.Model(new(T)).Clauses(clause.Locking{Strength: "KEY SHARE"}).
+		Where("id IN ?", ids).Order("id").Pluck("id", &found).Error
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if !slices.Contains(found, id) {
+			return fmt.Errorf("%w: %d", ErrParentNotFound, id)
+		}
+	}
+	return nil
+}
+
+// Update는 한 트랜잭션에서 SELECT ... FOR UPDATE로 행을 잠근 뒤 fn으로 바꾼 값을 저장
+// 잠근 동안 다른 쓰기는 기다리므로 읽은 뒤에 바뀐 내용을 덮어쓰지 않음
+// fn에 넘기는 ctx에는 트랜잭션이 있어서 fn 안의 조회도 같은 트랜잭션에서 실행되며,
+// 직렬화 실패나 교착 상태로 트랜잭션 전체를 다시 실행하면 fn도 새로 읽은 값으로 다시 호출됨
+func (r *recorder[T, PT]) Update(ctx context.Context, id uint, fn func(ctx context.Context, model *T) error) (*T, error) {
+	var m T
+	err := r.run(ctx, false, func(ctx context.Context) error {
+		return r.conn.Writer(ctx).Transaction(func(tx *gorm.DB) error {
+			var zero T
+			m = zero
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(unexpired).First(&m, id).Error; err != nil {
+				return err
+			}
+			if err := fn(database.WithTx(ctx, tx), &m); err != nil {
+				return err
+			}
+			PT(&m).GetBase().ID = id
+			if err := r.lockParents(tx, &m); err != nil {
+				return err
+			}
+			return tx.Save(&m).Error
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Remove는 하위 리소스가 없을 때만 삭제 (있으면 HasChildrenError)
+func (r *recorder[T, PT]) Remove(ctx context.Context, model *T) error {
+	return r.run(ctx, false, func(ctx context.Context) error {
+		return r.removeLeaves(ctx, []uint{PT(model).GetBase().ID}, false)
+	})
+}
+
+// removeLeaves는 한 트랜잭션에서 ids를 SELECT ... FOR UPDATE로 잠그고, 함께 삭제하지 않는 하위 리소스가 없을 때만 삭제
+// 하위 리소스를 저장하는 쓰기는 lockParents에서 부모의 잠금을 기다리므로, 확인한 뒤 삭제하기 전에 하위 리소스가 생기지 않음
+// all이면 ids 중 하나라도 없을 때 아무것도 삭제하지 않고 gorm.ErrRecordNotFound를 반환
+func (r *recorder[T, PT]) removeLeaves(ctx context.Context, ids []uint, all bool) error {
+	return r.conn.Writer(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked []uint
+		err := tx.Model(new(T)).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", ids).Order("id").Pluck("id", &locked).Error
+		if err != nil {
+			return err
+		}
+		var child T
+		err = tx.Scopes(unexpired).Where("parent_id IN ? AND id NOT IN ?", ids, ids).Order("id").Take(&child).Error
+		if err == nil {
+			c := PT(&child).GetBase()
+			return &HasChildrenError{ID: *c.ParentID, Child: c.ID}
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if all && len(locked) != len(ids) {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Delete(new(T), ids).Error
+	})
+}
+
+// 일괄 처리는 모두 하나의 트랜잭션에서 실행되어 하나라도 실패하면 전체가 롤백됨
+// 직렬화 실패나 교착 상태로 롤백되면 트랜잭션 전체를 다시 실행
+func (r *recorder[T, PT]) BatchInsert(ctx context.Context, models []*T) error {
+	return r.run(ctx, false, func(ctx context.Context) error {
+		return r.conn.Writer(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := r.lockParents(tx, models...); err != nil {
+				return err
+			}
+			return tx.CreateInBatches(models, batchSize).Error
+		})
+	})
+}
+
+func (r *recorder[T, PT]) BatchModify(ctx context.Context, models []*T) error {
+	return r.run(ctx, false, func(ctx context.Context) error {
+		return r.batchModify(ctx, models)
+	})
+}
+
+func (r *recorder[T, PT]) batchModify(ctx context.Context, models []*T) error {
+	return r.conn.Writer(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.lockParents(tx, models...); err != nil {
+			return err
+		}
+		for _, m := range models {
+			// Save는 없는 ID를 새로 생성하므로 Updates로 존재하는 행만 수정
+			result := tx.Model(m).Select("*").Omit("created_at").Updates(m)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		return nil
+	})
+}
+
+func (r *recorder[T, PT]) BatchRemove(ctx context.Context, ids []uint) error {
+	return r.run(ctx, false, func(ctx context.Context) error {
+		return r.removeLeaves(ctx, ids, true)
+	})
+}
+
+func (r *recorder[T, PT]) GetByName(ctx context.Context, name string) (*T, error) {
+	var m T
+	err := r.run(ctx, true, func(ctx context.Context) error {
+		return r.conn.Writer(ctx).Scopes(unexpired).Where("name = ?", name).First(&m).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Stream은 전체 테이블을 메모리에 올리지 않도록 batchSize 단위로 나눠 읽으면서 fn을 호출
+// 오래 걸릴 수 있고 fn을 이미 호출한 행을 다시 보낼 수 없으므로 쿼리 제한 시간과 재시도를 적용하지 않음
+func (r *recorder[T, PT]) Stream(ctx context.Context, fn func(*T) error) error {
+	var ms []*T
+	return r.conn.Reader(ctx).Scopes(unexpired).FindInBatches(&ms, batchSize, func(tx *gorm.DB, batch int) error {
+		for _, m := range ms {
+			if err := fn(m); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// List는 ID 순으로 한 페이지를 조회하고 전체 개수를 함께 반환
+func (r *recorder[T, PT]) List(ctx context.Context, opts model.ListOptions) ([]*T, int64, error) {
+	var total int64
+	var ms []*T
+	err := r.run(ctx, true, func(ctx context.Context) error {
+		// 개수와 목록이 서로 다른 복제본에서 조회되지 않도록 같은 연결 사용
+		db := r.conn.Reader(ctx)
+		if err := db.Model(new(T)).Scopes(listFilter(opts)).Count(&total).Error; err != nil {
+			return err
+		}
+
+		query := db.Scopes(listFilter(opts)).Order("id").Offset(opts.Offset())
+		if opts.Size > 0 {
+			query = query.Limit(opts.Size)
+		}
+		ms = nil
+		return query.Find(&ms).Error
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return ms, total, nil
+}
+
+// listFilter는 ListOptions의 필터 조건을 WHERE 절로 추가 (만료된 리소스는 항상 제외)
+func listFilter(opts model.ListOptions) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = unexpired(db)
+		if len(opts.IDs) > 0 {
+			db = db.Where("id IN ?", opts.IDs)
+		}
+		if opts.NameContains != "" {
+			db = db.Where("name ILIKE ?", "%"+likeEscaper.Replace(opts.NameContains)+"%")
+		}
+		for _, req := range opts.Selector {
+			db = db.Where(selectorCondition(req))
+		}
+		if opts.ParentID != nil {
+			db = db.Where("parent_id = ?", *opts.ParentID)
+		}
+		for _, f := range opts.Attributes {
+			db = db.Where("attributes #>> ? = ?", jsonPath(f.Path), f.Value)
+		}
+		return db
+	}
+}
+
+// unexpired는 만료 시각이 없거나 아직 지나지 않은 리소스만 남기는 조건을 추가
+// 서버마다 시계가 조금씩 다를 수 있지만 memoryRecorder와 같은 기준이 되도록 DB의 now() 대신 서버 시각을 씀
+func unexpired(db *gorm.DB) *gorm.DB {
+	return db.Where("expires_at IS NULL OR expires_at > ?", time.Now())
+}
+
+// selectorCondition은 라벨 셀렉터 조건 하나를 labels JSONB 열에 대한 조건으로 변환
+// !=, notin은 Kubernetes와 같이 키가 없는 리소스도 포함
+func selectorCondition(req labels.Requirement) clause.Expr {
+	switch req.Operator {
+	case labels.Equals:
+		return gorm.Expr("labels->>? = ?", req.Key, req.Values[0])
+	case labels.NotEquals:
+		return gorm.Expr("(labels->>? IS NULL OR labels->>? <> ?)", req.Key, req.Key, req.Values[0])
+	case labels.In:
+		return gorm.Expr("labels->>? IN ?", req.Key, req.Values)
+	case labels.NotIn:
+		return gorm.Expr("(labels->>? IS NULL OR labels->>? NOT IN ?)", req.Key, req.Key, req.Values)
+	case labels.Exists:
+		return gorm.Expr("labels->>? IS NOT NULL", req.Key)
+	default:
+		return gorm.Expr("labels->>? IS NULL", req.Key)
+	}
+}
+
+// jsonPath는 경로를 #>> 연산자에 넘길 text[] 리터럴로 변환 (예: {"owner","email"})
+func jsonPath(path []string) string {
+	quoted := make([]string, len(path))
+	for i, p := range path {
+		quoted[i] = `"` + arrayEscaper.Replace(p) + `"`
+	}
+	return "{" + strings.Join(quoted, ",") + "}"
+}
+
+// 배열 리터럴의 따옴표 안에서는 \와 "만 이스케이프하면 됨
+var arrayEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// LIKE 패턴의 특수 문자를 그대로 비교하도록 이스케이프 (PostgreSQL 기본 이스케이프 문자는 \)
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// GetMany는 ID 목록에 해당하는 리소스를 한 번의 쿼리로 조회
+// 없는 ID는 결과에서 빠지며 에러로 처리하지 않음
+func (r *recorder[T, PT]) GetMany(ctx context.Context, ids []uint) ([]*T, error) {
+	var ms []*T
+	if len(ids) == 0 {
+		return ms, nil
+	}
+	err := r.run(ctx, true, func(ctx context.Context) error {
+		ms = nil
+		return r.conn.Reader(ctx).Scopes(unexpired).Where("id IN ?", ids).Order("id").Find(&ms).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+// 조상을 따라 올라갈 최대 깊이 (데이터에 순환이 있어도 쿼리가 끝나도록 제한)
+const maxTreeDepth = 1000
+
+// tableName은 T가 저장된 테이블 이름을 반환
+func (r *recorder[T, PT]) tableName() (string, error) {
+	stmt := &gorm.Statement{DB: r.conn.Primary()}
+	if err := stmt.Parse(new(T)); err != nil {
+		return "", err
+	}
+	return stmt.Schema.Table, nil
+}
+
+// Descendants는 재귀 CTE로 id 아래의 모든 하위 리소스를 ID 순으로 조회 (id 자신은 제외)
+// UNION은 중복 행을 버리므로 데이터에 순환이 있어도 끝남
+// 만료된 리소스는 결과에서 빠지지만 그 아래의 하위 리소스는 포함
+func (r *recorder[T, PT]) Descendants(ctx context.Context, id uint) ([]*T, error) {
+	table, err := r.tableName()
+	if err != nil {
+		return nil, err
+	}
+	subtree := gorm.Expr(`WITH RECURSIVE subtree AS (
+		SELECT id FROM `+table+` WHERE parent_id = ?
+		UNION
+		SELECT t.id FROM `+table+` t JOIN subtree s ON t.parent_id = s.id
+	) SELECT id FROM subtree`, id)
+
+	var ms []*T
+	err = r.run(ctx, true, func(ctx context.Context) error {
+		ms = nil
+		return r.conn.Writer(ctx).Scopes(unexpired).Where("id IN (?)", subtree).Order("id").Find(&ms).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+// Ancestors는 재귀 CTE로 id의 조상을 최상위부터 직계 부모 순으로 조회 (id 자신은 제외)
+// 만료된 조상은 결과에서 빠지지만 그 위의 조상은 포함 (순환 확인에 쓰므로 중간에 멈추지 않음)
+func (r *recorder[T, PT]) Ancestors(ctx context.Context, id uint) ([]*T, error) {
+	table, err := r.tableName()
+	if err != nil {
+		return nil, err
+	}
+	var ms []*T
+	err = r.run(ctx, true, func(ctx context.Context) error {
+		ms = nil
+		return r.conn.Writer(ctx).Raw(`WITH RECURSIVE chain AS (
+			SELECT parent_id, 1 AS depth FROM `+table+` WHERE id = ?
+			UNION ALL
+			SELECT t.parent_id, c.depth + 1 FROM `+table+` t JOIN chain c ON t.id = c.parent_id WHERE c.depth < ?
+		) SELECT t.* FROM `+table+` t JOIN chain c ON t.id = c.parent_id
+		WHERE t.expires_at IS NULL OR t.expires_at > ? ORDER BY c.depth DESC`, id, maxTreeDepth, time.Now()).Scan(&ms).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+// ts_headline 옵션 (이름 전체를 보여주고 일치한 단어를 search.HighlightStart/Stop으로 감쌈)
+var headlineOptions = "StartSel=" + search.HighlightStart + ", StopSel=" + search.HighlightStop + ", HighlightAll=true"
+
+// Search는 이름의 tsvector('simple' 설정)로 검색 (Base.Name의 GIN 표현식 인덱스 사용)
+func (r *recorder[T, PT]) Search(ctx context.Context, query string, limit int) ([]model.SearchHit[T], error) {
+	tsquery := prefixQuery(query)
+	if tsquery == "" {
+		return []model.SearchHit[T]{}, nil
+	}
+	table, err := r.tableName()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		ID      uint
+		Rank    float64
+		Snippet string
 	}
+	err = r.run(ctx, true, func(ctx context.Context) error {
+		rows = nil
+		return r.conn.Reader(ctx).Raw(`SELECT t.id, ts_rank(to_tsvector('simple', t.name), q) AS rank,
+			ts_headline('simple', t.name, q, ?) AS snippet
+			FROM `+table+` t, to_tsquery('simple', ?) q
+			WHERE to_tsvector('simple', t.name) @@ q AND (t.expires_at IS NULL OR t.expires_at > ?)
+			ORDER BY rank DESC, t.id LIMIT ?`, headlineOptions, tsquery, time.Now(), limit).Scan(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	ms, err := r.GetMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*T, len(ms))
+	for _, m := range ms {
+		byID[PT(m).GetBase().ID] = m
+	}
+
+	hits := make([]model.SearchHit[T], 0, len(rows))
+	for _, row := range rows {
+		// 두 쿼리 사이에 삭제되거나 만료된 리소스는 건너뜀
+		if m, ok := byID[row.ID]; ok {
+			hits = append(hits, model.SearchHit[T]{Resource: m, Rank: row.Rank, Snippet: row.Snippet})
+		}
+	}
+	return hits, nil
 }
 
-func (r *recorder) Insert(ctx context.Context, model *model.Base) error {
-	return r.db.WithContext(ctx).Create(model).Error
+// prefixQuery는 검색어를 단어마다 접두사로 찾는 tsquery로 변환 (예: "web serv" → 'web':* & 'serv':*)
+// search.Terms의 단어는 글자와 숫자로만 이루어지므로 따옴표를 이스케이프할 필요가 없음
+func prefixQuery(query string) string {
+	terms := search.Terms(query)
+	for i, t := range terms {
+		terms[i] = "'" + t + "':*"
+	}
+	return strings.Join(terms, " & ")
 }
 
-func (r *recorder) Get(ctx context.Context, id uint) (*model.Base, error) {
-	var base model.Base
-	if err := r.db.WithContext(ctx).First(&base, id).Error; err != nil {
+// Similar는 pg_trgm의 similarity()로 검색 (pg_trgm 확장과 Base.Name의 gin_trgm_ops 인덱스 필요)
+func (r *recorder[T, PT]) Similar(ctx context.Context, name string, threshold float64, limit int) ([]model.FuzzyMatch[T], error) {
+	if strings.TrimSpace(name) == "" {
+		return []model.FuzzyMatch[T]{}, nil
+	}
+	table, err := r.tableName()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		ID    uint
+		Score float64
+	}
+	err = r.run(ctx, true, func(ctx context.Context) error {
+		rows = nil
+		return r.conn.Reader(ctx).Transaction(func(tx *gorm.DB) error {
+			// % 연산자는 인덱스를 사용하지만 기준값이 설정으로 정해지므로 이 트랜잭션에서만 threshold로 바꿈
+			if err := tx.Exec("SELECT set_config('pg_trgm.similarity_threshold', ?, true)", strconv.FormatFloat(threshold, 'f', -1, 64)).Error; err != nil {
+				return err
+			}
+			return tx.Raw(`SELECT id, similarity(name, ?) AS score FROM `+table+`
+				WHERE name % ? AND (expires_at IS NULL OR expires_at > ?)
+				ORDER BY score DESC, id LIMIT ?`, name, name, time.Now(), limit).Scan(&rows).Error
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
-	return &base, nil
+
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	ms, err := r.GetMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*T, len(ms))
+	for _, m := range ms {
+		byID[PT(m).GetBase().ID] = m
+	}
+
+	matches := make([]model.FuzzyMatch[T], 0, len(rows))
+	for _, row := range rows {
+		if m, ok := byID[row.ID]; ok {
+			matches = append(matches, model.FuzzyMatch[T]{Resource: m, Score: row.Score})
+		}
+	}
+	return matches, nil
+}
+
+// Expired는 만료 시각(expires_at 인덱스)으로 before까지 만료된 리소스를 조회하고,
+// 만료되지 않은 하위 리소스가 있는 리소스는 NOT EXISTS 조건(parent_id 인덱스)으로 같은 쿼리에서 뺌
+// 정리 작업이 만료된 직후의 리소스를 빠뜨리지 않도록 복제본이 아닌 primary에서 조회
+func (r *recorder[T, PT]) Expired(ctx context.Context, before time.Time) ([]*T, error) {
+	removable, err := r.removable(before)
+	if err != nil {
+		return nil, err
+	}
+	var ms []*T
+	err = r.run(ctx, true, func(ctx context.Context) error {
+		ms = nil
+		return r.conn.Writer(ctx).Where(removable).Order("id").Find(&ms).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ms, nil
 }
 
-func (r *recorder) GetAll(ctx context.Context) ([]*model.Base, error) {
-	var bases []*model.Base
-	if err := r.db.WithContext(ctx).Find(&bases).Error; err != nil {
+// RemoveExpired는 조회와 삭제 사이에 만료 시각이 바뀌었거나 하위 리소스가 생긴 리소스를 지우지 않도록 삭제할 때 조건을 다시 확인
+// 삭제한 행은 RETURNING으로 받아서 ID를 반환
+func (r *recorder[T, PT]) RemoveExpired(ctx context.Context, ids []uint, before time.Time) ([]uint, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	removable, err := r.removable(before)
+	if err != nil {
+		return nil, err
+	}
+	var removed []T
+	err = r.run(ctx, false, func(ctx context.Context) error {
+		removed = nil
+		return r.conn.Writer(ctx).Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("id IN ?", ids).Where(removable).Delete(&removed).Error
+	})
+	if err != nil {
 		return nil, err
 	}
-	return bases, nil
+	removedIDs := make([]uint, len(removed))
+	for i := range removed {
+		removedIDs[i] = PT(&removed[i]).GetBase().ID
+	}
+	return removedIDs, nil
 }
 
-func (r *recorder) Modify(ctx context.Context, model *model.Base) error {
-	return r.db.WithContext(ctx).Save(model).Error
+// removable은 before까지 만료됐고 before에 만료되지 않은 하위 리소스가 없는 행의 조건을 반환
+func (r *recorder[T, PT]) removable(before time.Time) (clause.Expr, error) {
+	table, err := r.tableName()
+	if err != nil {
+		return clause.Expr{}, err
+	}
+	return gorm.Expr(table+`.expires_at <= ? AND NOT EXISTS (
+		SELECT 1 FROM `+table+` c WHERE c.parent_id = `+table+`.id AND (c.expires_at IS NULL OR c.expires_at > ?)
+	)`, before, before), nil
 }
 
-func (r *recorder) Remove(ctx context.Context, model *model.Base) error {
-	return r.db.WithContext(ctx).Delete(model).Error
+// Extend는 만료되지 않은 행의 만료 시각만 조건부 UPDATE로 바꾸고 RETURNING으로 바뀐 행을 읽음
+// 조회와 수정 사이에 만료된 리소스를 되살리지 않고, 다른 열은 쓰지 않으므로 동시에 들어온 수정을 덮어쓰지 않음
+func (r *recorder[T, PT]) Extend(ctx context.Context, id uint, expiresAt time.Time) (*T, error) {
+	var m T
+	err := r.run(ctx, false, func(ctx context.Context) error {
+		var zero T
+		m = zero
+		result := r.conn.Writer(ctx).Model(&m).Clauses(clause.Returning{}).
+			Where("id = ? AND (expires_at IS NULL OR expires_at > ?)", id, time.Now()).
+			Update("expires_at", expiresAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 const (
@@ -24,7 +25,7 @@ const (
 type RecorderTestSuite struct {
 	suite.Suite
 	db       *gorm.DB
-	recorder Recorder
+	recorder Recorder[model.Base]
 }
 
 func (s *RecorderTestSuite) SetupSuite() {
@@ -36,12 +37,13 @@ func (s *RecorderTestSuite) SetupSuite() {
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	s.Require().NoError(err)
 
-	// 테이블 자동 생성
+	// 유사도 검색 인덱스(gin_trgm_ops)에 필요한 확장 설치 후 테이블 자동 생성
+	s.Require().NoError(db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error)
 	err = db.AutoMigrate(&model.Base{})
 	s.Require().NoError(err)
 
 	s.db = db
-	s.recorder = NewRecorder(db)
+	s.recorder = NewRecorder[model.Base](db)
 }
 
 func (s *RecorderTestSuite) TearDownTest() {
@@ -163,6 +165,307 @@ func (s *RecorderTestSuite) TestRemove() {
 	s.True(errors.Is(err, gorm.ErrRecordNotFound))
 }
 
+func (s *RecorderTestSuite) TestRemove_Children() {
+	// given: 1 ─ 2, 3
+	root := &model.Base{Name: "1"}
+	s.db.Create(root)
+	child := &model.Base{Name: "2", ParentID: &root.ID}
+	s.db.Create(child)
+	other := &model.Base{Name: "3"}
+	s.db.Create(other)
+	ctx := context.Background()
+
+	// when, then: 함께 삭제하지 않는 하위 리소스가 있으면 아무것도 삭제하지 않음
+	var children *HasChildrenError
+	s.ErrorAs(s.recorder.Remove(ctx, root), &children)
+	s.Equal(child.ID, children.Child)
+	s.ErrorAs(s.recorder.BatchRemove(ctx, []uint{root.ID, other.ID}), &children)
+	var count int64
+	s.db.Model(&model.Base{}).Count(&count)
+	s.Equal(int64(3), count)
+
+	// 삭제한 부모 아래에는 저장하지 않음
+	s.NoError(s.recorder.BatchRemove(ctx, []uint{root.ID, child.ID}))
+	s.ErrorIs(s.recorder.Insert(ctx, &model.Base{Name: "4", ParentID: &root.ID}), ErrParentNotFound)
+}
+
+func (s *RecorderTestSuite) TestBatchInsert() {
+	// given
+	ms := []*model.Base{
+		{Name: "일괄1"},
+		{Name: "일괄2"},
+	}
+
+	// when
+	err := s.recorder.BatchInsert(context.Background(), ms)
+
+	// then
+	s.NoError(err)
+	for _, m := range ms {
+		s.NotZero(m.ID)
+	}
+}
+
+func (s *RecorderTestSuite) TestBatchModify_Rollback() {
+	// given
+	m := &model.Base{Name: "테스트_데이터"}
+	s.db.Create(m)
+
+	// when - 존재하지 않는 ID가 섞여 있으면 전체가 롤백되어야 함
+	err := s.recorder.BatchModify(context.Background(), []*model.Base{
+		{ID: m.ID, Name: "수정된_데이터"},
+		{ID: m.ID + 999, Name: "없는_데이터"},
+	})
+
+	// then
+	s.True(errors.Is(err, gorm.ErrRecordNotFound))
+	var saved model.Base
+	s.NoError(s.db.First(&saved, m.ID).Error)
+	s.Equal("테스트_데이터", saved.Name)
+}
+
+func (s *RecorderTestSuite) TestBatchRemove() {
+	// given
+	ms := []*model.Base{{Name: "삭제1"}, {Name: "삭제2"}}
+	for _, e := range ms {
+		s.db.Create(e)
+	}
+
+	// when
+	err := s.recorder.BatchRemove(context.Background(), []uint{ms[0].ID, ms[1].ID})
+
+	// then
+	s.NoError(err)
+	var count int64
+	s.db.Model(&model.Base{}).Count(&count)
+	s.Zero(count)
+}
+
+func (s *RecorderTestSuite) TestGetByName() {
+	// given
+	m := &model.Base{Name: "이름으로_찾기"}
+	s.db.Create(m)
+
+	// when
+	result, err := s.recorder.GetByName(context.Background(), "이름으로_찾기")
+
+	// then
+	s.NoError(err)
+	s.Equal(m.ID, result.ID)
+}
+
+func (s *RecorderTestSuite) TestStream() {
+	// given - batchSize보다 많은 행
+	ms := make([]*model.Base, batchSize+5)
+	for i := range ms {
+		ms[i] = &model.Base{Name: fmt.Sprintf("데이터%d", i)}
+	}
+	s.db.Create(ms)
+
+	// when
+	var ids []uint
+	err := s.recorder.Stream(context.Background(), func(m *model.Base) error {
+		ids = append(ids, m.ID)
+		return nil
+	})
+
+	// then
+	s.NoError(err)
+	s.Len(ids, len(ms))
+	s.IsIncreasing(ids)
+}
+
+func (s *RecorderTestSuite) TestList() {
+	// given
+	for _, name := range []string{"데이터1", "데이터2", "데이터3"} {
+		s.db.Create(&model.Base{Name: name})
+	}
+
+	// when
+	results, total, err := s.recorder.List(context.Background(), model.ListOptions{Page: 2, Size: 2})
+
+	// then
+	s.NoError(err)
+	s.Equal(int64(3), total)
+	s.Len(results, 1)
+	s.Equal("데이터3", results[0].Name)
+}
+
+func (s *RecorderTestSuite) TestTree() {
+	// given: 1 ─ 2 ─ 3, 1 ─ 4
+	root := &model.Base{Name: "1"}
+	s.db.Create(root)
+	child := &model.Base{Name: "2", ParentID: &root.ID}
+	s.db.Create(child)
+	s.db.Create(&model.Base{Name: "3", ParentID: &child.ID})
+	s.db.Create(&model.Base{Name: "4", ParentID: &root.ID})
+
+	// when
+	descendants, err := s.recorder.Descendants(context.Background(), root.ID)
+	s.NoError(err)
+	ancestors, err := s.recorder.Ancestors(context.Background(), descendants[1].ID)
+	s.NoError(err)
+
+	// then
+	s.Len(descendants, 3)
+	s.Equal("3", descendants[1].Name)
+	s.Len(ancestors, 2)
+	s.Equal("1", ancestors[0].Name)
+	s.Equal("2", ancestors[1].Name)
+}
+
+func (s *RecorderTestSuite) TestSearch() {
+	// given
+	s.db.Create(&model.Base{Name: "web-server"})
+	s.db.Create(&model.Base{Name: "web server backup"})
+	s.db.Create(&model.Base{Name: "database"})
+
+	// when
+	hits, err := s.recorder.Search(context.Background(), "web serv", 10)
+
+	// then
+	s.NoError(err)
+	s.Require().Len(hits, 2)
+	s.Equal("web-server", hits[0].Resource.Name)
+	s.Contains(hits[0].Snippet, "<mark>web</mark>")
+	s.Equal("'web':* & 'serv':*", prefixQuery("Web, serv!"))
+}
+
+func (s *RecorderTestSuite) TestSimilar() {
+	// given
+	s.db.Create(&model.Base{Name: "web-server"})
+	s.db.Create(&model.Base{Name: "database"})
+
+	// when
+	matches, err := s.recorder.Similar(context.Background(), "web-sevrer", 0.3, 10)
+
+	// then
+	s.NoError(err)
+	s.Require().Len(matches, 1)
+	s.Equal("web-server", matches[0].Resource.Name)
+	s.Greater(matches[0].Score, 0.3)
+}
+
+func (s *RecorderTestSuite) TestExpired() {
+	// given
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	expired := &model.Base{Name: "web 만료됨", ExpiresAt: &past}
+	live := &model.Base{Name: "web 남음", ExpiresAt: &future}
+	s.db.Create(expired)
+	s.db.Create(live)
+
+	// when
+	_, getErr := s.recorder.Get(context.Background(), expired.ID)
+	all, _ := s.recorder.GetAll(context.Background())
+	hits, _ := s.recorder.Search(context.Background(), "web", 10)
+	found, err := s.recorder.Expired(context.Background(), time.Now())
+
+	// then
+	s.ErrorIs(getErr, gorm.ErrRecordNotFound)
+	s.Len(all, 1)
+	s.Len(hits, 1)
+	s.NoError(err)
+	s.Require().Len(found, 1)
+	s.Equal(expired.ID, found[0].ID)
+
+	// 만료되지 않은 리소스는 ID를 넘겨도 지우지 않음
+	removed, err := s.recorder.RemoveExpired(context.Background(), []uint{expired.ID, live.ID}, time.Now())
+	s.NoError(err)
+	s.Equal([]uint{expired.ID}, removed)
+}
+
+func (s *RecorderTestSuite) TestExpired_LiveChildren() {
+	// given
+	past := time.Now().Add(-time.Minute)
+	parent := &model.Base{Name: "부모", ExpiresAt: &past}
+	s.db.Create(parent)
+	s.db.Create(&model.Base{Name: "자식", ParentID: &parent.ID})
+
+	// when
+	found, err := s.recorder.Expired(context.Background(), time.Now())
+	removed, removeErr := s.recorder.RemoveExpired(context.Background(), []uint{parent.ID}, time.Now())
+
+	// then
+	s.NoError(err)
+	s.Empty(found)
+	s.NoError(removeErr)
+	s.Empty(removed)
+}
+
+func (s *RecorderTestSuite) TestExtend() {
+	// given
+	soon, later := time.Now().Add(time.Minute), time.Now().Add(time.Hour)
+	m := &model.Base{Name: "임시", ExpiresAt: &soon}
+	s.db.Create(m)
+	past := time.Now().Add(-time.Minute)
+	expired := &model.Base{Name: "만료됨", ExpiresAt: &past}
+	s.db.Create(expired)
+
+	// when
+	got, err := s.recorder.Extend(context.Background(), m.ID, later)
+	_, expiredErr := s.recorder.Extend(context.Background(), expired.ID, later)
+
+	// then
+	s.Require().NoError(err)
+	s.Equal("임시", got.Name)
+	s.WithinDuration(later, *got.ExpiresAt, time.Millisecond)
+	s.ErrorIs(expiredErr, gorm.ErrRecordNotFound)
+}
+
 func TestRecorderSuite(t *testing.T) {
 	suite.Run(t, new(RecorderTestSuite))
 }
+
+// countingConn은 Reader/Writer 호출 수를 세는 Conn (연결할 수 없는 DB로 재시도를 확인)
+type countingConn struct {
+	db            *gorm.DB
+	reads, writes int
+}
+
+func (c *countingConn) Primary() *gorm.DB { return c.db }
+func (c *countingConn) Reader(ctx context.Context) *gorm.DB {
+	c.reads++
+	return c.db.WithContext(ctx)
+}
+func (c *countingConn) Writer(ctx context.Context) *gorm.DB {
+	c.writes++
+	return c.db.WithContext(ctx)
+}
+
+func TestRecorder_Retry(t *testing.T) {
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=test dbname=test sslmode=disable connect_timeout=1"), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := &countingConn{db: db}
+	r := NewReplicatedRecorder[model.Base](conn, WithRetry(3, time.Millisecond), WithQueryTimeout(5*time.Second))
+	ctx := context.Background()
+
+	// 연결하지 못한 쿼리는 조회와 쓰기 모두 재시도
+	if _, err := r.Get(ctx, 1); err == nil {
+		t.Fatal("Get() error = nil, want 연결 오류")
+	}
+	if conn.reads != 3 {
+		t.Errorf("Get() 실행 횟수 = %d, want 3", conn.reads)
+	}
+	if err := r.Insert(ctx, &model.Base{Name: "web"}); err == nil {
+		t.Fatal("Insert() error = nil, want 연결 오류")
+	}
+	if conn.writes != 3 {
+		t.Errorf("Insert() 실행 횟수 = %d, want 3", conn.writes)
+	}
+
+	// ctx가 끝나면 더 기다리지 않음
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	conn.reads = 0
+	if _, err := r.GetAll(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetAll() error = %v, want context.Canceled", err)
+	}
+	if conn.reads != 1 {
+		t.Errorf("GetAll() 실행 횟수 = %d, want 1", conn.reads)
+	}
+}
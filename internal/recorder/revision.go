@@ -0,0 +1,159 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go_project/internal/model"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// RevisionRecorder는 리소스 리비전(스냅샷)을 저장하는 인터페이스
+type RevisionRecorder interface {
+	// Append는 rev.ResourceID의 다음 번호로 rev를 저장 (ID, Number, CreatedAt을 채움)
+	Append(ctx context.Context, rev *model.Revision) error
+	Get(ctx context.Context, resourceID uint, number int) (*model.Revision, error)
+	// List는 번호 역순(최근 리비전부터)으로 한 페이지를 조회하고 전체 개수를 함께 반환 (size가 0이면 전체)
+	List(ctx context.Context, resourceID uint, page, size int) ([]*model.Revision, int64, error)
+}
+
+// 같은 번호를 동시에 매겨 unique 인덱스에 걸렸을 때 다시 시도하는 횟수
+const maxAppendAttempts = 3
+
+type revisionRecorder struct {
+	conn Conn
+	runner
+}
+
+// MigrateRevisions는 revisions 테이블과 인덱스를 생성하거나 Revision의 변경 사항을 반영
+func MigrateRevisions(db *gorm.DB) error {
+	if err := db.AutoMigrate(&model.Revision{}); err != nil {
+		return fmt.Errorf("revisions 테이블 마이그레이션 실패: %w", err)
+	}
+	return nil
+}
+
+// NewRevisionRecorder는 revisions 테이블을 사용하는 RevisionRecorder를 생성 (예: NewRevisionRecorder(cluster, opts...))
+// 테이블은 MigrateRevisions로 생성
+// 리소스 Recorder와 같이 조회는 conn.Reader로, 저장은 conn.Writer로 보내고 쿼리 제한 시간과 재시도를 적용하며,
+// ctx에 트랜잭션(Transactor)이 있으면 그 트랜잭션에서 실행
+func NewRevisionRecorder(conn Conn, opts ...Option) RevisionRecorder {
+	return &revisionRecorder{conn: conn, runner: newRunner(opts)}
+}
+
+// Append는 마지막 번호 다음으로 저장하고, 다른 요청이 먼저 그 번호를 쓰면 번호를 다시 매김
+// 바깥 트랜잭션 안에서는 세이브포인트로 실행되므로 번호가 겹쳐도 바깥 트랜잭션은 계속 쓸 수 있음
+func (r *revisionRecorder) Append(ctx context.Context, rev *model.Revision) error {
+	var err error
+	for attempt := 0; attempt < maxAppendAttempts; attempt++ {
+		err = r.run(ctx, false, func(ctx context.Context) error {
+			return r.conn.Writer(ctx).Transaction(func(tx *gorm.DB) error {
+				var last int
+				if err := tx.Model(&model.Revision{}).Where("resource_id = ?", rev.ResourceID).
+					Select("COALESCE(MAX(number), 0)").Scan(&last).Error; err != nil {
+					return err
+				}
+				rev.ID = 0
+				rev.Number = last + 1
+				return tx.Create(rev).Error
+			})
+		})
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) || pgErr.Code != "23505" { // unique_violation
+			return err
+		}
+	}
+	return err
+}
+
+func (r *revisionRecorder) Get(ctx context.Context, resourceID uint, number int) (*model.Revision, error) {
+	var rev model.Revision
+	err := r.run(ctx, true, func(ctx context.Context) error {
+		return r.conn.Reader(ctx).Where("resource_id = ? AND number = ?", resourceID, number).First(&rev).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+func (r *revisionRecorder) List(ctx context.Context, resourceID uint, page, size int) ([]*model.Revision, int64, error) {
+	var total int64
+	var revs []*model.Revision
+	err := r.run(ctx, true, func(ctx context.Context) error {
+		// 개수와 목록이 서로 다른 복제본에서 조회되지 않도록 같은 연결 사용
+		q := r.conn.Reader(ctx).Model(&model.Revision{}).Where("resource_id = ?", resourceID)
+		if err := q.Count(&total).Error; err != nil {
+			return err
+		}
+		q = q.Order("number DESC")
+		if size > 0 {
+			q = q.Offset(model.ListOptions{Page: page, Size: size}.Offset()).Limit(size)
+		}
+		revs = nil
+		return q.Find(&revs).Error
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return revs, total, nil
+}
+
+// memoryRevisionRecorder는 메모리에 저장하는 RevisionRecorder (없는 행은 gorm.ErrRecordNotFound)
+type memoryRevisionRecorder struct {
+	mu     sync.RWMutex
+	rows   map[uint][]model.Revision // 리소스 ID별 리비전 (번호 순)
+	nextID uint
+}
+
+// NewMemoryRevisionRecorder는 메모리 RevisionRecorder를 생성
+func NewMemoryRevisionRecorder() RevisionRecorder {
+	return &memoryRevisionRecorder{
+		rows:   make(map[uint][]model.Revision),
+		nextID: 1,
+	}
+}
+
+func (r *memoryRevisionRecorder) Append(ctx context.Context, rev *model.Revision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rev.ID = r.nextID
+	r.nextID++
+	rev.Number = len(r.rows[rev.ResourceID]) + 1
+	rev.CreatedAt = time.Now()
+	r.rows[rev.ResourceID] = append(r.rows[rev.ResourceID], *rev)
+	return nil
+}
+
+func (r *memoryRevisionRecorder) Get(ctx context.Context, resourceID uint, number int) (*model.Revision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	revs := r.rows[resourceID]
+	if number < 1 || number > len(revs) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	rev := revs[number-1]
+	return &rev, nil
+}
+
+func (r *memoryRevisionRecorder) List(ctx context.Context, resourceID uint, page, size int) ([]*model.Revision, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	revs := make([]*model.Revision, 0, len(r.rows[resourceID]))
+	for _, rev := range r.rows[resourceID] {
+		rev := rev
+		revs = append(revs, &rev)
+	}
+	sort.Slice(revs, func(i, j int) bool { return revs[i].Number > revs[j].Number })
+	total := int64(len(revs))
+	if size > 0 {
+		offset := min(model.ListOptions{Page: page, Size: size}.Offset(), len(revs))
+		revs = revs[offset:min(offset+size, len(revs))]
+	}
+	return revs, total, nil
+}
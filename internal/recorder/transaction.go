@@ -0,0 +1,69 @@
+package recorder
+
+import (
+	"context"
+	"go_project/internal/database"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Transactor는 여러 Recorder의 쓰기를 한 트랜잭션으로 묶는 인터페이스
+type Transactor interface {
+	// Transaction은 트랜잭션을 담은 ctx로 fn을 실행하고, fn이 에러를 반환하면 롤백
+	// Recorder에 이 ctx를 넘기면 같은 트랜잭션에서 실행되며, ctx에 이미 트랜잭션이 있으면 그 트랜잭션에 참여
+	// fn 안에서 database.AfterCommit으로 등록한 함수는 커밋한 뒤에 실행
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type transactor struct {
+	conn Conn
+	runner
+}
+
+// NewTransactor는 conn의 primary에서 트랜잭션을 시작하는 Transactor를 생성 (예: NewTransactor(cluster, opts...))
+// 트랜잭션 안의 쿼리는 재시도할 수 없으므로, 직렬화 실패나 교착 상태처럼 반영되지 않은 것이 확실한 에러면
+// WithRetry의 횟수만큼 fn을 처음부터 다시 실행함 (쿼리 제한 시간은 트랜잭션 안의 쿼리마다 Recorder가 적용)
+func NewTransactor(conn Conn, opts ...Option) Transactor {
+	t := &transactor{conn: conn, runner: newRunner(opts)}
+	t.opts.queryTimeout = 0
+	return t
+}
+
+func (t *transactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if database.TxFrom(ctx) != nil {
+		return fn(ctx)
+	}
+	return t.run(ctx, false, func(ctx context.Context) error {
+		// 다시 실행하면 앞의 시도에서 등록한 함수는 버림
+		ctx, committed := database.WithCommitHooks(ctx)
+		err := t.conn.Writer(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(database.WithTx(ctx, tx))
+		})
+		if err == nil {
+			committed()
+		}
+		return err
+	})
+}
+
+type memoryTxKey struct{}
+
+type memoryTransactor struct {
+	mu sync.Mutex
+}
+
+// NewMemoryTransactor는 메모리 Recorder용 Transactor를 생성
+// 트랜잭션끼리는 차례로 실행하지만, fn이 실패해도 그 전에 한 쓰기는 되돌리지 않음
+func NewMemoryTransactor() Transactor {
+	return &memoryTransactor{}
+}
+
+func (t *memoryTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(memoryTxKey{}) != nil {
+		return fn(ctx)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return fn(context.WithValue(ctx, memoryTxKey{}, t))
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"go_project/internal/model"
+	"go_project/internal/recorder"
+)
+
+// AttachmentRepository는 첨부 파일 메타데이터 접근 계층
+type AttachmentRepository interface {
+	Insert(ctx context.Context, a *model.Attachment) error
+	Get(ctx context.Context, id uint) (*model.Attachment, error)
+	ListByResource(ctx context.Context, resourceID uint) ([]*model.Attachment, error)
+	Remove(ctx context.Context, a *model.Attachment) error
+}
+
+type attachmentRepository struct {
+	recorder recorder.AttachmentRecorder
+}
+
+func NewAttachmentRepository(recorder recorder.AttachmentRecorder) AttachmentRepository {
+	return &attachmentRepository{
+		recorder: recorder,
+	}
+}
+
+func (r *attachmentRepository) Insert(ctx context.Context, a *model.Attachment) error {
+	return r.recorder.Insert(ctx, a)
+}
+
+func (r *attachmentRepository) Get(ctx context.Context, id uint) (*model.Attachment, error) {
+	return r.recorder.Get(ctx, id)
+}
+
+func (r *attachmentRepository) ListByResource(ctx context.Context, resourceID uint) ([]*model.Attachment, error) {
+	return r.recorder.ListByResource(ctx, resourceID)
+}
+
+func (r *attachmentRepository) Remove(ctx context.Context, a *model.Attachment) error {
+	return r.recorder.Remove(ctx, a)
+}
@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go_project/internal/cache"
+	"go_project/internal/database"
+	"go_project/internal/model"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+// CachingRepository는 ID 조회 결과를 캐시하는 Repository
+type CachingRepository[T any] interface {
+	Repository[T]
+	CacheStats() CacheStats
+}
+
+// CacheStats는 캐시 사용 통계 (서버 시작 후 누적값)
+type CacheStats struct {
+	Hits          uint64 `json:"hits"`          // 캐시로 응답한 조회 (없는 리소스 응답 포함)
+	NegativeHits  uint64 `json:"negative_hits"` // Hits 중 없는 리소스로 캐시된 응답
+	RemoteHits    uint64 `json:"remote_hits"`   // Hits 중 원격 캐시에서 가져온 응답
+	Misses        uint64 `json:"misses"`        // 저장소까지 간 조회 (동시에 들어온 같은 ID 조회는 한 번으로 셈)
+	Invalidations uint64 `json:"invalidations"` // 쓰기 때문에 지운 항목 수
+	RemoteErrors  uint64 `json:"remote_errors"` // 원격 캐시 오류 (오류가 나면 캐시 없이 처리)
+	Evictions     uint64 `json:"evictions"`     // 크기 제한 때문에 프로세스 내 캐시에서 버린 항목 수
+	Size          int    `json:"size"`          // 프로세스 내 캐시의 항목 수
+}
+
+// 캐시 기본 설정
+const (
+	DefaultCacheSize        = 10000
+	DefaultCacheTTL         = time.Minute
+	DefaultNegativeCacheTTL = 10 * time.Second
+	defaultRemotePrefix     = "resource:"
+)
+
+type cacheOptions struct {
+	size         int
+	ttl          time.Duration
+	negativeTTL  time.Duration
+	remote       cache.Store
+	remotePrefix string
+}
+
+type CacheOption func(*cacheOptions)
+
+// WithCacheSize는 프로세스 내 캐시에 보관할 최대 리소스 수를 지정 (기본: DefaultCacheSize)
+func WithCacheSize(n int) CacheOption {
+	return func(o *cacheOptions) {
+		o.size = n
+	}
+}
+
+// WithCacheTTL은 조회한 리소스를 캐시에 보관할 시간을 지정 (기본: DefaultCacheTTL)
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithNegativeCacheTTL은 없는 리소스라는 결과를 보관할 시간을 지정 (기본: DefaultNegativeCacheTTL)
+func WithNegativeCacheTTL(ttl time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.negativeTTL = ttl
+	}
+}
+
+// WithRemoteCache는 프로세스 내 캐시에 없을 때 확인할 원격 캐시를 지정 (키는 prefix+ID, prefix가 비어 있으면 "resource:")
+// 원격 캐시에는 JSON으로 저장하므로 json:"-"인 필드는 캐시에서 가져올 때 비어 있음
+func WithRemoteCache(store cache.Store, prefix string) CacheOption {
+	return func(o *cacheOptions) {
+		o.remote = store
+		o.remotePrefix = prefix
+	}
+}
+
+type cachingRepository[T any, PT model.Model[T]] struct {
+	Repository[T]
+	local        *cache.LRU[uint, []byte]
+	remote       cache.Store
+	remotePrefix string
+	ttl          time.Duration
+	negativeTTL  time.Duration
+	loads        singleflight.Group
+
+	// 쓰기마다 증가하는 값, 조회 중에 바뀌면 조회 결과가 오래됐을 수 있으므로 캐시에 넣지 않음
+	epoch atomic.Uint64
+
+	hits, negativeHits, remoteHits, misses, invalidations, remoteErrors atomic.Uint64
+}
+
+// NewCachingRepository는 Get 결과를 캐시하는 repo 데코레이터를 생성
+//
+// 프로세스 내 LRU → (지정했으면) 원격 캐시 → repo 순서로 조회하고, 같은 ID를 동시에 조회하면 repo는 한 번만 조회함
+// 없는 리소스도 짧게 캐시하며, 이 Repository로 생성/수정/삭제하면 해당 ID를 캐시에서 지움
+// 캐시된 리소스라도 만료 시각이 지났으면 없는 리소스로 응답
+// 캐시에는 직렬화한 값을 보관하므로 반환된 리소스를 바꿔도 캐시에는 영향이 없음
+func NewCachingRepository[T any, PT model.Model[T]](repo Repository[T], opts ...CacheOption) CachingRepository[T] {
+	o := cacheOptions{
+		size:        DefaultCacheSize,
+		ttl:         DefaultCacheTTL,
+		negativeTTL: DefaultNegativeCacheTTL,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.remotePrefix == "" {
+		o.remotePrefix = defaultRemotePrefix
+	}
+	return &cachingRepository[T, PT]{
+		Repository:   repo,
+		local:        cache.NewLRU[uint, []byte](o.size, o.ttl),
+		remote:       o.remote,
+		remotePrefix: o.remotePrefix,
+		ttl:          o.ttl,
+		negativeTTL:  o.negativeTTL,
+	}
+}
+
+func (r *cachingRepository[T, PT]) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:          r.hits.Load(),
+		NegativeHits:  r.negativeHits.Load(),
+		RemoteHits:    r.remoteHits.Load(),
+		Misses:        r.misses.Load(),
+		Invalidations: r.invalidations.Load(),
+		RemoteErrors:  r.remoteErrors.Load(),
+		Evictions:     r.local.Evictions(),
+		Size:          r.local.Len(),
+	}
+}
+
+func (r *cachingRepository[T, PT]) Get(ctx context.Context, id uint) (*T, error) {
+	// 트랜잭션 안의 조회는 커밋하지 않은 값을 볼 수 있으므로 캐시를 거치지 않음
+	if database.TxFrom(ctx) != nil {
+		return r.Repository.Get(ctx, id)
+	}
+	if data, ok := r.local.Get(id); ok {
+		r.countHit(data)
+		return r.decode(data)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// 먼저 조회를 시작한 요청이 취소돼도 함께 기다리는 요청에는 영향이 없도록 취소를 떼어 냄
+	ch := r.loads.DoChan(strconv.FormatUint(uint64(id), 10), func() (interface{}, error) {
+		return r.load(context.WithoutCancel(ctx), id)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return r.decode(res.Val.([]byte))
+	}
+}
+
+// decode는 캐시 값을 복원하고, 캐시에 넣은 뒤 만료된 리소스는 없는 리소스로 처리
+func (r *cachingRepository[T, PT]) decode(data []byte) (*T, error) {
+	m, err := decodeCached[T](data)
+	if err != nil {
+		return nil, err
+	}
+	if PT(m).GetBase().Expired(time.Now()) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return m, nil
+}
+
+// load는 원격 캐시와 repo에서 id를 조회해서 직렬화한 값을 반환 (없는 리소스는 빈 값)
+func (r *cachingRepository[T, PT]) load(ctx context.Context, id uint) ([]byte, error) {
+	epoch := r.epoch.Load()
+	if r.remote != nil {
+		data, err := r.remote.Get(ctx, r.remoteKey(id))
+		switch {
+		case err == nil:
+			r.countHit(data)
+			r.remoteHits.Add(1)
+			r.storeLocal(epoch, id, data)
+			return data, nil
+		case !errors.Is(err, cache.ErrMiss):
+			r.remoteErrors.Add(1)
+		}
+	}
+
+	r.misses.Add(1)
+	var data []byte
+	// 쓰기 직후 복제본의 이전 값을 캐시에 넣으면 무효화한 뒤에도 TTL 동안 남으므로 primary에서 조회
+	m, err := r.Repository.Get(database.WithPrimary(ctx), id)
+	switch {
+	case err == nil:
+		if data, err = json.Marshal(m); err != nil {
+			return nil, fmt.Errorf("캐시 값 직렬화 실패: %v", err)
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		data = []byte{}
+	default:
+		return nil, err
+	}
+
+	if r.storeLocal(epoch, id, data) && r.remote != nil {
+		if err := r.remote.Set(ctx, r.remoteKey(id), data, r.ttlFor(data)); err != nil {
+			r.remoteErrors.Add(1)
+		}
+	}
+	return data, nil
+}
+
+// storeLocal은 조회를 시작한 뒤 쓰기가 없었을 때만 프로세스 내 캐시에 넣고 넣었는지를 반환
+func (r *cachingRepository[T, PT]) storeLocal(epoch uint64, id uint, data []byte) bool {
+	if r.epoch.Load() != epoch {
+		return false
+	}
+	r.local.AddWithTTL(id, data, r.ttlFor(data))
+	return true
+}
+
+func (r *cachingRepository[T, PT]) ttlFor(data []byte) time.Duration {
+	if len(data) == 0 {
+		return r.negativeTTL
+	}
+	return r.ttl
+}
+
+func (r *cachingRepository[T, PT]) countHit(data []byte) {
+	r.hits.Add(1)
+	if len(data) == 0 {
+		r.negativeHits.Add(1)
+	}
+}
+
+func (r *cachingRepository[T, PT]) remoteKey(id uint) string {
+	return r.remotePrefix + strconv.FormatUint(uint64(id), 10)
+}
+
+// decodeCached는 캐시 값을 새 리소스로 복원 (빈 값은 없는 리소스)
+func decodeCached[T any](data []byte) (*T, error) {
+	if len(data) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	var m T
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("캐시 값 해석 실패: %v", err)
+	}
+	return &m, nil
+}
+
+// invalidate는 ids를 모든 캐시에서 지우고 진행 중인 조회 결과도 캐시에 넣지 않게 함
+// 쓰기는 실패해도 일부가 반영됐을 수 있으므로 쓰기 결과와 관계없이 호출
+// 트랜잭션 안의 쓰기면 커밋 전에 다른 요청이 이전 값을 다시 캐시에 넣을 수 있으므로 커밋한 뒤에 한 번 더 지움
+func (r *cachingRepository[T, PT]) invalidate(ctx context.Context, ids ...uint) {
+	r.evict(ctx, ids...)
+	if database.TxFrom(ctx) != nil {
+		database.AfterCommit(ctx, func() {
+			r.evict(context.WithoutCancel(ctx), ids...)
+		})
+	}
+}
+
+// evict는 ids를 모든 캐시에서 지움
+// 원격 캐시에서 지우지 못하면 다른 서버는 TTL이 지날 때까지 이전 값을 볼 수 있음
+func (r *cachingRepository[T, PT]) evict(ctx context.Context, ids ...uint) {
+	r.epoch.Add(1)
+	for _, id := range ids {
+		r.local.Remove(id)
+		r.loads.Forget(strconv.FormatUint(uint64(id), 10))
+		if r.remote != nil {
+			if err := r.remote.Delete(ctx, r.remoteKey(id)); err != nil {
+				r.remoteErrors.Add(1)
+			}
+		}
+	}
+	r.invalidations.Add(uint64(len(ids)))
+}
+
+func idsOf[T any, PT model.Model[T]](models []*T) []uint {
+	ids := make([]uint, len(models))
+	for i, m := range models {
+		ids[i] = PT(m).GetBase().ID
+	}
+	return ids
+}
+
+// Insert는 새 ID가 없는 리소스로 캐시돼 있을 수 있으므로 생성 후에도 지움
+func (r *cachingRepository[T, PT]) Insert(ctx context.Context, m *T) error {
+	err := r.Repository.Insert(ctx, m)
+	r.invalidate(ctx, PT(m).GetBase().ID)
+	return err
+}
+
+func (r *cachingRepository[T, PT]) Modify(ctx context.Context, m *T) error {
+	err := r.Repository.Modify(ctx, m)
+	r.invalidate(ctx, PT(m).GetBase().ID)
+	return err
+}
+
+func (r *cachingRepository[T, PT]) Update(ctx context.Context, id uint, fn func(ctx context.Context, m *T) error) (*T, error) {
+	m, err := r.Repository.Update(ctx, id, fn)
+	r.invalidate(ctx, id)
+	return m, err
+}
+
+func (r *cachingRepository[T, PT]) Remove(ctx context.Context, m *T) error {
+	err := r.Repository.Remove(ctx, m)
+	r.invalidate(ctx, PT(m).GetBase().ID)
+	return err
+}
+
+func (r *cachingRepository[T, PT]) BatchInsert(ctx context.Context, models []*T) error {
+	err := r.Repository.BatchInsert(ctx, models)
+	r.invalidate(ctx, idsOf[T, PT](models)...)
+	return err
+}
+
+func (r *cachingRepository[T, PT]) BatchModify(ctx context.Context, models []*T) error {
+	err := r.Repository.BatchModify(ctx, models)
+	r.invalidate(ctx, idsOf[T, PT](models)...)
+	return err
+}
+
+func (r *cachingRepository[T, PT]) BatchRemove(ctx context.Context, ids []uint) error {
+	err := r.Repository.BatchRemove(ctx, ids)
+	r.invalidate(ctx, ids...)
+	return err
+}
+
+func (r *cachingRepository[T, PT]) RemoveExpired(ctx context.Context, ids []uint, before time.Time) ([]uint, error) {
+	removed, err := r.Repository.RemoveExpired(ctx, ids, before)
+	r.invalidate(ctx, ids...)
+	return removed, err
+}
+
+func (r *cachingRepository[T, PT]) Extend(ctx context.Context, id uint, expiresAt time.Time) (*T, error) {
+	m, err := r.Repository.Extend(ctx, id, expiresAt)
+	r.invalidate(ctx, id)
+	return m, err
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go_project/internal/cache"
+	"go_project/internal/labels"
+	"go_project/internal/model"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type CachingRepositoryTestSuite struct {
+	suite.Suite
+	mockRecorder *mockRecorder
+	repo         CachingRepository[model.Base]
+}
+
+func (s *CachingRepositoryTestSuite) SetupTest() {
+	s.mockRecorder = new(mockRecorder)
+	s.repo = NewCachingRepository(NewRepository[model.Base](s.mockRecorder))
+}
+
+func (s *CachingRepositoryTestSuite) TestGet_Hit() {
+	s.mockRecorder.On("Get", mock.Anything, uint(1)).
+		Return(&model.Base{ID: 1, Name: "web", Labels: labels.Set{"env": "dev"}}, nil).Once()
+
+	got, err := s.repo.Get(context.Background(), 1)
+	s.Require().NoError(err)
+	// 반환된 값을 바꿔도 캐시에는 영향이 없어야 함
+	got.Labels["env"] = "prod"
+
+	got, err = s.repo.Get(context.Background(), 1)
+	s.Require().NoError(err)
+	s.Equal("web", got.Name)
+	s.Equal(labels.Set{"env": "dev"}, got.Labels)
+	s.mockRecorder.AssertNumberOfCalls(s.T(), "Get", 1)
+	s.Equal(CacheStats{Hits: 1, Misses: 1, Size: 1}, s.repo.CacheStats())
+}
+
+func (s *CachingRepositoryTestSuite) TestGet_Negative() {
+	s.mockRecorder.On("Get", mock.Anything, uint(2)).Return((*model.Base)(nil), gorm.ErrRecordNotFound).Once()
+	s.mockRecorder.On("Get", mock.Anything, uint(3)).Return((*model.Base)(nil), errors.New("연결 끊김"))
+
+	for range 2 {
+		_, err := s.repo.Get(context.Background(), 2)
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+	}
+	s.Equal(uint64(1), s.repo.CacheStats().NegativeHits)
+
+	// 다른 오류는 캐시하지 않음
+	for range 2 {
+		_, err := s.repo.Get(context.Background(), 3)
+		s.ErrorContains(err, "연결 끊김")
+	}
+	s.mockRecorder.AssertNumberOfCalls(s.T(), "Get", 3)
+
+	// 없던 ID로 생성하면 부정 캐시도 지움
+	s.mockRecorder.On("Insert", mock.Anything, mock.AnythingOfType("*model.Base")).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*model.Base).ID = 2
+	})
+	s.mockRecorder.On("Get", mock.Anything, uint(2)).Return(&model.Base{ID: 2, Name: "new"}, nil)
+	s.NoError(s.repo.Insert(context.Background(), &model.Base{Name: "new"}))
+	got, err := s.repo.Get(context.Background(), 2)
+	s.NoError(err)
+	s.Equal("new", got.Name)
+}
+
+func (s *CachingRepositoryTestSuite) TestGet_Expired() {
+	expiresAt := time.Now().Add(50 * time.Millisecond)
+	s.mockRecorder.On("Get", mock.Anything, uint(1)).Return(&model.Base{ID: 1, Name: "임시", ExpiresAt: &expiresAt}, nil).Once()
+
+	_, err := s.repo.Get(context.Background(), 1)
+	s.Require().NoError(err)
+
+	// 캐시 TTL이 남아 있어도 만료 시각이 지나면 없는 리소스
+	time.Sleep(60 * time.Millisecond)
+	_, err = s.repo.Get(context.Background(), 1)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	s.mockRecorder.AssertNumberOfCalls(s.T(), "Get", 1)
+}
+
+func (s *CachingRepositoryTestSuite) TestInvalidate() {
+	ctx := context.Background()
+	s.mockRecorder.On("Get", mock.Anything, mock.Anything).Return(&model.Base{ID: 1, Name: "web"}, nil)
+	s.mockRecorder.On("Modify", mock.Anything, mock.Anything).Return(nil)
+	s.mockRecorder.On("Remove", mock.Anything, mock.Anything).Return(errors.New("삭제 오류"))
+	s.mockRecorder.On("BatchRemove", mock.Anything, mock.Anything).Return(nil)
+
+	tests := []struct {
+		name  string
+		write func() error
+	}{
+		{name: "수정", write: func() error { return s.repo.Modify(ctx, &model.Base{ID: 1}) }},
+		// 실패해도 일부가 반영됐을 수 있으므로 지움
+		{name: "삭제_실패", write: func() error { return s.repo.Remove(ctx, &model.Base{ID: 1}) }},
+		{name: "일괄_삭제", write: func() error { return s.repo.BatchRemove(ctx, []uint{1, 2}) }},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.repo.Get(ctx, 1)
+			s.repo.Get(ctx, 1)
+			before := s.repo.CacheStats().Misses
+
+			tt.write()
+			s.repo.Get(ctx, 1)
+			s.Equal(before+1, s.repo.CacheStats().Misses)
+		})
+	}
+}
+
+func (s *CachingRepositoryTestSuite) TestGet_Singleflight() {
+	release := make(chan struct{})
+	s.mockRecorder.On("Get", mock.Anything, uint(1)).Return(&model.Base{ID: 1, Name: "web"}, nil).
+		Run(func(mock.Arguments) { <-release })
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.repo.Get(context.Background(), 1)
+			errs <- err
+		}()
+	}
+	// 모든 고루틴이 기다리기 시작할 시간을 줌
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		s.NoError(err)
+	}
+	s.mockRecorder.AssertNumberOfCalls(s.T(), "Get", 1)
+
+	// 취소된 요청은 조회하지 않고 바로 반환
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.repo.Get(ctx, 99)
+	s.ErrorIs(err, context.Canceled)
+}
+
+func (s *CachingRepositoryTestSuite) TestTTL() {
+	s.repo = NewCachingRepository(NewRepository[model.Base](s.mockRecorder),
+		WithCacheTTL(10*time.Millisecond), WithCacheSize(1))
+	s.mockRecorder.On("Get", mock.Anything, mock.Anything).Return(&model.Base{ID: 1}, nil)
+
+	s.repo.Get(context.Background(), 1)
+	s.repo.Get(context.Background(), 2) // 크기가 1이므로 1을 버림
+	s.Equal(uint64(1), s.repo.CacheStats().Evictions)
+
+	time.Sleep(20 * time.Millisecond)
+	s.repo.Get(context.Background(), 2)
+	s.Equal(uint64(3), s.repo.CacheStats().Misses)
+}
+
+func (s *CachingRepositoryTestSuite) TestRemoteCache() {
+	ctx := context.Background()
+	remote := cache.NewMemoryStore()
+	other := new(mockRecorder)
+	s.repo = NewCachingRepository(NewRepository[model.Base](s.mockRecorder), WithRemoteCache(remote, "test:"))
+	otherRepo := NewCachingRepository(NewRepository[model.Base](other), WithRemoteCache(remote, "test:"))
+	s.mockRecorder.On("Get", mock.Anything, uint(1)).Return(&model.Base{ID: 1, Name: "web"}, nil)
+	other.On("Modify", mock.Anything, mock.Anything).Return(nil)
+
+	// 한 서버가 조회한 값을 다른 서버는 원격 캐시에서 가져옴
+	s.repo.Get(ctx, 1)
+	got, err := otherRepo.Get(ctx, 1)
+	s.NoError(err)
+	s.Equal("web", got.Name)
+	s.Equal(uint64(1), otherRepo.CacheStats().RemoteHits)
+	other.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+
+	// 다른 서버의 수정은 원격 캐시에서 지워짐
+	s.NoError(otherRepo.Modify(ctx, &model.Base{ID: 1}))
+	_, err = remote.Get(ctx, "test:1")
+	s.ErrorIs(err, cache.ErrMiss)
+}
+
+func TestCachingRepositorySuite(t *testing.T) {
+	suite.Run(t, new(CachingRepositoryTestSuite))
+}
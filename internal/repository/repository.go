@@ -4,32 +4,49 @@ import (
 	"context"
 	"go_project/internal/model"
 	"go_project/internal/recorder"
+	"time"
 )
 
 // Repository 인터페이스는 비즈니스 로직을 위한 데이터 접근 계층
-type Repository interface {
-	Insert(ctx context.Context, model *model.Base) error
-	Get(ctx context.Context, id uint) (*model.Base, error)
-	GetAll(ctx context.Context) ([]*model.Base, error)
-	Modify(ctx context.Context, model *model.Base) error
-	Remove(ctx context.Context, model *model.Base) error
+// T는 model.Base를 임베딩한 모델 (예: Repository[model.Base])
+type Repository[T any] interface {
+	Insert(ctx context.Context, model *T) error
+	Get(ctx context.Context, id uint) (*T, error)
+	GetAll(ctx context.Context) ([]*T, error)
+	Modify(ctx context.Context, model *T) error
+	Update(ctx context.Context, id uint, fn func(ctx context.Context, model *T) error) (*T, error)
+	Remove(ctx context.Context, model *T) error
+	BatchInsert(ctx context.Context, models []*T) error
+	BatchModify(ctx context.Context, models []*T) error
+	BatchRemove(ctx context.Context, ids []uint) error
+	GetByName(ctx context.Context, name string) (*T, error)
+	Stream(ctx context.Context, fn func(*T) error) error
+	List(ctx context.Context, opts model.ListOptions) ([]*T, int64, error)
+	GetMany(ctx context.Context, ids []uint) ([]*T, error)
+	Descendants(ctx context.Context, id uint) ([]*T, error)
+	Ancestors(ctx context.Context, id uint) ([]*T, error)
+	Search(ctx context.Context, query string, limit int) ([]model.SearchHit[T], error)
+	Similar(ctx context.Context, name string, threshold float64, limit int) ([]model.FuzzyMatch[T], error)
+	Expired(ctx context.Context, before time.Time) ([]*T, error)
+	RemoveExpired(ctx context.Context, ids []uint, before time.Time) ([]uint, error)
+	Extend(ctx context.Context, id uint, expiresAt time.Time) (*T, error)
 }
 
-type repository struct {
-	recorder recorder.Recorder
+type repository[T any] struct {
+	recorder recorder.Recorder[T]
 }
 
-func NewRepository(recorder recorder.Recorder) Repository {
-	return &repository{
+func NewRepository[T any](recorder recorder.Recorder[T]) Repository[T] {
+	return &repository[T]{
 		recorder: recorder,
 	}
 }
 
-func (r *repository) Insert(ctx context.Context, model *model.Base) error {
+func (r *repository[T]) Insert(ctx context.Context, model *T) error {
 	return r.recorder.Insert(ctx, model)
 }
 
-func (r *repository) Get(ctx context.Context, id uint) (*model.Base, error) {
+func (r *repository[T]) Get(ctx context.Context, id uint) (*T, error) {
 	result, err := r.recorder.Get(ctx, id)
 	if err != nil {
 		return nil, err
@@ -37,7 +54,7 @@ func (r *repository) Get(ctx context.Context, id uint) (*model.Base, error) {
 	return result, nil
 }
 
-func (r *repository) GetAll(ctx context.Context) ([]*model.Base, error) {
+func (r *repository[T]) GetAll(ctx context.Context) ([]*T, error) {
 	results, err := r.recorder.GetAll(ctx)
 	if err != nil {
 		return nil, err
@@ -45,10 +62,78 @@ func (r *repository) GetAll(ctx context.Context) ([]*model.Base, error) {
 	return results, nil
 }
 
-func (r *repository) Modify(ctx context.Context, model *model.Base) error {
+func (r *repository[T]) Modify(ctx context.Context, model *T) error {
 	return r.recorder.Modify(ctx, model)
 }
 
-func (r *repository) Remove(ctx context.Context, model *model.Base) error {
+func (r *repository[T]) Update(ctx context.Context, id uint, fn func(ctx context.Context, model *T) error) (*T, error) {
+	return r.recorder.Update(ctx, id, fn)
+}
+
+func (r *repository[T]) Remove(ctx context.Context, model *T) error {
 	return r.recorder.Remove(ctx, model)
 }
+
+func (r *repository[T]) BatchInsert(ctx context.Context, models []*T) error {
+	return r.recorder.BatchInsert(ctx, models)
+}
+
+func (r *repository[T]) BatchModify(ctx context.Context, models []*T) error {
+	return r.recorder.BatchModify(ctx, models)
+}
+
+func (r *repository[T]) BatchRemove(ctx context.Context, ids []uint) error {
+	return r.recorder.BatchRemove(ctx, ids)
+}
+
+func (r *repository[T]) GetByName(ctx context.Context, name string) (*T, error) {
+	result, err := r.recorder.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *repository[T]) Stream(ctx context.Context, fn func(*T) error) error {
+	return r.recorder.Stream(ctx, fn)
+}
+
+func (r *repository[T]) List(ctx context.Context, opts model.ListOptions) ([]*T, int64, error) {
+	results, total, err := r.recorder.List(ctx, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+func (r *repository[T]) GetMany(ctx context.Context, ids []uint) ([]*T, error) {
+	return r.recorder.GetMany(ctx, ids)
+}
+
+func (r *repository[T]) Descendants(ctx context.Context, id uint) ([]*T, error) {
+	return r.recorder.Descendants(ctx, id)
+}
+
+func (r *repository[T]) Ancestors(ctx context.Context, id uint) ([]*T, error) {
+	return r.recorder.Ancestors(ctx, id)
+}
+
+func (r *repository[T]) Search(ctx context.Context, query string, limit int) ([]model.SearchHit[T], error) {
+	return r.recorder.Search(ctx, query, limit)
+}
+
+func (r *repository[T]) Similar(ctx context.Context, name string, threshold float64, limit int) ([]model.FuzzyMatch[T], error) {
+	return r.recorder.Similar(ctx, name, threshold, limit)
+}
+
+func (r *repository[T]) Expired(ctx context.Context, before time.Time) ([]*T, error) {
+	return r.recorder.Expired(ctx, before)
+}
+
+func (r *repository[T]) RemoveExpired(ctx context.Context, ids []uint, before time.Time) ([]uint, error) {
+	return r.recorder.RemoveExpired(ctx, ids, before)
+}
+
+func (r *repository[T]) Extend(ctx context.Context, id uint, expiresAt time.Time) (*T, error) {
+	return r.recorder.Extend(ctx, id, expiresAt)
+}
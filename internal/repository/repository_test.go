@@ -5,6 +5,7 @@ import (
 	"errors"
 	"go_project/internal/model"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -35,20 +36,95 @@ func (m *mockRecorder) Modify(ctx context.Context, model *model.Base) error {
 	return args.Error(0)
 }
 
+func (m *mockRecorder) Update(ctx context.Context, id uint, fn func(context.Context, *model.Base) error) (*model.Base, error) {
+	args := m.Called(ctx, id, fn)
+	return args.Get(0).(*model.Base), args.Error(1)
+}
+
 func (m *mockRecorder) Remove(ctx context.Context, model *model.Base) error {
 	args := m.Called(ctx, model)
 	return args.Error(0)
 }
 
+func (m *mockRecorder) BatchInsert(ctx context.Context, models []*model.Base) error {
+	args := m.Called(ctx, models)
+	return args.Error(0)
+}
+
+func (m *mockRecorder) BatchModify(ctx context.Context, models []*model.Base) error {
+	args := m.Called(ctx, models)
+	return args.Error(0)
+}
+
+func (m *mockRecorder) BatchRemove(ctx context.Context, ids []uint) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *mockRecorder) GetByName(ctx context.Context, name string) (*model.Base, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(*model.Base), args.Error(1)
+}
+
+func (m *mockRecorder) Stream(ctx context.Context, fn func(*model.Base) error) error {
+	args := m.Called(ctx, fn)
+	return args.Error(0)
+}
+
+func (m *mockRecorder) List(ctx context.Context, opts model.ListOptions) ([]*model.Base, int64, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).([]*model.Base), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockRecorder) GetMany(ctx context.Context, ids []uint) ([]*model.Base, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockRecorder) Descendants(ctx context.Context, id uint) ([]*model.Base, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockRecorder) Ancestors(ctx context.Context, id uint) ([]*model.Base, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockRecorder) Search(ctx context.Context, query string, limit int) ([]model.SearchHit[model.Base], error) {
+	args := m.Called(ctx, query, limit)
+	return args.Get(0).([]model.SearchHit[model.Base]), args.Error(1)
+}
+
+func (m *mockRecorder) Similar(ctx context.Context, name string, threshold float64, limit int) ([]model.FuzzyMatch[model.Base], error) {
+	args := m.Called(ctx, name, threshold, limit)
+	return args.Get(0).([]model.FuzzyMatch[model.Base]), args.Error(1)
+}
+
+func (m *mockRecorder) Expired(ctx context.Context, before time.Time) ([]*model.Base, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockRecorder) Extend(ctx context.Context, id uint, expiresAt time.Time) (*model.Base, error) {
+	args := m.Called(ctx, id, expiresAt)
+	return args.Get(0).(*model.Base), args.Error(1)
+}
+
+func (m *mockRecorder) RemoveExpired(ctx context.Context, ids []uint, before time.Time) ([]uint, error) {
+	args := m.Called(ctx, ids, before)
+	return args.Get(0).([]uint), args.Error(1)
+}
+
 type RepositoryTestSuite struct {
 	suite.Suite
 	mockRecorder *mockRecorder
-	repo         Repository
+	repo         Repository[model.Base]
 }
 
 func (s *RepositoryTestSuite) SetupTest() {
 	s.mockRecorder = new(mockRecorder)
-	s.repo = NewRepository(s.mockRecorder)
+	s.repo = NewRepository[model.Base](s.mockRecorder)
 }
 
 func (s *RepositoryTestSuite) TestInsert() {
@@ -292,6 +368,121 @@ func (s *RepositoryTestSuite) TestRemove() {
 	}
 }
 
+func (s *RepositoryTestSuite) TestBatchInsert() {
+	tests := []struct {
+		name    string
+		models  []*model.Base
+		mockFn  func(*mockRecorder)
+		wantErr bool
+	}{
+		{
+			name:   "성공_케이스",
+			models: []*model.Base{{Name: "데이터1"}, {Name: "데이터2"}},
+			mockFn: func(m *mockRecorder) {
+				m.On("BatchInsert", mock.Anything, mock.AnythingOfType("[]*model.Base")).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "실패_케이스",
+			models: []*model.Base{{Name: "데이터1"}},
+			mockFn: func(m *mockRecorder) {
+				m.On("BatchInsert", mock.Anything, mock.AnythingOfType("[]*model.Base")).
+					Return(errors.New("일괄 생성 오류"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.mockFn(s.mockRecorder)
+
+			err := s.repo.BatchInsert(context.Background(), tt.models)
+
+			if tt.wantErr {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func (s *RepositoryTestSuite) TestBatchRemove() {
+	tests := []struct {
+		name    string
+		ids     []uint
+		mockFn  func(*mockRecorder)
+		wantErr bool
+	}{
+		{
+			name: "성공_케이스",
+			ids:  []uint{1, 2},
+			mockFn: func(m *mockRecorder) {
+				m.On("BatchRemove", mock.Anything, []uint{1, 2}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "실패_케이스",
+			ids:  []uint{3},
+			mockFn: func(m *mockRecorder) {
+				m.On("BatchRemove", mock.Anything, []uint{3}).
+					Return(errors.New("일괄 삭제 오류"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.mockFn(s.mockRecorder)
+
+			err := s.repo.BatchRemove(context.Background(), tt.ids)
+
+			if tt.wantErr {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func (s *RepositoryTestSuite) TestStream() {
+	s.mockRecorder.On("Stream", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*model.Base) error)
+			fn(&model.Base{ID: 1, Name: "데이터1"})
+			fn(&model.Base{ID: 2, Name: "데이터2"})
+		}).
+		Return(nil)
+
+	var names []string
+	err := s.repo.Stream(context.Background(), func(m *model.Base) error {
+		names = append(names, m.Name)
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal([]string{"데이터1", "데이터2"}, names)
+}
+
+func (s *RepositoryTestSuite) TestList() {
+	opts := model.ListOptions{Page: 2, Size: 1}
+	s.mockRecorder.On("List", mock.Anything, opts).
+		Return([]*model.Base{{ID: 2, Name: "데이터2"}}, int64(3), nil)
+
+	got, total, err := s.repo.List(context.Background(), opts)
+
+	s.NoError(err)
+	s.Equal(int64(3), total)
+	s.Equal([]*model.Base{{ID: 2, Name: "데이터2"}}, got)
+}
+
 func TestRepositorySuite(t *testing.T) {
 	suite.Run(t, new(RepositoryTestSuite))
 }
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go_project/internal/model"
+	"go_project/internal/recorder"
+	"time"
+)
+
+// RevisionRepository는 리소스 리비전 접근 계층
+type RevisionRepository interface {
+	Append(ctx context.Context, rev *model.Revision) error
+	Get(ctx context.Context, resourceID uint, number int) (*model.Revision, error)
+	List(ctx context.Context, resourceID uint, page, size int) ([]*model.Revision, int64, error)
+}
+
+type revisionRepository struct {
+	recorder recorder.RevisionRecorder
+}
+
+func NewRevisionRepository(recorder recorder.RevisionRecorder) RevisionRepository {
+	return &revisionRepository{
+		recorder: recorder,
+	}
+}
+
+func (r *revisionRepository) Append(ctx context.Context, rev *model.Revision) error {
+	return r.recorder.Append(ctx, rev)
+}
+
+func (r *revisionRepository) Get(ctx context.Context, resourceID uint, number int) (*model.Revision, error) {
+	return r.recorder.Get(ctx, resourceID, number)
+}
+
+func (r *revisionRepository) List(ctx context.Context, resourceID uint, page, size int) ([]*model.Revision, int64, error) {
+	return r.recorder.List(ctx, resourceID, page, size)
+}
+
+type rollbackKey struct{}
+
+// Rollback은 롤백으로 하는 수정의 정보 (WithRollback으로 ctx에 담음)
+type Rollback struct {
+	// Of는 되돌릴 리비전 번호 (저장하는 리비전의 RollbackOf로 기록)
+	Of int
+	// Revision은 수정과 함께 저장한 리비전 (versionedRepository가 채움)
+	Revision *model.Revision
+}
+
+// WithRollback은 ctx로 하는 수정이 rb.Of번 리비전으로의 롤백임을 표시
+// 이 ctx로 리소스 하나를 수정하면 저장한 리비전을 rb.Revision에 담음
+func WithRollback(ctx context.Context, rb *Rollback) context.Context {
+	return context.WithValue(ctx, rollbackKey{}, rb)
+}
+
+type versionedRepository[T any, PT model.Model[T]] struct {
+	Repository[T]
+	revisions RevisionRepository
+	tx        recorder.Transactor
+}
+
+// NewVersionedRepository는 생성/수정할 때마다 저장한 리소스 전체를 revisions에 리비전으로 남기는 repo 데코레이터를 생성
+//
+// 리소스 저장과 리비전 저장은 tx의 한 트랜잭션에서 실행하므로 하나가 실패하면 둘 다 반영되지 않음
+// (tx는 repo와 revisions가 같은 DB를 쓰는 Transactor여야 하고, 캐시는 커밋 뒤에 지우도록 이 데코레이터 바깥에 둠)
+// 삭제해도 리비전은 지우지 않음
+func NewVersionedRepository[T any, PT model.Model[T]](repo Repository[T], revisions RevisionRepository, tx recorder.Transactor) Repository[T] {
+	return &versionedRepository[T, PT]{
+		Repository: repo,
+		revisions:  revisions,
+		tx:         tx,
+	}
+}
+
+// write는 한 트랜잭션에서 fn으로 리소스를 저장하고 저장된 리소스의 리비전을 남김
+// 롤백(WithRollback)으로 리소스 하나를 저장하면 남긴 리비전을 Rollback.Revision에 담음
+func (r *versionedRepository[T, PT]) write(ctx context.Context, fn func(ctx context.Context) ([]*T, error)) error {
+	return r.tx.Transaction(ctx, func(ctx context.Context) error {
+		models, err := fn(ctx)
+		if err != nil {
+			return err
+		}
+		revs, err := r.record(ctx, models...)
+		if err != nil {
+			return err
+		}
+		if rb, ok := ctx.Value(rollbackKey{}).(*Rollback); ok && len(revs) == 1 {
+			rb.Revision = revs[0]
+		}
+		return nil
+	})
+}
+
+// record는 저장된 models의 스냅샷을 리비전으로 남기고 남긴 리비전을 models 순서로 반환
+func (r *versionedRepository[T, PT]) record(ctx context.Context, models ...*T) ([]*model.Revision, error) {
+	var rollbackOf *int
+	if rb, ok := ctx.Value(rollbackKey{}).(*Rollback); ok {
+		rollbackOf = &rb.Of
+	}
+	revs := make([]*model.Revision, len(models))
+	for i, m := range models {
+		snapshot, err := json.Marshal(m)
+		if err != nil {
+			return nil, fmt.Errorf("리비전 직렬화 실패: %v", err)
+		}
+		rev := &model.Revision{
+			ResourceID: PT(m).GetBase().ID,
+			Snapshot:   string(snapshot),
+			RollbackOf: rollbackOf,
+		}
+		if err := r.revisions.Append(ctx, rev); err != nil {
+			return nil, fmt.Errorf("리비전 저장 실패: %w", err)
+		}
+		revs[i] = rev
+	}
+	return revs, nil
+}
+
+func (r *versionedRepository[T, PT]) Insert(ctx context.Context, m *T) error {
+	return r.write(ctx, func(ctx context.Context) ([]*T, error) {
+		return []*T{m}, r.Repository.Insert(ctx, m)
+	})
+}
+
+func (r *versionedRepository[T, PT]) Modify(ctx context.Context, m *T) error {
+	return r.write(ctx, func(ctx context.Context) ([]*T, error) {
+		return []*T{m}, r.Repository.Modify(ctx, m)
+	})
+}
+
+func (r *versionedRepository[T, PT]) Update(ctx context.Context, id uint, fn func(ctx context.Context, m *T) error) (*T, error) {
+	var m *T
+	err := r.write(ctx, func(ctx context.Context) ([]*T, error) {
+		var err error
+		m, err = r.Repository.Update(ctx, id, fn)
+		return []*T{m}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (r *versionedRepository[T, PT]) BatchInsert(ctx context.Context, models []*T) error {
+	return r.write(ctx, func(ctx context.Context) ([]*T, error) {
+		return models, r.Repository.BatchInsert(ctx, models)
+	})
+}
+
+func (r *versionedRepository[T, PT]) BatchModify(ctx context.Context, models []*T) error {
+	return r.write(ctx, func(ctx context.Context) ([]*T, error) {
+		return models, r.Repository.BatchModify(ctx, models)
+	})
+}
+
+func (r *versionedRepository[T, PT]) Extend(ctx context.Context, id uint, expiresAt time.Time) (*T, error) {
+	var m *T
+	err := r.write(ctx, func(ctx context.Context) ([]*T, error) {
+		var err error
+		m, err = r.Repository.Extend(ctx, id, expiresAt)
+		return []*T{m}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,198 @@
+package scheduler
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule은 파싱한 cron 표현식
+//
+// 표현식은 "분 시 일 월 요일" 다섯 필드로 쓰고, 필드마다 *, 값, 범위(a-b), 목록(a,b), 간격(*/n, a-b/n)을 쓸 수 있음
+// 월과 요일은 이름(JAN, MON 등)도 쓸 수 있고 요일의 0과 7은 일요일
+// 일과 요일을 둘 다 지정하면 둘 중 하나만 맞아도 실행 (표준 cron과 같음)
+// @hourly, @daily(@midnight), @weekly, @monthly, @yearly(@annually)도 지원
+type Schedule struct {
+	spec                         string
+	minute, hour, dom, month     uint64
+	dow                          uint64
+	domRestricted, dowRestricted bool
+}
+
+// 약어 표현식
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// 필드별 값 범위와 이름
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "분", min: 0, max: 59}
+	hourField   = field{name: "시", min: 0, max: 23}
+	domField    = field{name: "일", min: 1, max: 31}
+	monthField  = field{name: "월", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	dowField = field{name: "요일", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+// Parse는 cron 표현식을 파싱
+func Parse(spec string) (*Schedule, error) {
+	expr := strings.TrimSpace(spec)
+	if strings.HasPrefix(expr, "@") {
+		e, ok := descriptors[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("알 수 없는 cron 약어: %s", expr)
+		}
+		expr = e
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron 표현식은 분 시 일 월 요일 다섯 필드여야 합니다: %q", spec)
+	}
+
+	s := &Schedule{spec: spec}
+	var err error
+	if s.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	// 7도 일요일
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domRestricted = fields[2] != "*" && !strings.HasPrefix(fields[2], "*/")
+	s.dowRestricted = fields[4] != "*" && !strings.HasPrefix(fields[4], "*/")
+	return s, nil
+}
+
+// parse는 필드 하나를 값마다 비트를 켠 집합으로 변환
+func (f field) parse(expr string) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepExpr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s 필드의 잘못된 간격: %q", f.name, item)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		if rangeExpr != "*" {
+			loExpr, hiExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if lo, err = f.value(loExpr); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = f.value(hiExpr); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// a/n은 a부터 최댓값까지 n 간격
+				hi = f.max
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%s 필드의 잘못된 범위: %q", f.name, item)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+func (f field) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s 필드의 값은 %d~%d여야 합니다: %q", f.name, f.min, f.max, s)
+	}
+	return v, nil
+}
+
+// String은 파싱한 원래 표현식을 반환
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// 맞는 시각을 찾을 때 살펴볼 최대 기간 (2월 30일처럼 없는 날짜만 지정한 표현식에서 멈추기 위함)
+const maxSearchYears = 5
+
+// Next는 t 이후(t는 제외) 처음으로 표현식에 맞는 시각을 t와 같은 시간대로 반환
+// 맞는 시각이 없으면 0 시각을 반환
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			// 다음으로 맞는 분으로 바로 이동 (이번 시간에 없으면 다음 시간으로)
+			rest := s.minute >> uint(t.Minute())
+			if rest == 0 {
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			} else {
+				t = t.Add(time.Duration(bits.TrailingZeros64(rest)) * time.Minute)
+			}
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"* * * FOO *",
+		"@minutely",
+	}
+	for _, spec := range tests {
+		t.Run(spec, func(t *testing.T) {
+			_, err := Parse(spec)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	// 2024-01-01은 월요일
+	from := time.Date(2024, 1, 1, 10, 30, 15, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 1, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"30 * * * *", time.Date(2024, 1, 1, 11, 30, 0, 0, time.UTC)},
+		{"5,10 9-11 * * *", time.Date(2024, 1, 1, 11, 5, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * FRI", time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1-5/2", time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 MAR *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		// 윤년의 2월 29일
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// 일과 요일을 둘 다 지정하면 둘 중 하나만 맞아도 실행 (15일 또는 금요일)
+		{"0 0 15 * 5", time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		// 요일을 */n으로 지정하면 제한하지 않은 것으로 보고 일과 모두 맞아야 함 (1월 10일은 수요일, 2월 10일은 토요일)
+		{"0 0 10 * */2", time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(from))
+		})
+	}
+}
+
+func TestSchedule_NextNever(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
+
+func TestSchedule_NextLocation(t *testing.T) {
+	seoul := time.FixedZone("KST", 9*60*60)
+	s, err := Parse("0 3 * * *")
+	require.NoError(t, err)
+	// 시간대는 인자의 시간대를 따름 (UTC 2024-01-01 20:00 = KST 2024-01-02 05:00)
+	next := s.Next(time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC).In(seoul))
+	assert.Equal(t, time.Date(2024, 1, 3, 3, 0, 0, 0, seoul), next)
+	assert.Equal(t, "0 3 * * *", s.String())
+}
@@ -0,0 +1,208 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status는 실행 결과
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Trigger는 실행한 계기
+type Trigger string
+
+const (
+	TriggerSchedule Trigger = "schedule" // cron 표현식에 따라 실행
+	TriggerManual   Trigger = "manual"   // API로 직접 실행
+)
+
+// Run은 예약 작업의 실행 기록 하나
+type Run struct {
+	ID      uint    `gorm:"primaryKey" json:"id"`
+	Task    string  `gorm:"size:128;not null;index:idx_task_runs_task,priority:1" json:"task"`
+	Trigger Trigger `gorm:"size:16;not null" json:"trigger"`
+	Status  Status  `gorm:"size:16;not null" json:"status"`
+	// Instance는 실행한 서버 (호스트 이름)
+	Instance string `gorm:"size:255" json:"instance"`
+	// ScheduledAt은 cron 표현식에 따른 예정 시각 (직접 실행이면 없음)
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+	StartedAt   time.Time  `gorm:"not null;index:idx_task_runs_task,priority:2" json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	DurationMS  int64      `json:"duration_ms"`
+	Error       string     `gorm:"type:text" json:"error,omitempty"`
+}
+
+func (Run) TableName() string {
+	return "task_runs"
+}
+
+// History는 실행 기록 저장소
+type History interface {
+	// Start는 실행 중인 run을 저장하고 ID를 채움
+	Start(ctx context.Context, run *Run) error
+	// Finish는 run의 결과(Status, FinishedAt, DurationMS, Error)를 저장
+	Finish(ctx context.Context, run *Run) error
+	// List는 task의 실행 기록을 최근 것부터 한 페이지 조회하고 전체 개수를 함께 반환 (page는 1부터, size가 0이면 전체)
+	List(ctx context.Context, task string, page, size int) ([]*Run, int64, error)
+	// Purge는 before 전에 시작한 기록을 지우고 지운 수를 반환
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+type gormHistory struct {
+	db *gorm.DB
+}
+
+// NewGormHistory는 task_runs 테이블을 사용하는 History를 생성 (테이블은 Migrate로 생성)
+func NewGormHistory(db *gorm.DB) History {
+	return &gormHistory{db: db}
+}
+
+// Migrate는 task_runs 테이블과 인덱스를 생성하거나 Run의 변경 사항을 반영
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&Run{}); err != nil {
+		return fmt.Errorf("task_runs 테이블 마이그레이션 실패: %w", err)
+	}
+	return nil
+}
+
+func (h *gormHistory) Start(ctx context.Context, run *Run) error {
+	if err := h.db.WithContext(ctx).Create(run).Error; err != nil {
+		return fmt.Errorf("실행 기록 저장 실패: %w", err)
+	}
+	return nil
+}
+
+func (h *gormHistory) Finish(ctx context.Context, run *Run) error {
+	err := h.db.WithContext(ctx).Model(&Run{}).Where("id = ?", run.ID).Updates(map[string]interface{}{
+		"status":      run.Status,
+		"finished_at": run.FinishedAt,
+		"duration_ms": run.DurationMS,
+		"error":       run.Error,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("실행 결과 저장 실패: %w", err)
+	}
+	return nil
+}
+
+func (h *gormHistory) List(ctx context.Context, task string, page, size int) ([]*Run, int64, error) {
+	db := h.db.WithContext(ctx).Model(&Run{}).Where("task = ?", task)
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("실행 기록 개수 조회 실패: %w", err)
+	}
+	runs := []*Run{}
+	q := db.Order("started_at DESC, id DESC")
+	if size > 0 {
+		q = q.Offset((max(page, 1) - 1) * size).Limit(size)
+	}
+	if err := q.Find(&runs).Error; err != nil {
+		return nil, 0, fmt.Errorf("실행 기록 조회 실패: %w", err)
+	}
+	return runs, total, nil
+}
+
+func (h *gormHistory) Purge(ctx context.Context, before time.Time) (int64, error) {
+	res := h.db.WithContext(ctx).Where("started_at < ?", before).Delete(&Run{})
+	if res.Error != nil {
+		return 0, fmt.Errorf("실행 기록 삭제 실패: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
+
+type memoryHistory struct {
+	mu     sync.Mutex
+	runs   map[uint]*Run
+	nextID uint
+}
+
+// NewMemoryHistory는 메모리에 저장하는 History를 생성 (테스트나 서버가 하나일 때 사용)
+func NewMemoryHistory() History {
+	return &memoryHistory{runs: make(map[uint]*Run), nextID: 1}
+}
+
+func (h *memoryHistory) Start(ctx context.Context, run *Run) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	run.ID = h.nextID
+	h.nextID++
+	c := *run
+	h.runs[run.ID] = &c
+	return nil
+}
+
+func (h *memoryHistory) Finish(ctx context.Context, run *Run) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	stored, ok := h.runs[run.ID]
+	if !ok {
+		return errors.New("실행 기록을 찾을 수 없습니다")
+	}
+	stored.Status = run.Status
+	stored.FinishedAt = run.FinishedAt
+	stored.DurationMS = run.DurationMS
+	stored.Error = run.Error
+	return nil
+}
+
+func (h *memoryHistory) List(ctx context.Context, task string, page, size int) ([]*Run, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	runs := []*Run{}
+	for _, r := range h.runs {
+		if r.Task == task {
+			c := *r
+			runs = append(runs, &c)
+		}
+	}
+	sort.Slice(runs, func(a, b int) bool {
+		if !runs[a].StartedAt.Equal(runs[b].StartedAt) {
+			return runs[a].StartedAt.After(runs[b].StartedAt)
+		}
+		return runs[a].ID > runs[b].ID
+	})
+
+	total := int64(len(runs))
+	if size > 0 {
+		start := min((max(page, 1)-1)*size, len(runs))
+		runs = runs[start:min(start+size, len(runs))]
+	}
+	return runs, total, nil
+}
+
+func (h *memoryHistory) Purge(ctx context.Context, before time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var n int64
+	for id, r := range h.runs {
+		if r.StartedAt.Before(before) {
+			delete(h.runs, id)
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Leader는 여러 서버 중 예약 작업을 실행할 한 서버를 정하는 리더 선출
+type Leader interface {
+	// IsLeader는 이 서버가 리더인지 확인 (리더가 없으면 리더가 되려고 시도)
+	IsLeader(ctx context.Context) (bool, error)
+	// Release는 리더 자리를 내놓음
+	Release(ctx context.Context) error
+}
+
+// DefaultLockKey는 예약 작업 리더 선출에 쓰는 Postgres advisory lock 키
+const DefaultLockKey int64 = 0x7363686564756c65 // "schedule"
+
+type advisoryLock struct {
+	db  *gorm.DB
+	key int64
+
+	mu   sync.Mutex
+	conn *sql.Conn // 락을 잡은 연결 (리더가 아니면 nil)
+}
+
+// NewAdvisoryLock은 Postgres의 세션 advisory lock(pg_try_advisory_lock)으로 리더를 정하는 Leader를 생성
+//
+// 리더는 락을 잡은 연결 하나를 풀에서 빌려서 계속 가지고 있고, 서버가 죽거나 연결이 끊기면 DB가 락을 풀어서
+// 다음으로 IsLeader를 호출한 서버가 리더가 됨
+// 리더가 바뀌는 사이에 실행 시각이 겹치면 그 회차는 두 번 실행되거나 건너뛸 수 있음
+func NewAdvisoryLock(db *gorm.DB, key int64) Leader {
+	return &advisoryLock{db: db, key: key}
+}
+
+func (l *advisoryLock) IsLeader(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn != nil {
+		// 연결이 살아 있으면 락도 그대로 잡고 있음
+		if err := l.conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		log.Printf("예약 작업 리더 연결이 끊김, 리더 자리를 내놓음")
+		l.conn.Close()
+		l.conn = nil
+	}
+
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("리더 선출 연결 실패: %w", err)
+	}
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&acquired); err != nil {
+		conn.Close()
+		return false, fmt.Errorf("리더 선출 실패: %w", err)
+	}
+	if !acquired {
+		conn.Close()
+		return false, nil
+	}
+	l.conn = conn
+	log.Printf("예약 작업 리더가 됨")
+	return true, nil
+}
+
+func (l *advisoryLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn == nil {
+		return nil
+	}
+	_, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key)
+	l.conn.Close()
+	l.conn = nil
+	if err != nil {
+		return fmt.Errorf("리더 자리 해제 실패: %w", err)
+	}
+	return nil
+}
+
+type localLeader struct{}
+
+// NewLocalLeader는 항상 리더인 Leader를 생성 (서버가 하나이거나 테스트할 때 사용)
+func NewLocalLeader() Leader {
+	return localLeader{}
+}
+
+func (localLeader) IsLeader(context.Context) (bool, error) { return true, nil }
+func (localLeader) Release(context.Context) error          { return nil }
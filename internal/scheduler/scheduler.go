@@ -0,0 +1,336 @@
+// Package scheduler는 cron 표현식에 따라 이름 붙은 작업(정리, 내보내기 등)을 주기적으로 실행하는 스케줄러를 제공
+//
+// 작업은 Register로 등록하고, 실행 주기는 설정 파일(Config)이나 Schedule로 지정함
+// 여러 서버가 같은 설정으로 실행해도 Leader로 정한 한 서버만 예약 작업을 실행하고,
+// 실행마다 시작·종료 시각과 에러를 History에 기록함
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Task는 예약 작업 하나 (에러를 반환하면 실패로 기록)
+type Task func(ctx context.Context) error
+
+var (
+	// ErrUnknownTask는 등록되지 않은 작업 이름일 때 반환 (errors.Is로 확인)
+	ErrUnknownTask = errors.New("등록되지 않은 예약 작업입니다")
+	// ErrAlreadyRunning은 같은 작업이 이 서버에서 아직 실행 중일 때 반환
+	ErrAlreadyRunning = errors.New("이미 실행 중인 예약 작업입니다")
+)
+
+// Config는 예약 작업 설정 파일의 내용
+//
+//	location: Asia/Seoul           # cron 표현식의 시간대 (기본: 서버 시간대)
+//	tasks:                         # 작업 이름: cron 표현식 (없는 작업은 직접 실행만 가능)
+//	  idempotency-purge: "@hourly"
+//	  nightly-export: "0 3 * * *"
+type Config struct {
+	Location string            `yaml:"location"`
+	Tasks    map[string]string `yaml:"tasks"`
+}
+
+// ParseConfig는 YAML 설정을 파싱
+func ParseConfig(data []byte) (Config, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("예약 작업 설정 파싱 실패: %v", err)
+	}
+	return cfg, nil
+}
+
+// TaskInfo는 등록된 작업의 상태
+type TaskInfo struct {
+	Name     string     `json:"name"`
+	Schedule string     `json:"schedule,omitempty"` // 없으면 직접 실행만 가능
+	NextRun  *time.Time `json:"next_run,omitempty"`
+	Running  bool       `json:"running"` // 이 서버에서 실행 중인지
+	LastRun  *Run       `json:"last_run,omitempty"`
+}
+
+type task struct {
+	name     string
+	fn       Task
+	schedule *Schedule
+	running  bool
+}
+
+// Option은 Scheduler 설정
+type Option func(*Scheduler)
+
+// WithLocation은 cron 표현식을 해석할 시간대를 지정 (기본 time.Local)
+func WithLocation(loc *time.Location) Option {
+	return func(s *Scheduler) {
+		s.loc = loc
+	}
+}
+
+// Scheduler는 등록된 작업을 cron 표현식에 따라 실행
+type Scheduler struct {
+	history  History
+	leader   Leader
+	loc      *time.Location
+	instance string
+	now      func() time.Time
+
+	mu    sync.Mutex
+	tasks map[string]*task
+	wg    sync.WaitGroup // 실행 중인 작업
+}
+
+// New는 실행 기록을 history에 남기고 leader로 정한 서버에서만 예약 작업을 실행하는 Scheduler를 생성
+func New(history History, leader Leader, opts ...Option) *Scheduler {
+	instance, _ := os.Hostname()
+	s := &Scheduler{
+		history:  history,
+		leader:   leader,
+		loc:      time.Local,
+		instance: instance,
+		now:      time.Now,
+		tasks:    make(map[string]*task),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// History는 실행 기록 저장소를 반환
+func (s *Scheduler) History() History {
+	return s.history
+}
+
+// Register는 name 작업을 등록 (같은 이름을 다시 등록하면 실행할 함수만 바꿈)
+func (s *Scheduler) Register(name string, fn Task) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.tasks[name]; ok {
+		t.fn = fn
+		return
+	}
+	s.tasks[name] = &task{name: name, fn: fn}
+}
+
+// Schedule은 등록된 name 작업을 spec(cron 표현식)에 따라 실행하도록 지정 (Run 전에 호출)
+func (s *Scheduler) Schedule(name, spec string) error {
+	sched, err := Parse(spec)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tasks[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownTask, name)
+	}
+	t.schedule = sched
+	return nil
+}
+
+// Configure는 설정의 시간대와 작업별 실행 주기를 적용 (Run 전에 호출)
+func (s *Scheduler) Configure(cfg Config) error {
+	if cfg.Location != "" {
+		loc, err := time.LoadLocation(cfg.Location)
+		if err != nil {
+			return fmt.Errorf("알 수 없는 시간대: %s", cfg.Location)
+		}
+		s.loc = loc
+	}
+	for name, spec := range cfg.Tasks {
+		if err := s.Schedule(name, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run은 실행 주기가 지정된 작업을 ctx가 끝날 때까지 예약된 시각마다 실행
+// 예약된 시각에 이 서버가 리더가 아니거나 이전 실행이 끝나지 않았으면 그 회차는 건너뜀
+// ctx가 끝나면 실행 중인 작업이 끝나기를 기다리고 리더 자리를 내놓은 뒤 반환
+func (s *Scheduler) Run(ctx context.Context) {
+	var loops sync.WaitGroup
+	s.mu.Lock()
+	for _, t := range s.tasks {
+		if t.schedule == nil {
+			continue
+		}
+		loops.Add(1)
+		go func() {
+			defer loops.Done()
+			s.loop(ctx, t)
+		}()
+	}
+	s.mu.Unlock()
+
+	loops.Wait()
+	s.wg.Wait()
+	if err := s.leader.Release(context.WithoutCancel(ctx)); err != nil {
+		log.Printf("%v", err)
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, t *task) {
+	for {
+		now := s.now().In(s.loc)
+		next := t.schedule.Next(now)
+		if next.IsZero() {
+			log.Printf("예약 작업 %s의 다음 실행 시각이 없음: %s", t.name, t.schedule)
+			return
+		}
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		s.tick(ctx, t, next)
+	}
+}
+
+// tick은 scheduledAt 회차를 이 서버가 리더일 때만 실행
+func (s *Scheduler) tick(ctx context.Context, t *task, scheduledAt time.Time) {
+	leader, err := s.leader.IsLeader(ctx)
+	if err != nil {
+		log.Printf("예약 작업 %s 건너뜀: %v", t.name, err)
+		return
+	}
+	if !leader {
+		return
+	}
+	if _, err := s.start(ctx, t, TriggerSchedule, &scheduledAt); err != nil {
+		log.Printf("예약 작업 %s 건너뜀: %v", t.name, err)
+	}
+}
+
+// Trigger는 name 작업을 바로 실행하고 실행 기록을 반환 (끝날 때까지 기다리지 않음)
+// 리더가 아니어도 이 서버에서 실행하며, 실행은 ctx가 끝나도 취소되지 않음
+func (s *Scheduler) Trigger(ctx context.Context, name string) (*Run, error) {
+	s.mu.Lock()
+	t, ok := s.tasks[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTask, name)
+	}
+	return s.start(context.WithoutCancel(ctx), t, TriggerManual, nil)
+}
+
+// start는 실행 기록을 남기고 t를 백그라운드에서 실행
+func (s *Scheduler) start(ctx context.Context, t *task, trigger Trigger, scheduledAt *time.Time) (*Run, error) {
+	s.mu.Lock()
+	if t.running {
+		s.mu.Unlock()
+		return nil, ErrAlreadyRunning
+	}
+	t.running = true
+	fn := t.fn
+	s.mu.Unlock()
+
+	run := &Run{
+		Task:        t.name,
+		Trigger:     trigger,
+		Status:      StatusRunning,
+		Instance:    s.instance,
+		ScheduledAt: scheduledAt,
+		StartedAt:   s.now(),
+	}
+	if err := s.history.Start(ctx, run); err != nil {
+		s.finished(t)
+		return nil, err
+	}
+	started := *run
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.execute(ctx, t, fn, run)
+	}()
+	return &started, nil
+}
+
+func (s *Scheduler) execute(ctx context.Context, t *task, fn Task, run *Run) {
+	defer s.finished(t)
+
+	err := call(ctx, fn)
+	finishedAt := s.now()
+	run.FinishedAt = &finishedAt
+	run.DurationMS = finishedAt.Sub(run.StartedAt).Milliseconds()
+	run.Status = StatusSucceeded
+	if err != nil {
+		run.Status = StatusFailed
+		run.Error = err.Error()
+		log.Printf("예약 작업 %s 실패 (%dms): %v", t.name, run.DurationMS, err)
+	} else {
+		log.Printf("예약 작업 %s 완료 (%dms)", t.name, run.DurationMS)
+	}
+	// 서버가 종료되는 중이어도 결과는 저장
+	if err := s.history.Finish(context.WithoutCancel(ctx), run); err != nil {
+		log.Printf("예약 작업 %s 결과 저장 실패: %v", t.name, err)
+	}
+}
+
+func (s *Scheduler) finished(t *task) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t.running = false
+}
+
+// call은 fn을 실행하고 패닉은 에러로 바꿈
+func call(ctx context.Context, fn Task) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("예약 작업 실행 중 패닉: %v", p)
+		}
+	}()
+	return fn(ctx)
+}
+
+// Tasks는 등록된 작업의 실행 주기, 다음 실행 시각, 마지막 실행 기록을 이름 순으로 반환
+func (s *Scheduler) Tasks(ctx context.Context) ([]TaskInfo, error) {
+	now := s.now().In(s.loc)
+	s.mu.Lock()
+	infos := make([]TaskInfo, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		info := TaskInfo{Name: t.name, Running: t.running}
+		if t.schedule != nil {
+			info.Schedule = t.schedule.String()
+			if next := t.schedule.Next(now); !next.IsZero() {
+				info.NextRun = &next
+			}
+		}
+		infos = append(infos, info)
+	}
+	s.mu.Unlock()
+	sort.Slice(infos, func(a, b int) bool { return infos[a].Name < infos[b].Name })
+
+	for i := range infos {
+		runs, _, err := s.history.List(ctx, infos[i].Name, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(runs) > 0 {
+			infos[i].LastRun = runs[0]
+		}
+	}
+	return infos, nil
+}
+
+// Runs는 name 작업의 실행 기록을 최근 것부터 한 페이지 조회
+func (s *Scheduler) Runs(ctx context.Context, name string, page, size int) ([]*Run, int64, error) {
+	s.mu.Lock()
+	_, ok := s.tasks[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %s", ErrUnknownTask, name)
+	}
+	return s.history.List(ctx, name, page, size)
+}
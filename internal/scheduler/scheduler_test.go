@@ -0,0 +1,323 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go_project/internal/jobs"
+	"go_project/internal/model"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/transfer"
+	"go_project/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLeader는 leader 값을 그대로 반환하는 Leader
+type fakeLeader struct {
+	leader bool
+	err    error
+}
+
+func (l *fakeLeader) IsLeader(context.Context) (bool, error) { return l.leader, l.err }
+func (l *fakeLeader) Release(context.Context) error          { return nil }
+
+// waitRuns는 task의 실행 기록이 n개 끝날 때까지 기다린 뒤 최근 것부터 반환
+func waitRuns(t *testing.T, s *Scheduler, task string, n int) []*Run {
+	var runs []*Run
+	require.Eventually(t, func() bool {
+		runs, _, _ = s.Runs(context.Background(), task, 1, 0)
+		finished := 0
+		for _, r := range runs {
+			if r.Status != StatusRunning {
+				finished++
+			}
+		}
+		return finished == n
+	}, time.Second, 5*time.Millisecond)
+	return runs
+}
+
+func TestScheduler_Trigger(t *testing.T) {
+	ctx := context.Background()
+	s := New(NewMemoryHistory(), NewLocalLeader())
+	release := make(chan struct{})
+	s.Register("slow", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	s.Register("broken", func(ctx context.Context) error {
+		return errors.New("디스크 가득 참")
+	})
+	s.Register("panic", func(ctx context.Context) error {
+		panic("버그")
+	})
+
+	run, err := s.Trigger(ctx, "slow")
+	require.NoError(t, err)
+	assert.Equal(t, TriggerManual, run.Trigger)
+	assert.Equal(t, StatusRunning, run.Status)
+	assert.Nil(t, run.ScheduledAt)
+
+	// 실행 중인 작업은 다시 실행하지 않음
+	_, err = s.Trigger(ctx, "slow")
+	assert.ErrorIs(t, err, ErrAlreadyRunning)
+	infos, err := s.Tasks(ctx)
+	require.NoError(t, err)
+	assert.True(t, infos[2].Running)
+	close(release)
+	runs := waitRuns(t, s, "slow", 1)
+	assert.Equal(t, StatusSucceeded, runs[0].Status)
+	assert.NotNil(t, runs[0].FinishedAt)
+
+	s.Trigger(ctx, "broken")
+	runs = waitRuns(t, s, "broken", 1)
+	assert.Equal(t, StatusFailed, runs[0].Status)
+	assert.Equal(t, "디스크 가득 참", runs[0].Error)
+
+	s.Trigger(ctx, "panic")
+	runs = waitRuns(t, s, "panic", 1)
+	assert.Contains(t, runs[0].Error, "패닉")
+
+	_, err = s.Trigger(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrUnknownTask)
+	_, _, err = s.Runs(ctx, "unknown", 1, 10)
+	assert.ErrorIs(t, err, ErrUnknownTask)
+}
+
+func TestScheduler_TickLeader(t *testing.T) {
+	ctx := context.Background()
+	leader := &fakeLeader{}
+	s := New(NewMemoryHistory(), leader)
+	s.Register("purge", func(ctx context.Context) error { return nil })
+	require.NoError(t, s.Schedule("purge", "@hourly"))
+	task := s.tasks["purge"]
+	at := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	// 리더가 아니거나 리더를 확인할 수 없으면 실행하지 않음
+	s.tick(ctx, task, at)
+	leader.err = errors.New("db down")
+	leader.leader = true
+	s.tick(ctx, task, at)
+	s.wg.Wait()
+	runs, _, _ := s.Runs(ctx, "purge", 1, 0)
+	assert.Empty(t, runs)
+
+	leader.err = nil
+	s.tick(ctx, task, at)
+	runs = waitRuns(t, s, "purge", 1)
+	assert.Equal(t, TriggerSchedule, runs[0].Trigger)
+	assert.Equal(t, at, *runs[0].ScheduledAt)
+}
+
+func TestScheduler_Configure(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+location: Asia/Seoul
+tasks:
+  purge: "0 3 * * *"
+`))
+	require.NoError(t, err)
+
+	s := New(NewMemoryHistory(), NewLocalLeader())
+	s.Register("purge", func(ctx context.Context) error { return nil })
+	s.Register("export", func(ctx context.Context) error { return nil })
+	s.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+	require.NoError(t, s.Configure(cfg))
+
+	infos, err := s.Tasks(context.Background())
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	assert.Equal(t, "export", infos[0].Name)
+	assert.Empty(t, infos[0].Schedule)
+	assert.Nil(t, infos[0].NextRun)
+	assert.Equal(t, "0 3 * * *", infos[1].Schedule)
+	// 서울 03:00 = UTC 전날 18:00
+	assert.True(t, infos[1].NextRun.Equal(time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)))
+
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"등록되지_않은_작업", Config{Tasks: map[string]string{"unknown": "@daily"}}},
+		{"잘못된_표현식", Config{Tasks: map[string]string{"purge": "61 * * * *"}}},
+		{"잘못된_시간대", Config{Location: "Mars/Base"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, s.Configure(tt.cfg))
+		})
+	}
+	_, err = ParseConfig([]byte("tasks: [1, 2]"))
+	assert.Error(t, err)
+}
+
+func TestScheduler_Run(t *testing.T) {
+	s := New(NewMemoryHistory(), NewLocalLeader())
+	s.Register("every-minute", func(ctx context.Context) error { return nil })
+	require.NoError(t, s.Schedule("every-minute", "* * * * *"))
+
+	// 다음 실행 전에 끝나면 아무것도 실행하지 않고 반환
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run이 반환되지 않음")
+	}
+}
+
+func TestMemoryHistory(t *testing.T) {
+	ctx := context.Background()
+	h := NewMemoryHistory()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		require.NoError(t, h.Start(ctx, &Run{Task: "a", Status: StatusRunning, StartedAt: base.Add(time.Duration(i) * time.Hour)}))
+	}
+	h.Start(ctx, &Run{Task: "b", StartedAt: base})
+
+	runs, total, err := h.List(ctx, "a", 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, runs, 1)
+	assert.Equal(t, uint(1), runs[0].ID)
+
+	finished := base.Add(time.Minute)
+	require.NoError(t, h.Finish(ctx, &Run{ID: 1, Status: StatusFailed, FinishedAt: &finished, DurationMS: 60000, Error: "x"}))
+	runs, _, _ = h.List(ctx, "a", 2, 2)
+	assert.Equal(t, StatusFailed, runs[0].Status)
+	assert.Equal(t, int64(60000), runs[0].DurationMS)
+	assert.Error(t, h.Finish(ctx, &Run{ID: 99}))
+
+	n, err := h.Purge(ctx, base.Add(90*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	_, total, _ = h.List(ctx, "a", 1, 0)
+	assert.Equal(t, int64(1), total)
+}
+
+func TestExportTask(t *testing.T) {
+	ctx := context.Background()
+	uc := usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))
+	require.NoError(t, uc.Insert(ctx, &model.Base{Name: "web"}))
+	dir := filepath.Join(t.TempDir(), "exports")
+
+	require.NoError(t, ExportTask(uc, dir, "ndjson")(ctx))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "임시 파일은 남기지 않음")
+	assert.Regexp(t, `^resources-\d{8}T\d{6}\.ndjson$`, entries[0].Name())
+	data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"name":"web"`)
+}
+
+func TestExpireTask(t *testing.T) {
+	ctx := context.Background()
+	rec := recorder.NewMemoryRecorder[model.Base]()
+	uc := usecase.NewUsecase(repository.NewRepository(rec))
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	// 이미 지난 만료 시각은 Usecase로 저장할 수 없으므로 Recorder로 직접 저장
+	require.NoError(t, rec.BatchInsert(ctx, []*model.Base{
+		{Name: "만료됨", ExpiresAt: &past},
+		{Name: "남음", ExpiresAt: &future},
+	}))
+	dir := filepath.Join(t.TempDir(), "expired")
+
+	require.NoError(t, ExpireTask(uc, dir)(ctx))
+
+	expired, err := rec.Expired(ctx, future.Add(time.Second))
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, "남음", expired[0].Name)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Regexp(t, `^expired-\d{8}T\d{6}\.ndjson$`, entries[0].Name())
+	data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"name":"만료됨"`)
+	assert.NotContains(t, string(data), `"name":"남음"`)
+
+	// 지울 것이 없으면 보관 파일도 만들지 않음
+	require.NoError(t, ExpireTask(uc, dir)(ctx))
+	entries, _ = os.ReadDir(dir)
+	assert.Len(t, entries, 1)
+}
+
+func TestPurgeTask(t *testing.T) {
+	var got time.Time
+	task := PurgeTask("기록", time.Hour, func(ctx context.Context, before time.Time) (int64, error) {
+		got = before
+		return 2, nil
+	})
+	require.NoError(t, task(context.Background()))
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), got, time.Second)
+
+	failing := PurgeTask("기록", 0, func(ctx context.Context, before time.Time) (int64, error) {
+		return 0, errors.New("실패")
+	})
+	assert.Error(t, failing(context.Background()))
+}
+
+func TestEnqueueTask(t *testing.T) {
+	ctx := context.Background()
+	store := jobs.NewMemoryStore()
+	task := EnqueueTask(store, ExportJob, ExportPayload{Format: transfer.FormatNDJSON})
+
+	require.NoError(t, task(ctx))
+	// 앞의 작업이 끝나지 않았으면 새로 넣지 않음
+	require.NoError(t, task(ctx))
+	list, total, err := store.List(ctx, jobs.ListOptions{Type: ExportJob})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.JSONEq(t, `{"format":"ndjson"}`, string(list[0].Payload))
+}
+
+func TestRegisterJobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uc := usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))
+	require.NoError(t, uc.Insert(ctx, &model.Base{Name: "web"}))
+	dir := filepath.Join(t.TempDir(), "exports")
+	store := jobs.NewMemoryStore()
+	runner := jobs.NewRunner(store, jobs.WithPollInterval(10*time.Millisecond))
+	RegisterJobs(runner, uc, dir, "")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runner.Run(ctx)
+	}()
+
+	export, err := jobs.Enqueue(ctx, store, ExportJob, ExportPayload{Format: transfer.FormatCSV})
+	require.NoError(t, err)
+	invalid, err := jobs.Enqueue(ctx, store, ExportJob, ExportPayload{Format: "xml"})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		a, _ := store.Get(ctx, export.ID)
+		b, _ := store.Get(ctx, invalid.ID)
+		return a.State == jobs.StateSucceeded && b.State == jobs.StateDead
+	}, 2*time.Second, 10*time.Millisecond)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Regexp(t, `^resources-\d{8}T\d{6}\.csv$`, entries[0].Name())
+	// 잘못된 형식은 재시도하지 않음
+	b, _ := store.Get(ctx, invalid.ID)
+	assert.Equal(t, 1, b.Attempts)
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,137 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go_project/internal/jobs"
+	"go_project/internal/model"
+	"go_project/internal/transfer"
+	"go_project/internal/usecase"
+)
+
+// PurgeTask는 retention보다 오래된 기록을 purge로 지우는 작업을 생성
+// (idempotency.Store.Purge처럼 만료 시각을 기준으로 지우는 경우 retention은 0)
+func PurgeTask(what string, retention time.Duration, purge func(ctx context.Context, before time.Time) (int64, error)) Task {
+	return func(ctx context.Context) error {
+		n, err := purge(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+		log.Printf("%s %d개 삭제", what, n)
+		return nil
+	}
+}
+
+// ExportTask는 리소스 전체를 dir 아래 resources-<시각>.<형식> 파일로 내보내는 작업을 생성
+func ExportTask(uc usecase.Usecase[model.Base], dir string, format transfer.Format) Task {
+	return func(ctx context.Context) error {
+		return writeFile(dir, "resources", format, func(w transfer.Writer) error {
+			return uc.Export(ctx, w.Write)
+		})
+	}
+}
+
+// ExpireTask는 만료된 리소스를 지우는 작업을 생성
+// archiveDir이 비어 있지 않으면 지우기 전에 archiveDir 아래 expired-<시각>.ndjson 파일로 보관
+func ExpireTask(uc usecase.Usecase[model.Base], archiveDir string) Task {
+	return func(ctx context.Context) error {
+		var archive func([]*model.Base) error
+		if archiveDir != "" {
+			archive = func(ms []*model.Base) error {
+				return writeFile(archiveDir, "expired", transfer.FormatNDJSON, func(w transfer.Writer) error {
+					for _, m := range ms {
+						if err := w.Write(m); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			}
+		}
+		n, err := uc.PurgeExpired(ctx, time.Now(), archive)
+		if err != nil {
+			return err
+		}
+		// 자주 실행하는 작업이므로 지운 것이 있을 때만 남김
+		if n > 0 {
+			log.Printf("만료된 리소스 %d개 삭제", n)
+		}
+		return nil
+	}
+}
+
+// 예약 작업이 작업 큐에 넣어 Runner가 실행하는 작업 종류
+const (
+	ExportJob = "resources.export" // 인자: ExportPayload
+	ExpireJob = "resources.expire" // 인자 없음
+)
+
+// ExportPayload는 ExportJob 작업의 인자
+type ExportPayload struct {
+	Format transfer.Format `json:"format"`
+}
+
+// RegisterJobs는 ExportJob과 ExpireJob을 실행할 Handler를 runner에 등록
+// 내보낸 파일은 exportDir에, 만료된 리소스는 archiveDir에 보관 (ExportTask, ExpireTask 참고)
+func RegisterJobs(runner *jobs.Runner, uc usecase.Usecase[model.Base], exportDir, archiveDir string) {
+	jobs.Register(runner, ExportJob, func(ctx context.Context, p ExportPayload) error {
+		format, err := transfer.ParseFormat(string(p.Format))
+		if err != nil {
+			return jobs.Permanent(err)
+		}
+		return ExportTask(uc, exportDir, format)(ctx)
+	})
+	jobs.Register(runner, ExpireJob, func(ctx context.Context, _ struct{}) error {
+		return ExpireTask(uc, archiveDir)(ctx)
+	})
+}
+
+// EnqueueTask는 jobType 작업을 store에 넣기만 하는 예약 작업을 생성
+// 실행은 Runner가 맡아서 실패하면 재시도하고, 이전에 넣은 같은 종류의 작업이 아직 끝나지 않았으면 새로 넣지 않음
+func EnqueueTask(store jobs.Store, jobType string, payload any) Task {
+	return func(ctx context.Context) error {
+		job, err := jobs.Enqueue(ctx, store, jobType, payload, jobs.WithUniqueKey(jobType))
+		if errors.Is(err, jobs.ErrDuplicate) {
+			log.Printf("%s 작업 %d이 아직 끝나지 않아 새로 넣지 않음", jobType, job.ID)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		log.Printf("%s 작업 %d을 큐에 넣음", jobType, job.ID)
+		return nil
+	}
+}
+
+// writeFile은 write로 쓴 리소스를 dir 아래 <prefix>-<시각>.<형식> 파일로 저장
+// 다 쓴 파일만 보이도록 임시 파일에 쓴 뒤 이름을 바꿈
+func writeFile(dir, prefix string, format transfer.Format, write func(transfer.Writer) error) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("디렉터리 생성 실패: %v", err)
+	}
+	name := filepath.Join(dir, fmt.Sprintf("%s-%s.%s", prefix, time.Now().Format("20060102T150405"), format))
+	f, err := os.CreateTemp(dir, "."+prefix+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("파일 생성 실패: %v", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	w := transfer.NewWriter(f, format)
+	if err := write(w); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("파일 저장 실패: %v", err)
+	}
+	return os.Rename(f.Name(), name)
+}
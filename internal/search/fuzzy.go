@@ -0,0 +1,106 @@
+package search
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// Similarity는 두 텍스트가 얼마나 비슷한지 0~1로 계산 (1이면 단어 구성이 같음, 대소문자 무시)
+// pg_trgm의 similarity()와 같은 방식의 트라이그램 유사도와, 한글을 자모로 나눈 편집 거리 비율 중 큰 값
+// 트라이그램은 짧은 한글 이름의 오타(예: 서버/서브)에 둔감하므로 편집 거리로 보완함
+func Similarity(a, b string) float64 {
+	return max(trigramSimilarity(a, b), editSimilarity(a, b))
+}
+
+// trigramSimilarity는 공통 트라이그램 수를 전체 트라이그램 수로 나눈 값
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	common := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+// trigrams는 pg_trgm처럼 단어마다 앞에 공백 두 개, 뒤에 공백 하나를 붙여 세 글자씩 자른 집합을 반환
+func trigrams(text string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, term := range Terms(text) {
+		runes := []rune("  " + term + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			set[string(runes[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
+
+// editSimilarity는 1 - (편집 거리 / 긴 쪽 길이) (단어 사이는 공백 하나로 보고 비교)
+func editSimilarity(a, b string) float64 {
+	ra, rb := jamo(a), jamo(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// 한글 음절 분해에 쓰는 값 (한글 음절 = 0xAC00 + (초성*21 + 중성)*28 + 종성, 자모는 U+1100부터)
+const (
+	hangulFirst = 0xAC00
+	hangulLast  = 0xD7A3
+	jungCount   = 21
+	jongCount   = 28
+	choBase     = 0x1100
+	jungBase    = 0x1161
+	jongBase    = 0x11A7
+)
+
+// jamo는 텍스트를 소문자 단어로 나누고 한글 음절은 초성/중성/종성으로 풀어서 반환
+// 음절 하나가 다른 오타도 자모 한두 개 차이로 계산하기 위함
+func jamo(text string) []rune {
+	var out []rune
+	for i, term := range Terms(text) {
+		if i > 0 {
+			out = append(out, ' ')
+		}
+		out = slices.Grow(out, utf8.RuneCountInString(term)*3)
+		for _, r := range term {
+			if r < hangulFirst || r > hangulLast {
+				out = append(out, r)
+				continue
+			}
+			s := r - hangulFirst
+			out = append(out, choBase+s/(jungCount*jongCount), jungBase+s%(jungCount*jongCount)/jongCount)
+			if jong := s % jongCount; jong > 0 {
+				out = append(out, jongBase+jong)
+			}
+		}
+	}
+	return out
+}
+
+// levenshtein은 a를 b로 바꾸는 데 필요한 최소 삽입/삭제/교체 횟수
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
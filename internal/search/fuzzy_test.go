@@ -0,0 +1,46 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FuzzyTestSuite struct {
+	suite.Suite
+}
+
+func (s *FuzzyTestSuite) TestSimilarity() {
+	tests := []struct {
+		name string
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{name: "같음_대소문자_무시", a: "Web Server", b: "web-server", min: 1, max: 1},
+		{name: "영문_오타", a: "web-server", b: "web-sevrer", min: 0.6, max: 0.9},
+		{name: "한글_음절_오타", a: "서버", b: "서브", min: 0.7, max: 0.8},
+		{name: "한글_받침_누락", a: "데이터베이스", b: "데이타베이스", min: 0.8, max: 0.95},
+		{name: "관계없음", a: "database", b: "웹서버", min: 0, max: 0.1},
+		{name: "빈_텍스트", a: "", b: "web", min: 0, max: 0},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got := Similarity(tt.a, tt.b)
+			s.GreaterOrEqual(got, tt.min)
+			s.LessOrEqual(got, tt.max)
+			s.Equal(got, Similarity(tt.b, tt.a), "대칭이어야 함")
+		})
+	}
+}
+
+func (s *FuzzyTestSuite) TestTrigramSimilarity() {
+	// pg_trgm: SELECT similarity('word', 'two words') = 0.363636
+	s.InDelta(0.363636, trigramSimilarity("word", "two words"), 1e-6)
+	s.Equal(3, levenshtein([]rune("kitten"), []rune("sitting")))
+	s.Equal([]rune{0x1109, 0x1165, 0x1107, 0x1165}, jamo("서버"))
+}
+
+func TestFuzzySuite(t *testing.T) {
+	suite.Run(t, new(FuzzyTestSuite))
+}
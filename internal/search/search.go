@@ -0,0 +1,203 @@
+// Package search는 리소스 이름 전문 검색에 쓰는 토큰화와 메모리 역색인을 제공
+//
+// PostgreSQL Recorder는 tsvector('simple' 설정)로 검색하고, DB가 없는 Recorder는 Index로 검색함
+// 두 방식 모두 검색어의 단어마다 접두사가 일치하는 단어가 있는 항목만 찾고(AND),
+// 일치한 단어를 HighlightStart/HighlightStop으로 감싼 스니펫을 만듦
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// 스니펫에서 일치한 단어를 감싸는 표시 (나머지 텍스트는 이스케이프하지 않으므로 화면에 넣을 때 주의)
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// Hit는 검색 결과 하나
+type Hit struct {
+	ID      uint
+	Rank    float64 // 클수록 관련도가 높음 (Recorder마다 척도가 다르므로 순서 비교에만 사용)
+	Snippet string  // 일치한 단어를 강조한 텍스트
+}
+
+// Terms는 텍스트를 소문자 단어 목록으로 나눔 (글자와 숫자가 아닌 문자는 구분자)
+func Terms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isSeparator)
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// Highlight는 text에서 terms 중 하나로 시작하는 단어를 강조 표시로 감쌈
+func Highlight(text string, terms []string) string {
+	var b strings.Builder
+	start := -1
+	flush := func(end int) {
+		word := text[start:end]
+		if matchesAny(strings.ToLower(word), terms) {
+			b.WriteString(HighlightStart + word + HighlightStop)
+		} else {
+			b.WriteString(word)
+		}
+		start = -1
+	}
+	for i, r := range text {
+		if isSeparator(r) {
+			if start >= 0 {
+				flush(i)
+			}
+			b.WriteRune(r)
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		flush(len(text))
+	}
+	return b.String()
+}
+
+func matchesAny(word string, terms []string) bool {
+	for _, t := range terms {
+		if strings.HasPrefix(word, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Index는 ID별 텍스트의 메모리 역색인 (여러 고루틴에서 함께 사용해도 됨)
+type Index struct {
+	mu       sync.Mutex
+	docs     map[uint]document
+	postings map[string]map[uint]int // 단어 → 문서 ID → 등장 횟수
+	sorted   []string                // 접두사 검색용으로 정렬한 단어 목록 (nil이면 다시 만듦)
+}
+
+type document struct {
+	text  string
+	terms []string
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[uint]document),
+		postings: make(map[string]map[uint]int),
+	}
+}
+
+// Add는 id의 텍스트를 색인 (이미 있으면 바꿈)
+func (ix *Index) Add(id uint, text string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.removeLocked(id)
+
+	terms := Terms(text)
+	ix.docs[id] = document{text: text, terms: terms}
+	for _, t := range terms {
+		p, ok := ix.postings[t]
+		if !ok {
+			p = make(map[uint]int)
+			ix.postings[t] = p
+			ix.sorted = nil
+		}
+		p[id]++
+	}
+}
+
+// Remove는 id를 색인에서 뺌 (없으면 아무것도 하지 않음)
+func (ix *Index) Remove(id uint) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.removeLocked(id)
+}
+
+func (ix *Index) removeLocked(id uint) {
+	doc, ok := ix.docs[id]
+	if !ok {
+		return
+	}
+	for _, t := range doc.terms {
+		p := ix.postings[t]
+		delete(p, id)
+		if len(p) == 0 {
+			delete(ix.postings, t)
+			ix.sorted = nil
+		}
+	}
+	delete(ix.docs, id)
+}
+
+// Search는 query의 모든 단어와 접두사가 일치하는 항목을 관련도 순으로 최대 limit개 반환
+// (limit이 0 이하면 전체, 관련도가 같으면 ID 순)
+func (ix *Index) Search(query string, limit int) []Hit {
+	terms := Terms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	// 검색 중에 정렬한 단어 목록을 다시 만들 수 있으므로 읽기에도 같은 잠금을 사용
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if ix.sorted == nil {
+		ix.sorted = make([]string, 0, len(ix.postings))
+		for t := range ix.postings {
+			ix.sorted = append(ix.sorted, t)
+		}
+		sort.Strings(ix.sorted)
+	}
+
+	var scores map[uint]float64
+	for _, q := range terms {
+		// 접두사가 q인 단어들의 등장 횟수를 문서별로 합침
+		freq := make(map[uint]int)
+		for i := sort.SearchStrings(ix.sorted, q); i < len(ix.sorted) && strings.HasPrefix(ix.sorted[i], q); i++ {
+			for id, n := range ix.postings[ix.sorted[i]] {
+				freq[id] += n
+			}
+		}
+		// 문서가 적을수록 드문 단어로 보고 가중치를 높임
+		idf := math.Log(1 + float64(len(ix.docs))/float64(max(len(freq), 1)))
+
+		next := make(map[uint]float64)
+		for id, n := range freq {
+			if scores != nil {
+				if _, ok := scores[id]; !ok {
+					continue
+				}
+			}
+			next[id] = scores[id] + float64(n)/float64(len(ix.docs[id].terms))*idf
+		}
+		scores = next
+		if len(scores) == 0 {
+			return nil
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Rank: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	for i := range hits {
+		hits[i].Snippet = Highlight(ix.docs[hits[i].ID].text, terms)
+	}
+	return hits
+}
@@ -0,0 +1,72 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SearchTestSuite struct {
+	suite.Suite
+	index *Index
+}
+
+func (s *SearchTestSuite) SetupTest() {
+	s.index = NewIndex()
+	s.index.Add(1, "Web Server")
+	s.index.Add(2, "web-server backup")
+	s.index.Add(3, "Database 서버")
+	s.index.Add(4, "web web web")
+}
+
+func (s *SearchTestSuite) ids(hits []Hit) []uint {
+	ids := make([]uint, len(hits))
+	for i, h := range hits {
+		ids[i] = h.ID
+	}
+	return ids
+}
+
+func (s *SearchTestSuite) TestSearch() {
+	tests := []struct {
+		query string
+		want  []uint
+	}{
+		// 등장 횟수가 많고 짧은 텍스트가 앞쪽
+		{query: "web", want: []uint{4, 1, 2}},
+		{query: "WEB serv", want: []uint{1, 2}},
+		{query: "backup web", want: []uint{2}},
+		{query: "서", want: []uint{3}},
+		{query: "web database", want: []uint{}},
+		{query: "  --  ", want: []uint{}},
+	}
+	for _, tt := range tests {
+		s.Run(tt.query, func() {
+			s.Equal(tt.want, s.ids(s.index.Search(tt.query, 0)))
+		})
+	}
+
+	s.Len(s.index.Search("web", 2), 2)
+}
+
+func (s *SearchTestSuite) TestUpdate() {
+	s.index.Add(1, "Mail Server")
+	s.Equal([]uint{4, 2}, s.ids(s.index.Search("web", 0)))
+	s.Equal([]uint{1}, s.ids(s.index.Search("mail", 0)))
+
+	s.index.Remove(2)
+	s.index.Remove(99)
+	s.Equal([]uint{1}, s.ids(s.index.Search("serv", 0)))
+}
+
+func (s *SearchTestSuite) TestHighlight() {
+	hits := s.index.Search("web serv", 0)
+	s.Require().Len(hits, 2)
+	s.Equal("<mark>Web</mark> <mark>Server</mark>", hits[0].Snippet)
+	s.Equal("<mark>web</mark>-<mark>server</mark> backup", hits[1].Snippet)
+	s.Equal("Database <mark>서버</mark>", Highlight("Database 서버", Terms("서")))
+}
+
+func TestSearchSuite(t *testing.T) {
+	suite.Run(t, new(SearchTestSuite))
+}
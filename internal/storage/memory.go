@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+// memoryStore는 내용을 메모리에 저장하는 BlobStore (테스트나 로컬 실행용)
+type memoryStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+// NewMemoryStore는 메모리 BlobStore를 생성
+func NewMemoryStore() BlobStore {
+	return &memoryStore{blobs: make(map[string][]byte)}
+}
+
+func (s *memoryStore) Put(ctx context.Context, key string, r io.Reader) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(contextReader{ctx, r})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *memoryStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	// 저장된 슬라이스는 바뀌지 않으므로 복사하지 않고 읽기 전용으로 넘김
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blobs, key)
+	return nil
+}
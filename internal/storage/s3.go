@@ -0,0 +1,375 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPartSize는 S3 멀티파트 업로드의 기본 파트 크기 (S3가 허용하는 최소 크기)
+const DefaultPartSize = 5 << 20
+
+// emptyPayloadHash는 본문이 없는 요청의 x-amz-content-sha256 값
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Config는 S3 호환 저장소 연결 설정
+type S3Config struct {
+	// Endpoint는 저장소 주소 (예: https://s3.ap-northeast-2.amazonaws.com, http://localhost:9000)
+	// 버킷은 경로 방식(Endpoint/Bucket/Key)으로 지정하므로 MinIO 같은 호환 서버에서도 그대로 동작
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PartSize보다 큰 내용은 멀티파트로 업로드 (0이면 DefaultPartSize)
+	PartSize int
+	// HTTPClient가 nil이면 http.DefaultClient 사용
+	HTTPClient *http.Client
+}
+
+// s3Store는 S3 API로 저장하는 BlobStore
+type s3Store struct {
+	endpoint *url.URL
+	bucket   string
+	partSize int
+	client   *http.Client
+	signer   signer
+	now      func() time.Time
+}
+
+// NewS3Store는 S3 호환 저장소에 저장하는 BlobStore를 생성
+func NewS3Store(cfg S3Config) (BlobStore, error) {
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("잘못된 S3 엔드포인트: %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, errors.New("S3 버킷이 필요합니다")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.PartSize <= 0 {
+		cfg.PartSize = DefaultPartSize
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &s3Store{
+		endpoint: endpoint,
+		bucket:   cfg.Bucket,
+		partSize: cfg.PartSize,
+		client:   cfg.HTTPClient,
+		signer:   signer{accessKey: cfg.AccessKey, secretKey: cfg.SecretKey, region: cfg.Region},
+		now:      time.Now,
+	}, nil
+}
+
+// Put은 PartSize만큼 읽어서 끝에 도달하면 한 번에 업로드하고,
+// 더 남아 있으면 멀티파트 업로드로 파트 단위로 보냄 (메모리에는 파트 하나만 유지)
+func (s *s3Store) Put(ctx context.Context, key string, r io.Reader) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	buf := make([]byte, s.partSize)
+	n, err := io.ReadFull(r, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return s.putObject(ctx, key, buf[:n])
+	}
+	if err != nil {
+		return err
+	}
+	return s.putMultipart(ctx, key, buf, r)
+}
+
+func (s *s3Store) putObject(ctx context.Context, key string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, nil, data)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+type initiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+// putMultipart는 first(가득 찬 첫 파트)와 r의 나머지를 멀티파트 업로드로 저장
+// 도중에 실패하면 업로드를 중단해서 올라간 파트가 남지 않게 함
+func (s *s3Store) putMultipart(ctx context.Context, key string, first []byte, r io.Reader) (err error) {
+	resp, err := s.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return err
+	}
+	var initiated initiateMultipartUploadResult
+	err = xml.NewDecoder(resp.Body).Decode(&initiated)
+	resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("멀티파트 업로드 시작 응답을 읽을 수 없습니다: %v", err)
+	}
+	uploadID := initiated.UploadID
+
+	defer func() {
+		if err != nil {
+			// 요청 컨텍스트가 취소된 경우에도 중단 요청은 보내야 하므로 새 컨텍스트 사용
+			abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+			defer cancel()
+			if resp, abortErr := s.do(abortCtx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil); abortErr == nil {
+				resp.Body.Close()
+			}
+		}
+	}()
+
+	var parts []completedPart
+	part := first
+	for {
+		query := url.Values{
+			"partNumber": {strconv.Itoa(len(parts) + 1)},
+			"uploadId":   {uploadID},
+		}
+		resp, err := s.do(ctx, http.MethodPut, key, query, part)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		parts = append(parts, completedPart{PartNumber: len(parts) + 1, ETag: resp.Header.Get("ETag")})
+
+		n, err := io.ReadFull(r, first)
+		if n == 0 && (errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)) {
+			break
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+		part = first[:n]
+	}
+
+	body, err := xml.Marshal(completeMultipartUpload{Parts: parts})
+	if err != nil {
+		return err
+	}
+	resp, err = s.do(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// CompleteMultipartUpload는 200 응답 본문에 에러를 담아 실패를 알릴 수 있음
+	return checkCompleteResponse(resp.Body)
+}
+
+func checkCompleteResponse(r io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(r, 64<<10))
+	if err != nil {
+		return err
+	}
+	var e s3Error
+	if xml.Unmarshal(data, &e) == nil && e.XMLName.Local == "Error" {
+		return fmt.Errorf("S3 멀티파트 업로드 완료 실패 (%s): %s", e.Code, e.Message)
+	}
+	return nil
+}
+
+func (s *s3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+type s3Error struct {
+	XMLName xml.Name
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// do는 서명한 요청을 보내고 2xx가 아니면 에러로 변환 (404는 ErrNotFound)
+func (s *s3Store) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	u := *s.endpoint
+	u.Path = s.endpoint.Path + "/" + s.bucket + "/" + key
+	u.RawQuery = canonicalQuery(query)
+
+	var r io.Reader
+	payloadHash := emptyPayloadHash
+	if body != nil {
+		r = bytes.NewReader(body)
+		sum := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), r)
+	if err != nil {
+		return nil, err
+	}
+	s.signer.sign(req, payloadHash, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 == 2 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	var e s3Error
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if xml.Unmarshal(data, &e) != nil || e.Code == "" {
+		return nil, fmt.Errorf("S3 요청 실패: %s", resp.Status)
+	}
+	return nil, fmt.Errorf("S3 요청 실패: %s (%s: %s)", resp.Status, e.Code, e.Message)
+}
+
+// signer는 AWS Signature Version 4로 요청에 서명
+type signer struct {
+	accessKey string
+	secretKey string
+	region    string
+}
+
+const (
+	signAlgorithm  = "AWS4-HMAC-SHA256"
+	amzDateFormat  = "20060102T150405Z"
+	signingService = "s3"
+)
+
+// sign은 x-amz-date, x-amz-content-sha256, Authorization 헤더를 설정
+func (s signer) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signed := signedHeaders(req.Header)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signAlgorithm, s.accessKey, s.scope(amzDate), strings.Join(signed, ";"),
+		s.signature(req, signed, payloadHash, amzDate)))
+}
+
+func (s signer) scope(amzDate string) string {
+	return amzDate[:8] + "/" + s.region + "/" + signingService + "/aws4_request"
+}
+
+// signature는 signed 헤더들로 만든 정규 요청의 서명을 계산
+func (s signer) signature(req *http.Request, signed []string, payloadHash, amzDate string) string {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	var headers strings.Builder
+	for _, name := range signed {
+		value := host
+		if name != "host" {
+			value = strings.Join(req.Header.Values(name), ",")
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		headers.String(),
+		strings.Join(signed, ";"),
+		payloadHash,
+	}, "\n")
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{signAlgorithm, amzDate, s.scope(amzDate), hex.EncodeToString(hash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), amzDate[:8])
+	for _, part := range []string{s.region, signingService, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// signedHeaders는 서명에 포함할 헤더 이름 (host, content-type, range, x-amz-*)
+func signedHeaders(h http.Header) []string {
+	names := []string{"host"}
+	for name := range h {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || lower == "content-md5" || lower == "range" || strings.HasPrefix(lower, "x-amz-") {
+			names = append(names, lower)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQuery는 키 순서로 정렬하고 RFC 3986 방식으로 인코딩한 쿼리 문자열
+// (url.Values.Encode는 공백을 +로 바꾸므로 서명에 사용할 수 없음)
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			pairs = append(pairs, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,57 @@
+// Package storage는 첨부 파일 내용을 저장하는 BlobStore와 구현체를 제공
+//
+// 로컬 파일 시스템(NewFileStore), 메모리(NewMemoryStore), S3 호환 저장소(NewS3Store)를 지원하며
+// 파일 시스템과 S3 구현은 내용 전체를 메모리에 올리지 않고 스트리밍으로 저장함
+// (S3는 멀티파트 업로드의 파트 크기만큼만 버퍼링)
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ErrNotFound는 키에 저장된 내용이 없을 때 반환 (errors.Is로 확인)
+var ErrNotFound = errors.New("저장된 파일을 찾을 수 없습니다")
+
+// BlobStore는 키로 파일 내용을 저장하고 읽는 저장소
+// 키는 '/'로 구분한 경로 형식 (예: resources/1/3f2a...)
+type BlobStore interface {
+	// Put은 r을 끝까지 읽어서 key에 저장 (r이 에러를 반환하면 아무것도 저장하지 않음)
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get은 key의 내용을 읽는 ReadCloser를 반환 (없으면 ErrNotFound)
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete는 key를 삭제 (없는 키여도 에러가 아님)
+	Delete(ctx context.Context, key string) error
+}
+
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+(/[A-Za-z0-9._-]+)*$`)
+
+// validateKey는 어느 구현에서나 안전한 키인지 확인 (상위 경로 참조 금지)
+func validateKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("잘못된 저장소 키: %q", key)
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "." || seg == ".." {
+			return fmt.Errorf("잘못된 저장소 키: %q", key)
+		}
+	}
+	return nil
+}
+
+// contextReader는 ctx가 끝나면 더 읽지 않는 Reader
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
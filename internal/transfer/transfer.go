@@ -0,0 +1,287 @@
+package transfer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go_project/internal/labels"
+	"go_project/internal/model"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format은 가져오기/내보내기 파일 형식
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+	FormatJSON   Format = "json"
+)
+
+// CSV 헤더 (내보내기와 가져오기가 같은 열 순서를 사용)
+// labels 열은 "env=prod,team=infra" 형식, attributes 열은 JSON 객체 (비어 있으면 빈 문자열)
+var csvHeader = []string{"id", "name", "created_at", "updated_at", "labels", "attributes"}
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatCSV, FormatNDJSON, FormatJSON:
+		return f, nil
+	}
+	return "", fmt.Errorf("지원하지 않는 형식: %s", s)
+}
+
+// FormatFromFilename은 파일 확장자로 형식을 추정
+func FormatFromFilename(name string) (Format, error) {
+	ext := strings.TrimPrefix(filepath.Ext(name), ".")
+	if ext == "jsonl" {
+		return FormatNDJSON, nil
+	}
+	return ParseFormat(ext)
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Writer는 리소스를 한 건씩 형식에 맞게 기록
+type Writer interface {
+	Write(m *model.Base) error
+	// Close는 남은 버퍼를 내보내고 닫는 구문(JSON 배열의 ']' 등)을 기록
+	Close() error
+}
+
+func NewWriter(w io.Writer, format Format) Writer {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}
+	case FormatNDJSON:
+		return &ndjsonWriter{enc: json.NewEncoder(w)}
+	default:
+		return &jsonWriter{w: w}
+	}
+}
+
+type csvWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (c *csvWriter) Write(m *model.Base) error {
+	var attrs string
+	if len(m.Attributes) > 0 {
+		b, err := json.Marshal(m.Attributes)
+		if err != nil {
+			return err
+		}
+		attrs = string(b)
+	}
+	if !c.wroteHeader {
+		if err := c.w.Write(csvHeader); err != nil {
+			return err
+		}
+		c.wroteHeader = true
+	}
+	return c.w.Write([]string{
+		strconv.FormatUint(uint64(m.ID), 10),
+		m.Name,
+		m.CreatedAt.Format(time.RFC3339),
+		m.UpdatedAt.Format(time.RFC3339),
+		m.Labels.String(),
+		attrs,
+	})
+}
+
+func (c *csvWriter) Close() error {
+	if !c.wroteHeader {
+		if err := c.w.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(m *model.Base) error {
+	return n.enc.Encode(m)
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
+
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonWriter) Write(m *model.Base) error {
+	prefix := ","
+	if j.count == 0 {
+		prefix = "["
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(j.w, prefix); err != nil {
+		return err
+	}
+	j.count++
+	_, err = j.w.Write(b)
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	closing := "]\n"
+	if j.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(j.w, closing)
+	return err
+}
+
+// ReadAll은 파일 전체를 행 단위로 파싱
+// 파싱에 실패한 행도 Err를 채워서 돌려주므로 호출자가 행 번호와 함께 보고할 수 있음
+// 파일 자체를 읽을 수 없는 경우에만 에러를 반환
+func ReadAll(r io.Reader, format Format) ([]model.ImportRow[model.Base], error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(r)
+	case FormatNDJSON:
+		return readNDJSON(r)
+	default:
+		return readJSON(r)
+	}
+}
+
+func readCSV(r io.Reader) ([]model.ImportRow[model.Base], error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("CSV 헤더 읽기 실패: %v", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.TrimSpace(strings.ToLower(h))] = i
+	}
+	nameCol, ok := columns["name"]
+	if !ok {
+		return nil, errors.New("CSV 헤더에 name 열이 없습니다")
+	}
+	idCol, hasID := columns["id"]
+	labelsCol, hasLabels := columns["labels"]
+	attrsCol, hasAttrs := columns["attributes"]
+
+	var rows []model.ImportRow[model.Base]
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var row model.ImportRow[model.Base]
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("CSV 읽기 실패: %v", err)
+			}
+			row.Line, row.Err = parseErr.StartLine, parseErr.Err
+			rows = append(rows, row)
+			continue
+		}
+		row.Line, _ = cr.FieldPos(0)
+
+		base := &model.Base{}
+		if nameCol < len(record) {
+			base.Name = record[nameCol]
+		}
+		if hasID && idCol < len(record) && record[idCol] != "" {
+			id, err := strconv.ParseUint(record[idCol], 10, 32)
+			if err != nil {
+				row.Err = fmt.Errorf("잘못된 ID 형식: %s", record[idCol])
+			}
+			base.ID = uint(id)
+		}
+		if hasLabels && labelsCol < len(record) && row.Err == nil {
+			base.Labels, row.Err = labels.ParseSet(record[labelsCol])
+		}
+		if hasAttrs && attrsCol < len(record) && record[attrsCol] != "" && row.Err == nil {
+			if err := base.Attributes.Scan(record[attrsCol]); err != nil {
+				row.Err = fmt.Errorf("잘못된 속성 형식: %v", err)
+			}
+		}
+		row.Resource = base
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func readNDJSON(r io.Reader) ([]model.ImportRow[model.Base], error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []model.ImportRow[model.Base]
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		row := model.ImportRow[model.Base]{Line: line}
+		var base model.Base
+		if err := json.Unmarshal([]byte(text), &base); err != nil {
+			row.Err = fmt.Errorf("잘못된 JSON: %v", err)
+		} else {
+			row.Resource = &base
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("NDJSON 읽기 실패: %v", err)
+	}
+	return rows, nil
+}
+
+func readJSON(r io.Reader) ([]model.ImportRow[model.Base], error) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return nil, errors.New("JSON 배열 형식이 아닙니다")
+	}
+
+	var rows []model.ImportRow[model.Base]
+	for line := 1; dec.More(); line++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("JSON 읽기 실패: %v", err)
+		}
+		row := model.ImportRow[model.Base]{Line: line}
+		var base model.Base
+		if err := json.Unmarshal(raw, &base); err != nil {
+			row.Err = fmt.Errorf("잘못된 항목: %v", err)
+		} else {
+			row.Resource = &base
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
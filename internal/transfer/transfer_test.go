@@ -0,0 +1,171 @@
+package transfer
+
+import (
+	"bytes"
+	"go_project/internal/attributes"
+	"go_project/internal/labels"
+	"go_project/internal/model"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TransferTestSuite struct {
+	suite.Suite
+}
+
+func (s *TransferTestSuite) TestRoundTrip() {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ms := []*model.Base{
+		{ID: 1, Name: "데이터1", CreatedAt: now, UpdatedAt: now},
+		{
+			ID: 2, Name: "쉼표, \"따옴표\"", CreatedAt: now, UpdatedAt: now,
+			Labels:     labels.Set{"env": "prod", "example.com/team": "infra"},
+			Attributes: attributes.Map{"owner": map[string]any{"email": "a@example.com"}},
+		},
+	}
+
+	for _, format := range []Format{FormatCSV, FormatNDJSON, FormatJSON} {
+		s.Run(string(format), func() {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, format)
+			for _, m := range ms {
+				s.NoError(w.Write(m))
+			}
+			s.NoError(w.Close())
+
+			rows, err := ReadAll(&buf, format)
+			s.NoError(err)
+			s.Len(rows, len(ms))
+			for i, row := range rows {
+				s.NoError(row.Err)
+				s.Equal(ms[i].ID, row.Resource.ID)
+				s.Equal(ms[i].Name, row.Resource.Name)
+				s.Equal(ms[i].Labels, row.Resource.Labels)
+				s.Equal(ms[i].Attributes, row.Resource.Attributes)
+			}
+		})
+	}
+}
+
+func (s *TransferTestSuite) TestEmptyExport() {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{format: FormatCSV, want: "id,name,created_at,updated_at,labels,attributes\n"},
+		{format: FormatNDJSON, want: ""},
+		{format: FormatJSON, want: "[]\n"},
+	}
+
+	for _, tt := range tests {
+		s.Run(string(tt.format), func() {
+			var buf bytes.Buffer
+			s.NoError(NewWriter(&buf, tt.format).Close())
+			s.Equal(tt.want, buf.String())
+		})
+	}
+}
+
+func (s *TransferTestSuite) TestReadAll_RowErrors() {
+	tests := []struct {
+		name      string
+		format    Format
+		input     string
+		wantLines []int
+		wantErrAt []int
+	}{
+		{
+			name:      "CSV_잘못된_ID",
+			format:    FormatCSV,
+			input:     "id,name\n1,정상\nabc,잘못된_ID\n,새_리소스\n",
+			wantLines: []int{2, 3, 4},
+			wantErrAt: []int{3},
+		},
+		{
+			name:      "CSV_잘못된_라벨",
+			format:    FormatCSV,
+			input:     "name,labels\n정상,env=prod\n라벨_형식,env\n",
+			wantLines: []int{2, 3},
+			wantErrAt: []int{3},
+		},
+		{
+			name:      "CSV_잘못된_속성",
+			format:    FormatCSV,
+			input:     "name,attributes\n정상,\"{\"\"owner\"\":\"\"a\"\"}\"\n속성_형식,owner\n",
+			wantLines: []int{2, 3},
+			wantErrAt: []int{3},
+		},
+		{
+			name:      "NDJSON_잘못된_JSON",
+			format:    FormatNDJSON,
+			input:     "{\"name\":\"정상\"}\n\n{잘못된}\n{\"name\":\"정상2\"}\n",
+			wantLines: []int{1, 3, 4},
+			wantErrAt: []int{3},
+		},
+		{
+			name:      "JSON_잘못된_항목",
+			format:    FormatJSON,
+			input:     `[{"name":"정상"},{"name":1}]`,
+			wantLines: []int{1, 2},
+			wantErrAt: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rows, err := ReadAll(strings.NewReader(tt.input), tt.format)
+			s.NoError(err)
+
+			var lines, errAt []int
+			for _, row := range rows {
+				lines = append(lines, row.Line)
+				if row.Err != nil {
+					errAt = append(errAt, row.Line)
+				}
+			}
+			s.Equal(tt.wantLines, lines)
+			s.Equal(tt.wantErrAt, errAt)
+		})
+	}
+}
+
+func (s *TransferTestSuite) TestReadAll_InvalidFile() {
+	_, err := ReadAll(strings.NewReader("id,title\n1,이름_열_없음\n"), FormatCSV)
+	s.Error(err)
+
+	_, err = ReadAll(strings.NewReader(`{"name":"배열_아님"}`), FormatJSON)
+	s.Error(err)
+}
+
+func (s *TransferTestSuite) TestFormatFromFilename() {
+	tests := []struct {
+		filename string
+		want     Format
+		wantErr  bool
+	}{
+		{filename: "resources.csv", want: FormatCSV},
+		{filename: "resources.jsonl", want: FormatNDJSON},
+		{filename: "resources.NDJSON", want: FormatNDJSON},
+		{filename: "resources.json", want: FormatJSON},
+		{filename: "resources.xlsx", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.filename, func() {
+			got, err := FormatFromFilename(tt.filename)
+			if tt.wantErr {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTransferSuite(t *testing.T) {
+	suite.Run(t, new(TransferTestSuite))
+}
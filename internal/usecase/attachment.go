@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go_project/internal/model"
+	"go_project/internal/repository"
+	"go_project/internal/storage"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AttachmentUsecase는 리소스 첨부 파일을 다루는 비즈니스 로직 계층
+// 메타데이터는 AttachmentRepository에, 내용은 storage.BlobStore에 저장
+type AttachmentUsecase interface {
+	// Upload는 r을 끝까지 읽어서 저장 (내용 전체를 메모리에 올리지 않음)
+	Upload(ctx context.Context, resourceID uint, filename string, r io.Reader) (*model.Attachment, error)
+	List(ctx context.Context, resourceID uint) ([]*model.Attachment, error)
+	// Open은 메타데이터와 내용을 반환 (호출자가 내용을 닫아야 함)
+	Open(ctx context.Context, resourceID, id uint) (*model.Attachment, io.ReadCloser, error)
+	Remove(ctx context.Context, resourceID, id uint) error
+	// RemoveAll은 resourceIDs에 첨부된 파일을 모두 지움 (삭제된 리소스의 정리용, 리소스가 없어도 됨)
+	// 하나가 실패해도 나머지는 계속 지우고 실패한 것을 모아서 반환
+	RemoveAll(ctx context.Context, resourceIDs ...uint) error
+}
+
+// ErrTooLarge는 첨부 파일이 허용 크기를 넘을 때 반환 (errors.Is로 확인)
+var ErrTooLarge = errors.New("파일이 너무 큽니다")
+
+// ErrUnsupportedType은 첨부 파일 내용의 MIME 타입이 허용되지 않을 때 반환 (errors.Is로 확인)
+var ErrUnsupportedType = errors.New("허용되지 않는 파일 형식입니다")
+
+// DefaultMaxAttachmentSize는 첨부 파일 하나의 기본 최대 크기
+const DefaultMaxAttachmentSize = 10 << 20
+
+// http.DetectContentType이 판별에 사용하는 최대 바이트 수
+const sniffLen = 512
+
+// 파일 이름의 최대 길이 (바이트)
+const maxFilenameLen = 255
+
+type attachmentUsecase[T any] struct {
+	repo      repository.AttachmentRepository
+	resources repository.Repository[T]
+	store     storage.BlobStore
+	maxSize   int64
+	allowed   []string
+}
+
+type attachmentOptions struct {
+	maxSize int64
+	allowed []string
+}
+
+type AttachmentOption func(*attachmentOptions)
+
+// WithMaxAttachmentSize는 첨부 파일 하나의 최대 크기(바이트)를 지정 (기본 DefaultMaxAttachmentSize)
+func WithMaxAttachmentSize(n int64) AttachmentOption {
+	return func(o *attachmentOptions) {
+		o.maxSize = n
+	}
+}
+
+// WithAllowedTypes는 허용할 MIME 타입을 지정 (image/*처럼 하위 타입 와일드카드 가능)
+// 지정하지 않으면 모든 형식을 허용하며, 형식은 파일 이름이나 요청 헤더가 아니라 내용으로 판별
+func WithAllowedTypes(types ...string) AttachmentOption {
+	return func(o *attachmentOptions) {
+		o.allowed = append(o.allowed, types...)
+	}
+}
+
+// NewAttachmentUsecase는 resources의 리소스에 파일을 첨부하는 AttachmentUsecase를 생성
+func NewAttachmentUsecase[T any](repo repository.AttachmentRepository, resources repository.Repository[T], store storage.BlobStore, opts ...AttachmentOption) AttachmentUsecase {
+	o := attachmentOptions{maxSize: DefaultMaxAttachmentSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &attachmentUsecase[T]{
+		repo:      repo,
+		resources: resources,
+		store:     store,
+		maxSize:   o.maxSize,
+		allowed:   o.allowed,
+	}
+}
+
+func (u *attachmentUsecase[T]) Upload(ctx context.Context, resourceID uint, filename string, r io.Reader) (*model.Attachment, error) {
+	if _, err := u.resources.Get(ctx, resourceID); err != nil {
+		return nil, wrapErr("첨부할 리소스를 찾을 수 없습니다", err)
+	}
+	filename = sanitizeFilename(filename)
+	if filename == "" {
+		return nil, fmt.Errorf("%w: 파일 이름이 필요합니다", ErrInvalid)
+	}
+
+	// 앞부분만 미리 읽어서 형식을 판별하고, 나머지는 저장소로 바로 흘려보냄
+	br := bufio.NewReaderSize(r, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("업로드 파일 읽기 실패: %v", err)
+	}
+	contentType := http.DetectContentType(head)
+	if !u.allowedType(contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+
+	key, err := newStorageKey(resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("저장소 키 생성 실패: %v", err)
+	}
+	body := &measuringReader{r: br, remaining: u.maxSize, hash: sha256.New()}
+	if err := u.store.Put(ctx, key, body); err != nil {
+		if errors.Is(err, ErrTooLarge) {
+			return nil, fmt.Errorf("%w: 최대 %d바이트까지 첨부할 수 있습니다", ErrTooLarge, u.maxSize)
+		}
+		return nil, fmt.Errorf("첨부 파일 저장 실패: %v", err)
+	}
+
+	a := &model.Attachment{
+		ResourceID:  resourceID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        body.n,
+		Checksum:    "sha256:" + hex.EncodeToString(body.hash.Sum(nil)),
+		StorageKey:  key,
+	}
+	if err := u.repo.Insert(ctx, a); err != nil {
+		// 메타데이터 없이 남은 내용은 찾을 방법이 없으므로 지움
+		_ = u.store.Delete(context.WithoutCancel(ctx), key)
+		return nil, fmt.Errorf("첨부 파일 정보 저장 실패: %w", err)
+	}
+	return a, nil
+}
+
+func (u *attachmentUsecase[T]) List(ctx context.Context, resourceID uint) ([]*model.Attachment, error) {
+	if _, err := u.resources.Get(ctx, resourceID); err != nil {
+		return nil, wrapErr("리소스를 찾을 수 없습니다", err)
+	}
+	as, err := u.repo.ListByResource(ctx, resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("첨부 파일 목록 조회 실패: %w", err)
+	}
+	return as, nil
+}
+
+func (u *attachmentUsecase[T]) Open(ctx context.Context, resourceID, id uint) (*model.Attachment, io.ReadCloser, error) {
+	a, err := u.get(ctx, resourceID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := u.store.Get(ctx, a.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, fmt.Errorf("첨부 파일 내용이 없습니다: %w", ErrNotFound)
+		}
+		return nil, nil, fmt.Errorf("첨부 파일 읽기 실패: %v", err)
+	}
+	return a, rc, nil
+}
+
+// Remove는 메타데이터를 먼저 지워서 다른 요청이 삭제 중인 내용을 읽지 않게 함
+func (u *attachmentUsecase[T]) Remove(ctx context.Context, resourceID, id uint) error {
+	a, err := u.get(ctx, resourceID, id)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.Remove(ctx, a); err != nil {
+		return fmt.Errorf("첨부 파일 삭제 실패: %w", err)
+	}
+	if err := u.store.Delete(ctx, a.StorageKey); err != nil {
+		return fmt.Errorf("첨부 파일 내용 삭제 실패 (%s): %v", a.StorageKey, err)
+	}
+	return nil
+}
+
+func (u *attachmentUsecase[T]) RemoveAll(ctx context.Context, resourceIDs ...uint) error {
+	var errs []error
+	for _, resourceID := range resourceIDs {
+		as, err := u.repo.ListByResource(ctx, resourceID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("리소스 %d의 첨부 파일 목록 조회 실패: %w", resourceID, err))
+			continue
+		}
+		for _, a := range as {
+			if err := u.repo.Remove(ctx, a); err != nil {
+				errs = append(errs, fmt.Errorf("첨부 파일 %d 삭제 실패: %w", a.ID, err))
+				continue
+			}
+			if err := u.store.Delete(ctx, a.StorageKey); err != nil && !errors.Is(err, storage.ErrNotFound) {
+				errs = append(errs, fmt.Errorf("첨부 파일 내용 삭제 실패 (%s): %v", a.StorageKey, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// get은 resourceID에 첨부된 id인 파일의 메타데이터를 반환 (다른 리소스의 첨부 파일이면 ErrNotFound)
+func (u *attachmentUsecase[T]) get(ctx context.Context, resourceID, id uint) (*model.Attachment, error) {
+	a, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return nil, wrapErr("첨부 파일을 찾을 수 없습니다", err)
+	}
+	if a.ResourceID != resourceID {
+		return nil, fmt.Errorf("첨부 파일을 찾을 수 없습니다: %w", ErrNotFound)
+	}
+	return a, nil
+}
+
+// allowedType은 판별한 MIME 타입이 허용 목록에 있는지 확인 (charset 같은 파라미터는 무시)
+func (u *attachmentUsecase[T]) allowedType(contentType string) bool {
+	if len(u.allowed) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range u.allowed {
+		if allowed == mediaType || allowed == "*/*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeFilename은 경로와 제어 문자를 없앤 파일 이름을 반환 (쓸 수 있는 이름이 없으면 빈 문자열)
+func sanitizeFilename(name string) string {
+	// 브라우저에 따라 전체 경로(C:\...\a.txt)를 보내기도 하므로 마지막 요소만 사용
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "." || name == ".." {
+		return ""
+	}
+	for len(name) > maxFilenameLen {
+		// 글자 중간에서 자르지 않도록 한 글자씩 제거
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+// newStorageKey는 리소스별로 겹치지 않는 저장소 키를 만듦 (파일 이름은 키에 쓰지 않음)
+func newStorageKey(resourceID uint) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("resources/%d/%s", resourceID, hex.EncodeToString(b)), nil
+}
+
+// measuringReader는 읽은 바이트 수와 체크섬을 계산하고, remaining을 넘게 읽으면 ErrTooLarge를 반환
+type measuringReader struct {
+	r         io.Reader
+	remaining int64
+	n         int64
+	hash      hash.Hash
+}
+
+func (m *measuringReader) Read(p []byte) (int, error) {
+	// 한도보다 1바이트 더 읽어 봐야 한도를 넘었는지 알 수 있음
+	if int64(len(p)) > m.remaining+1 {
+		p = p[:m.remaining+1]
+	}
+	n, err := m.r.Read(p)
+	if int64(n) > m.remaining {
+		return 0, ErrTooLarge
+	}
+	m.remaining -= int64(n)
+	m.n += int64(n)
+	m.hash.Write(p[:n])
+	return n, err
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go_project/internal/model"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/storage"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AttachmentUsecaseTestSuite struct {
+	suite.Suite
+	resources repository.Repository[model.Base]
+	repo      repository.AttachmentRepository
+	store     storage.BlobStore
+	usecase   AttachmentUsecase
+	resource  *model.Base
+}
+
+func (s *AttachmentUsecaseTestSuite) SetupTest() {
+	s.resources = repository.NewRepository(recorder.NewMemoryRecorder[model.Base]())
+	s.repo = repository.NewAttachmentRepository(recorder.NewMemoryAttachmentRecorder())
+	s.store = storage.NewMemoryStore()
+	s.usecase = NewAttachmentUsecase(
+		s.repo, s.resources, s.store,
+		WithMaxAttachmentSize(16), WithAllowedTypes("text/*", "image/png"),
+	)
+	s.resource = &model.Base{Name: "첨부_대상"}
+	s.Require().NoError(s.resources.Insert(context.Background(), s.resource))
+}
+
+func (s *AttachmentUsecaseTestSuite) TestUploadAndOpen() {
+	ctx := context.Background()
+	a, err := s.usecase.Upload(ctx, s.resource.ID, `C:\temp\메모.txt`, strings.NewReader("hello"))
+	s.Require().NoError(err)
+	s.Equal("메모.txt", a.Filename)
+	s.Equal("text/plain; charset=utf-8", a.ContentType)
+	s.Equal(int64(5), a.Size)
+	s.Equal("sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", a.Checksum)
+
+	got, rc, err := s.usecase.Open(ctx, s.resource.ID, a.ID)
+	s.Require().NoError(err)
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	s.Equal("hello", string(data))
+	s.Equal(a.Checksum, got.Checksum)
+
+	list, err := s.usecase.List(ctx, s.resource.ID)
+	s.NoError(err)
+	s.Len(list, 1)
+
+	// 다른 리소스 경로로는 조회할 수 없음
+	other := &model.Base{Name: "다른_리소스"}
+	s.Require().NoError(s.resources.Insert(ctx, other))
+	_, _, err = s.usecase.Open(ctx, other.ID, a.ID)
+	s.ErrorIs(err, ErrNotFound)
+
+	s.NoError(s.usecase.Remove(ctx, s.resource.ID, a.ID))
+	_, err = s.store.Get(ctx, a.StorageKey)
+	s.ErrorIs(err, storage.ErrNotFound)
+	s.ErrorIs(s.usecase.Remove(ctx, s.resource.ID, a.ID), ErrNotFound)
+}
+
+func (s *AttachmentUsecaseTestSuite) TestUploadRejected() {
+	ctx := context.Background()
+	png := "\x89PNG\r\n\x1a\n"
+	tests := []struct {
+		name       string
+		resourceID uint
+		filename   string
+		content    string
+		wantErr    error
+	}{
+		{name: "허용_형식", resourceID: s.resource.ID, filename: "a.png", content: png},
+		{name: "최대_크기", resourceID: s.resource.ID, filename: "a.txt", content: strings.Repeat("a", 16)},
+		{name: "크기_초과", resourceID: s.resource.ID, filename: "a.txt", content: strings.Repeat("a", 17), wantErr: ErrTooLarge},
+		// 파일 이름과 관계없이 내용으로 판별
+		{name: "허용되지_않는_형식", resourceID: s.resource.ID, filename: "a.txt", content: "%PDF-1.4", wantErr: ErrUnsupportedType},
+		{name: "리소스_없음", resourceID: 999, filename: "a.txt", content: "a", wantErr: ErrNotFound},
+		{name: "파일_이름_없음", resourceID: s.resource.ID, filename: "../", content: "a", wantErr: ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, err := s.usecase.Upload(ctx, tt.resourceID, tt.filename, strings.NewReader(tt.content))
+			if tt.wantErr == nil {
+				s.NoError(err)
+				return
+			}
+			s.True(errors.Is(err, tt.wantErr), err)
+		})
+	}
+
+	// 실패한 업로드는 목록에 남지 않음
+	list, err := s.usecase.List(ctx, s.resource.ID)
+	s.NoError(err)
+	s.Len(list, 2)
+}
+
+func (s *AttachmentUsecaseTestSuite) TestRemoveResource() {
+	ctx := context.Background()
+	past := time.Now().Add(-time.Minute)
+	uc := NewUsecase(s.resources, WithAttachments(s.usecase))
+	child := &model.Base{Name: "하위", ParentID: &s.resource.ID}
+	s.Require().NoError(s.resources.Insert(ctx, child))
+	single := &model.Base{Name: "단독"}
+	s.Require().NoError(s.resources.Insert(ctx, single))
+	expired := &model.Base{Name: "만료"}
+	s.Require().NoError(s.resources.Insert(ctx, expired))
+
+	upload := func(resourceID uint) *model.Attachment {
+		a, err := s.usecase.Upload(ctx, resourceID, "a.txt", strings.NewReader("a"))
+		s.Require().NoError(err)
+		return a
+	}
+	attached := []*model.Attachment{upload(s.resource.ID), upload(child.ID), upload(single.ID), upload(expired.ID)}
+	s.Require().NoError(s.resources.Modify(ctx, &model.Base{ID: expired.ID, Name: "만료", ExpiresAt: &past}))
+
+	// 단건 삭제, 하위 리소스까지 삭제, 만료된 리소스 정리 모두 첨부 파일을 함께 지움
+	s.Require().NoError(uc.Remove(ctx, single.ID))
+	_, err := uc.RemoveTree(ctx, s.resource.ID)
+	s.Require().NoError(err)
+	n, err := uc.PurgeExpired(ctx, time.Now(), nil)
+	s.Require().NoError(err)
+	s.Equal(int64(1), n)
+
+	for _, a := range attached {
+		as, err := s.repo.ListByResource(ctx, a.ResourceID)
+		s.NoError(err)
+		s.Empty(as, "리소스 %d의 첨부 파일 정보", a.ResourceID)
+		_, err = s.store.Get(ctx, a.StorageKey)
+		s.ErrorIs(err, storage.ErrNotFound, "리소스 %d의 첨부 파일 내용", a.ResourceID)
+	}
+}
+
+func (s *AttachmentUsecaseTestSuite) TestSanitizeFilename() {
+	s.Equal("a.txt", sanitizeFilename("../../a.txt"))
+	s.Equal("a.txt", sanitizeFilename(" a\r\n.txt "))
+	s.Equal("", sanitizeFilename(".."))
+	long := sanitizeFilename(strings.Repeat("가", 100))
+	s.LessOrEqual(len(long), maxFilenameLen)
+	s.Equal(strings.Repeat("가", 85), long)
+}
+
+func TestAttachmentUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentUsecaseTestSuite))
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go_project/internal/model"
+	"go_project/internal/repository"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RevisionUsecase는 리소스 리비전 조회, 비교, 롤백을 다루는 비즈니스 로직 계층
+// 리비전은 repository.NewVersionedRepository로 감싼 Repository를 쓰는 Usecase가 저장함
+type RevisionUsecase[T any] interface {
+	// List는 최근 리비전부터 한 페이지를 조회하고 전체 개수를 함께 반환 (size가 0이면 전체)
+	List(ctx context.Context, resourceID uint, page, size int) ([]*model.ResourceRevision[T], int64, error)
+	Get(ctx context.Context, resourceID uint, number int) (*model.ResourceRevision[T], error)
+	// Diff는 from 리비전에서 to 리비전으로 바뀐 값을 반환
+	Diff(ctx context.Context, resourceID uint, from, to int) (*model.RevisionDiff, error)
+	// Rollback은 리소스를 number 리비전의 내용으로 수정하고 그 결과로 남은 새 리비전을 반환
+	// expected가 0보다 크면 현재 마지막 리비전 번호가 expected일 때만 수정 (다르면 ErrConflict)
+	// 확인과 수정은 리소스를 잠근 같은 트랜잭션에서 하므로 그 사이에 다른 수정이 끼어들지 않음
+	Rollback(ctx context.Context, resourceID uint, number, expected int) (*model.ResourceRevision[T], error)
+}
+
+type revisionUsecase[T any, PT model.Model[T]] struct {
+	repo      repository.RevisionRepository
+	resources Usecase[T]
+}
+
+// NewRevisionUsecase는 resources의 리소스 리비전을 다루는 RevisionUsecase를 생성
+// 롤백은 resources.Update로 수정하므로 일반 수정과 같은 검증을 거침
+func NewRevisionUsecase[T any, PT model.Model[T]](repo repository.RevisionRepository, resources Usecase[T]) RevisionUsecase[T] {
+	return &revisionUsecase[T, PT]{
+		repo:      repo,
+		resources: resources,
+	}
+}
+
+func (u *revisionUsecase[T, PT]) List(ctx context.Context, resourceID uint, page, size int) ([]*model.ResourceRevision[T], int64, error) {
+	if _, err := u.resources.Get(ctx, resourceID); err != nil {
+		return nil, 0, err
+	}
+	revs, total, err := u.repo.List(ctx, resourceID, page, size)
+	if err != nil {
+		return nil, 0, fmt.Errorf("리비전 목록 조회 실패: %w", err)
+	}
+	results := make([]*model.ResourceRevision[T], len(revs))
+	for i, rev := range revs {
+		if results[i], err = restore[T](rev); err != nil {
+			return nil, 0, err
+		}
+	}
+	return results, total, nil
+}
+
+func (u *revisionUsecase[T, PT]) Get(ctx context.Context, resourceID uint, number int) (*model.ResourceRevision[T], error) {
+	rev, err := u.get(ctx, resourceID, number)
+	if err != nil {
+		return nil, err
+	}
+	return restore[T](rev)
+}
+
+func (u *revisionUsecase[T, PT]) Diff(ctx context.Context, resourceID uint, from, to int) (*model.RevisionDiff, error) {
+	a, err := u.get(ctx, resourceID, from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := u.get(ctx, resourceID, to)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := diffSnapshots(a.Snapshot, b.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &model.RevisionDiff{From: from, To: to, Changes: changes}, nil
+}
+
+func (u *revisionUsecase[T, PT]) Rollback(ctx context.Context, resourceID uint, number, expected int) (*model.ResourceRevision[T], error) {
+	target, err := u.get(ctx, resourceID, number)
+	if err != nil {
+		return nil, err
+	}
+	restored, err := restore[T](target)
+	if err != nil {
+		return nil, err
+	}
+
+	rb := &repository.Rollback{Of: number}
+	_, err = u.resources.Update(repository.WithRollback(ctx, rb), resourceID, func(ctx context.Context, m *T) error {
+		// 리소스를 잠근 뒤에 확인하므로 확인한 리비전 다음에 다른 리비전이 저장될 수 없음
+		if expected > 0 {
+			latest, err := u.latest(ctx, resourceID)
+			if err != nil {
+				return err
+			}
+			if latest != expected {
+				return fmt.Errorf("%w: 리비전 %d 이후에 수정되었습니다 (현재 리비전 %d)", ErrConflict, expected, latest)
+			}
+		}
+		*m = *restored.Resource
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("롤백 실패: %w", err)
+	}
+	if rb.Revision == nil {
+		return nil, fmt.Errorf("롤백한 리비전이 저장되지 않았습니다")
+	}
+	return restore[T](rb.Revision)
+}
+
+// get은 리소스가 있을 때만 리비전을 조회 (삭제되거나 만료된 리소스의 리비전은 ErrNotFound)
+func (u *revisionUsecase[T, PT]) get(ctx context.Context, resourceID uint, number int) (*model.Revision, error) {
+	if _, err := u.resources.Get(ctx, resourceID); err != nil {
+		return nil, err
+	}
+	rev, err := u.repo.Get(ctx, resourceID, number)
+	if err != nil {
+		return nil, wrapErr(fmt.Sprintf("리비전 %d을(를) 찾을 수 없습니다", number), err)
+	}
+	return rev, nil
+}
+
+// latest는 마지막 리비전 번호를 반환 (리비전이 없으면 0)
+func (u *revisionUsecase[T, PT]) latest(ctx context.Context, resourceID uint) (int, error) {
+	revs, _, err := u.repo.List(ctx, resourceID, 1, 1)
+	if err != nil {
+		return 0, fmt.Errorf("리비전 조회 실패: %w", err)
+	}
+	if len(revs) == 0 {
+		return 0, nil
+	}
+	return revs[0].Number, nil
+}
+
+// restore는 스냅샷을 리소스로 복원한 리비전을 반환
+func restore[T any](rev *model.Revision) (*model.ResourceRevision[T], error) {
+	var m T
+	if err := json.Unmarshal([]byte(rev.Snapshot), &m); err != nil {
+		return nil, fmt.Errorf("리비전 %d 스냅샷 해석 실패: %v", rev.Number, err)
+	}
+	return &model.ResourceRevision[T]{
+		Revision:   rev.Number,
+		Resource:   &m,
+		RollbackOf: rev.RollbackOf,
+		CreatedAt:  rev.CreatedAt,
+	}, nil
+}
+
+// diffSnapshots는 두 JSON 스냅샷의 값을 끝 값(문자열, 숫자, 불리언, null) 단위로 비교해서 바뀐 값을 경로 순으로 반환
+// 객체는 키로, 배열은 인덱스로 경로를 만들고, 빈 객체나 빈 배열은 값이 없는 것으로 봄
+func diffSnapshots(from, to string) ([]model.FieldChange, error) {
+	a, err := flattenJSON(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := flattenJSON(to)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(a)+len(b))
+	for path := range a {
+		paths = append(paths, path)
+	}
+	for path := range b {
+		if _, ok := a[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	changes := []model.FieldChange{}
+	for _, path := range paths {
+		va, inA := a[path]
+		vb, inB := b[path]
+		if inA && inB && reflect.DeepEqual(va, vb) {
+			continue
+		}
+		changes = append(changes, model.FieldChange{Path: path, From: va, To: vb})
+	}
+	return changes, nil
+}
+
+// flattenJSON은 JSON 문서의 끝 값을 JSON Pointer 경로별로 모음
+func flattenJSON(doc string) (map[string]interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(doc), &v); err != nil {
+		return nil, fmt.Errorf("리비전 스냅샷 해석 실패: %v", err)
+	}
+	leaves := make(map[string]interface{})
+	var walk func(path string, v interface{})
+	walk = func(path string, v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for key, child := range v {
+				walk(path+"/"+escapePointer(key), child)
+			}
+		case []interface{}:
+			for i, child := range v {
+				walk(path+"/"+strconv.Itoa(i), child)
+			}
+		default:
+			leaves[path] = v
+		}
+	}
+	walk("", v)
+	return leaves, nil
+}
+
+// escapePointer는 JSON Pointer 경로의 한 단계로 쓸 수 있게 ~와 /를 바꿈 (RFC 6901)
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go_project/internal/labels"
+	"go_project/internal/model"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RevisionUsecaseTestSuite struct {
+	suite.Suite
+	resources Usecase[model.Base]
+	usecase   RevisionUsecase[model.Base]
+	resource  *model.Base
+}
+
+func (s *RevisionUsecaseTestSuite) SetupTest() {
+	revisions := repository.NewRevisionRepository(recorder.NewMemoryRevisionRecorder())
+	repo := repository.NewVersionedRepository(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()), revisions, recorder.NewMemoryTransactor())
+	s.resources = NewUsecase(repo)
+	s.usecase = NewRevisionUsecase(revisions, s.resources)
+
+	s.resource = &model.Base{Name: "v1", Labels: labels.Set{"env": "dev"}}
+	s.Require().NoError(s.resources.Insert(context.Background(), s.resource))
+}
+
+func (s *RevisionUsecaseTestSuite) TestRecordOnWrite() {
+	ctx := context.Background()
+	s.Require().NoError(s.resources.Modify(ctx, s.resource.ID, &model.Base{Name: "v2", Labels: labels.Set{"env": "dev"}}))
+	_, err := s.resources.AddLabels(ctx, s.resource.ID, labels.Set{"env": "prod"})
+	s.Require().NoError(err)
+
+	revs, total, err := s.usecase.List(ctx, s.resource.ID, 1, 2)
+	s.Require().NoError(err)
+	s.Equal(int64(3), total)
+	s.Require().Len(revs, 2)
+	// 최근 리비전부터
+	s.Equal(3, revs[0].Revision)
+	s.Equal("prod", revs[0].Resource.Labels["env"])
+	s.Equal(2, revs[1].Revision)
+	s.Equal("v2", revs[1].Resource.Name)
+
+	first, err := s.usecase.Get(ctx, s.resource.ID, 1)
+	s.Require().NoError(err)
+	s.Equal("v1", first.Resource.Name)
+	s.Nil(first.RollbackOf)
+
+	_, err = s.usecase.Get(ctx, s.resource.ID, 4)
+	s.True(errors.Is(err, ErrNotFound))
+	_, _, err = s.usecase.List(ctx, 999, 1, 20)
+	s.True(errors.Is(err, ErrNotFound))
+}
+
+func (s *RevisionUsecaseTestSuite) TestDiff() {
+	ctx := context.Background()
+	s.Require().NoError(s.resources.Modify(ctx, s.resource.ID, &model.Base{Name: "v2", Labels: labels.Set{"team": "a/b"}}))
+
+	diff, err := s.usecase.Diff(ctx, s.resource.ID, 1, 2)
+	s.Require().NoError(err)
+	s.Equal(1, diff.From)
+	s.Equal(2, diff.To)
+
+	changes := make(map[string]model.FieldChange)
+	for _, c := range diff.Changes {
+		changes[c.Path] = c
+	}
+	s.Equal(model.FieldChange{Path: "/name", From: "v1", To: "v2"}, changes["/name"])
+	s.Equal(model.FieldChange{Path: "/labels/env", From: "dev"}, changes["/labels/env"])
+	s.Equal(model.FieldChange{Path: "/labels/team", To: "a/b"}, changes["/labels/team"])
+	s.Contains(changes, "/updated_at")
+	s.NotContains(changes, "/id")
+	s.NotContains(changes, "/created_at")
+
+	same, err := s.usecase.Diff(ctx, s.resource.ID, 2, 2)
+	s.NoError(err)
+	s.Empty(same.Changes)
+
+	_, err = s.usecase.Diff(ctx, s.resource.ID, 1, 9)
+	s.True(errors.Is(err, ErrNotFound))
+}
+
+func (s *RevisionUsecaseTestSuite) TestRollback() {
+	ctx := context.Background()
+	s.Require().NoError(s.resources.Modify(ctx, s.resource.ID, &model.Base{Name: "v2"}))
+
+	rev, err := s.usecase.Rollback(ctx, s.resource.ID, 1, 2)
+	s.Require().NoError(err)
+	s.Equal(3, rev.Revision)
+	s.Require().NotNil(rev.RollbackOf)
+	s.Equal(1, *rev.RollbackOf)
+	s.Equal("v1", rev.Resource.Name)
+
+	got, err := s.resources.Get(ctx, s.resource.ID)
+	s.Require().NoError(err)
+	s.Equal("v1", got.Name)
+	s.Equal("dev", got.Labels["env"])
+	s.Equal(s.resource.CreatedAt.Unix(), got.CreatedAt.Unix())
+
+	// 그 사이에 다른 수정이 있었으면 충돌
+	_, err = s.usecase.Rollback(ctx, s.resource.ID, 2, 2)
+	s.True(errors.Is(err, ErrConflict))
+	_, err = s.usecase.Rollback(ctx, s.resource.ID, 9, 0)
+	s.True(errors.Is(err, ErrNotFound))
+}
+
+func (s *RevisionUsecaseTestSuite) TestRollback_Concurrent() {
+	ctx := context.Background()
+	s.Require().NoError(s.resources.Modify(ctx, s.resource.ID, &model.Base{Name: "v2"}))
+
+	// 같은 리비전을 기준으로 한 롤백이 동시에 오면 하나만 성공
+	const n = 8
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.usecase.Rollback(ctx, s.resource.ID, 1, 2); err == nil {
+				succeeded.Add(1)
+			} else {
+				s.True(errors.Is(err, ErrConflict), err)
+			}
+		}()
+	}
+	wg.Wait()
+	s.Equal(int32(1), succeeded.Load())
+
+	_, total, err := s.usecase.List(ctx, s.resource.ID, 1, 20)
+	s.NoError(err)
+	s.Equal(int64(3), total)
+}
+
+func (s *RevisionUsecaseTestSuite) TestRollback_Validation() {
+	ctx := context.Background()
+	expiresAt := time.Now().Add(50 * time.Millisecond)
+	s.Require().NoError(s.resources.Modify(ctx, s.resource.ID, &model.Base{Name: "v2", ExpiresAt: &expiresAt}))
+	s.Require().NoError(s.resources.Modify(ctx, s.resource.ID, &model.Base{Name: "v3"}))
+	time.Sleep(60 * time.Millisecond)
+
+	// 되돌릴 내용도 수정과 같은 검증을 거침 (이미 지난 만료 시각)
+	_, err := s.usecase.Rollback(ctx, s.resource.ID, 2, 0)
+	s.True(errors.Is(err, ErrInvalid))
+
+	_, total, err := s.usecase.List(ctx, s.resource.ID, 1, 20)
+	s.NoError(err)
+	s.Equal(int64(3), total, "실패한 롤백은 리비전을 남기지 않음")
+}
+
+func TestRevisionUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(RevisionUsecaseTestSuite))
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     []model.FieldChange
+	}{
+		{
+			name: "값_변경",
+			from: `{"name":"a","n":1}`,
+			to:   `{"name":"b","n":1}`,
+			want: []model.FieldChange{{Path: "/name", From: "a", To: "b"}},
+		},
+		{
+			name: "중첩_배열",
+			from: `{"attributes":{"tags":["x"]}}`,
+			to:   `{"attributes":{"tags":["x","y"]}}`,
+			want: []model.FieldChange{{Path: "/attributes/tags/1", To: "y"}},
+		},
+		{
+			name: "키_이스케이프",
+			from: `{"labels":{"example.com/team":"a"}}`,
+			to:   `{"labels":{}}`,
+			want: []model.FieldChange{{Path: "/labels/example.com~1team", From: "a"}},
+		},
+		{
+			name: "null로_변경",
+			from: `{"parent_id":1}`,
+			to:   `{"parent_id":null}`,
+			want: []model.FieldChange{{Path: "/parent_id", From: float64(1)}},
+		},
+		{
+			name: "변경_없음",
+			from: `{"a":{"b":true}}`,
+			to:   `{"a":{"b":true}}`,
+			want: []model.FieldChange{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diffSnapshots(tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -2,75 +2,866 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"go_project/internal/attributes"
+	"go_project/internal/database"
+	"go_project/internal/labels"
 	"go_project/internal/model"
+	"go_project/internal/recorder"
 	"go_project/internal/repository"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
-type Usecase interface {
-	Insert(ctx context.Context, model *model.Base) error
-	Get(ctx context.Context, id uint) (*model.Base, error)
-	GetAll(ctx context.Context) ([]*model.Base, error)
-	Modify(ctx context.Context, id uint, model *model.Base) error
+// Usecase는 T에 대한 비즈니스 로직 계층
+// T는 model.Base를 임베딩한 모델 (예: Usecase[model.Base])
+type Usecase[T any] interface {
+	Insert(ctx context.Context, model *T) error
+	Get(ctx context.Context, id uint) (*T, error)
+	GetAll(ctx context.Context) ([]*T, error)
+	Modify(ctx context.Context, id uint, model *T) error
+	// Update는 잠근 최신 리소스를 fn으로 바꾼 뒤 Modify와 같은 검증을 거쳐 저장 (일부 필드만 바꾸는 수정용)
+	// 저장소가 같은 리소스의 다른 쓰기를 기다리게 하므로 읽은 뒤에 바뀐 내용을 덮어쓰지 않음
+	// fn은 (트랜잭션을 다시 실행하면) 여러 번 호출될 수 있고, fn이 반환한 에러는 그대로 반환
+	// fn에 넘기는 ctx는 저장과 같은 트랜잭션이므로, 저장 전에 확인할 조회는 이 ctx로 해야 함
+	Update(ctx context.Context, id uint, fn func(ctx context.Context, model *T) error) (*T, error)
 	Remove(ctx context.Context, id uint) error
+	BatchInsert(ctx context.Context, models []*T, mode model.BatchMode) ([]model.BatchResult, error)
+	BatchModify(ctx context.Context, models []*T, mode model.BatchMode) ([]model.BatchResult, error)
+	BatchRemove(ctx context.Context, ids []uint, mode model.BatchMode) ([]model.BatchResult, error)
+	Export(ctx context.Context, fn func(*T) error) error
+	Import(ctx context.Context, rows []model.ImportRow[T], opts model.ImportOptions) (*model.ImportReport, error)
+	List(ctx context.Context, opts model.ListOptions) ([]*T, int64, error)
+	GetMany(ctx context.Context, ids []uint) ([]*T, error)
+	Watch(ctx context.Context, interval time.Duration, fn func(model.Event[T]) error) error
+	AddLabels(ctx context.Context, id uint, set labels.Set) (*T, error)
+	RemoveLabels(ctx context.Context, id uint, keys ...string) (*T, error)
+	Children(ctx context.Context, id uint) ([]*T, error)
+	Ancestors(ctx context.Context, id uint) ([]*T, error)
+	Descendants(ctx context.Context, id uint) ([]*T, error)
+	Move(ctx context.Context, id uint, parentID *uint) (*T, error)
+	RemoveTree(ctx context.Context, id uint) ([]uint, error)
+	Search(ctx context.Context, query string, limit int) (*model.SearchResult[T], error)
+	Similar(ctx context.Context, name string, limit int) ([]model.FuzzyMatch[T], error)
+	Extend(ctx context.Context, id uint, expiresAt time.Time) (*T, error)
+	PurgeExpired(ctx context.Context, before time.Time, archive func([]*T) error) (int64, error)
+}
+
+// ErrNotFound는 대상 리소스가 없을 때 반환 (errors.Is로 확인)
+var ErrNotFound = errors.New("리소스를 찾을 수 없습니다")
+
+// wrapErr는 에러에 설명을 붙이되, 리소스가 없는 경우는 ErrNotFound로 바꿔서 감쌈 (그 외는 saveErr)
+func wrapErr(msg string, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("%s: %w", msg, ErrNotFound)
+	}
+	return saveErr(msg, err)
+}
+
+// saveErr는 저장 에러에 설명을 붙이되, 부모를 확인한 뒤 저장하기 전에 부모가 삭제된 경우는 ErrInvalid로 바꿔서 감쌈
+func saveErr(msg string, err error) error {
+	if errors.Is(err, recorder.ErrParentNotFound) {
+		return fmt.Errorf("%s: %w: %v", msg, ErrInvalid, err)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// ErrConflict는 현재 상태 때문에 요청을 처리할 수 없을 때 반환 (예: 하위 리소스가 있는 리소스 삭제)
+var ErrConflict = errors.New("현재 상태와 충돌합니다")
+
+// ErrInvalid는 리소스가 검증을 통과하지 못했을 때 반환 (errors.Is로 확인)
+var ErrInvalid = errors.New("유효하지 않은 리소스")
+
+// ErrUnavailable은 DB에 연결할 수 없거나 회로 차단기가 열려 있어 요청을 처리하지 못했을 때 반환 (errors.Is로 확인)
+// 저장소 에러를 감싸서 돌려주므로 database.ErrUnavailable과 같은 값
+var ErrUnavailable = database.ErrUnavailable
+
+type usecase[T any, PT model.Model[T]] struct {
+	repo        repository.Repository[T]
+	schema      *attributes.Schema
+	attachments AttachmentUsecase
+	tx          recorder.Transactor
 }
 
-type usecase struct {
-	repo repository.Repository
+type options struct {
+	schema      *attributes.Schema
+	attachments AttachmentUsecase
+	tx          recorder.Transactor
 }
 
-func NewUsecase(repo repository.Repository) Usecase {
-	return &usecase{
-		repo: repo,
+type Option func(*options)
+
+// WithAttributeSchema는 생성/수정/가져오기 시 리소스 속성을 schema로 검증하도록 지정
+// 지정하지 않으면 속성은 검증하지 않음
+func WithAttributeSchema(schema *attributes.Schema) Option {
+	return func(o *options) {
+		o.schema = schema
+	}
+}
+
+// WithAttachments는 리소스를 삭제(Remove, RemoveTree, BatchRemove, PurgeExpired)한 뒤 그 첨부 파일도 지우도록 지정
+// 지정하지 않으면 첨부 파일은 남음
+func WithAttachments(attachments AttachmentUsecase) Option {
+	return func(o *options) {
+		o.attachments = attachments
+	}
+}
+
+// WithTransactor는 가져오기(Import)의 저장을 tx의 트랜잭션 하나로 묶도록 지정
+// 지정하지 않으면 행마다 따로 저장하므로 저장 중에 실패하면 앞의 행은 저장된 채로 남음
+func WithTransactor(tx recorder.Transactor) Option {
+	return func(o *options) {
+		o.tx = tx
+	}
+}
+
+// NewUsecase는 repo를 사용하는 Usecase를 생성 (T는 repo에서 추론됨)
+func NewUsecase[T any, PT model.Model[T]](repo repository.Repository[T], opts ...Option) Usecase[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &usecase[T, PT]{
+		repo:        repo,
+		schema:      o.schema,
+		attachments: o.attachments,
+		tx:          o.tx,
+	}
+}
+
+// base는 m에 임베딩된 Base를 반환
+func base[T any, PT model.Model[T]](m *T) *model.Base {
+	return PT(m).GetBase()
+}
+
+// validate는 만료 시각이 이미 지나지 않았는지 확인하고, 속성 스키마가 있으면 m의 속성을 검증
+func (u *usecase[T, PT]) validate(m *T) error {
+	if b := base[T, PT](m); b.Expired(time.Now()) {
+		return fmt.Errorf("%w: 만료 시각(%s)이 이미 지났습니다", ErrInvalid, b.ExpiresAt.Format(time.RFC3339))
+	}
+	if u.schema == nil {
+		return nil
+	}
+	if err := u.schema.Validate(base[T, PT](m).Attributes); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return nil
+}
+
+// validateAll은 모든 항목의 속성과 부모를 검증하고 처음 실패한 항목의 위치와 함께 에러를 반환
+// 항목마다 저장된 리소스를 기준으로 부모를 확인한 뒤, 모든 항목을 반영한 트리도 확인 (checkTree)
+func (u *usecase[T, PT]) validateAll(ctx context.Context, models []*T) error {
+	for i, m := range models {
+		b := base[T, PT](m)
+		if err := u.validate(m); err != nil {
+			return fmt.Errorf("%d번째 항목: %w", i, err)
+		}
+		if err := u.checkParent(ctx, b.ID, b.ParentID); err != nil {
+			return fmt.Errorf("%d번째 항목: %w", i, err)
+		}
+	}
+	return u.checkTree(ctx, models)
+}
+
+// 부모를 따라 올라갈 수 있는 최대 깊이 (Repository.Ancestors가 조회하는 깊이와 같음)
+const maxTreeDepth = 1000
+
+// checkTree는 저장된 리소스에 models의 부모를 모두 반영했을 때 순환이 생기거나 maxTreeDepth보다 깊어지는지 확인
+// 항목마다는 문제가 없어도 함께 반영하면 순환이 생길 수 있음 (예: A의 부모를 B로, B의 부모를 A로)
+func (u *usecase[T, PT]) checkTree(ctx context.Context, models []*T) error {
+	parents := make(map[uint]*uint, len(models))
+	for _, m := range models {
+		if b := base[T, PT](m); b.ID != 0 {
+			parents[b.ID] = b.ParentID
+		}
+	}
+	// parentOf는 id의 바뀔 부모를 반환 (일괄 처리에 없으면 저장된 부모, 없는 리소스면 nil)
+	parentOf := func(id uint) (*uint, error) {
+		if p, ok := parents[id]; ok {
+			return p, nil
+		}
+		stored, err := u.repo.Get(ctx, id)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			parents[id] = nil
+		case err != nil:
+			return nil, fmt.Errorf("부모 리소스 조회 실패: %w", err)
+		default:
+			parents[id] = base[T, PT](stored).ParentID
+		}
+		return parents[id], nil
+	}
+
+	for i, m := range models {
+		b := base[T, PT](m)
+		visited := map[uint]bool{b.ID: b.ID != 0}
+		for p, depth := b.ParentID, 1; p != nil; depth++ {
+			if visited[*p] {
+				return fmt.Errorf("%d번째 항목: %w: 함께 수정하는 항목과 부모 관계에 순환이 생깁니다 (리소스 %d)", i, ErrInvalid, *p)
+			}
+			if depth > maxTreeDepth {
+				return fmt.Errorf("%d번째 항목: %w: 트리 깊이가 %d을 넘습니다", i, ErrInvalid, maxTreeDepth)
+			}
+			visited[*p] = true
+			var err error
+			if p, err = parentOf(*p); err != nil {
+				return fmt.Errorf("%d번째 항목: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkParent는 id인 리소스(새 리소스면 0)의 부모를 parentID로 지정할 수 있는지 확인
+// 부모가 없거나, 자기 자신이나 자신의 하위 리소스를 부모로 지정해서 순환이 생기면 ErrInvalid
+func (u *usecase[T, PT]) checkParent(ctx context.Context, id uint, parentID *uint) error {
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == id {
+		return fmt.Errorf("%w: 자기 자신을 부모로 지정할 수 없습니다", ErrInvalid)
+	}
+	if _, err := u.repo.Get(ctx, *parentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: 부모 리소스(%d)가 없습니다", ErrInvalid, *parentID)
+		}
+		return fmt.Errorf("부모 리소스 조회 실패: %w", err)
+	}
+	if id == 0 {
+		return nil
+	}
+
+	// 새 부모의 조상 중에 자신이 있으면 새 부모는 자신의 하위 리소스
+	ancestors, err := u.repo.Ancestors(ctx, *parentID)
+	if err != nil {
+		return fmt.Errorf("부모 리소스 조회 실패: %w", err)
+	}
+	for _, a := range ancestors {
+		if base[T, PT](a).ID == id {
+			return fmt.Errorf("%w: 하위 리소스(%d)를 부모로 지정할 수 없습니다", ErrInvalid, *parentID)
+		}
 	}
+	return nil
 }
 
 // 기본 CRUD 구현
-func (u *usecase) Insert(ctx context.Context, model *model.Base) error {
+func (u *usecase[T, PT]) Insert(ctx context.Context, model *T) error {
+	if err := u.validate(model); err != nil {
+		return err
+	}
+	if err := u.checkParent(ctx, 0, base[T, PT](model).ParentID); err != nil {
+		return err
+	}
+	u.warnNearDuplicates(ctx, base[T, PT](model).Name)
 	if err := u.repo.Insert(ctx, model); err != nil {
-		return fmt.Errorf("생성 실패: %v", err)
+		return saveErr("생성 실패", err)
 	}
 	return nil
 }
 
-func (u *usecase) Get(ctx context.Context, id uint) (*model.Base, error) {
+func (u *usecase[T, PT]) Get(ctx context.Context, id uint) (*T, error) {
 	result, err := u.repo.Get(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("조회 실패: %v", err)
+		return nil, wrapErr("조회 실패", err)
 	}
 	return result, nil
 }
 
-func (u *usecase) GetAll(ctx context.Context) ([]*model.Base, error) {
+func (u *usecase[T, PT]) GetAll(ctx context.Context) ([]*T, error) {
 	results, err := u.repo.GetAll(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("목록 조회 실패: %v", err)
+		return nil, fmt.Errorf("목록 조회 실패: %w", err)
 	}
 	return results, nil
 }
 
-func (u *usecase) Modify(ctx context.Context, id uint, model *model.Base) error {
+func (u *usecase[T, PT]) Modify(ctx context.Context, id uint, model *T) error {
+	if err := u.validate(model); err != nil {
+		return err
+	}
+
 	// 먼저 존재하는지 확인
-	_, err := u.repo.Get(ctx, id)
+	existing, err := u.repo.Get(ctx, id)
 	if err != nil {
-		return fmt.Errorf("업데이트할 모델을 찾을 수 없습니다: %v", err)
+		return wrapErr("업데이트할 모델을 찾을 수 없습니다", err)
+	}
+	if err := u.checkParent(ctx, id, base[T, PT](model).ParentID); err != nil {
+		return err
+	}
+
+	// 본문의 ID와 관계없이 경로의 ID를 수정하고, 생성일은 유지
+	b := base[T, PT](model)
+	b.ID = id
+	if b.CreatedAt.IsZero() {
+		b.CreatedAt = base[T, PT](existing).CreatedAt
 	}
 
 	if err := u.repo.Modify(ctx, model); err != nil {
-		return fmt.Errorf("업데이트 실패: %v", err)
+		return saveErr("업데이트 실패", err)
 	}
 	return nil
 }
 
-func (u *usecase) Remove(ctx context.Context, id uint) error {
+func (u *usecase[T, PT]) Update(ctx context.Context, id uint, fn func(context.Context, *T) error) (*T, error) {
+	return u.update(ctx, id, "업데이트 실패", func(ctx context.Context, m *T) error {
+		if err := fn(ctx, m); err != nil {
+			return err
+		}
+		if err := u.validate(m); err != nil {
+			return err
+		}
+		return u.checkParent(ctx, id, base[T, PT](m).ParentID)
+	})
+}
+
+// update는 repo.Update로 잠근 리소스를 fn으로 바꿔서 저장
+// fn의 에러는 그대로 반환하고, 조회나 저장 에러는 msg를 붙여 반환
+func (u *usecase[T, PT]) update(ctx context.Context, id uint, msg string, fn func(ctx context.Context, m *T) error) (*T, error) {
+	var fnErr error
+	m, err := u.repo.Update(ctx, id, func(ctx context.Context, m *T) error {
+		fnErr = fn(ctx, m)
+		return fnErr
+	})
+	if fnErr != nil {
+		return nil, fnErr
+	}
+	if err != nil {
+		return nil, wrapErr(msg, err)
+	}
+	return m, nil
+}
+
+// Remove는 하위 리소스가 없는 리소스만 삭제 (하위 리소스까지 지우려면 RemoveTree)
+func (u *usecase[T, PT]) Remove(ctx context.Context, id uint) error {
 	// 먼저 존재하는지 확인
-	model, err := u.repo.Get(ctx, id)
+	m, err := u.repo.Get(ctx, id)
 	if err != nil {
-		return fmt.Errorf("삭제할 모델을 찾을 수 없습니다: %v", err)
+		return wrapErr("삭제할 모델을 찾을 수 없습니다", err)
 	}
+	// 하위 리소스는 repo.Remove가 리소스를 잠근 트랜잭션에서 확인
+	if err := u.repo.Remove(ctx, m); err != nil {
+		var children *recorder.HasChildrenError
+		if errors.As(err, &children) {
+			return fmt.Errorf("%w: 하위 리소스(%d)가 있어 삭제할 수 없습니다", ErrConflict, children.Child)
+		}
+		return fmt.Errorf("삭제 실패: %w", err)
+	}
+	u.removeAttachments(ctx, id)
+	return nil
+}
 
-	if err := u.repo.Remove(ctx, model); err != nil {
-		return fmt.Errorf("삭제 실패: %v", err)
+// removeAttachments는 삭제한 리소스의 첨부 파일을 지움 (WithAttachments를 지정했을 때만)
+// 리소스는 이미 삭제됐으므로 실패해도 에러를 반환하지 않고 로그와 경고로 알림
+func (u *usecase[T, PT]) removeAttachments(ctx context.Context, ids ...uint) {
+	if u.attachments == nil || len(ids) == 0 {
+		return
+	}
+	// 요청이 끝나도 정리는 마치도록 취소를 떼어 냄
+	if err := u.attachments.RemoveAll(context.WithoutCancel(ctx), ids...); err != nil {
+		log.Printf("삭제한 리소스의 첨부 파일 정리 실패: %v", err)
+		warn(ctx, "삭제한 리소스의 첨부 파일 일부를 지우지 못했습니다")
+	}
+}
+
+func (u *usecase[T, PT]) List(ctx context.Context, opts model.ListOptions) ([]*T, int64, error) {
+	results, total, err := u.repo.List(ctx, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("목록 조회 실패: %w", err)
+	}
+	return results, total, nil
+}
+
+// GetMany는 ID 목록의 리소스를 한 번에 조회 (없는 ID는 결과에서 빠짐)
+func (u *usecase[T, PT]) GetMany(ctx context.Context, ids []uint) ([]*T, error) {
+	results, err := u.repo.GetMany(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("조회 실패: %w", err)
+	}
+	return results, nil
+}
+
+// Watch는 interval마다 전체 목록을 조회해서 이전 결과와 달라진 리소스를 fn으로 전달
+// 처음에는 현재 리소스를 모두 EventAdded로 전달하고, 수정 여부는 updated_at으로 판단
+// 만료 시각이 지나 목록에서 빠진 리소스는 EventDeleted 대신 EventExpired로 전달
+// ctx가 끝나면 ctx.Err()를, fn이 에러를 반환하면 그 에러를 반환
+func (u *usecase[T, PT]) Watch(ctx context.Context, interval time.Duration, fn func(model.Event[T]) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	prev := map[uint]*T{}
+	for {
+		bases, err := u.repo.GetAll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("변경 감시 실패: %w", err)
+		}
+
+		curr := make(map[uint]*T, len(bases))
+		for _, m := range bases {
+			curr[base[T, PT](m).ID] = m
+		}
+		for _, e := range diffModels[T, PT](prev, curr, time.Now()) {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		prev = curr
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// diffModels는 두 목록을 비교한 변경 사항을 ID 순으로 반환
+// curr에 없는 리소스는 now에 만료됐으면 EventExpired, 아니면 EventDeleted
+func diffModels[T any, PT model.Model[T]](prev, curr map[uint]*T, now time.Time) []model.Event[T] {
+	var events []model.Event[T]
+	for id, m := range curr {
+		old, ok := prev[id]
+		switch {
+		case !ok:
+			events = append(events, model.Event[T]{Type: model.EventAdded, Resource: m})
+		case !base[T, PT](old).UpdatedAt.Equal(base[T, PT](m).UpdatedAt) || base[T, PT](old).Name != base[T, PT](m).Name:
+			events = append(events, model.Event[T]{Type: model.EventModified, Resource: m})
+		}
+	}
+	for id, m := range prev {
+		if _, ok := curr[id]; !ok {
+			typ := model.EventDeleted
+			if base[T, PT](m).Expired(now) {
+				typ = model.EventExpired
+			}
+			events = append(events, model.Event[T]{Type: typ, Resource: m})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return base[T, PT](events[i].Resource).ID < base[T, PT](events[j].Resource).ID
+	})
+	return events
+}
+
+// AddLabels는 리소스에 라벨을 추가 (같은 키가 있으면 값을 바꿈)
+func (u *usecase[T, PT]) AddLabels(ctx context.Context, id uint, set labels.Set) (*T, error) {
+	return u.updateLabels(ctx, id, func(l labels.Set) {
+		for k, v := range set {
+			l[k] = v
+		}
+	})
+}
+
+// RemoveLabels는 리소스에서 라벨을 삭제 (없는 키는 무시)
+func (u *usecase[T, PT]) RemoveLabels(ctx context.Context, id uint, keys ...string) (*T, error) {
+	return u.updateLabels(ctx, id, func(l labels.Set) {
+		for _, k := range keys {
+			delete(l, k)
+		}
+	})
+}
+
+// updateLabels는 잠근 리소스의 라벨 복사본을 fn으로 바꾼 뒤 저장
+func (u *usecase[T, PT]) updateLabels(ctx context.Context, id uint, fn func(labels.Set)) (*T, error) {
+	return u.update(ctx, id, "라벨 수정 실패", func(ctx context.Context, m *T) error {
+		b := base[T, PT](m)
+		l := b.Labels.Clone()
+		if l == nil {
+			l = labels.Set{}
+		}
+		fn(l)
+		b.Labels = l
+		return nil
+	})
+}
+
+// Children은 리소스의 직계 자식을 ID 순으로 조회
+func (u *usecase[T, PT]) Children(ctx context.Context, id uint) ([]*T, error) {
+	if _, err := u.repo.Get(ctx, id); err != nil {
+		return nil, wrapErr("조회 실패", err)
+	}
+	results, _, err := u.repo.List(ctx, model.ListOptions{ParentID: &id})
+	if err != nil {
+		return nil, fmt.Errorf("하위 리소스 조회 실패: %w", err)
+	}
+	return results, nil
+}
+
+// Ancestors는 리소스의 조상을 최상위부터 직계 부모 순으로 조회 (브레드크럼용, 자신은 제외)
+func (u *usecase[T, PT]) Ancestors(ctx context.Context, id uint) ([]*T, error) {
+	if _, err := u.repo.Get(ctx, id); err != nil {
+		return nil, wrapErr("조회 실패", err)
+	}
+	results, err := u.repo.Ancestors(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("상위 리소스 조회 실패: %w", err)
+	}
+	return results, nil
+}
+
+// Descendants는 리소스 아래의 모든 하위 리소스를 ID 순으로 조회 (자신은 제외)
+func (u *usecase[T, PT]) Descendants(ctx context.Context, id uint) ([]*T, error) {
+	if _, err := u.repo.Get(ctx, id); err != nil {
+		return nil, wrapErr("조회 실패", err)
+	}
+	results, err := u.repo.Descendants(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("하위 리소스 조회 실패: %w", err)
+	}
+	return results, nil
+}
+
+// 유사도 검색 기준 (0~1)
+const (
+	similarThreshold       = 0.3 // Similar와 검색어 제안의 최소 유사도 (pg_trgm 기본값과 같음)
+	nearDuplicateThreshold = 0.6 // 생성 시 비슷한 이름이라고 경고할 최소 유사도
+	maxNearDuplicates      = 3   // 생성 시 경고할 최대 리소스 수
+)
+
+// Search는 이름에 검색어의 단어들이 (접두사로) 모두 들어 있는 리소스를 관련도 순으로 최대 limit개 반환
+// 결과가 없으면 검색어와 가장 비슷한 이름을 Suggestion으로 제안
+func (u *usecase[T, PT]) Search(ctx context.Context, query string, limit int) (*model.SearchResult[T], error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("%w: 검색어가 필요합니다", ErrInvalid)
+	}
+	hits, err := u.repo.Search(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("검색 실패: %w", err)
+	}
+	result := &model.SearchResult[T]{Hits: hits}
+	if len(hits) == 0 {
+		// 제안은 부가 정보이므로 유사도 검색이 실패해도 검색 결과는 그대로 반환
+		if matches, err := u.repo.Similar(ctx, query, similarThreshold, 1); err == nil && len(matches) > 0 {
+			result.Suggestion = base[T, PT](matches[0].Resource).Name
+		}
+	}
+	return result, nil
+}
+
+// Similar는 이름이 name과 비슷한 리소스를 유사도 순으로 최대 limit개 반환 (오타 허용)
+func (u *usecase[T, PT]) Similar(ctx context.Context, name string, limit int) ([]model.FuzzyMatch[T], error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: 이름이 필요합니다", ErrInvalid)
+	}
+	matches, err := u.repo.Similar(ctx, name, similarThreshold, limit)
+	if err != nil {
+		return nil, fmt.Errorf("유사 이름 검색 실패: %w", err)
+	}
+	return matches, nil
+}
+
+// warnNearDuplicates는 name과 비슷한 이름의 리소스가 있으면 경고를 남김 (ctx에 경고를 모으는 곳이 있을 때만 확인)
+// 생성을 막지는 않으므로 조회가 실패해도 무시
+func (u *usecase[T, PT]) warnNearDuplicates(ctx context.Context, name string) {
+	if warningsFrom(ctx) == nil || strings.TrimSpace(name) == "" {
+		return
+	}
+	matches, err := u.repo.Similar(ctx, name, nearDuplicateThreshold, maxNearDuplicates)
+	if err != nil {
+		return
+	}
+	for _, m := range matches {
+		b := base[T, PT](m.Resource)
+		warn(ctx, "비슷한 이름의 리소스가 있습니다: %q (ID %d, 유사도 %.2f)", b.Name, b.ID, m.Score)
+	}
+}
+
+// Move는 리소스를 parentID 아래로 옮김 (nil이면 최상위로), 하위 리소스는 그대로 따라감
+func (u *usecase[T, PT]) Move(ctx context.Context, id uint, parentID *uint) (*T, error) {
+	return u.update(ctx, id, "이동 실패", func(ctx context.Context, m *T) error {
+		if err := u.checkParent(ctx, id, parentID); err != nil {
+			return err
+		}
+		base[T, PT](m).ParentID = parentID
+		return nil
+	})
+}
+
+// RemoveTree는 리소스와 모든 하위 리소스를 한 번에 삭제하고 삭제한 ID를 반환
+func (u *usecase[T, PT]) RemoveTree(ctx context.Context, id uint) ([]uint, error) {
+	if _, err := u.repo.Get(ctx, id); err != nil {
+		return nil, wrapErr("삭제할 모델을 찾을 수 없습니다", err)
+	}
+	descendants, err := u.repo.Descendants(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("하위 리소스 조회 실패: %w", err)
+	}
+
+	ids := []uint{id}
+	for _, d := range descendants {
+		ids = append(ids, base[T, PT](d).ID)
+	}
+	if err := u.repo.BatchRemove(ctx, ids); err != nil {
+		// 하위 리소스 목록을 조회한 뒤 새 하위 리소스가 생긴 경우
+		var children *recorder.HasChildrenError
+		if errors.As(err, &children) {
+			return nil, fmt.Errorf("%w: 하위 리소스(%d)가 추가되어 삭제할 수 없습니다", ErrConflict, children.Child)
+		}
+		return nil, fmt.Errorf("삭제 실패: %w", err)
+	}
+	u.removeAttachments(ctx, ids...)
+	return ids, nil
+}
+
+// Extend는 리소스의 만료 시각을 expiresAt으로 바꿈 (앞당길 수도 있지만 이미 지난 시각이면 ErrInvalid)
+// 이미 만료된 리소스는 되살릴 수 없으므로 ErrNotFound
+func (u *usecase[T, PT]) Extend(ctx context.Context, id uint, expiresAt time.Time) (*T, error) {
+	if !expiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: 만료 시각은 현재보다 뒤여야 합니다", ErrInvalid)
+	}
+	// 만료 시각만 조건부로 바꾸므로 조회한 뒤에 만료된 리소스를 되살리거나 다른 수정을 덮어쓰지 않음
+	m, err := u.repo.Extend(ctx, id, expiresAt)
+	if err != nil {
+		return nil, wrapErr("만료 시각 변경 실패", err)
+	}
+	return m, nil
+}
+
+// PurgeExpired는 before까지 만료된 리소스를 archive(nil이 아니면)에 넘긴 뒤 삭제하고 삭제한 수를 반환
+// 만료되지 않은 하위 리소스가 있는 리소스는 (repo.Expired가 빼므로) 지우지 않고 계속 숨겨 두며, 하위 리소스가 없어진 뒤에 지움
+// archive가 실패하면 아무것도 지우지 않음
+func (u *usecase[T, PT]) PurgeExpired(ctx context.Context, before time.Time, archive func([]*T) error) (int64, error) {
+	removing, err := u.repo.Expired(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("만료된 리소스 조회 실패: %w", err)
+	}
+	if len(removing) == 0 {
+		return 0, nil
+	}
+	ids := make([]uint, len(removing))
+	for i, m := range removing {
+		ids[i] = base[T, PT](m).ID
+	}
+
+	if archive != nil {
+		if err := archive(removing); err != nil {
+			return 0, fmt.Errorf("만료된 리소스 보관 실패: %w", err)
+		}
+	}
+	removed, err := u.repo.RemoveExpired(ctx, ids, before)
+	if err != nil {
+		return 0, fmt.Errorf("만료된 리소스 삭제 실패: %w", err)
+	}
+	u.removeAttachments(ctx, removed...)
+	return int64(len(removed)), nil
+}
+
+// 일괄 처리 구현
+// atomic 모드는 Repository의 일괄 메서드로 한 번에 처리하고 실패 시 에러를 반환
+// partial 모드는 항목별로 단건 메서드를 호출하고 각 항목의 결과를 돌려줌
+func (u *usecase[T, PT]) BatchInsert(ctx context.Context, models []*T, mode model.BatchMode) ([]model.BatchResult, error) {
+	if mode == model.BatchModePartial {
+		results := make([]model.BatchResult, len(models))
+		for i, m := range models {
+			results[i] = model.BatchResult{Index: i, Err: u.Insert(ctx, m)}
+			results[i].ID = base[T, PT](m).ID
+		}
+		return results, nil
+	}
+
+	if err := u.validateAll(ctx, models); err != nil {
+		return nil, err
+	}
+	if err := u.repo.BatchInsert(ctx, models); err != nil {
+		return nil, saveErr("일괄 생성 실패", err)
+	}
+	results := make([]model.BatchResult, len(models))
+	for i, m := range models {
+		results[i] = model.BatchResult{Index: i, ID: base[T, PT](m).ID}
+	}
+	return results, nil
+}
+
+func (u *usecase[T, PT]) BatchModify(ctx context.Context, models []*T, mode model.BatchMode) ([]model.BatchResult, error) {
+	if mode == model.BatchModePartial {
+		results := make([]model.BatchResult, len(models))
+		for i, m := range models {
+			id := base[T, PT](m).ID
+			results[i] = model.BatchResult{Index: i, ID: id, Err: u.Modify(ctx, id, m)}
+		}
+		return results, nil
+	}
+
+	if err := u.validateAll(ctx, models); err != nil {
+		return nil, err
+	}
+	if err := u.repo.BatchModify(ctx, models); err != nil {
+		return nil, saveErr("일괄 업데이트 실패", err)
+	}
+	results := make([]model.BatchResult, len(models))
+	for i, m := range models {
+		results[i] = model.BatchResult{Index: i, ID: base[T, PT](m).ID}
+	}
+	return results, nil
+}
+
+func (u *usecase[T, PT]) BatchRemove(ctx context.Context, ids []uint, mode model.BatchMode) ([]model.BatchResult, error) {
+	if mode == model.BatchModePartial {
+		results := make([]model.BatchResult, len(ids))
+		for i, id := range ids {
+			results[i] = model.BatchResult{Index: i, ID: id, Err: u.Remove(ctx, id)}
+		}
+		return results, nil
+	}
+
+	// 함께 삭제하지 않는 하위 리소스가 있으면 아무것도 삭제하지 않음 (repo.BatchRemove가 ids를 잠근 트랜잭션에서 확인)
+	if err := u.repo.BatchRemove(ctx, ids); err != nil {
+		var children *recorder.HasChildrenError
+		if errors.As(err, &children) {
+			i := slices.Index(ids, children.ID)
+			return nil, fmt.Errorf("%d번째 항목: %w: 하위 리소스(%d)가 있어 삭제할 수 없습니다", i, ErrConflict, children.Child)
+		}
+		return nil, fmt.Errorf("일괄 삭제 실패: %w", err)
+	}
+	u.removeAttachments(ctx, ids...)
+	results := make([]model.BatchResult, len(ids))
+	for i, id := range ids {
+		results[i] = model.BatchResult{Index: i, ID: id}
+	}
+	return results, nil
+}
+
+// 가져오기/내보내기 구현
+func (u *usecase[T, PT]) Export(ctx context.Context, fn func(*T) error) error {
+	if err := u.repo.Stream(ctx, fn); err != nil {
+		return fmt.Errorf("내보내기 실패: %w", err)
 	}
 	return nil
 }
+
+// Import는 먼저 모든 행을 검증하고, 하나라도 실패하면 아무것도 저장하지 않음
+// 검증을 통과한 뒤 저장하다 실패하면 WithTransactor를 지정한 경우 모든 행을 되돌리고(생성/수정 0건),
+// 지정하지 않은 경우 실패한 행만 빼고 저장된 건수를 보고함 (어느 쪽이든 실패한 행은 Errors에 남음)
+// DryRun이면 검증과 생성/수정 예상 건수만 보고
+func (u *usecase[T, PT]) Import(ctx context.Context, rows []model.ImportRow[T], opts model.ImportOptions) (*model.ImportReport, error) {
+	report := &model.ImportReport{DryRun: opts.DryRun, Total: len(rows), Errors: []model.ImportError{}}
+	fail := func(row model.ImportRow[T], msg string) {
+		report.Failed++
+		report.Errors = append(report.Errors, model.ImportError{Row: row.Line, Message: msg})
+	}
+
+	// 1단계: 검증 및 생성/수정 대상 결정
+	existing := make([]*T, len(rows))
+	seen := make(map[string]int, len(rows))
+	for i, row := range rows {
+		if row.Err != nil {
+			fail(row, row.Err.Error())
+			continue
+		}
+		name := strings.TrimSpace(base[T, PT](row.Resource).Name)
+		if name == "" {
+			fail(row, "이름이 비어 있습니다")
+			continue
+		}
+		base[T, PT](row.Resource).Name = name
+		if err := u.validate(row.Resource); err != nil {
+			fail(row, err.Error())
+			continue
+		}
+		if line, ok := seen[name]; ok && opts.Upsert {
+			fail(row, fmt.Sprintf("%d행과 이름이 중복됩니다", line))
+			continue
+		}
+		seen[name] = row.Line
+
+		var id uint
+		if opts.Upsert {
+			found, err := u.repo.GetByName(ctx, name)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("가져오기 검증 실패: %w", err)
+			}
+			existing[i] = found
+			if found != nil {
+				id = base[T, PT](found).ID
+			}
+		}
+		if err := u.checkParent(ctx, id, base[T, PT](row.Resource).ParentID); err != nil {
+			if !errors.Is(err, ErrInvalid) {
+				return nil, fmt.Errorf("가져오기 검증 실패: %w", err)
+			}
+			fail(row, err.Error())
+		}
+	}
+
+	if report.Failed > 0 {
+		return report, nil
+	}
+
+	// 2단계: 저장 (DryRun이면 건수만 집계)
+	if opts.DryRun {
+		for i := range rows {
+			if existing[i] != nil {
+				report.Updated++
+			} else {
+				report.Created++
+			}
+		}
+		return report, nil
+	}
+	if u.tx == nil {
+		u.saveImported(ctx, rows, existing, report, false)
+		return report, nil
+	}
+
+	err := u.tx.Transaction(ctx, func(ctx context.Context) error {
+		// 트랜잭션을 다시 실행하면 앞의 시도에서 센 건수는 버림
+		report.Created, report.Updated, report.Failed, report.Errors = 0, 0, 0, []model.ImportError{}
+		if !u.saveImported(ctx, rows, existing, report, true) {
+			return errImportAborted
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, errImportAborted):
+		report.Created, report.Updated = 0, 0
+	case err != nil:
+		return nil, fmt.Errorf("가져오기 실패: %w", err)
+	}
+	return report, nil
+}
+
+// errImportAborted는 가져오기 중 한 행을 저장하지 못해 트랜잭션을 되돌릴 때 사용
+var errImportAborted = errors.New("가져오기 중단")
+
+// saveImported는 검증을 통과한 rows를 생성하거나 existing[i]를 수정하고 결과를 report에 셈
+// stop이면 처음 실패한 행에서 멈추고, 모든 행을 저장했는지를 반환
+func (u *usecase[T, PT]) saveImported(ctx context.Context, rows []model.ImportRow[T], existing []*T, report *model.ImportReport, stop bool) bool {
+	for i, row := range rows {
+		var err error
+		if existing[i] != nil {
+			b := base[T, PT](row.Resource)
+			b.ID = base[T, PT](existing[i]).ID
+			b.CreatedAt = base[T, PT](existing[i]).CreatedAt
+			if err = u.repo.Modify(ctx, row.Resource); err != nil {
+				err = fmt.Errorf("수정 실패: %v", err)
+			} else {
+				report.Updated++
+			}
+		} else {
+			// 새로 생성하는 행은 파일의 ID를 무시
+			base[T, PT](row.Resource).ID = 0
+			if err = u.repo.Insert(ctx, row.Resource); err != nil {
+				err = fmt.Errorf("생성 실패: %v", err)
+			} else {
+				report.Created++
+			}
+		}
+		if err == nil {
+			continue
+		}
+		report.Failed++
+		report.Errors = append(report.Errors, model.ImportError{Row: row.Line, Message: err.Error()})
+		if stop {
+			return false
+		}
+	}
+	return report.Failed == 0
+}
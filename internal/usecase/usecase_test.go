@@ -3,11 +3,19 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"go_project/internal/attributes"
+	"go_project/internal/labels"
 	"go_project/internal/model"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 )
 
 // Repository 모의 객체 정의
@@ -35,22 +43,105 @@ func (m *mockRepository) Modify(ctx context.Context, model *model.Base) error {
 	return args.Error(0)
 }
 
+// Update는 Get처럼 설정한 리소스에 fn을 적용해서 돌려줌 (잠금은 흉내 내지 않음)
+func (m *mockRepository) Update(ctx context.Context, id uint, fn func(context.Context, *model.Base) error) (*model.Base, error) {
+	args := m.Called(ctx, id)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+	resource := args.Get(0).(*model.Base)
+	if err := fn(ctx, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
 func (m *mockRepository) Remove(ctx context.Context, model *model.Base) error {
 	args := m.Called(ctx, model)
 	return args.Error(0)
 }
 
+func (m *mockRepository) BatchInsert(ctx context.Context, models []*model.Base) error {
+	args := m.Called(ctx, models)
+	return args.Error(0)
+}
+
+func (m *mockRepository) BatchModify(ctx context.Context, models []*model.Base) error {
+	args := m.Called(ctx, models)
+	return args.Error(0)
+}
+
+func (m *mockRepository) BatchRemove(ctx context.Context, ids []uint) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *mockRepository) GetByName(ctx context.Context, name string) (*model.Base, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(*model.Base), args.Error(1)
+}
+
+func (m *mockRepository) Stream(ctx context.Context, fn func(*model.Base) error) error {
+	args := m.Called(ctx, fn)
+	return args.Error(0)
+}
+
+func (m *mockRepository) List(ctx context.Context, opts model.ListOptions) ([]*model.Base, int64, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).([]*model.Base), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockRepository) GetMany(ctx context.Context, ids []uint) ([]*model.Base, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockRepository) Descendants(ctx context.Context, id uint) ([]*model.Base, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockRepository) Ancestors(ctx context.Context, id uint) ([]*model.Base, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockRepository) Search(ctx context.Context, query string, limit int) ([]model.SearchHit[model.Base], error) {
+	args := m.Called(ctx, query, limit)
+	return args.Get(0).([]model.SearchHit[model.Base]), args.Error(1)
+}
+
+func (m *mockRepository) Similar(ctx context.Context, name string, threshold float64, limit int) ([]model.FuzzyMatch[model.Base], error) {
+	args := m.Called(ctx, name, threshold, limit)
+	return args.Get(0).([]model.FuzzyMatch[model.Base]), args.Error(1)
+}
+
+func (m *mockRepository) Expired(ctx context.Context, before time.Time) ([]*model.Base, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).([]*model.Base), args.Error(1)
+}
+
+func (m *mockRepository) Extend(ctx context.Context, id uint, expiresAt time.Time) (*model.Base, error) {
+	args := m.Called(ctx, id, expiresAt)
+	return args.Get(0).(*model.Base), args.Error(1)
+}
+
+func (m *mockRepository) RemoveExpired(ctx context.Context, ids []uint, before time.Time) ([]uint, error) {
+	args := m.Called(ctx, ids, before)
+	return args.Get(0).([]uint), args.Error(1)
+}
+
 // 관련된 테스트를 하나의 Suite로 묶어서 관리
 type UsecaseTestSuite struct {
 	suite.Suite
 	mockRepo *mockRepository
-	uc       Usecase
+	uc       Usecase[model.Base]
 }
 
 func (s *UsecaseTestSuite) SetupTest() {
 	// 테스트 초기화
 	s.mockRepo = new(mockRepository)
-	s.uc = NewUsecase(s.mockRepo)
+	s.uc = NewUsecase[model.Base](s.mockRepo)
 }
 
 func (s *UsecaseTestSuite) TearDownTest() {
@@ -252,12 +343,22 @@ func (s *UsecaseTestSuite) TestRemove() {
 			name: "성공_케이스",
 			id:   1,
 			mockFn: func(m *mockRepository) {
-				model := &model.Base{ID: 1, Name: "삭제할_데이터"}
-				m.On("Get", mock.Anything, uint(1)).Return(model, nil)
+				target := &model.Base{ID: 1, Name: "삭제할_데이터"}
+				m.On("Get", mock.Anything, uint(1)).Return(target, nil)
 				m.On("Remove", mock.Anything, mock.AnythingOfType("*model.Base")).Return(nil)
 			},
 			wantErr: false,
 		},
+		{
+			name: "실패_케이스_하위_리소스",
+			id:   4,
+			mockFn: func(m *mockRepository) {
+				m.On("Get", mock.Anything, uint(4)).Return(&model.Base{ID: 4}, nil)
+				m.On("Remove", mock.Anything, mock.AnythingOfType("*model.Base")).
+					Return(&recorder.HasChildrenError{ID: 4, Child: 5})
+			},
+			wantErr: true,
+		},
 		{
 			name: "실패_케이스_엔티티_없음",
 			id:   2,
@@ -271,8 +372,8 @@ func (s *UsecaseTestSuite) TestRemove() {
 			name: "실패_케이스_삭제_오류",
 			id:   3,
 			mockFn: func(m *mockRepository) {
-				model := &model.Base{ID: 3, Name: "삭제할_데이터"}
-				m.On("Get", mock.Anything, uint(3)).Return(model, nil)
+				target := &model.Base{ID: 3, Name: "삭제할_데이터"}
+				m.On("Get", mock.Anything, uint(3)).Return(target, nil)
 				m.On("Remove", mock.Anything, mock.AnythingOfType("*model.Base")).
 					Return(errors.New("삭제 실패"))
 			},
@@ -361,7 +462,638 @@ func (s *UsecaseTestSuite) TestGetAll() {
 	}
 }
 
+func (s *UsecaseTestSuite) TestBatchInsert() {
+	tests := []struct {
+		name       string
+		mode       model.BatchMode
+		mockFn     func(*mockRepository)
+		wantFailed []int
+		wantErr    bool
+	}{
+		{
+			name: "성공_케이스_atomic",
+			mode: model.BatchModeAtomic,
+			mockFn: func(m *mockRepository) {
+				m.On("BatchInsert", mock.Anything, mock.AnythingOfType("[]*model.Base")).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "실패_케이스_atomic",
+			mode: model.BatchModeAtomic,
+			mockFn: func(m *mockRepository) {
+				m.On("BatchInsert", mock.Anything, mock.AnythingOfType("[]*model.Base")).
+					Return(errors.New("일괄 생성 오류"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "부분_실패_케이스_partial",
+			mode: model.BatchModePartial,
+			mockFn: func(m *mockRepository) {
+				m.On("Insert", mock.Anything, mock.MatchedBy(func(model *model.Base) bool {
+					return model.Name == "데이터1"
+				})).Return(nil)
+				m.On("Insert", mock.Anything, mock.MatchedBy(func(model *model.Base) bool {
+					return model.Name == "데이터2"
+				})).Return(errors.New("생성 오류"))
+			},
+			wantFailed: []int{1},
+			wantErr:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.mockFn(s.mockRepo)
+
+			models := []*model.Base{{Name: "데이터1"}, {Name: "데이터2"}}
+			results, err := s.uc.BatchInsert(context.Background(), models, tt.mode)
+
+			if tt.wantErr {
+				s.Error(err)
+				s.Nil(results)
+			} else {
+				s.NoError(err)
+				s.Len(results, len(models))
+				var failed []int
+				for _, r := range results {
+					if r.Err != nil {
+						failed = append(failed, r.Index)
+					}
+				}
+				s.Equal(tt.wantFailed, failed)
+			}
+			s.TearDownTest()
+		})
+	}
+}
+
+func (s *UsecaseTestSuite) TestBatchRemove() {
+	tests := []struct {
+		name       string
+		mode       model.BatchMode
+		mockFn     func(*mockRepository)
+		wantFailed []int
+		wantErr    bool
+	}{
+		{
+			name: "성공_케이스_atomic",
+			mode: model.BatchModeAtomic,
+			mockFn: func(m *mockRepository) {
+				m.On("BatchRemove", mock.Anything, []uint{1, 2}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "실패_케이스_atomic_하위_리소스",
+			mode: model.BatchModeAtomic,
+			mockFn: func(m *mockRepository) {
+				m.On("BatchRemove", mock.Anything, []uint{1, 2}).
+					Return(&recorder.HasChildrenError{ID: 2, Child: 3})
+			},
+			wantErr: true,
+		},
+		{
+			name: "부분_실패_케이스_partial",
+			mode: model.BatchModePartial,
+			mockFn: func(m *mockRepository) {
+				target := &model.Base{ID: 1, Name: "삭제할_데이터"}
+				m.On("Get", mock.Anything, uint(1)).Return(target, nil)
+				m.On("Remove", mock.Anything, target).Return(nil)
+				m.On("Get", mock.Anything, uint(2)).
+					Return((*model.Base)(nil), errors.New("엔티티 없음"))
+			},
+			wantFailed: []int{1},
+			wantErr:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.mockFn(s.mockRepo)
+
+			results, err := s.uc.BatchRemove(context.Background(), []uint{1, 2}, tt.mode)
+
+			if tt.wantErr {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+				var failed []int
+				for _, r := range results {
+					if r.Err != nil {
+						failed = append(failed, r.Index)
+					}
+				}
+				s.Equal(tt.wantFailed, failed)
+			}
+			s.TearDownTest()
+		})
+	}
+}
+
+func (s *UsecaseTestSuite) TestImport() {
+	tests := []struct {
+		name       string
+		rows       []model.ImportRow[model.Base]
+		opts       model.ImportOptions
+		tx         bool // WithTransactor로 저장을 한 트랜잭션으로 묶음
+		mockFn     func(*mockRepository)
+		want       *model.ImportReport
+		wantErrRow []int
+	}{
+		{
+			name: "성공_케이스_생성",
+			rows: []model.ImportRow[model.Base]{
+				{Line: 2, Resource: &model.Base{Name: "데이터1"}},
+				{Line: 3, Resource: &model.Base{ID: 7, Name: " 데이터2 "}},
+			},
+			mockFn: func(m *mockRepository) {
+				m.On("Insert", mock.Anything, mock.MatchedBy(func(model *model.Base) bool {
+					return model.ID == 0 && (model.Name == "데이터1" || model.Name == "데이터2")
+				})).Return(nil).Twice()
+			},
+			want: &model.ImportReport{Total: 2, Created: 2},
+		},
+		{
+			name: "성공_케이스_upsert",
+			rows: []model.ImportRow[model.Base]{
+				{Line: 2, Resource: &model.Base{Name: "기존"}},
+				{Line: 3, Resource: &model.Base{Name: "신규"}},
+			},
+			opts: model.ImportOptions{Upsert: true},
+			mockFn: func(m *mockRepository) {
+				m.On("GetByName", mock.Anything, "기존").Return(&model.Base{ID: 5, Name: "기존"}, nil)
+				m.On("GetByName", mock.Anything, "신규").Return((*model.Base)(nil), gorm.ErrRecordNotFound)
+				m.On("Modify", mock.Anything, mock.MatchedBy(func(model *model.Base) bool {
+					return model.ID == 5
+				})).Return(nil)
+				m.On("Insert", mock.Anything, mock.MatchedBy(func(model *model.Base) bool {
+					return model.Name == "신규"
+				})).Return(nil)
+			},
+			want: &model.ImportReport{Total: 2, Created: 1, Updated: 1},
+		},
+		{
+			name: "성공_케이스_dry_run은_저장하지_않음",
+			rows: []model.ImportRow[model.Base]{
+				{Line: 2, Resource: &model.Base{Name: "기존"}},
+				{Line: 3, Resource: &model.Base{Name: "신규"}},
+			},
+			opts: model.ImportOptions{Upsert: true, DryRun: true},
+			mockFn: func(m *mockRepository) {
+				m.On("GetByName", mock.Anything, "기존").Return(&model.Base{ID: 5, Name: "기존"}, nil)
+				m.On("GetByName", mock.Anything, "신규").Return((*model.Base)(nil), gorm.ErrRecordNotFound)
+			},
+			want: &model.ImportReport{DryRun: true, Total: 2, Created: 1, Updated: 1},
+		},
+		{
+			name: "실패_케이스_검증_오류가_있으면_저장하지_않음",
+			rows: []model.ImportRow[model.Base]{
+				{Line: 2, Resource: &model.Base{Name: "정상"}},
+				{Line: 3, Resource: &model.Base{Name: "  "}},
+				{Line: 4, Err: errors.New("잘못된 JSON")},
+			},
+			mockFn:     func(m *mockRepository) {},
+			want:       &model.ImportReport{Total: 3, Failed: 2},
+			wantErrRow: []int{3, 4},
+		},
+		{
+			name: "실패_케이스_저장_중_실패하면_트랜잭션을_되돌림",
+			rows: []model.ImportRow[model.Base]{
+				{Line: 2, Resource: &model.Base{Name: "데이터1"}},
+				{Line: 3, Resource: &model.Base{Name: "데이터2"}},
+				{Line: 4, Resource: &model.Base{Name: "데이터3"}},
+			},
+			tx: true,
+			mockFn: func(m *mockRepository) {
+				m.On("Insert", mock.Anything, mock.MatchedBy(func(model *model.Base) bool { return model.Name == "데이터1" })).Return(nil).Once()
+				m.On("Insert", mock.Anything, mock.MatchedBy(func(model *model.Base) bool { return model.Name == "데이터2" })).Return(errors.New("DB 오류")).Once()
+			},
+			want:       &model.ImportReport{Total: 3, Failed: 1},
+			wantErrRow: []int{3},
+		},
+		{
+			name: "실패_케이스_트랜잭션이_없으면_실패한_행만_빼고_저장",
+			rows: []model.ImportRow[model.Base]{
+				{Line: 2, Resource: &model.Base{Name: "데이터1"}},
+				{Line: 3, Resource: &model.Base{Name: "데이터2"}},
+				{Line: 4, Resource: &model.Base{Name: "데이터3"}},
+			},
+			mockFn: func(m *mockRepository) {
+				m.On("Insert", mock.Anything, mock.MatchedBy(func(model *model.Base) bool { return model.Name == "데이터2" })).Return(errors.New("DB 오류")).Once()
+				m.On("Insert", mock.Anything, mock.Anything).Return(nil).Twice()
+			},
+			want:       &model.ImportReport{Total: 3, Created: 2, Failed: 1},
+			wantErrRow: []int{3},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			if tt.tx {
+				s.uc = NewUsecase[model.Base](s.mockRepo, WithTransactor(recorder.NewMemoryTransactor()))
+			}
+			tt.mockFn(s.mockRepo)
+
+			got, err := s.uc.Import(context.Background(), tt.rows, tt.opts)
+
+			s.NoError(err)
+			s.Equal(tt.want.DryRun, got.DryRun)
+			s.Equal(tt.want.Total, got.Total)
+			s.Equal(tt.want.Created, got.Created)
+			s.Equal(tt.want.Updated, got.Updated)
+			s.Equal(tt.want.Failed, got.Failed)
+			var errRows []int
+			for _, e := range got.Errors {
+				errRows = append(errRows, e.Row)
+			}
+			s.Equal(tt.wantErrRow, errRows)
+			s.TearDownTest()
+		})
+	}
+}
+
+func (s *UsecaseTestSuite) TestExport() {
+	s.mockRepo.On("Stream", mock.Anything, mock.Anything).Return(errors.New("조회 오류"))
+
+	err := s.uc.Export(context.Background(), func(*model.Base) error { return nil })
+
+	s.Error(err)
+}
+
+func (s *UsecaseTestSuite) TestList() {
+	tests := []struct {
+		name      string
+		mockFn    func(*mockRepository)
+		wantTotal int64
+		wantErr   bool
+	}{
+		{
+			name: "성공_케이스",
+			mockFn: func(m *mockRepository) {
+				m.On("List", mock.Anything, model.ListOptions{Page: 1, Size: 2}).
+					Return([]*model.Base{{ID: 1}, {ID: 2}}, int64(5), nil)
+			},
+			wantTotal: 5,
+		},
+		{
+			name: "실패_케이스",
+			mockFn: func(m *mockRepository) {
+				m.On("List", mock.Anything, model.ListOptions{Page: 1, Size: 2}).
+					Return([]*model.Base(nil), int64(0), errors.New("조회 오류"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			tt.mockFn(s.mockRepo)
+
+			got, total, err := s.uc.List(context.Background(), model.ListOptions{Page: 1, Size: 2})
+
+			if tt.wantErr {
+				s.Error(err)
+				s.Nil(got)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.wantTotal, total)
+			}
+			s.TearDownTest()
+		})
+	}
+}
+
+func (s *UsecaseTestSuite) TestErrNotFound() {
+	s.mockRepo.On("Get", mock.Anything, uint(1)).
+		Return((*model.Base)(nil), gorm.ErrRecordNotFound)
+
+	_, err := s.uc.Get(context.Background(), 1)
+	s.ErrorIs(err, ErrNotFound)
+
+	err = s.uc.Remove(context.Background(), 1)
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *UsecaseTestSuite) TestModify_KeepsPathIDAndCreatedAt() {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.mockRepo.On("Get", mock.Anything, uint(1)).
+		Return(&model.Base{ID: 1, Name: "기존_데이터", CreatedAt: createdAt}, nil)
+	s.mockRepo.On("Modify", mock.Anything, mock.AnythingOfType("*model.Base")).Return(nil)
+
+	// 본문에 ID가 없어도 경로의 ID로 수정
+	m := &model.Base{Name: "수정할_데이터"}
+	err := s.uc.Modify(context.Background(), 1, m)
+
+	s.NoError(err)
+	s.Equal(uint(1), m.ID)
+	s.Equal(createdAt, m.CreatedAt)
+}
+
+func (s *UsecaseTestSuite) TestWatch() {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.mockRepo.On("GetAll", mock.Anything).
+		Return([]*model.Base{{ID: 1, UpdatedAt: t0}, {ID: 2, UpdatedAt: t0}}, nil).Once()
+	s.mockRepo.On("GetAll", mock.Anything).
+		Return([]*model.Base{{ID: 1, UpdatedAt: t0.Add(time.Second)}, {ID: 3, UpdatedAt: t0}}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []string
+	err := s.uc.Watch(ctx, time.Millisecond, func(e model.Event[model.Base]) error {
+		got = append(got, fmt.Sprintf("%s:%d", e.Type, e.Resource.ID))
+		if len(got) == 5 {
+			cancel()
+		}
+		return nil
+	})
+
+	s.ErrorIs(err, context.Canceled)
+	s.Equal([]string{"added:1", "added:2", "modified:1", "deleted:2", "added:3"}, got)
+}
+
+func (s *UsecaseTestSuite) TestWatch_Expired() {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.mockRepo.On("GetAll", mock.Anything).
+		Return([]*model.Base{{ID: 1, UpdatedAt: t0, ExpiresAt: &t0}, {ID: 2, UpdatedAt: t0}}, nil).Once()
+	s.mockRepo.On("GetAll", mock.Anything).Return([]*model.Base{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []string
+	err := s.uc.Watch(ctx, time.Millisecond, func(e model.Event[model.Base]) error {
+		got = append(got, fmt.Sprintf("%s:%d", e.Type, e.Resource.ID))
+		if len(got) == 4 {
+			cancel()
+		}
+		return nil
+	})
+
+	// 만료 시각이 지나 목록에서 빠진 리소스는 삭제가 아닌 만료로 알림
+	s.ErrorIs(err, context.Canceled)
+	s.Equal([]string{"added:1", "added:2", "expired:1", "deleted:2"}, got)
+}
+
+func (s *UsecaseTestSuite) TestExtend() {
+	expiresAt := time.Now().Add(time.Hour)
+	s.mockRepo.On("Extend", mock.Anything, uint(1), expiresAt).Return(&model.Base{ID: 1, ExpiresAt: &expiresAt}, nil)
+	s.mockRepo.On("Extend", mock.Anything, uint(2), expiresAt).Return((*model.Base)(nil), gorm.ErrRecordNotFound)
+
+	got, err := s.uc.Extend(context.Background(), 1, expiresAt)
+	s.NoError(err)
+	s.Require().NotNil(got.ExpiresAt)
+	s.True(expiresAt.Equal(*got.ExpiresAt))
+
+	_, err = s.uc.Extend(context.Background(), 1, time.Now().Add(-time.Second))
+	s.ErrorIs(err, ErrInvalid)
+
+	// 이미 만료돼서 조회되지 않는 리소스는 되살릴 수 없음
+	_, err = s.uc.Extend(context.Background(), 2, expiresAt)
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *UsecaseTestSuite) TestInsert_Expired() {
+	past := time.Now().Add(-time.Minute)
+	err := s.uc.Insert(context.Background(), &model.Base{Name: "임시", ExpiresAt: &past})
+	s.ErrorIs(err, ErrInvalid)
+	s.mockRepo.AssertNotCalled(s.T(), "Insert", mock.Anything, mock.Anything)
+}
+
+func (s *UsecaseTestSuite) TestPurgeExpired() {
+	now := time.Now()
+	expired := []*model.Base{{ID: 1, ExpiresAt: &now}}
+	s.mockRepo.On("Expired", mock.Anything, now).Return(expired, nil)
+	s.mockRepo.On("RemoveExpired", mock.Anything, []uint{1}, now).Return([]uint{1}, nil)
+
+	var archived []*model.Base
+	n, err := s.uc.PurgeExpired(context.Background(), now, func(ms []*model.Base) error {
+		archived = ms
+		return nil
+	})
+	s.NoError(err)
+	s.Equal(int64(1), n)
+	s.Equal(expired[:1], archived)
+
+	// 보관에 실패하면 지우지 않음
+	_, err = s.uc.PurgeExpired(context.Background(), now, func([]*model.Base) error {
+		return errors.New("디스크 가득 참")
+	})
+	s.Error(err)
+	s.mockRepo.AssertNumberOfCalls(s.T(), "RemoveExpired", 1)
+}
+
+func (s *UsecaseTestSuite) TestLabels() {
+	existing := &model.Base{ID: 1, Labels: labels.Set{"env": "dev", "tier": "a"}}
+	s.mockRepo.On("Update", mock.Anything, uint(1)).Return(existing, nil)
+	s.mockRepo.On("Update", mock.Anything, uint(2)).Return((*model.Base)(nil), gorm.ErrRecordNotFound)
+
+	got, err := s.uc.AddLabels(context.Background(), 1, labels.Set{"env": "prod", "team": "infra"})
+	s.NoError(err)
+	s.Equal(labels.Set{"env": "prod", "tier": "a", "team": "infra"}, got.Labels)
+
+	got, err = s.uc.RemoveLabels(context.Background(), 1, "tier", "없는_키")
+	s.NoError(err)
+	s.Equal(labels.Set{"env": "prod", "team": "infra"}, got.Labels)
+
+	_, err = s.uc.AddLabels(context.Background(), 2, labels.Set{"env": "prod"})
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *UsecaseTestSuite) TestSearch() {
+	hits := []model.SearchHit[model.Base]{{Resource: &model.Base{ID: 1, Name: "web"}, Rank: 0.5, Snippet: "<mark>web</mark>"}}
+	s.mockRepo.On("Search", mock.Anything, "we", 10).Return(hits, nil)
+	s.mockRepo.On("Search", mock.Anything, "db", 10).Return(([]model.SearchHit[model.Base])(nil), errors.New("연결 끊김"))
+
+	got, err := s.uc.Search(context.Background(), "we", 10)
+	s.NoError(err)
+	s.Equal(hits, got.Hits)
+	s.Empty(got.Suggestion)
+
+	_, err = s.uc.Search(context.Background(), "db", 10)
+	s.ErrorContains(err, "검색 실패")
+
+	_, err = s.uc.Search(context.Background(), "  ", 10)
+	s.ErrorIs(err, ErrInvalid)
+	s.mockRepo.AssertNumberOfCalls(s.T(), "Search", 2)
+	s.mockRepo.AssertNotCalled(s.T(), "Similar", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *UsecaseTestSuite) TestSearch_Suggestion() {
+	none := []model.SearchHit[model.Base]{}
+	s.mockRepo.On("Search", mock.Anything, mock.Anything, 10).Return(none, nil)
+	s.mockRepo.On("Similar", mock.Anything, "sevrer", similarThreshold, 1).
+		Return([]model.FuzzyMatch[model.Base]{{Resource: &model.Base{ID: 1, Name: "server"}, Score: 0.5}}, nil)
+	s.mockRepo.On("Similar", mock.Anything, "db", similarThreshold, 1).
+		Return(([]model.FuzzyMatch[model.Base])(nil), errors.New("연결 끊김"))
+
+	got, err := s.uc.Search(context.Background(), "sevrer", 10)
+	s.NoError(err)
+	s.Empty(got.Hits)
+	s.Equal("server", got.Suggestion)
+
+	// 제안을 만들지 못해도 검색은 성공
+	got, err = s.uc.Search(context.Background(), "db", 10)
+	s.NoError(err)
+	s.Empty(got.Suggestion)
+}
+
+func (s *UsecaseTestSuite) TestSimilar() {
+	matches := []model.FuzzyMatch[model.Base]{{Resource: &model.Base{ID: 1, Name: "서버"}, Score: 0.75}}
+	s.mockRepo.On("Similar", mock.Anything, "서브", similarThreshold, 5).Return(matches, nil)
+
+	got, err := s.uc.Similar(context.Background(), "서브", 5)
+	s.NoError(err)
+	s.Equal(matches, got)
+
+	_, err = s.uc.Similar(context.Background(), "", 5)
+	s.ErrorIs(err, ErrInvalid)
+}
+
+func (s *UsecaseTestSuite) TestInsert_NearDuplicateWarning() {
+	existing := []model.FuzzyMatch[model.Base]{{Resource: &model.Base{ID: 7, Name: "web-server"}, Score: 0.8}}
+	s.mockRepo.On("Similar", mock.Anything, "web-sever", nearDuplicateThreshold, maxNearDuplicates).Return(existing, nil)
+	s.mockRepo.On("Insert", mock.Anything, mock.AnythingOfType("*model.Base")).Return(nil)
+
+	ctx, warnings := WithWarnings(context.Background())
+	s.NoError(s.uc.Insert(ctx, &model.Base{Name: "web-sever"}))
+	s.Equal([]string{`비슷한 이름의 리소스가 있습니다: "web-server" (ID 7, 유사도 0.80)`}, warnings.Messages())
+
+	// 경고를 모으지 않으면 유사도 검색도 하지 않음
+	s.NoError(s.uc.Insert(context.Background(), &model.Base{Name: "web-sever"}))
+	s.mockRepo.AssertNumberOfCalls(s.T(), "Similar", 1)
+}
+
+func (s *UsecaseTestSuite) TestAttributeSchema() {
+	schema, err := attributes.ParseSchema([]byte(`{"required":["owner"],"properties":{"owner":{"type":"string"}}}`))
+	s.Require().NoError(err)
+	s.uc = NewUsecase[model.Base](s.mockRepo, WithAttributeSchema(schema))
+	s.mockRepo.On("Insert", mock.Anything, mock.AnythingOfType("*model.Base")).Return(nil)
+
+	// 검증에 실패하면 저장하지 않음
+	err = s.uc.Insert(context.Background(), &model.Base{Name: "속성_없음"})
+	s.ErrorIs(err, ErrInvalid)
+	s.Contains(err.Error(), "속성 owner: 필수입니다")
+	_, err = s.uc.BatchInsert(context.Background(), []*model.Base{
+		{Name: "정상", Attributes: attributes.Map{"owner": "infra"}},
+		{Name: "잘못된_타입", Attributes: attributes.Map{"owner": 1.0}},
+	}, model.BatchModeAtomic)
+	s.ErrorIs(err, ErrInvalid)
+	s.mockRepo.AssertNotCalled(s.T(), "Insert", mock.Anything, mock.Anything)
+	s.mockRepo.AssertNotCalled(s.T(), "BatchInsert", mock.Anything, mock.Anything)
+
+	s.NoError(s.uc.Insert(context.Background(), &model.Base{Name: "정상", Attributes: attributes.Map{"owner": "infra"}}))
+
+	report, err := s.uc.Import(context.Background(), []model.ImportRow[model.Base]{
+		{Line: 1, Resource: &model.Base{Name: "정상", Attributes: attributes.Map{"owner": "infra"}}},
+		{Line: 2, Resource: &model.Base{Name: "속성_없음"}},
+	}, model.ImportOptions{})
+	s.NoError(err)
+	s.Equal(1, report.Failed)
+	s.Equal(2, report.Errors[0].Row)
+}
+
 // 테스트 실행을 위한 엔트리 포인트
 func TestUsecaseSuite(t *testing.T) {
 	suite.Run(t, new(UsecaseTestSuite))
 }
+
+// 부분 수정은 캐시나 복제본에서 읽은 오래된 값이 아니라 잠근 최신 값을 바꾸므로 그 사이의 수정이 남음
+func TestBatchModify_ParentCycle(t *testing.T) {
+	ctx := context.Background()
+	rec := recorder.NewMemoryRecorder[model.Base]()
+	uc := NewUsecase(repository.NewRepository(rec))
+
+	a := &model.Base{Name: "a"}
+	require.NoError(t, uc.Insert(ctx, a))
+	b := &model.Base{Name: "b"}
+	require.NoError(t, uc.Insert(ctx, b))
+	c := &model.Base{Name: "c", ParentID: &b.ID}
+	require.NoError(t, uc.Insert(ctx, c))
+
+	// 항목마다는 저장된 트리에서 문제가 없지만 함께 반영하면 a → c → b → a 순환
+	_, err := uc.BatchModify(ctx, []*model.Base{
+		{ID: a.ID, Name: "a", ParentID: &c.ID},
+		{ID: b.ID, Name: "b", ParentID: &a.ID},
+	}, model.BatchModeAtomic)
+	require.ErrorIs(t, err, ErrInvalid)
+
+	got, err := uc.Get(ctx, a.ID)
+	require.NoError(t, err)
+	require.Nil(t, got.ParentID, "atomic 모드는 하나라도 실패하면 아무것도 수정하지 않음")
+
+	// 순환이 없으면 함께 옮길 수 있음 (b를 a 아래로, a는 최상위 유지)
+	_, err = uc.BatchModify(ctx, []*model.Base{
+		{ID: b.ID, Name: "b", ParentID: &a.ID},
+		{ID: c.ID, Name: "c", ParentID: &a.ID},
+	}, model.BatchModeAtomic)
+	require.NoError(t, err)
+}
+
+func TestUpdate_StaleReader(t *testing.T) {
+	ctx := context.Background()
+	rec := recorder.NewMemoryRecorder[model.Base]()
+	uc := NewUsecase(repository.NewCachingRepository(repository.NewRepository(rec)))
+
+	m := &model.Base{Name: "web"}
+	require.NoError(t, uc.Insert(ctx, m))
+	parent := &model.Base{Name: "group"}
+	require.NoError(t, uc.Insert(ctx, parent))
+
+	// 캐시에 올린 뒤 캐시를 거치지 않고 수정 (다른 서버의 수정이나 복제 지연으로 오래된 값을 읽는 상황)
+	_, err := uc.Get(ctx, m.ID)
+	require.NoError(t, err)
+	require.NoError(t, rec.Modify(ctx, &model.Base{ID: m.ID, Name: "web-2", CreatedAt: m.CreatedAt}))
+	stale, err := uc.Get(ctx, m.ID)
+	require.NoError(t, err)
+	require.Equal(t, "web", stale.Name)
+
+	got, err := uc.AddLabels(ctx, m.ID, labels.Set{"env": "prod"})
+	require.NoError(t, err)
+	require.Equal(t, "web-2", got.Name)
+
+	got, err = uc.Move(ctx, m.ID, &parent.ID)
+	require.NoError(t, err)
+	require.Equal(t, "prod", got.Labels["env"])
+
+	// 수정 중에 들어온 다른 수정은 앞의 수정이 저장될 때까지 기다렸다가 그 결과 위에 반영됨
+	editing, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		_, err := uc.Update(ctx, m.ID, func(_ context.Context, m *model.Base) error {
+			close(editing)
+			<-release
+			m.Name = "web-3"
+			return nil
+		})
+		done <- err
+	}()
+	<-editing
+	labeled := make(chan error, 1)
+	go func() {
+		_, err := uc.AddLabels(ctx, m.ID, labels.Set{"tier": "a"})
+		labeled <- err
+	}()
+	close(release)
+	require.NoError(t, <-done)
+	require.NoError(t, <-labeled)
+
+	got, err = uc.Get(ctx, m.ID)
+	require.NoError(t, err)
+	require.Equal(t, "web-3", got.Name)
+	require.Equal(t, labels.Set{"env": "prod", "tier": "a"}, got.Labels)
+	require.Equal(t, parent.ID, *got.ParentID)
+
+	// 검증에 실패하면 저장하지 않음
+	_, err = uc.Update(ctx, m.ID, func(_ context.Context, m *model.Base) error {
+		m.ParentID = &m.ID
+		return nil
+	})
+	require.ErrorIs(t, err, ErrInvalid)
+	_, err = uc.Update(ctx, 999, func(context.Context, *model.Base) error { return nil })
+	require.ErrorIs(t, err, ErrNotFound)
+}
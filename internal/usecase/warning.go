@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Warnings는 요청은 처리했지만 호출자에게 알려야 할 내용을 모음 (예: 비슷한 이름의 리소스가 이미 있음)
+type Warnings struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+type warningsKey struct{}
+
+// WithWarnings는 경고를 모으는 ctx를 반환
+// 이 ctx로 호출한 Usecase 메서드의 경고가 w에 쌓이고, 모으지 않는 ctx에서는 경고를 확인하는 작업도 생략함
+func WithWarnings(ctx context.Context) (context.Context, *Warnings) {
+	w := &Warnings{}
+	return context.WithValue(ctx, warningsKey{}, w), w
+}
+
+// Messages는 지금까지 쌓인 경고를 순서대로 반환
+func (w *Warnings) Messages() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.messages...)
+}
+
+func warningsFrom(ctx context.Context) *Warnings {
+	w, _ := ctx.Value(warningsKey{}).(*Warnings)
+	return w
+}
+
+// warn은 ctx에 경고를 모으는 곳이 있으면 경고를 추가
+func warn(ctx context.Context, format string, args ...any) {
+	if w := warningsFrom(ctx); w != nil {
+		w.mu.Lock()
+		w.messages = append(w.messages, fmt.Sprintf(format, args...))
+		w.mu.Unlock()
+	}
+}
@@ -0,0 +1,369 @@
+// Package client는 /api/v1/resources API의 Go 클라이언트
+//
+// 공통 응답 구조({status, message, data})를 풀어서 타입이 있는 값으로 돌려주고,
+// 실패 응답은 *APIError(errors.Is로 ErrNotFound 등과 비교 가능)로 변환
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Resource는 API가 주고받는 리소스
+type Resource struct {
+	ID         uint              `json:"id" yaml:"id"`
+	Name       string            `json:"name" yaml:"name"`
+	ParentID   *uint             `json:"parent_id,omitempty" yaml:"parent_id,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Attributes map[string]any    `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	CreatedAt  time.Time         `json:"created_at" yaml:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at" yaml:"updated_at"`
+}
+
+// Page는 목록 조회 한 페이지의 결과
+type Page struct {
+	Items []*Resource
+	Total int64
+	Page  int
+	Size  int
+}
+
+// HasNext는 다음 페이지가 있는지 반환
+func (p *Page) HasNext() bool {
+	return int64(p.Page*p.Size) < p.Total
+}
+
+// ListOptions는 목록 조회 조건 (0이면 서버 기본값)
+type ListOptions struct {
+	Page int
+	Size int
+}
+
+// PatchRequest는 부분 수정할 필드 (nil인 필드는 보내지 않음)
+type PatchRequest struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// 공통 응답 구조
+type envelope struct {
+	Status  int             `json:"status"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+const apiPath = "/api/v1/resources"
+
+// Client는 리소스 API 클라이언트
+// 여러 고루틴에서 동시에 사용해도 안전함
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+	timeout    time.Duration
+	maxRetries int
+	retryWait  time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient는 요청에 사용할 http.Client를 지정
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithToken은 모든 요청에 Authorization: Bearer 헤더를 붙임
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithTimeout은 재시도를 포함하지 않은 요청 한 번의 제한 시간을 지정
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithRetries는 네트워크 오류, 429, 5xx 응답 시 재시도 횟수와 첫 대기 시간을 지정
+// 대기 시간은 재시도마다 두 배로 늘고 지터가 더해지며, Retry-After 헤더가 있으면 그 값을 따름
+// POST처럼 멱등하지 않은 요청은 재시도하지 않음
+func WithRetries(n int, wait time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = n
+		c.retryWait = wait
+	}
+}
+
+// New는 baseURL(예: http://localhost:8080)의 서버에 요청하는 클라이언트를 생성
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		timeout:    30 * time.Second,
+		retryWait:  200 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// List는 한 페이지를 조회
+func (c *Client) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	query := url.Values{}
+	page := max(opts.Page, 1)
+	query.Set("page", strconv.Itoa(page))
+	if opts.Size > 0 {
+		query.Set("size", strconv.Itoa(opts.Size))
+	}
+
+	var items []*Resource
+	header, err := c.do(ctx, http.MethodGet, apiPath+"?"+query.Encode(), nil, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	total, _ := strconv.ParseInt(header.Get("X-Total-Count"), 10, 64)
+	size := opts.Size
+	if size <= 0 {
+		size = max(len(items), 1)
+	}
+	return &Page{Items: items, Total: total, Page: page, Size: size}, nil
+}
+
+// Iterate는 size 단위로 페이지를 넘기며 모든 리소스를 순회하는 Iterator를 생성
+func (c *Client) Iterate(ctx context.Context, size int) *Iterator {
+	return &Iterator{client: c, ctx: ctx, size: size}
+}
+
+func (c *Client) Get(ctx context.Context, id uint) (*Resource, error) {
+	var r Resource
+	if _, err := c.do(ctx, http.MethodGet, fmt.Sprintf("%s/%d", apiPath, id), nil, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (c *Client) Create(ctx context.Context, r *Resource) (*Resource, error) {
+	var created Resource
+	if _, err := c.do(ctx, http.MethodPost, apiPath, r, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *Client) Update(ctx context.Context, id uint, r *Resource) (*Resource, error) {
+	var updated Resource
+	if _, err := c.do(ctx, http.MethodPut, fmt.Sprintf("%s/%d", apiPath, id), r, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (c *Client) Patch(ctx context.Context, id uint, p *PatchRequest) (*Resource, error) {
+	var patched Resource
+	if _, err := c.do(ctx, http.MethodPatch, fmt.Sprintf("%s/%d", apiPath, id), p, &patched); err != nil {
+		return nil, err
+	}
+	return &patched, nil
+}
+
+func (c *Client) Delete(ctx context.Context, id uint) error {
+	_, err := c.do(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", apiPath, id), nil, nil)
+	return err
+}
+
+// do는 요청을 보내고 공통 응답 구조의 data를 out에 디코딩
+// 재시도 가능한 실패는 WithRetries 설정에 따라 다시 요청
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) (http.Header, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("요청 본문 인코딩 실패: %w", err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		header, retryAfter, err := c.once(ctx, method, path, payload, out)
+		if err == nil || attempt >= c.maxRetries || !retryable(method, err) {
+			return header, err
+		}
+
+		wait := retryAfter
+		if wait == 0 {
+			wait = c.backoff(attempt)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// once는 요청을 한 번 보냄
+// 서버가 Retry-After를 보냈으면 그 대기 시간을 함께 반환
+func (c *Client) once(ctx context.Context, method, path string, payload []byte, out interface{}) (http.Header, time.Duration, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+	req, err := c.newRequest(ctx, method, path, reader)
+	if err != nil {
+		return nil, 0, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := decodeResponse(resp, out); err != nil {
+		return resp.Header, retryAfter(resp.Header), err
+	}
+	return resp.Header, 0, nil
+}
+
+// newRequest는 JSON 응답과 인증 헤더를 설정한 요청을 생성
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
+
+// decodeResponse는 공통 응답 구조의 data를 out에 디코딩
+// 2xx가 아니면 *APIError를 반환하며, 실패 응답에도 data가 있으면 out에 채움
+func decodeResponse(resp *http.Response, out interface{}) error {
+	var env envelope
+	decodeErr := json.NewDecoder(resp.Body).Decode(&env)
+	if decodeErr == nil && out != nil && len(env.Data) > 0 && string(env.Data) != "null" {
+		if err := json.Unmarshal(env.Data, out); err != nil && resp.StatusCode < 300 {
+			return fmt.Errorf("응답 데이터 디코딩 실패: %w", err)
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: env.Message}
+		if decodeErr != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("응답 디코딩 실패: %w", decodeErr)
+	}
+	return nil
+}
+
+// 재시도해도 같은 결과가 보장되는 메서드만 재시도
+func retryable(method string, err error) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	// 호출자가 취소한 경우는 재시도하지 않음 (요청 한 번의 제한 시간 초과는 재시도)
+	return !errors.Is(err, context.Canceled)
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	wait := c.retryWait << attempt
+	return wait + time.Duration(rand.Int63n(int64(wait)/2+1))
+}
+
+func retryAfter(h http.Header) time.Duration {
+	if secs, err := strconv.Atoi(h.Get("Retry-After")); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return 0
+}
+
+// Iterator는 페이지를 차례로 조회하며 리소스를 하나씩 돌려줌
+//
+//	it := c.Iterate(ctx, 50)
+//	for it.Next() {
+//		r := it.Resource()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator struct {
+	client *Client
+	ctx    context.Context
+	size   int
+
+	page    *Page
+	index   int
+	current *Resource
+	err     error
+	done    bool
+}
+
+// Next는 다음 리소스로 이동하고, 더 없거나 에러가 나면 false를 반환
+func (it *Iterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	for it.page == nil || it.index >= len(it.page.Items) {
+		if it.page != nil && (!it.page.HasNext() || len(it.page.Items) == 0) {
+			it.done = true
+			return false
+		}
+		next := 1
+		if it.page != nil {
+			next = it.page.Page + 1
+		}
+		page, err := it.client.List(it.ctx, ListOptions{Page: next, Size: it.size})
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page, it.index = page, 0
+	}
+
+	it.current = it.page.Items[it.index]
+	it.index++
+	return true
+}
+
+func (it *Iterator) Resource() *Resource {
+	return it.current
+}
+
+func (it *Iterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go_project/internal/handler"
+	"go_project/internal/model"
+	"go_project/internal/recorder"
+	"go_project/internal/repository"
+	"go_project/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// ClientTestSuite는 메모리 Recorder 위에 실제 핸들러를 띄워 클라이언트를 검증
+type ClientTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+	client *Client
+	auth   string
+}
+
+func (s *ClientTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	uc := usecase.NewUsecase(repository.NewRepository(recorder.NewMemoryRecorder[model.Base]()))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		s.auth = c.GetHeader("Authorization")
+	})
+	handler.NewHandler(uc).RegisterAPIRoutes(router)
+
+	s.server = httptest.NewServer(router)
+	s.client = New(s.server.URL, WithToken("secret"))
+}
+
+func (s *ClientTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ClientTestSuite) TestCRUD() {
+	ctx := context.Background()
+
+	created, err := s.client.Create(ctx, &Resource{Name: "테스트_데이터"})
+	s.NoError(err)
+	s.NotZero(created.ID)
+	s.Equal("테스트_데이터", created.Name)
+	s.Equal("Bearer secret", s.auth)
+
+	got, err := s.client.Get(ctx, created.ID)
+	s.NoError(err)
+	s.Equal(created.Name, got.Name)
+
+	updated, err := s.client.Update(ctx, created.ID, &Resource{Name: "수정된_데이터"})
+	s.NoError(err)
+	s.Equal(created.ID, updated.ID)
+	s.Equal("수정된_데이터", updated.Name)
+
+	name := "패치된_데이터"
+	patched, err := s.client.Patch(ctx, created.ID, &PatchRequest{Name: &name})
+	s.NoError(err)
+	s.Equal(name, patched.Name)
+
+	s.NoError(s.client.Delete(ctx, created.ID))
+
+	_, err = s.client.Get(ctx, created.ID)
+	s.True(errors.Is(err, ErrNotFound))
+	var apiErr *APIError
+	s.True(errors.As(err, &apiErr))
+	s.Equal(http.StatusNotFound, apiErr.StatusCode)
+	s.Equal("리소스를 찾을 수 없습니다", apiErr.Message)
+}
+
+func (s *ClientTestSuite) TestListAndIterate() {
+	ctx := context.Background()
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		_, err := s.client.Create(ctx, &Resource{Name: name})
+		s.NoError(err)
+	}
+
+	page, err := s.client.List(ctx, ListOptions{Page: 2, Size: 2})
+	s.NoError(err)
+	s.Equal(int64(5), page.Total)
+	s.Len(page.Items, 2)
+	s.Equal("c", page.Items[0].Name)
+	s.True(page.HasNext())
+
+	var names []string
+	it := s.client.Iterate(ctx, 2)
+	for it.Next() {
+		names = append(names, it.Resource().Name)
+	}
+	s.NoError(it.Err())
+	s.Equal([]string{"a", "b", "c", "d", "e"}, names)
+}
+
+func (s *ClientTestSuite) TestBadRequest() {
+	// 숫자가 아닌 ID
+	_, err := s.client.do(context.Background(), http.MethodGet, apiPath+"/abc", nil, nil)
+	s.True(errors.Is(err, ErrBadRequest))
+	s.False(errors.Is(err, ErrNotFound))
+}
+
+func (s *ClientTestSuite) TestImportExport() {
+	ctx := context.Background()
+
+	report, err := s.client.Import(ctx, "resources.csv", strings.NewReader("name\na\nb\n"), ImportOptions{})
+	s.NoError(err)
+	s.Equal(2, report.Created)
+
+	// 실패한 행이 있으면 보고서와 에러를 함께 반환
+	report, err = s.client.Import(ctx, "resources.csv", strings.NewReader("name\nc\n\n,\n"), ImportOptions{})
+	s.True(errors.Is(err, ErrUnprocessable))
+	s.NotNil(report)
+	s.Equal(1, report.Failed)
+
+	var buf bytes.Buffer
+	s.NoError(s.client.Export(ctx, "ndjson", &buf))
+	s.Equal(2, strings.Count(buf.String(), "\n"))
+	s.Contains(buf.String(), `"name":"b"`)
+
+	s.True(errors.Is(s.client.Export(ctx, "xlsx", &buf), ErrBadRequest))
+}
+
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+func TestRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":200,"message":"성공","data":{"id":1,"name":"a"}}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRetries(3, time.Millisecond))
+	r, err := c.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("재시도 후 성공해야 함: %v", err)
+	}
+	if r.Name != "a" || atomic.LoadInt32(&calls) != 3 {
+		t.Fatalf("결과 %+v, 호출 %d회", r, calls)
+	}
+
+	// POST는 재시도하지 않음
+	atomic.StoreInt32(&calls, 0)
+	_, err = c.Create(context.Background(), &Resource{Name: "a"})
+	if !errors.Is(err, ErrServer) || atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("에러 %v, 호출 %d회", err, calls)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithTimeout(20*time.Millisecond))
+	_, err := c.Get(context.Background(), 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("제한 시간 초과 에러가 나야 함: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// 응답 상태 코드별 에러 종류 (errors.Is로 확인)
+var (
+	ErrBadRequest       = errors.New("잘못된 요청")
+	ErrUnauthorized     = errors.New("인증 실패")
+	ErrForbidden        = errors.New("권한 없음")
+	ErrNotFound         = errors.New("리소스를 찾을 수 없습니다")
+	ErrConflict         = errors.New("충돌")
+	ErrUnprocessable    = errors.New("처리할 수 없는 요청")
+	ErrTooManyRequests  = errors.New("요청 한도 초과")
+	ErrServer           = errors.New("서버 오류")
+	ErrUnexpectedStatus = errors.New("예상하지 못한 응답")
+)
+
+// APIError는 서버가 2xx가 아닌 상태 코드로 응답했을 때의 에러
+// 공통 응답 구조의 message를 그대로 담음
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API 오류 (%d): %s", e.StatusCode, e.Message)
+}
+
+// Is는 상태 코드에 해당하는 에러 종류와 비교
+// 예: errors.Is(err, client.ErrNotFound)
+func (e *APIError) Is(target error) bool {
+	return kindOf(e.StatusCode) == target
+}
+
+func kindOf(status int) error {
+	switch {
+	case status == http.StatusBadRequest,
+		status == http.StatusNotAcceptable,
+		status == http.StatusUnsupportedMediaType:
+		return ErrBadRequest
+	case status == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case status == http.StatusForbidden:
+		return ErrForbidden
+	case status == http.StatusNotFound:
+		return ErrNotFound
+	case status == http.StatusConflict:
+		return ErrConflict
+	case status == http.StatusUnprocessableEntity:
+		return ErrUnprocessable
+	case status == http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case status >= 500:
+		return ErrServer
+	}
+	return ErrUnexpectedStatus
+}
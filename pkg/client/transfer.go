@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path/filepath"
+)
+
+// ImportOptions는 가져오기 옵션
+// Format이 비어 있으면 서버가 파일 이름의 확장자로 형식을 판단
+type ImportOptions struct {
+	Format string
+	DryRun bool
+	Upsert bool
+}
+
+// ImportError는 가져오기에 실패한 행과 이유
+type ImportError struct {
+	Row     int    `json:"row" yaml:"row"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// ImportReport는 가져오기 결과
+type ImportReport struct {
+	DryRun  bool          `json:"dry_run" yaml:"dry_run"`
+	Total   int           `json:"total" yaml:"total"`
+	Created int           `json:"created" yaml:"created"`
+	Updated int           `json:"updated" yaml:"updated"`
+	Failed  int           `json:"failed" yaml:"failed"`
+	Errors  []ImportError `json:"errors" yaml:"errors"`
+}
+
+// Export는 모든 리소스를 format(csv, ndjson, json) 형식으로 w에 기록
+// 응답을 그대로 흘려보내므로 데이터 양과 관계없이 메모리를 적게 사용함
+func (c *Client) Export(ctx context.Context, format string, w io.Writer) error {
+	req, err := c.newRequest(ctx, http.MethodGet, apiPath+"/export?format="+url.QueryEscape(format), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Del("Accept")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decodeResponse(resp, nil)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("내보내기 응답 읽기 실패: %w", err)
+	}
+	return nil
+}
+
+// Import는 r의 내용을 filename이라는 파일로 업로드해서 리소스를 가져옴
+// 일부 행이 실패하면 보고서와 함께 ErrUnprocessable에 해당하는 *APIError를 반환
+// 같은 요청을 다시 보내면 결과가 달라질 수 있으므로 재시도하지 않음
+func (c *Client) Import(ctx context.Context, filename string, r io.Reader, opts ImportOptions) (*ImportReport, error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	// 파일을 메모리에 모두 올리지 않도록 파이프로 본문을 만들면서 전송
+	go func() {
+		pw.CloseWithError(writeImportForm(mw, filename, r, opts))
+	}()
+
+	req, err := c.newRequest(ctx, http.MethodPost, apiPath+"/import", pr)
+	if err != nil {
+		pr.Close()
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var report ImportReport
+	if err := decodeResponse(resp, &report); err != nil {
+		if report.Total > 0 {
+			return &report, err
+		}
+		return nil, err
+	}
+	return &report, nil
+}
+
+func writeImportForm(mw *multipart.Writer, filename string, r io.Reader, opts ImportOptions) error {
+	fields := map[string]string{
+		"format":  opts.Format,
+		"dry_run": fmt.Sprint(opts.DryRun),
+		"upsert":  fmt.Sprint(opts.Upsert),
+	}
+	for name, value := range fields {
+		if value == "" {
+			continue
+		}
+		if err := mw.WriteField(name, value); err != nil {
+			return err
+		}
+	}
+
+	part, err := mw.CreateFormFile("file", filepath.Base(filename))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return err
+	}
+	return mw.Close()
+}
@@ -2,13 +2,22 @@ const API_BASE_URL = 'http://localhost:8080/api/v1';
 
 // API 호출 함수들
 const api = {
-    // 목록 조회
-    async listResources() {
-        const response = await fetch(`${API_BASE_URL}/resources`);
+    // 목록 조회 (selector가 있으면 라벨 셀렉터로 필터링)
+    async listResources(selector = '') {
+        const query = selector ? `?labelSelector=${encodeURIComponent(selector)}` : '';
+        const response = await fetch(`${API_BASE_URL}/resources${query}`);
         if (!response.ok) throw new Error('리소스 목록 조회 실패');
         return response.json();
     },
 
+    // 이름 전문 검색 (관련도 순, snippet의 일치한 단어는 <mark>로 감싸져 있음)
+    async searchResources(query, limit = 20) {
+        const params = new URLSearchParams({ q: query, limit });
+        const response = await fetch(`${API_BASE_URL}/resources/search?${params}`);
+        if (!response.ok) throw new Error('리소스 검색 실패');
+        return response.json();
+    },
+
     // 단일 리소스 조회
     async getResource(id) {
         const response = await fetch(`${API_BASE_URL}/resources/${id}`);
@@ -42,12 +51,80 @@ const api = {
         return response.json();
     },
 
-    // 리소스 삭제
-    async deleteResource(id) {
+    // 리소스 부분 수정 (본문에 있는 필드만 변경)
+    async patchResource(id, data) {
         const response = await fetch(`${API_BASE_URL}/resources/${id}`, {
+            method: 'PATCH',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify(data),
+        });
+        if (!response.ok) throw new Error('리소스 수정 실패');
+        return response.json();
+    },
+
+    // 라벨 추가 (같은 키는 덮어씀)
+    async addLabels(id, labels) {
+        const response = await fetch(`${API_BASE_URL}/resources/${id}/labels`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify(labels),
+        });
+        if (!response.ok) throw new Error('라벨 추가 실패');
+        return response.json();
+    },
+
+    // 라벨 삭제 (접두사의 /는 경로에 그대로 둠)
+    async removeLabel(id, key) {
+        const path = key.split('/').map(encodeURIComponent).join('/');
+        const response = await fetch(`${API_BASE_URL}/resources/${id}/labels/${path}`, {
+            method: 'DELETE',
+        });
+        if (!response.ok) throw new Error('라벨 삭제 실패');
+        return response.json();
+    },
+
+    // 리소스 이동 (parentId가 null이면 최상위로)
+    async moveResource(id, parentId) {
+        const response = await fetch(`${API_BASE_URL}/resources/${id}/move`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({ parent_id: parentId }),
+        });
+        if (!response.ok) throw new Error('리소스 이동 실패');
+        return response.json();
+    },
+
+    // 만료 시각 변경 (ttl은 지금부터 남길 시간, 예: 30m, 24h)
+    async extendResource(id, ttl) {
+        const response = await fetch(`${API_BASE_URL}/resources/${id}/extend`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({ ttl }),
+        });
+        if (!response.ok) throw new Error('만료 시각 변경 실패');
+        return response.json();
+    },
+
+    // 리소스 삭제 (cascade면 하위 리소스까지 삭제)
+    // 하위 리소스가 있어 삭제할 수 없으면 status가 409인 에러를 던짐
+    async deleteResource(id, cascade = false) {
+        const query = cascade ? '?cascade=true' : '';
+        const response = await fetch(`${API_BASE_URL}/resources/${id}${query}`, {
             method: 'DELETE',
         });
-        if (!response.ok) throw new Error('리소스 삭제 실패');
+        if (!response.ok) {
+            const error = new Error('리소스 삭제 실패');
+            error.status = response.status;
+            throw error;
+        }
         return response.json();
     },
 }; 
\ No newline at end of file
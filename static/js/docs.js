@@ -0,0 +1,189 @@
+// OpenAPI 문서를 읽어서 엔드포인트 목록을 렌더링
+const OPENAPI_URL = '/api/v1/openapi.json';
+
+let spec = null;
+
+async function loadSpec() {
+    try {
+        const response = await fetch(OPENAPI_URL);
+        if (!response.ok) throw new Error('API 문서 조회 실패');
+        spec = await response.json();
+        renderSpec();
+    } catch (error) {
+        showError(error.message);
+    }
+}
+
+function renderSpec() {
+    document.getElementById('docTitle').textContent = `${spec.info.title} (${spec.info.version})`;
+    document.getElementById('docDescription').textContent = spec.info.description || '';
+
+    const container = document.getElementById('operations');
+    container.innerHTML = '';
+
+    Object.keys(spec.paths).sort().forEach(path => {
+        Object.entries(spec.paths[path]).forEach(([method, operation]) => {
+            container.appendChild(createOperation(path, method, operation));
+        });
+    });
+}
+
+function createOperation(path, method, operation) {
+    const div = document.createElement('div');
+    div.className = 'operation';
+
+    const header = document.createElement('div');
+    header.className = 'operation-header';
+    header.innerHTML = `
+        <span class="method method-${method}">${method.toUpperCase()}</span>
+        <span class="path">${escapeHTML(path)}</span>
+        - ${escapeHTML(operation.summary || '')}
+    `;
+    header.onclick = () => div.classList.toggle('open');
+
+    const body = document.createElement('div');
+    body.className = 'operation-body';
+    body.appendChild(createParameters(operation));
+    if (operation.requestBody) {
+        body.appendChild(createSection('요청 본문', operation.requestBody.content));
+    }
+    body.appendChild(createResponses(operation.responses));
+    body.appendChild(createTryIt(path, method, operation));
+
+    div.appendChild(header);
+    div.appendChild(body);
+    return div;
+}
+
+function createParameters(operation) {
+    const div = document.createElement('div');
+    if (!operation.parameters || operation.parameters.length === 0) return div;
+
+    const rows = operation.parameters.map(p => `
+        <tr>
+            <td>${escapeHTML(p.name)}${p.required ? ' *' : ''}</td>
+            <td>${p.in}</td>
+            <td>${p.schema.type || ''}${p.schema.enum ? ` (${p.schema.enum.join(', ')})` : ''}</td>
+            <td>${escapeHTML(p.description || '')}</td>
+        </tr>
+    `).join('');
+    div.innerHTML = `
+        <h4>파라미터</h4>
+        <table>
+            <thead><tr><th>이름</th><th>위치</th><th>타입</th><th>설명</th></tr></thead>
+            <tbody>${rows}</tbody>
+        </table>
+    `;
+    return div;
+}
+
+function createSection(title, content) {
+    const div = document.createElement('div');
+    const mimeTypes = Object.keys(content || {});
+    if (mimeTypes.length === 0) return div;
+
+    div.innerHTML = `<h4>${title}</h4><p>${mimeTypes.join(', ')}</p>`;
+    const pre = document.createElement('pre');
+    pre.textContent = JSON.stringify(resolveSchema(content[mimeTypes[0]].schema), null, 2);
+    div.appendChild(pre);
+    return div;
+}
+
+function createResponses(responses) {
+    const div = document.createElement('div');
+    div.innerHTML = '<h4>응답</h4>';
+    Object.entries(responses).forEach(([status, response]) => {
+        div.appendChild(createSection(`${status} ${response.description}`, response.content));
+        if (!response.content) {
+            const p = document.createElement('p');
+            p.textContent = `${status} ${response.description}`;
+            div.appendChild(p);
+        }
+    });
+    return div;
+}
+
+// 요청을 직접 보내보는 폼 (경로/쿼리 파라미터와 JSON 본문)
+function createTryIt(path, method, operation) {
+    const div = document.createElement('div');
+    div.innerHTML = '<h4>실행해 보기</h4>';
+
+    const inputs = {};
+    (operation.parameters || []).forEach(p => {
+        const input = document.createElement('input');
+        input.placeholder = `${p.name} (${p.in})`;
+        inputs[p.name] = { param: p, input };
+        div.appendChild(input);
+    });
+
+    let bodyInput = null;
+    const content = operation.requestBody && operation.requestBody.content;
+    if (content && content['application/json']) {
+        bodyInput = document.createElement('textarea');
+        bodyInput.rows = 5;
+        bodyInput.style.width = '100%';
+        bodyInput.placeholder = 'JSON 요청 본문';
+        div.appendChild(bodyInput);
+    }
+
+    const button = document.createElement('button');
+    button.className = 'btn';
+    button.textContent = '요청';
+    const result = document.createElement('pre');
+    button.onclick = async () => {
+        let url = path;
+        const query = new URLSearchParams();
+        Object.values(inputs).forEach(({ param, input }) => {
+            if (!input.value) return;
+            if (param.in === 'path') url = url.replace(`{${param.name}}`, encodeURIComponent(input.value));
+            if (param.in === 'query') query.set(param.name, input.value);
+        });
+        if (query.toString()) url += `?${query}`;
+
+        const options = { method: method.toUpperCase(), headers: {} };
+        if (bodyInput && bodyInput.value) {
+            options.headers['Content-Type'] = 'application/json';
+            options.body = bodyInput.value;
+        }
+        try {
+            const response = await fetch(url, options);
+            result.textContent = `${response.status}\n${await response.text()}`;
+        } catch (error) {
+            result.textContent = error.message;
+        }
+    };
+    div.appendChild(button);
+    div.appendChild(result);
+    return div;
+}
+
+// $ref를 components.schemas의 실제 스키마로 치환 (순환 참조는 한 번만 펼침)
+function resolveSchema(schema, seen = new Set()) {
+    if (!schema || typeof schema !== 'object') return schema;
+    if (schema.$ref) {
+        const name = schema.$ref.split('/').pop();
+        if (seen.has(name)) return { $ref: schema.$ref };
+        return resolveSchema(spec.components.schemas[name], new Set([...seen, name]));
+    }
+    const resolved = Array.isArray(schema) ? [] : {};
+    Object.entries(schema).forEach(([key, value]) => {
+        resolved[key] = resolveSchema(value, seen);
+    });
+    return resolved;
+}
+
+function escapeHTML(text) {
+    const div = document.createElement('div');
+    div.textContent = text;
+    return div.innerHTML;
+}
+
+function showError(message) {
+    const errorDiv = document.createElement('div');
+    errorDiv.className = 'error';
+    errorDiv.textContent = message;
+    document.body.appendChild(errorDiv);
+    setTimeout(() => errorDiv.remove(), 3000);
+}
+
+document.addEventListener('DOMContentLoaded', loadSpec);
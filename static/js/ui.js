@@ -1,9 +1,11 @@
 // UI 관련 함수들
 async function loadResources() {
     try {
-        const response = await api.listResources();
+        const selector = document.getElementById('labelSelector').value.trim();
+        const response = await api.listResources(selector);
         const tableBody = document.getElementById('resourceTableBody');
         tableBody.innerHTML = '';
+        renderTree(response.data || []);
 
         if (response.data && response.data.length > 0) {
             response.data.forEach(resource => {
@@ -11,7 +13,7 @@ async function loadResources() {
                 tableBody.appendChild(row);
             });
         } else {
-            tableBody.innerHTML = '<tr><td colspan="5" style="text-align: center;">리소스가 없습니다.</td></tr>';
+            tableBody.innerHTML = '<tr><td colspan="8" style="text-align: center;">리소스가 없습니다.</td></tr>';
         }
     } catch (error) {
         showError(error.message);
@@ -30,24 +32,184 @@ function formatDate(dateString) {
     }).format(date);
 }
 
+function escapeHTML(value) {
+    const div = document.createElement('div');
+    div.textContent = value;
+    return div.innerHTML;
+}
+
+// 라벨을 키 순으로 칩으로 표시 (칩의 ×를 누르면 라벨 삭제)
+function createLabelChips(resource) {
+    const labels = resource.labels || {};
+    return Object.keys(labels).sort().map(key => `
+        <span class="chip">${escapeHTML(key)}=${escapeHTML(labels[key])}<button class="chip-remove" data-key="${escapeHTML(key)}" onclick="removeLabel(${resource.id}, this.dataset.key)">×</button></span>
+    `).join('');
+}
+
+// 목록의 parent_id로 트리를 만들어 접고 펼 수 있는 목록으로 표시
+function renderTree(resources) {
+    const container = document.getElementById('resourceTree');
+    const ids = new Set(resources.map(resource => resource.id));
+    const children = new Map();
+    resources.forEach(resource => {
+        // 부모가 목록에 없으면(셀렉터로 걸러진 경우 등) 최상위로 표시
+        const parentId = resource.parent_id && ids.has(resource.parent_id) ? resource.parent_id : 0;
+        if (!children.has(parentId)) children.set(parentId, []);
+        children.get(parentId).push(resource);
+    });
+
+    container.innerHTML = '';
+    if (!children.has(0)) {
+        container.textContent = '리소스가 없습니다.';
+        return;
+    }
+    container.appendChild(createTreeList(0, children));
+}
+
+function createTreeList(parentId, children) {
+    const ul = document.createElement('ul');
+    children.get(parentId).forEach(resource => {
+        const li = document.createElement('li');
+        const label = `${escapeHTML(resource.name || 'Unnamed Resource')} <span class="tree-id">#${resource.id}</span>`;
+        if (children.has(resource.id)) {
+            li.innerHTML = `<details open><summary>${label}</summary></details>`;
+            li.firstChild.appendChild(createTreeList(resource.id, children));
+        } else {
+            li.innerHTML = `<span class="tree-leaf">${label}</span>`;
+        }
+        ul.appendChild(li);
+    });
+    return ul;
+}
+
 function createResourceRow(resource) {
     const tr = document.createElement('tr');
     tr.innerHTML = `
         <td>${resource.id}</td>
         <td>${resource.name || 'Unnamed Resource'}</td>
+        <td>${resource.parent_id || '-'}</td>
+        <td class="labels">${createLabelChips(resource)}</td>
         <td>${formatDate(resource.created_at)}</td>
         <td>${formatDate(resource.updated_at)}</td>
+        <td class="expires" data-expires-at="${resource.expires_at || ''}" title="${resource.expires_at ? formatDate(resource.expires_at) : ''}">-</td>
         <td class="actions">
+            <button onclick="addLabel(${resource.id})" class="btn btn-primary">라벨</button>
+            <button onclick="extendResource(${resource.id})" class="btn btn-primary">연장</button>
             <button onclick="editResource(${resource.id})" class="btn btn-warning">수정</button>
+            <button onclick="moveResource(${resource.id})" class="btn btn-warning">이동</button>
             <button onclick="deleteResource(${resource.id})" class="btn btn-danger">삭제</button>
         </td>
     `;
+    updateCountdown(tr.querySelector('.expires'), Date.now());
     return tr;
 }
 
+// 만료까지 이 시간(ms) 이하로 남으면 강조 표시
+const EXPIRY_SOON_MS = 10 * 60 * 1000;
+
+// 만료 칸에 남은 시간을 표시하고, 만료된 리소스가 있으면 true를 반환
+function updateCountdown(cell, now) {
+    if (!cell.dataset.expiresAt) return false;
+    const remaining = new Date(cell.dataset.expiresAt).getTime() - now;
+    cell.classList.toggle('soon', remaining <= EXPIRY_SOON_MS);
+    if (remaining <= 0) {
+        cell.textContent = '만료됨';
+        return true;
+    }
+    cell.textContent = formatRemaining(remaining);
+    return false;
+}
+
+// 남은 시간을 "1일 2:03:04" 형식으로 변환
+function formatRemaining(ms) {
+    const total = Math.floor(ms / 1000);
+    const days = Math.floor(total / 86400);
+    const pad = n => String(n).padStart(2, '0');
+    const time = `${Math.floor(total % 86400 / 3600)}:${pad(Math.floor(total % 3600 / 60))}:${pad(total % 60)}`;
+    return days > 0 ? `${days}일 ${time}` : time;
+}
+
+// 1초마다 남은 시간을 갱신하고, 만료된 리소스가 생기면 목록을 다시 불러와서 숨김
+let expiredReloadPending = false;
+setInterval(() => {
+    const now = Date.now();
+    let expired = false;
+    document.querySelectorAll('#resourceTableBody .expires').forEach(cell => {
+        expired = updateCountdown(cell, now) || expired;
+    });
+    if (expired && !expiredReloadPending) {
+        expiredReloadPending = true;
+        loadResources().finally(() => { expiredReloadPending = false; });
+    }
+}, 1000);
+
+// 검색어 입력이 멈춘 뒤 요청을 보낼 때까지 기다리는 시간 (ms)
+const SEARCH_DEBOUNCE_MS = 300;
+let searchTimer = null;
+let searchSeq = 0;
+
+function onSearchInput() {
+    clearTimeout(searchTimer);
+    searchTimer = setTimeout(searchResources, SEARCH_DEBOUNCE_MS);
+}
+
+async function searchResources() {
+    const query = document.getElementById('searchQuery').value.trim();
+    const results = document.getElementById('searchResults');
+    // 늦게 도착한 이전 검색의 응답은 무시
+    const seq = ++searchSeq;
+    if (!query) {
+        results.innerHTML = '';
+        return;
+    }
+
+    try {
+        const response = await api.searchResources(query);
+        if (seq !== searchSeq) return;
+        const { hits = [], suggestion } = response.data || {};
+        if (hits.length === 0) {
+            results.textContent = '검색 결과가 없습니다.';
+            if (suggestion) results.appendChild(createSuggestion(suggestion));
+            return;
+        }
+        results.innerHTML = '<ul>' + hits.map(hit => `
+            <li>${highlightSnippet(hit.snippet)} <span class="tree-id">#${hit.resource.id}</span></li>
+        `).join('') + '</ul>';
+    } catch (error) {
+        if (seq === searchSeq) showError(error.message);
+    }
+}
+
+// "혹시 ...을(를) 찾으셨나요?" 링크 (누르면 제안한 이름으로 다시 검색)
+function createSuggestion(suggestion) {
+    const p = document.createElement('p');
+    const link = document.createElement('a');
+    link.href = '#';
+    link.textContent = suggestion;
+    link.onclick = event => {
+        event.preventDefault();
+        document.getElementById('searchQuery').value = suggestion;
+        searchResources();
+    };
+    p.append('혹시 ', link, '을(를) 찾으셨나요?');
+    return p;
+}
+
+// 스니펫을 이스케이프한 뒤 강조 표시(<mark>)만 다시 살림
+function highlightSnippet(snippet) {
+    return escapeHTML(snippet)
+        .replaceAll('&lt;mark&gt;', '<mark>')
+        .replaceAll('&lt;/mark&gt;', '</mark>');
+}
+
 async function createResource() {
     const nameInput = document.getElementById('resourceName');
+    const parentInput = document.getElementById('resourceParent');
+    const expiresInput = document.getElementById('resourceExpires');
     const name = nameInput.value.trim();
+    const parentId = parentInput.value ? Number(parentInput.value) : undefined;
+    // datetime-local 값은 브라우저의 시간대 기준
+    const expiresAt = expiresInput.value ? new Date(expiresInput.value).toISOString() : undefined;
     
     if (!name) {
         showError('리소스 이름을 입력해주세요.');
@@ -55,8 +217,12 @@ async function createResource() {
     }
 
     try {
-        await api.createResource({ name });
+        const response = await api.createResource({ name, parent_id: parentId, expires_at: expiresAt });
+        // 비슷한 이름의 리소스가 있어도 생성은 되므로 경고만 표시
+        (response.warnings || []).forEach(showWarning);
         nameInput.value = '';
+        parentInput.value = '';
+        expiresInput.value = '';
         loadResources();
     } catch (error) {
         showError(error.message);
@@ -68,7 +234,72 @@ async function editResource(id) {
     if (newName === null) return;
 
     try {
-        await api.updateResource(id, { name: newName });
+        // PUT은 라벨까지 덮어쓰므로 이름만 바꾸도록 PATCH 사용
+        await api.patchResource(id, { name: newName });
+        loadResources();
+    } catch (error) {
+        showError(error.message);
+    }
+}
+
+// "key=value,key2=value2" 형식으로 입력받아 라벨 추가
+async function addLabel(id) {
+    const input = prompt('추가할 라벨을 입력하세요 (예: env=prod,team=infra):');
+    if (!input) return;
+
+    const labels = {};
+    for (const pair of input.split(',')) {
+        const index = pair.indexOf('=');
+        if (index <= 0) {
+            showError(`잘못된 라벨 형식입니다: ${pair.trim()}`);
+            return;
+        }
+        labels[pair.slice(0, index).trim()] = pair.slice(index + 1).trim();
+    }
+
+    try {
+        await api.addLabels(id, labels);
+        loadResources();
+    } catch (error) {
+        showError(error.message);
+    }
+}
+
+async function removeLabel(id, key) {
+    try {
+        await api.removeLabel(id, key);
+        loadResources();
+    } catch (error) {
+        showError(error.message);
+    }
+}
+
+// 빈 값을 입력하면 최상위로 이동
+async function moveResource(id) {
+    const input = prompt('새 부모 리소스 ID를 입력하세요 (비우면 최상위로 이동):');
+    if (input === null) return;
+
+    const parentId = input.trim() === '' ? null : Number(input.trim());
+    if (Number.isNaN(parentId)) {
+        showError('잘못된 ID 형식입니다.');
+        return;
+    }
+
+    try {
+        await api.moveResource(id, parentId);
+        loadResources();
+    } catch (error) {
+        showError(error.message);
+    }
+}
+
+// 지금부터 입력한 시간 뒤로 만료 시각을 바꿈
+async function extendResource(id) {
+    const ttl = prompt('지금부터 남길 시간을 입력하세요 (예: 30m, 2h, 24h):');
+    if (!ttl) return;
+
+    try {
+        await api.extendResource(id, ttl.trim());
         loadResources();
     } catch (error) {
         showError(error.message);
@@ -82,10 +313,29 @@ async function deleteResource(id) {
         await api.deleteResource(id);
         loadResources();
     } catch (error) {
-        showError(error.message);
+        // 하위 리소스가 있으면 함께 삭제할지 다시 확인
+        if (error.status !== 409) {
+            showError(error.message);
+            return;
+        }
+        if (!confirm('하위 리소스가 있습니다. 하위 리소스까지 모두 삭제하시겠습니까?')) return;
+        try {
+            await api.deleteResource(id, true);
+            loadResources();
+        } catch (cascadeError) {
+            showError(cascadeError.message);
+        }
     }
 }
 
+function showWarning(message) {
+    const warningDiv = document.createElement('div');
+    warningDiv.className = 'warning';
+    warningDiv.textContent = message;
+    document.body.appendChild(warningDiv);
+    setTimeout(() => warningDiv.remove(), 5000);
+}
+
 function showError(message) {
     const errorDiv = document.createElement('div');
     errorDiv.className = 'error';